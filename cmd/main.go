@@ -1,36 +1,51 @@
 package main
 
 import (
-	"errors"
+	"context"
 	"flag"
 	"fmt"
 	"os"
+	"os/signal"
 	"path"
-	"strings"
+	"syscall"
 
 	log "github.com/sirupsen/logrus"
 
+	"github.com/exasol/extension-manager/pkg/apiErrors"
+	"github.com/exasol/extension-manager/pkg/config"
+	"github.com/exasol/extension-manager/pkg/logging"
 	"github.com/exasol/extension-manager/pkg/restAPI"
+	"github.com/exasol/extension-manager/pkg/tracing"
 
 	"github.com/exasol/extension-manager/pkg/extensionController"
 )
 
 func main() {
-	var extensionRegistryURL = flag.String("extensionRegistryURL", "", "URL of the extension registry index used to find available extensions or the path of a local directory")
-	var serverAddress = flag.String("serverAddress", ":8080", `Server address, e.g. ":8080" (all network interfaces) or "localhost:8080" (only local interface)`)
 	var openAPIOutputPath = flag.String("openAPIOutputPath", "", "Generate the OpenAPI spec at the given path instead of starting the server")
-	var addCauseToInternalServerError = flag.Bool("addCauseToInternalServerError", false, "Add cause of internal server errors (status 500) to the error message. Don't use this in production!")
+	var errorCatalogOutputPath = flag.String("errorCatalogOutputPath", "", "Generate the catalog of error codes at the given path instead of starting the server")
+	configLoader := config.NewLoader(flag.CommandLine)
 	flag.Parse()
-	log.SetLevel(log.DebugLevel)
-	log.SetFormatter(&simpleFormatter{})
+	configureLogging(config.Defaults().Log)
 	if openAPIOutputPath != nil && *openAPIOutputPath != "" {
 		err := generateOpenAPISpec(*openAPIOutputPath)
 		if err != nil {
 			fmt.Printf("failed to generate OpenAPI to %q: %v\n", *openAPIOutputPath, err)
 			os.Exit(1)
 		}
+	} else if errorCatalogOutputPath != nil && *errorCatalogOutputPath != "" {
+		err := writeFile(*errorCatalogOutputPath, []byte(apiErrors.CatalogMarkdown()))
+		if err != nil {
+			fmt.Printf("failed to generate error catalog to %q: %v\n", *errorCatalogOutputPath, err)
+			os.Exit(1)
+		}
 	} else {
-		err := startServer(*extensionRegistryURL, *serverAddress, *addCauseToInternalServerError)
+		cfg, err := configLoader.Load(os.LookupEnv)
+		if err != nil {
+			fmt.Printf("invalid configuration:\n%v\n", err)
+			os.Exit(1)
+		}
+		configureLogging(cfg.Log)
+		err = startServer(cfg)
 		if err != nil {
 			fmt.Printf("failed to start server: %v\n", err)
 			os.Exit(1)
@@ -38,20 +53,45 @@ func main() {
 	}
 }
 
-func startServer(pathToExtensionFolder string, serverAddress string, addCauseToInternalServerError bool) error {
-	if pathToExtensionFolder == "" {
-		return errors.New("please specify extension registry with parameter '-extensionRegistryURL'")
+// configureLogging applies the validated log configuration.
+func configureLogging(logConfig config.LogConfig) {
+	level, err := log.ParseLevel(logConfig.Level)
+	if err != nil {
+		panic(fmt.Sprintf("log level %q was not validated: %v", logConfig.Level, err))
+	}
+	formatter, err := logging.NewFormatter(logConfig.Format)
+	if err != nil {
+		panic(fmt.Sprintf("log format %q was not validated: %v", logConfig.Format, err))
+	}
+	log.SetLevel(level)
+	log.SetFormatter(formatter)
+}
+
+func startServer(cfg *config.Config) error {
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.TracingConfig())
+	if err != nil {
+		return err
 	}
-	log.Printf("Starting extension manager with extension folder %q", pathToExtensionFolder)
-	controller, err := extensionController.CreateWithValidatedConfig(extensionController.ExtensionManagerConfig{
-		ExtensionRegistryURL: pathToExtensionFolder,
-		ExtensionSchema:      restAPI.EXTENSION_SCHEMA_NAME,
-		BucketFSBasePath:     "/buckets/bfsdefault/default/"})
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			log.Warnf("Failed to flush spans: %v", err)
+		}
+	}()
+	log.Printf("Starting extension manager with extension folder %q and BucketFS base paths %q", cfg.ExtensionRegistryURL, cfg.BucketFs.BasePaths)
+	controller, err := extensionController.CreateWithValidatedConfig(cfg.ExtensionManagerConfig())
 	if err != nil {
 		return err
 	}
-	restApi := restAPI.Create(controller, serverAddress, addCauseToInternalServerError)
-	restApi.Serve()
+	restApi, err := restAPI.CreateWithConfig(controller, cfg.RestAPIConfig())
+	if err != nil {
+		return err
+	}
+	signals, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+	defer stop()
+	restApi.StartInBackground()
+	<-signals.Done()
+	log.Infof("Received signal, shutting down")
+	restApi.Stop()
 	return nil
 }
 
@@ -73,7 +113,7 @@ func writeFile(filename string, content []byte) error {
 	if err != nil {
 		return err
 	}
-	fmt.Printf("Wrote OpenAPI spec to %s\n", filename)
+	fmt.Printf("Wrote %s\n", filename)
 	return nil
 }
 
@@ -93,10 +133,3 @@ func generateOpenAPIJson() ([]byte, error) {
 	}
 	return json, err
 }
-
-type simpleFormatter struct {
-}
-
-func (f *simpleFormatter) Format(entry *log.Entry) ([]byte, error) {
-	return []byte(fmt.Sprintf("%-7s %s\n", strings.ToUpper(entry.Level.String()), entry.Message)), nil
-}
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/exasol/exasol-driver-go"
+	"github.com/exasol/extension-manager/pkg/config"
 	"github.com/exasol/extension-manager/pkg/extensionAPI"
 	"github.com/exasol/extension-manager/pkg/extensionController"
 
@@ -35,13 +36,12 @@ func TestManualITestSuite(t *testing.T) {
 }
 
 func (suite *ManualITestSuite) SetupSuite() {
-	log.SetLevel(log.DebugLevel)
-	log.SetFormatter(new(simpleFormatter))
-	config, err := readPropertiesFile("../manual-test.properties")
+	configureLogging(config.Defaults().Log)
+	properties, err := readPropertiesFile("../manual-test.properties")
 	if err != nil {
 		suite.T().Skip("Skipping manual integration tests: " + err.Error())
 	}
-	suite.config = config
+	suite.config = properties
 	suite.db = suite.createDBConnection()
 	suite.ctrl = suite.createController()
 }
@@ -0,0 +1,193 @@
+package apiErrors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ErrorCode describes an error reported to clients with a stable code.
+// Clients should use the code instead of the message to identify an error because messages may change.
+type ErrorCode struct {
+	Code        string   // Unique code, e.g. "E-EM-REG-1"
+	Status      int      // HTTP status code returned to the client
+	Description string   // Description of the error for the error catalog
+	Mitigations []string // Optional steps that help fixing the error
+}
+
+var catalog = make([]ErrorCode, 0)
+
+func newErrorCode(code string, status int, description string, mitigations ...string) ErrorCode {
+	errorCode := ErrorCode{Code: code, Status: status, Description: description, Mitigations: mitigations}
+	catalog = append(catalog, errorCode)
+	return errorCode
+}
+
+// Catalog returns all error codes in the order of their definition.
+func Catalog() []ErrorCode {
+	result := make([]ErrorCode, len(catalog))
+	copy(result, catalog)
+	return result
+}
+
+// Errors of the REST API.
+var (
+	API_INTERNAL_SERVER_ERROR = newErrorCode("E-EM-API-1", http.StatusInternalServerError, "An unexpected error occurred while processing the request.",
+		"Check the log of the extension manager for details.")
+	API_MISSING_AUTHORIZATION = newErrorCode("E-EM-API-2", http.StatusUnauthorized, "The request does not contain an Authorization header.",
+		"Specify database credentials using basic authentication or a bearer token.")
+	API_INVALID_AUTHORIZATION = newErrorCode("E-EM-API-3", http.StatusUnauthorized, "The Authorization header of the request is invalid.",
+		"Use scheme 'Basic' with base64 encoded 'user:password' or scheme 'Bearer' with a token.")
+	API_INVALID_DATABASE_PARAMETERS = newErrorCode("E-EM-API-4", http.StatusBadRequest, "Query parameters dbHost or dbPort are missing or invalid.",
+		"Specify host name and port of the database with query parameters dbHost and dbPort.")
+	API_INVALID_CONTENT_TYPE = newErrorCode("E-EM-API-5", http.StatusBadRequest, "The Content-Type header of the request is not application/json.",
+		"Send the request body as JSON with header 'Content-Type: application/json'.")
+	API_MALFORMED_REQUEST_BODY = newErrorCode("E-EM-API-6", http.StatusBadRequest, "The request body is empty or not a single valid JSON object.")
+	API_REQUEST_BODY_TOO_LARGE = newErrorCode("E-EM-API-7", http.StatusBadRequest, "The request body is larger than 1MB.")
+	API_INVALID_REQUEST_FIELD  = newErrorCode("E-EM-API-8", http.StatusBadRequest, "The request body contains an unknown field or a field with an invalid value.",
+		"Check the details of the error for the affected field.")
+	API_INVALID_PARAMETER_VALUES_QUERY = newErrorCode("E-EM-API-9", http.StatusBadRequest, "Query parameter parameterValues is not a valid JSON array of parameter values.")
+	API_DATABASE_CERTIFICATE_INVALID   = newErrorCode("E-EM-API-10", http.StatusInternalServerError, "Validating the TLS certificate of the database failed.",
+		"Check the CA bundle and the certificate fingerprint configured for the database.")
+	API_DATABASE_HOST_NOT_ALLOWED = newErrorCode("E-EM-API-11", http.StatusBadRequest, "Query parameters dbHost and dbPort are not allowed because the server only connects to configured database targets.",
+		"Remove query parameters dbHost and dbPort and select a configured database target with query parameter dbTarget.")
+	API_INVALID_DATABASE_TARGET = newErrorCode("E-EM-API-12", http.StatusBadRequest, "Query parameter dbTarget is missing or does not refer to a configured database target.",
+		"Select one of the configured database targets with query parameter dbTarget. The error message lists the available targets.")
+	API_JOB_NOT_FOUND = newErrorCode("E-EM-API-13", http.StatusNotFound, "The job does not exist or was started by another user.",
+		"Check the job ID and use the same credentials as for starting the job. EM deletes finished jobs after the configured retention period.")
+	API_INVALID_ASYNC_PARAMETER = newErrorCode("E-EM-API-14", http.StatusBadRequest, "Query parameter async is not a boolean value.",
+		"Use async=true for running the operation as a job or omit the parameter.")
+	API_SHUTTING_DOWN = newErrorCode("E-EM-API-15", http.StatusServiceUnavailable, "The server is shutting down and does not accept modifying requests.",
+		"Retry the request, e.g. on another instance of the extension manager.")
+)
+
+// Errors of the extension controller.
+var (
+	CTRL_INSTANCES_EXIST = newErrorCode("E-EM-CTRL-1", http.StatusBadRequest, "The extension can't be uninstalled because instances still exist.",
+		"Delete all instances of the extension before uninstalling it.")
+	CTRL_INSTANCE_NOT_FOUND = newErrorCode("E-EM-CTRL-2", http.StatusNotFound, "The instance does not exist.",
+		"Check the instance ID. The list of instances contains all existing instances.")
+	CTRL_INVALID_PARAMETERS = newErrorCode("E-EM-CTRL-3", http.StatusBadRequest, "Parameter values are invalid.",
+		"Correct the parameter values listed in the details of the error.")
+	CTRL_INVALID_DATABASE_CREDENTIALS = newErrorCode("E-EM-CTRL-4", http.StatusUnauthorized, "The database rejected the credentials.",
+		"Check user name and password or the token.")
+	CTRL_BEGIN_TRANSACTION_FAILED = newErrorCode("E-EM-CTRL-5", http.StatusInternalServerError, "Starting a database transaction failed.",
+		"Check that the database is running and reachable.")
+	CTRL_READING_METADATA_FAILED = newErrorCode("E-EM-CTRL-6", http.StatusInternalServerError, "Reading database metadata failed.",
+		"Check that the database user has privileges to read the system tables.")
+	CTRL_CREATING_SCHEMA_FAILED = newErrorCode("E-EM-CTRL-7", http.StatusInternalServerError, "Creating the schema for extensions failed.",
+		"Check that the database user has privileges to create schemas.")
+	CTRL_NO_INSTANCE_RETURNED = newErrorCode("E-EM-CTRL-8", http.StatusInternalServerError, "The extension did not return the created or updated instance.",
+		"Report this to the developers of the extension.")
+	CTRL_INVALID_CONFIGURATION = newErrorCode("E-EM-CTRL-9", http.StatusInternalServerError, "The configuration of the extension manager is invalid.",
+		"Check the configuration of the extension manager.")
+)
+
+// Errors of the extension registry.
+var (
+	REG_EXTENSION_NOT_FOUND = newErrorCode("E-EM-REG-1", http.StatusNotFound, "The extension does not exist in the registry.",
+		"Check the extension ID. IDs may change when the extension manager is restarted, so get the current ID from the list of available extensions.")
+	REG_LISTING_EXTENSIONS_FAILED = newErrorCode("E-EM-REG-2", http.StatusInternalServerError, "Listing the extensions in the registry directory failed.",
+		"Check that the configured registry directory exists and is readable.")
+	REG_READING_EXTENSION_FAILED = newErrorCode("E-EM-REG-3", http.StatusInternalServerError, "Reading the definition of an extension from the registry failed.",
+		"Check that the registry is reachable and contains the extension file.")
+	REG_LOADING_INDEX_FAILED = newErrorCode("E-EM-REG-4", http.StatusInternalServerError, "Loading the index of the extension registry failed.",
+		"Check that the configured registry URL is correct and reachable.")
+)
+
+// Errors of the BucketFS access.
+var (
+	BFS_EMPTY_BASE_PATH = newErrorCode("E-EM-BFS-1", http.StatusInternalServerError, "No BucketFS base path is configured or a base path is empty.",
+		"Configure at least one non-empty BucketFS base path.")
+	BFS_DUPLICATE_BASE_PATH = newErrorCode("E-EM-BFS-2", http.StatusInternalServerError, "A BucketFS base path is configured more than once.",
+		"Remove the duplicate BucketFS base path from the configuration.")
+	BFS_LISTING_FAILED = newErrorCode("E-EM-BFS-3", http.StatusInternalServerError, "Listing the files in BucketFS failed.",
+		"Check that the database can access the configured BucketFS base paths.")
+	BFS_FILE_NOT_FOUND = newErrorCode("E-EM-BFS-4", http.StatusInternalServerError, "A file required by the extension does not exist in BucketFS.",
+		"Upload the file to one of the configured BucketFS base paths.")
+	BFS_CREATING_LIST_UDF_FAILED = newErrorCode("E-EM-BFS-5", http.StatusInternalServerError, "Creating the UDF for listing files in BucketFS failed.",
+		"Check that the database user has privileges to create schemas and scripts.")
+	BFS_CLEANUP_FAILED = newErrorCode("E-EM-BFS-6", http.StatusInternalServerError, "Removing temporary database objects for listing files in BucketFS failed.")
+)
+
+// NewErrorF creates a new [APIError] with this code, its status and mitigations.
+// Use this for errors caused by the client.
+func (c ErrorCode) NewErrorF(format string, a ...interface{}) error {
+	return c.newAPIError(fmt.Sprintf(format, a...), nil)
+}
+
+// NewErrorWithDetails creates a new [APIError] with this code and a list of details describing each problem.
+func (c ErrorCode) NewErrorWithDetails(message string, details []ErrorDetail) error {
+	return c.newAPIError(message, details)
+}
+
+func (c ErrorCode) newAPIError(message string, details []ErrorDetail) *APIError {
+	return &APIError{
+		Status:        c.Status,
+		Message:       message,
+		RequestID:     "",
+		ErrorCode:     c.Code,
+		Mitigations:   c.Mitigations,
+		Details:       details,
+		OriginalError: nil,
+	}
+}
+
+// NewInternalErrorF creates a new error with this code for problems not caused by the client, e.g. a failing database query.
+// The format supports wrapping errors with %w. Clients will receive an internal server error with this code but without the message.
+func (c ErrorCode) NewInternalErrorF(format string, a ...interface{}) error {
+	return &codedError{code: c, cause: fmt.Errorf(format, a...)}
+}
+
+// codedError is an internal error with an error code.
+type codedError struct {
+	code  ErrorCode
+	cause error
+}
+
+func (e *codedError) Error() string {
+	return e.cause.Error()
+}
+
+func (e *codedError) Unwrap() error {
+	return e.cause
+}
+
+// GetErrorCode returns the error code of the given error or of the first error with a code in its chain.
+func GetErrorCode(err error) (ErrorCode, bool) {
+	var apiErr *APIError
+	var coded *codedError
+	switch {
+	case errors.As(err, &apiErr) && apiErr.ErrorCode != "":
+		return findErrorCode(apiErr.ErrorCode)
+	case errors.As(err, &coded):
+		return coded.code, true
+	default:
+		return ErrorCode{}, false
+	}
+}
+
+func findErrorCode(code string) (ErrorCode, bool) {
+	for _, c := range catalog {
+		if c.Code == code {
+			return c, true
+		}
+	}
+	return ErrorCode{}, false
+}
+
+// CatalogMarkdown returns the catalog of all error codes as Markdown document.
+func CatalogMarkdown() string {
+	var builder strings.Builder
+	builder.WriteString("# Error Codes\n\n")
+	builder.WriteString("<!-- This file is generated by running `go run cmd/main.go -errorCatalogOutputPath doc/error_codes.md`. Don't edit it manually. -->\n\n")
+	builder.WriteString("Error responses of the extension manager contain a stable code in field `errorCode` and optional steps for fixing the error in field `mitigations`. ")
+	builder.WriteString("Clients should use the code instead of the message to identify an error.\n\n")
+	builder.WriteString("| Code | HTTP Status | Description | Mitigations |\n")
+	builder.WriteString("|------|-------------|-------------|-------------|\n")
+	for _, code := range catalog {
+		fmt.Fprintf(&builder, "| %s | %d | %s | %s |\n", code.Code, code.Status, code.Description, strings.Join(code.Mitigations, "<br>"))
+	}
+	return builder.String()
+}
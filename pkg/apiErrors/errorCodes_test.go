@@ -0,0 +1,114 @@
+package apiErrors_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/exasol/extension-manager/pkg/apiErrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatalogContainsUniqueCodes(t *testing.T) {
+	codes := make(map[string]bool)
+	for _, code := range apiErrors.Catalog() {
+		assert.False(t, codes[code.Code], "duplicate code %q", code.Code)
+		codes[code.Code] = true
+	}
+}
+
+func TestCatalogCodesHaveValidFormat(t *testing.T) {
+	pattern := regexp.MustCompile(`^E-EM-(API|CTRL|REG|BFS)-\d+$`)
+	for _, code := range apiErrors.Catalog() {
+		assert.Regexp(t, pattern, code.Code)
+		assert.NotEmpty(t, code.Description, "description of %q", code.Code)
+		assert.NotZero(t, code.Status, "status of %q", code.Code)
+	}
+}
+
+func TestCatalogMarkdownIsUpToDate(t *testing.T) {
+	content, err := os.ReadFile("../../doc/error_codes.md")
+	require.NoError(t, err)
+	assert.Equal(t, apiErrors.CatalogMarkdown(), string(content),
+		"Catalog is outdated, run 'go run cmd/main.go -errorCatalogOutputPath doc/error_codes.md' to update it")
+}
+
+func TestCatalogMarkdownContainsCode(t *testing.T) {
+	assert.Contains(t, apiErrors.CatalogMarkdown(),
+		"| E-EM-REG-1 | 404 | The extension does not exist in the registry. | Check the extension ID.")
+}
+
+func TestErrorCodeNewErrorF(t *testing.T) {
+	err := apiErrors.REG_EXTENSION_NOT_FOUND.NewErrorF("extension %q not found", "ext")
+	assertApiError(t, err, `extension "ext" not found`, 404, nil)
+	apiErr := apiErrors.UnwrapAPIError(err)
+	assert.Equal(t, "E-EM-REG-1", apiErr.ErrorCode)
+	assert.Equal(t, apiErrors.REG_EXTENSION_NOT_FOUND.Mitigations, apiErr.Mitigations)
+}
+
+func TestErrorCodeNewErrorWithDetails(t *testing.T) {
+	details := []apiErrors.ErrorDetail{{Field: "p1", ErrorCode: apiErrors.DETAIL_INVALID_VALUE, Message: "invalid"}}
+	err := apiErrors.CTRL_INVALID_PARAMETERS.NewErrorWithDetails("invalid parameters", details)
+	assertApiError(t, err, "invalid parameters", 400, nil)
+	apiErr := apiErrors.UnwrapAPIError(err)
+	assert.Equal(t, "E-EM-CTRL-3", apiErr.ErrorCode)
+	assert.Equal(t, details, apiErr.Details)
+}
+
+func TestErrorCodeNewInternalErrorF(t *testing.T) {
+	cause := errors.New("cause")
+	err := apiErrors.BFS_LISTING_FAILED.NewInternalErrorF("failed to list files: %w", cause)
+	assert.EqualError(t, err, "failed to list files: cause")
+	assert.ErrorIs(t, err, cause)
+	_, isApiError := apiErrors.AsAPIError(err)
+	assert.False(t, isApiError)
+}
+
+func TestUnwrapAPIErrorInternalErrorWithCode(t *testing.T) {
+	orgErr := fmt.Errorf("wrapper: %w", apiErrors.BFS_FILE_NOT_FOUND.NewInternalErrorF("file %q not found", "file.jar"))
+	apiErr := apiErrors.UnwrapAPIError(orgErr)
+	assertApiError(t, apiErr, "Internal server error", 500, orgErr)
+	assert.Equal(t, "E-EM-BFS-4", apiErr.ErrorCode)
+	assert.Equal(t, []string{"Upload the file to one of the configured BucketFS base paths."}, apiErr.Mitigations)
+}
+
+func TestUnwrapAPIErrorInternalErrorWithoutCode(t *testing.T) {
+	apiErr := apiErrors.UnwrapAPIError(errors.New("mock"))
+	assert.Equal(t, "E-EM-API-1", apiErr.ErrorCode)
+	assert.Equal(t, apiErrors.API_INTERNAL_SERVER_ERROR.Mitigations, apiErr.Mitigations)
+}
+
+func TestNewAPIErrorWithCauseKeepsErrorCode(t *testing.T) {
+	err := apiErrors.NewAPIErrorWithCause("msg", apiErrors.CTRL_INSTANCE_NOT_FOUND.NewErrorF("cause"))
+	assert.Equal(t, "E-EM-CTRL-2", apiErrors.UnwrapAPIError(err).ErrorCode)
+}
+
+func TestGetErrorCode(t *testing.T) {
+	var tests = []struct {
+		name         string
+		err          error
+		expectedCode string
+	}{
+		{"API error", apiErrors.REG_EXTENSION_NOT_FOUND.NewErrorF("not found"), "E-EM-REG-1"},
+		{"wrapped API error", fmt.Errorf("wrapper: %w", apiErrors.REG_EXTENSION_NOT_FOUND.NewErrorF("not found")), "E-EM-REG-1"},
+		{"internal error", apiErrors.BFS_CLEANUP_FAILED.NewInternalErrorF("failed"), "E-EM-BFS-6"},
+		{"wrapped internal error", fmt.Errorf("wrapper: %w", apiErrors.BFS_CLEANUP_FAILED.NewInternalErrorF("failed")), "E-EM-BFS-6"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			code, found := apiErrors.GetErrorCode(test.err)
+			assert.True(t, found)
+			assert.Equal(t, test.expectedCode, code.Code)
+		})
+	}
+}
+
+func TestGetErrorCodeNotFound(t *testing.T) {
+	for _, err := range []error{errors.New("mock"), apiErrors.NewBadRequestErrorF("no code"), nil} {
+		_, found := apiErrors.GetErrorCode(err)
+		assert.False(t, found, "error %v", err)
+	}
+}
@@ -6,11 +6,20 @@ import (
 	"net/http"
 )
 
+// NewInternalServerError creates a new internal server error for the given error.
+// The error code is taken from the given error's chain and defaults to [API_INTERNAL_SERVER_ERROR].
 func NewInternalServerError(originalError error) *APIError {
+	code, found := GetErrorCode(originalError)
+	if !found {
+		code = API_INTERNAL_SERVER_ERROR
+	}
 	return &APIError{
 		Status:        http.StatusInternalServerError,
 		Message:       "Internal server error",
 		RequestID:     "",
+		ErrorCode:     code.Code,
+		Mitigations:   code.Mitigations,
+		Details:       nil,
 		OriginalError: originalError,
 	}
 }
@@ -32,6 +41,9 @@ func NewAPIErrorF(status int, format string, a ...interface{}) error {
 		Status:        status,
 		Message:       fmt.Sprintf(format, a...),
 		RequestID:     "",
+		ErrorCode:     "",
+		Mitigations:   nil,
+		Details:       nil,
 		OriginalError: nil,
 	}
 }
@@ -41,6 +53,22 @@ func NewAPIError(status int, message string) error {
 		Status:        status,
 		Message:       message,
 		RequestID:     "",
+		ErrorCode:     "",
+		Mitigations:   nil,
+		Details:       nil,
+		OriginalError: nil,
+	}
+}
+
+// NewBadRequestErrorWithDetails creates a new bad request error with a list of details describing each problem.
+func NewBadRequestErrorWithDetails(message string, details []ErrorDetail) error {
+	return &APIError{
+		Status:        http.StatusBadRequest,
+		Message:       message,
+		RequestID:     "",
+		ErrorCode:     "",
+		Mitigations:   nil,
+		Details:       details,
 		OriginalError: nil,
 	}
 }
@@ -52,6 +80,9 @@ func NewAPIErrorWithCause(message string, cause error) error {
 			Status:        apiErr.Status,
 			Message:       fmt.Sprintf("%s: %s", message, apiErr.Message),
 			RequestID:     "",
+			ErrorCode:     apiErr.ErrorCode,
+			Mitigations:   apiErr.Mitigations,
+			Details:       apiErr.Details,
 			OriginalError: cause,
 		}
 	}
@@ -68,10 +99,28 @@ func UnwrapAPIError(err error) *APIError {
 }
 
 type APIError struct {
-	Status        int    `json:"code"`                // HTTP status code
-	Message       string `json:"message"`             // human-readable message
-	RequestID     string `json:"requestID,omitempty"` // ID to identify the request that caused this error
-	OriginalError error  `json:"-"`
+	Status        int           `json:"code"`                  // HTTP status code
+	Message       string        `json:"message"`               // human-readable message
+	RequestID     string        `json:"requestID,omitempty"`   // ID to identify the request that caused this error
+	ErrorCode     string        `json:"errorCode,omitempty"`   // stable code identifying the error, see [Catalog]
+	Mitigations   []string      `json:"mitigations,omitempty"` // optional steps that help fixing the error
+	Details       []ErrorDetail `json:"details,omitempty"`     // optional list of problems, e.g. one entry per invalid field
+	OriginalError error         `json:"-"`
+}
+
+// Codes for [ErrorDetail.ErrorCode].
+const (
+	DETAIL_MISSING_VALUE = "missing-value" // A required value is missing
+	DETAIL_INVALID_VALUE = "invalid-value" // A value is invalid, e.g. because it has the wrong format
+	DETAIL_UNKNOWN_FIELD = "unknown-field" // A value was specified for an unknown field
+)
+
+// ErrorDetail describes a single problem that caused an [APIError], e.g. an invalid parameter value.
+// This allows clients to highlight the affected field.
+type ErrorDetail struct {
+	Field     string `json:"field"`     // ID of the field or parameter that caused the problem
+	ErrorCode string `json:"errorCode"` // machine-readable code of the problem, e.g. [DETAIL_INVALID_VALUE]
+	Message   string `json:"message"`   // human-readable message
 }
 
 func (a *APIError) Error() string {
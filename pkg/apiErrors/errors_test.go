@@ -1,6 +1,7 @@
 package apiErrors_test
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"testing"
@@ -42,6 +43,33 @@ func TestNewAPIErrorWithCauseApiErrorCause(t *testing.T) {
 	assertApiError(t, err, "msg: cause", 123, cause)
 }
 
+func TestNewBadRequestErrorWithDetails(t *testing.T) {
+	details := []apiErrors.ErrorDetail{{Field: "p1", ErrorCode: apiErrors.DETAIL_INVALID_VALUE, Message: "invalid"}}
+	err := apiErrors.NewBadRequestErrorWithDetails("err", details)
+	assertApiError(t, err, "err", 400, nil)
+	assert.Equal(t, details, apiErrors.UnwrapAPIError(err).Details)
+}
+
+func TestNewAPIErrorWithCauseKeepsDetails(t *testing.T) {
+	details := []apiErrors.ErrorDetail{{Field: "p1", ErrorCode: apiErrors.DETAIL_MISSING_VALUE, Message: "missing"}}
+	cause := apiErrors.NewBadRequestErrorWithDetails("cause", details)
+	err := apiErrors.NewAPIErrorWithCause("msg", cause)
+	assertApiError(t, err, "msg: cause", 400, cause)
+	assert.Equal(t, details, apiErrors.UnwrapAPIError(err).Details)
+}
+
+func TestAPIErrorJsonOmitsEmptyDetails(t *testing.T) {
+	data, err := json.Marshal(apiErrors.NewAPIError(400, "err"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"code":400,"message":"err"}`, string(data))
+}
+
+func TestAPIErrorJsonContainsDetails(t *testing.T) {
+	data, err := json.Marshal(apiErrors.NewBadRequestErrorWithDetails("err", []apiErrors.ErrorDetail{{Field: "p1", ErrorCode: apiErrors.DETAIL_UNKNOWN_FIELD, Message: "unknown"}}))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"code":400,"message":"err","details":[{"field":"p1","errorCode":"unknown-field","message":"unknown"}]}`, string(data))
+}
+
 func TestNewAPIErrorWithCauseNonApiErrorCause(t *testing.T) {
 	cause := errors.New("cause")
 	err := apiErrors.NewAPIErrorWithCause("msg", cause)
@@ -6,7 +6,11 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/exasol/extension-manager/pkg/secrets"
+	"github.com/exasol/extension-manager/pkg/tracing"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // SimpleSQLClient allows extensions to execute statements and queries against the database.
@@ -30,15 +34,17 @@ func NewSqlClient(ctx context.Context, tx *sql.Tx) SimpleSQLClient {
 
 // Execute executes a statement like `CREATE VIRTUAL SCHEMA`.
 /* [impl -> dsn~extension-context-sql-client~1]. */
-func (c *exasolSqlClient) Execute(query string, args ...any) (sql.Result, error) {
+func (c *exasolSqlClient) Execute(query string, args ...any) (result sql.Result, errResult error) {
+	span := c.startSpan("SimpleSQLClient.Execute", query)
+	defer func() { c.endSpan(span, errResult) }()
 	err := validateQuery(query)
 	if err != nil {
 		return nil, err
 	}
-	logrus.Tracef("Executing SQL statement %q...", query)
-	result, err := c.transaction.ExecContext(c.ctx, query, args...)
+	logrus.Tracef("Executing SQL statement %q...", secrets.MaskerFromContext(c.ctx).Mask(query))
+	result, err = c.transaction.ExecContext(c.ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("error executing statement '%s': %w", query, err)
+		return nil, fmt.Errorf("error executing statement '%s': %w", secrets.MaskerFromContext(c.ctx).Mask(query), err)
 	}
 	return result, nil
 }
@@ -46,14 +52,16 @@ func (c *exasolSqlClient) Execute(query string, args ...any) (sql.Result, error)
 // Query runs a query like `SELECT` and returns the result.
 /* [impl -> dsn~extension-context-sql-client~1]. */
 func (c *exasolSqlClient) Query(query string, args ...any) (result *QueryResult, errResult error) {
+	span := c.startSpan("SimpleSQLClient.Query", query)
+	defer func() { c.endSpan(span, errResult) }()
 	err := validateQuery(query)
 	if err != nil {
 		return nil, err
 	}
-	logrus.Tracef("Executing SQL query %q...", query)
+	logrus.Tracef("Executing SQL query %q...", secrets.MaskerFromContext(c.ctx).Mask(query))
 	rows, err := c.transaction.QueryContext(c.ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("error executing query '%s': %w", query, err)
+		return nil, fmt.Errorf("error executing query '%s': %w", secrets.MaskerFromContext(c.ctx).Mask(query), err)
 	}
 	defer func() {
 		if err := closeRows(rows); err != nil {
@@ -63,11 +71,22 @@ func (c *exasolSqlClient) Query(query string, args ...any) (result *QueryResult,
 	}()
 	result, err = c.extractResult(rows)
 	if err != nil {
-		return nil, fmt.Errorf("error reading result from statement %q: %w", query, err)
+		return nil, fmt.Errorf("error reading result from statement %q: %w", secrets.MaskerFromContext(c.ctx).Mask(query), err)
 	}
 	return result, nil
 }
 
+// startSpan starts a span for the given statement. The statement is masked because it may contain secret parameter values.
+func (c *exasolSqlClient) startSpan(spanName, query string) trace.Span {
+	_, span := tracing.Start(c.ctx, spanName, attribute.String("db.query.text", secrets.MaskerFromContext(c.ctx).Mask(query)))
+	return span
+}
+
+// endSpan ends the span, masking secret values contained in the error message.
+func (c *exasolSqlClient) endSpan(span trace.Span, err error) {
+	tracing.End(span, secrets.MaskerFromContext(c.ctx).MaskError(err))
+}
+
 func closeRows(rows *sql.Rows) error {
 	err := rows.Close()
 	if err != nil {
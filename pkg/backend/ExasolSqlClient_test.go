@@ -8,7 +8,15 @@ import (
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/exasol/extension-manager/pkg/secrets"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 type ExasolSqlClientUTestSuite struct {
@@ -51,6 +59,55 @@ func (suite *ExasolSqlClientUTestSuite) TestExecuteSucceeds() {
 	suite.NotNil(result)
 }
 
+func (suite *ExasolSqlClientUTestSuite) TestExecuteMasksSecretsInLog() {
+	logHook := test.NewGlobal()
+	defer logHook.Reset()
+	defer logrus.SetLevel(logrus.GetLevel())
+	logrus.SetLevel(logrus.TraceLevel)
+	ctx := secrets.WithMasker(context.Background(), secrets.NewMasker("secret"))
+	client := NewSqlClient(ctx, suite.createMockTransaction())
+	suite.dbMock.ExpectExec("CREATE CONNECTION").WillReturnResult(sqlmock.NewResult(1, 1))
+	_, err := client.Execute("CREATE CONNECTION con TO '' USER 'user' IDENTIFIED BY 'secret'")
+	suite.Require().NoError(err)
+	suite.Equal(`Executing SQL statement "CREATE CONNECTION con TO '' USER 'user' IDENTIFIED BY '******'"...`, logHook.LastEntry().Message)
+}
+
+func (suite *ExasolSqlClientUTestSuite) TestExecuteCreatesSpanWithMaskedStatement() {
+	recorder := suite.recordSpans()
+	ctx := secrets.WithMasker(context.Background(), secrets.NewMasker("secret"))
+	client := NewSqlClient(ctx, suite.createMockTransaction())
+	suite.dbMock.ExpectExec("CREATE CONNECTION").WillReturnError(errors.New("invalid password 'secret'"))
+	_, err := client.Execute("CREATE CONNECTION con TO '' USER 'user' IDENTIFIED BY 'secret'")
+	suite.Require().Error(err)
+	spans := recorder.Ended()
+	suite.Require().Len(spans, 1)
+	suite.Equal("SimpleSQLClient.Execute", spans[0].Name())
+	suite.Equal([]attribute.KeyValue{attribute.String("db.query.text", "CREATE CONNECTION con TO '' USER 'user' IDENTIFIED BY '******'")}, spans[0].Attributes())
+	suite.Equal(codes.Error, spans[0].Status().Code)
+	suite.NotContains(spans[0].Status().Description, "secret")
+}
+
+func (suite *ExasolSqlClientUTestSuite) TestQueryCreatesSpan() {
+	recorder := suite.recordSpans()
+	client := suite.createClient()
+	suite.dbMock.ExpectQuery("select 1").WillReturnRows(sqlmock.NewRows([]string{"col"}).AddRow(1))
+	_, err := client.Query("select 1")
+	suite.Require().NoError(err)
+	spans := recorder.Ended()
+	suite.Require().Len(spans, 1)
+	suite.Equal("SimpleSQLClient.Query", spans[0].Name())
+	suite.Equal(codes.Unset, spans[0].Status().Code)
+}
+
+// recordSpans records all spans until the end of the current test.
+func (suite *ExasolSqlClientUTestSuite) recordSpans() *tracetest.SpanRecorder {
+	previousProvider := otel.GetTracerProvider()
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	suite.T().Cleanup(func() { otel.SetTracerProvider(previousProvider) })
+	return recorder
+}
+
 func (suite *ExasolSqlClientUTestSuite) TestExecuteFails() {
 	client := suite.createClient()
 	suite.dbMock.ExpectExec("invalid").WillReturnError(errors.New("expected"))
@@ -59,6 +116,24 @@ func (suite *ExasolSqlClientUTestSuite) TestExecuteFails() {
 	suite.Nil(result)
 }
 
+func (suite *ExasolSqlClientUTestSuite) TestExecuteFailsMasksSecretsInError() {
+	ctx := secrets.WithMasker(context.Background(), secrets.NewMasker("secret"))
+	client := NewSqlClient(ctx, suite.createMockTransaction())
+	suite.dbMock.ExpectExec("CREATE CONNECTION").WillReturnError(errors.New("expected"))
+	result, err := client.Execute("CREATE CONNECTION con TO '' USER 'user' IDENTIFIED BY 'secret'")
+	suite.Require().EqualError(err, "error executing statement 'CREATE CONNECTION con TO '' USER 'user' IDENTIFIED BY '******'': expected")
+	suite.Nil(result)
+}
+
+func (suite *ExasolSqlClientUTestSuite) TestExecuteFailsMasksEscapedSecretsInError() {
+	ctx := secrets.WithMasker(context.Background(), secrets.NewMasker("pass'word"))
+	client := NewSqlClient(ctx, suite.createMockTransaction())
+	suite.dbMock.ExpectExec("CREATE CONNECTION").WillReturnError(errors.New("expected"))
+	result, err := client.Execute("CREATE CONNECTION con TO '' USER 'user' IDENTIFIED BY 'pass''word'")
+	suite.Require().EqualError(err, "error executing statement 'CREATE CONNECTION con TO '' USER 'user' IDENTIFIED BY '******'': expected")
+	suite.Nil(result)
+}
+
 var forbiddenCommandTests = []struct {
 	statement        string
 	forbiddenCommand string
@@ -103,6 +178,15 @@ func (suite *ExasolSqlClientUTestSuite) TestQueryFails() {
 	suite.Nil(result)
 }
 
+func (suite *ExasolSqlClientUTestSuite) TestQueryFailsMasksSecretsInError() {
+	ctx := secrets.WithMasker(context.Background(), secrets.NewMasker("secret"))
+	client := NewSqlClient(ctx, suite.createMockTransaction())
+	suite.dbMock.ExpectQuery("SELECT").WillReturnError(errors.New("expected")).RowsWillBeClosed()
+	result, err := client.Query("SELECT 'secret'")
+	suite.Require().EqualError(err, "error executing query 'SELECT '******'': expected")
+	suite.Nil(result)
+}
+
 /* [utest -> dsn~extension-context-sql-client~1]. */
 func (suite *ExasolSqlClientUTestSuite) TestQuerySucceeds() {
 	client := suite.createClient()
@@ -0,0 +1,196 @@
+// Package config loads the configuration of the standalone extension manager server
+// from configuration files, environment variables and command line flags.
+package config
+
+import (
+	"time"
+
+	"github.com/exasol/extension-manager/pkg/extensionController"
+	"github.com/exasol/extension-manager/pkg/logging"
+	"github.com/exasol/extension-manager/pkg/restAPI"
+	"github.com/exasol/extension-manager/pkg/tracing"
+)
+
+// Supported values for [LogConfig.Format].
+const (
+	LogFormatText = logging.FormatText // Human readable lines containing level and message
+	LogFormatJSON = logging.FormatJSON // One JSON object per line with timestamp, level, message, request ID and other fields
+)
+
+// DefaultBucketFsBasePath is the BucketFS base path used when no base paths are configured.
+const DefaultBucketFsBasePath = "/buckets/bfsdefault/default/"
+
+// DefaultShutdownDelay is the time the standalone server keeps accepting connections after it receives SIGTERM,
+// so that load balancers can remove it from their endpoints, see [restAPI.ServerConfig.ShutdownDelay].
+const DefaultShutdownDelay = 5 * time.Second
+
+// Config contains all settings of the standalone extension manager server.
+type Config struct {
+	// URL of the extension registry index or path of a local directory containing extensions.
+	ExtensionRegistryURL string `yaml:"extensionRegistryURL"`
+	// Schema where EM searches for and creates extensions.
+	ExtensionSchema string         `yaml:"extensionSchema"`
+	BucketFs        BucketFsConfig `yaml:"bucketFs"`
+	Server          ServerConfig   `yaml:"server"`
+	Database        DatabaseConfig `yaml:"database"`
+	Jobs            JobsConfig     `yaml:"jobs"`
+	Log             LogConfig      `yaml:"log"`
+	Tracing         TracingConfig  `yaml:"tracing"`
+}
+
+// BucketFsConfig configures where EM searches for extension files in BucketFS.
+type BucketFsConfig struct {
+	// BucketFS base paths where EM searches for extension files in the given order.
+	BasePaths []string `yaml:"basePaths"`
+	// Duration for reusing BucketFS file listings across requests, 0 disables the cache.
+	ListingCacheTTL time.Duration `yaml:"listingCacheTTL"`
+}
+
+// ServerConfig configures the REST server, see [restAPI.ServerConfig].
+type ServerConfig struct {
+	Address                       string          `yaml:"address"`
+	AddCauseToInternalServerError bool            `yaml:"addCauseToInternalServerError"`
+	RequestTimeout                time.Duration   `yaml:"requestTimeout"`
+	ReadHeaderTimeout             time.Duration   `yaml:"readHeaderTimeout"`
+	ShutdownTimeout               time.Duration   `yaml:"shutdownTimeout"`
+	ShutdownDelay                 time.Duration   `yaml:"shutdownDelay"`
+	TLS                           ServerTLSConfig `yaml:"tls"`
+}
+
+// ServerTLSConfig configures TLS for the REST server, see [restAPI.ServerTLSConfig]. The server uses TLS if any of the files is set.
+type ServerTLSConfig struct {
+	CertFile     string `yaml:"certFile"`
+	KeyFile      string `yaml:"keyFile"`
+	ClientCAFile string `yaml:"clientCAFile"`
+}
+
+// DatabaseConfig configures connections to the Exasol database, see [restAPI.DatabaseConfig].
+type DatabaseConfig struct {
+	ValidateServerCertificate bool               `yaml:"validateServerCertificate"`
+	CABundleFile              string             `yaml:"caBundleFile"`
+	CertificateFingerprint    string             `yaml:"certificateFingerprint"`
+	Targets                   []DatabaseTarget   `yaml:"targets"`
+	DefaultTarget             string             `yaml:"defaultTarget"`
+	Pool                      DatabasePoolConfig `yaml:"pool"`
+}
+
+// DatabaseTarget is a named Exasol database, see [restAPI.DatabaseTarget].
+type DatabaseTarget struct {
+	Name string `yaml:"name"`
+	Host string `yaml:"host"`
+	Port int    `yaml:"port"`
+}
+
+// DatabasePoolConfig configures reusing database connections, see [restAPI.DatabasePoolConfig].
+type DatabasePoolConfig struct {
+	MaxSize     int           `yaml:"maxSize"`
+	IdleTimeout time.Duration `yaml:"idleTimeout"`
+}
+
+// JobsConfig configures asynchronous jobs, see [restAPI.JobsConfig].
+type JobsConfig struct {
+	Directory string        `yaml:"directory"`
+	Retention time.Duration `yaml:"retention"`
+}
+
+// LogConfig configures log messages.
+type LogConfig struct {
+	// Log level, e.g. "info" or "debug".
+	Level string `yaml:"level"`
+	// Format of log messages, [LogFormatText] or [LogFormatJSON].
+	Format string `yaml:"format"`
+}
+
+// TracingConfig configures exporting OpenTelemetry spans, see [tracing.Config].
+type TracingConfig struct {
+	Exporter     string `yaml:"exporter"`
+	OTLPEndpoint string `yaml:"otlpEndpoint"`
+	OTLPInsecure bool   `yaml:"otlpInsecure"`
+}
+
+// Defaults returns the configuration used for all settings that are not configured explicitly.
+func Defaults() *Config {
+	return &Config{
+		ExtensionRegistryURL: "",
+		ExtensionSchema:      restAPI.EXTENSION_SCHEMA_NAME,
+		BucketFs:             BucketFsConfig{BasePaths: []string{DefaultBucketFsBasePath}, ListingCacheTTL: 0},
+		Server: ServerConfig{
+			Address:                       ":8080",
+			AddCauseToInternalServerError: false,
+			RequestTimeout:                restAPI.DefaultRequestTimeout,
+			ReadHeaderTimeout:             restAPI.DefaultReadHeaderTimeout,
+			ShutdownTimeout:               restAPI.DefaultShutdownTimeout,
+			ShutdownDelay:                 DefaultShutdownDelay,
+			TLS:                           ServerTLSConfig{CertFile: "", KeyFile: "", ClientCAFile: ""},
+		},
+		Database: DatabaseConfig{
+			ValidateServerCertificate: false,
+			CABundleFile:              "",
+			CertificateFingerprint:    "",
+			Targets:                   nil,
+			DefaultTarget:             "",
+			Pool:                      DatabasePoolConfig{MaxSize: 0, IdleTimeout: restAPI.DefaultDatabasePoolIdleTimeout},
+		},
+		Jobs:    JobsConfig{Directory: "", Retention: restAPI.DefaultJobRetention},
+		Log:     LogConfig{Level: "info", Format: LogFormatText},
+		Tracing: TracingConfig{Exporter: tracing.ExporterNone, OTLPEndpoint: "", OTLPInsecure: false},
+	}
+}
+
+// ExtensionManagerConfig returns the configuration of the extension controller.
+func (c *Config) ExtensionManagerConfig() extensionController.ExtensionManagerConfig {
+	return extensionController.ExtensionManagerConfig{
+		ExtensionRegistryURL:    c.ExtensionRegistryURL,
+		BucketFSBasePath:        "",
+		BucketFSBasePaths:       c.BucketFs.BasePaths,
+		ExtensionSchema:         c.ExtensionSchema,
+		BucketFSListingCacheTTL: c.BucketFs.ListingCacheTTL,
+	}
+}
+
+// RestAPIConfig returns the configuration of the REST server.
+func (c *Config) RestAPIConfig() restAPI.ServerConfig {
+	return restAPI.ServerConfig{
+		ServerAddress:                 c.Server.Address,
+		AddCauseToInternalServerError: c.Server.AddCauseToInternalServerError,
+		TLS:                           c.serverTLSConfig(),
+		Database: restAPI.DatabaseConfig{
+			ValidateServerCertificate: c.Database.ValidateServerCertificate,
+			CABundleFile:              c.Database.CABundleFile,
+			CertificateFingerprint:    c.Database.CertificateFingerprint,
+			Targets:                   c.databaseTargets(),
+			DefaultTarget:             c.Database.DefaultTarget,
+			Pool:                      restAPI.DatabasePoolConfig{MaxSize: c.Database.Pool.MaxSize, IdleTimeout: c.Database.Pool.IdleTimeout},
+		},
+		Jobs:              restAPI.JobsConfig{Directory: c.Jobs.Directory, Retention: c.Jobs.Retention},
+		RequestTimeout:    c.Server.RequestTimeout,
+		ReadHeaderTimeout: c.Server.ReadHeaderTimeout,
+		ShutdownTimeout:   c.Server.ShutdownTimeout,
+		ShutdownDelay:     c.Server.ShutdownDelay,
+	}
+}
+
+// serverTLSConfig returns the TLS configuration for the server or nil if no TLS file is configured.
+func (c *Config) serverTLSConfig() *restAPI.ServerTLSConfig {
+	tls := c.Server.TLS
+	if tls.CertFile == "" && tls.KeyFile == "" && tls.ClientCAFile == "" {
+		return nil
+	}
+	return &restAPI.ServerTLSConfig{CertFile: tls.CertFile, KeyFile: tls.KeyFile, ClientCAFile: tls.ClientCAFile}
+}
+
+func (c *Config) databaseTargets() []restAPI.DatabaseTarget {
+	if len(c.Database.Targets) == 0 {
+		return nil
+	}
+	targets := make([]restAPI.DatabaseTarget, 0, len(c.Database.Targets))
+	for _, target := range c.Database.Targets {
+		targets = append(targets, restAPI.DatabaseTarget(target))
+	}
+	return targets
+}
+
+// TracingConfig returns the configuration for exporting spans.
+func (c *Config) TracingConfig() tracing.Config {
+	return tracing.Config{Exporter: c.Tracing.Exporter, OTLPEndpoint: c.Tracing.OTLPEndpoint, OTLPInsecure: c.Tracing.OTLPInsecure, ServiceName: tracing.DefaultServiceName}
+}
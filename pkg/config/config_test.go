@@ -0,0 +1,57 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/exasol/extension-manager/pkg/extensionController"
+	"github.com/exasol/extension-manager/pkg/restAPI"
+	"github.com/exasol/extension-manager/pkg/tracing"
+	"github.com/stretchr/testify/suite"
+)
+
+type ConfigSuite struct {
+	suite.Suite
+}
+
+func TestConfigSuite(t *testing.T) {
+	suite.Run(t, new(ConfigSuite))
+}
+
+func (suite *ConfigSuite) TestExtensionManagerConfig() {
+	config := Defaults()
+	config.ExtensionRegistryURL = "registry"
+	config.BucketFs.ListingCacheTTL = time.Minute
+	suite.Equal(extensionController.ExtensionManagerConfig{ExtensionRegistryURL: "registry", BucketFSBasePath: "", BucketFSBasePaths: []string{DefaultBucketFsBasePath},
+		ExtensionSchema: restAPI.EXTENSION_SCHEMA_NAME, BucketFSListingCacheTTL: time.Minute}, config.ExtensionManagerConfig())
+}
+
+func (suite *ConfigSuite) TestRestAPIConfigDefaults() {
+	suite.Equal(restAPI.ServerConfig{
+		ServerAddress:                 ":8080",
+		AddCauseToInternalServerError: false,
+		TLS:                           nil,
+		Database: restAPI.DatabaseConfig{ValidateServerCertificate: false, CABundleFile: "", CertificateFingerprint: "", Targets: nil, DefaultTarget: "",
+			Pool: restAPI.DatabasePoolConfig{MaxSize: 0, IdleTimeout: restAPI.DefaultDatabasePoolIdleTimeout}},
+		Jobs:              restAPI.JobsConfig{Directory: "", Retention: restAPI.DefaultJobRetention},
+		RequestTimeout:    restAPI.DefaultRequestTimeout,
+		ReadHeaderTimeout: restAPI.DefaultReadHeaderTimeout,
+		ShutdownTimeout:   restAPI.DefaultShutdownTimeout,
+		ShutdownDelay:     DefaultShutdownDelay,
+	}, Defaults().RestAPIConfig())
+}
+
+func (suite *ConfigSuite) TestRestAPIConfigWithTlsAndTargets() {
+	config := Defaults()
+	config.Server.TLS = ServerTLSConfig{CertFile: "cert.pem", KeyFile: "key.pem", ClientCAFile: ""}
+	config.Database.Targets = []DatabaseTarget{{Name: "prod", Host: "exasol", Port: 8563}}
+	actual := config.RestAPIConfig()
+	suite.Equal(&restAPI.ServerTLSConfig{CertFile: "cert.pem", KeyFile: "key.pem", ClientCAFile: ""}, actual.TLS)
+	suite.Equal([]restAPI.DatabaseTarget{{Name: "prod", Host: "exasol", Port: 8563}}, actual.Database.Targets)
+}
+
+func (suite *ConfigSuite) TestTracingConfig() {
+	config := Defaults()
+	config.Tracing = TracingConfig{Exporter: tracing.ExporterOTLP, OTLPEndpoint: "collector:4318", OTLPInsecure: true}
+	suite.Equal(tracing.Config{Exporter: tracing.ExporterOTLP, OTLPEndpoint: "collector:4318", OTLPInsecure: true, ServiceName: tracing.DefaultServiceName}, config.TracingConfig())
+}
@@ -0,0 +1,282 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/exasol/extension-manager/pkg/restAPI"
+	"go.yaml.in/yaml/v3"
+)
+
+// Names of the flag and environment variable containing the configuration files.
+const (
+	configFileFlag = "config"
+	configFileEnv  = "EM_CONFIG_FILE"
+)
+
+// setting is a single configuration value that can be set with a command line flag or an environment variable.
+type setting struct {
+	flag  string              // Name of the command line flag, e.g. "serverAddress"
+	env   string              // Name of the environment variable, e.g. "EM_SERVER_ADDRESS"
+	usage string              // Description of the flag
+	field func(c *Config) any // Returns a pointer to the field of this setting
+}
+
+// getSettings returns all settings that can be configured with flags and environment variables.
+// Lists are separated by commas, durations use the format of [time.ParseDuration], e.g. "30s".
+func getSettings() []setting {
+	return []setting{
+		{"extensionRegistryURL", "EM_EXTENSION_REGISTRY_URL", "URL of the extension registry index used to find available extensions or the path of a local directory",
+			func(c *Config) any { return &c.ExtensionRegistryURL }},
+		{"extensionSchema", "EM_EXTENSION_SCHEMA", "Schema where EM searches for and creates extensions",
+			func(c *Config) any { return &c.ExtensionSchema }},
+		{"bucketFsBasePaths", "EM_BUCKETFS_BASE_PATHS", "Comma separated list of BucketFS base paths where to search for extension files. EM searches the paths in the given order.",
+			func(c *Config) any { return &c.BucketFs.BasePaths }},
+		{"bucketFsListingCacheTTL", "EM_BUCKETFS_LISTING_CACHE_TTL", `Duration for reusing BucketFS file listings across requests, e.g. "30s". 0 disables the cache.`,
+			func(c *Config) any { return &c.BucketFs.ListingCacheTTL }},
+		{"serverAddress", "EM_SERVER_ADDRESS", `Server address, e.g. ":8080" (all network interfaces) or "localhost:8080" (only local interface)`,
+			func(c *Config) any { return &c.Server.Address }},
+		{"addCauseToInternalServerError", "EM_ADD_CAUSE_TO_INTERNAL_SERVER_ERROR", "Add cause of internal server errors (status 500) to the error message. Don't use this in production!",
+			func(c *Config) any { return &c.Server.AddCauseToInternalServerError }},
+		{"requestTimeout", "EM_REQUEST_TIMEOUT", "Maximum duration for processing a synchronous request",
+			func(c *Config) any { return &c.Server.RequestTimeout }},
+		{"readHeaderTimeout", "EM_READ_HEADER_TIMEOUT", "Maximum duration for reading the request headers",
+			func(c *Config) any { return &c.Server.ReadHeaderTimeout }},
+		{"shutdownTimeout", "EM_SHUTDOWN_TIMEOUT", "Drain period when the server receives SIGTERM or SIGINT. EM refuses new modifying requests and waits for running requests and jobs, then aborts them.",
+			func(c *Config) any { return &c.Server.ShutdownTimeout }},
+		{"shutdownDelay", "EM_SHUTDOWN_DELAY", "Part of the drain period during which the server still accepts connections but reports that it is not ready, so that load balancers stop sending requests",
+			func(c *Config) any { return &c.Server.ShutdownDelay }},
+		{"serverCertFile", "EM_SERVER_CERT_FILE", "PEM file with the server certificate. If this and -serverKeyFile are set, the server uses HTTPS and reloads the certificate when the file changes.",
+			func(c *Config) any { return &c.Server.TLS.CertFile }},
+		{"serverKeyFile", "EM_SERVER_KEY_FILE", "PEM file with the private key of the server certificate",
+			func(c *Config) any { return &c.Server.TLS.KeyFile }},
+		{"serverClientCAFile", "EM_SERVER_CLIENT_CA_FILE", "Optional PEM file with CA certificates. If set, clients must authenticate with a certificate signed by one of these CAs.",
+			func(c *Config) any { return &c.Server.TLS.ClientCAFile }},
+		{"dbValidateServerCertificate", "EM_DB_VALIDATE_SERVER_CERTIFICATE", "Validate the TLS certificate of the Exasol database",
+			func(c *Config) any { return &c.Database.ValidateServerCertificate }},
+		{"dbCABundleFile", "EM_DB_CA_BUNDLE_FILE", "Optional PEM file with CA certificates for validating the database certificate instead of the system's CA certificates. Requires -dbValidateServerCertificate.",
+			func(c *Config) any { return &c.Database.CABundleFile }},
+		{"dbCertificateFingerprint", "EM_DB_CERTIFICATE_FINGERPRINT", "Optional SHA256 fingerprint of the database certificate in hex format. If set, EM only accepts a certificate with this fingerprint.",
+			func(c *Config) any { return &c.Database.CertificateFingerprint }},
+		{"dbTargets", "EM_DB_TARGETS", `Optional comma separated list of database targets in format "name=host:port". If set, clients can only connect to these databases by selecting a target with query parameter dbTarget.`,
+			func(c *Config) any { return &c.Database.Targets }},
+		{"dbDefaultTarget", "EM_DB_DEFAULT_TARGET", "Name of the database target used when a request does not specify query parameter dbTarget. Optional if -dbTargets contains only one target.",
+			func(c *Config) any { return &c.Database.DefaultTarget }},
+		{"dbPoolMaxSize", "EM_DB_POOL_MAX_SIZE", "Maximum number of pooled database connections per combination of database and credentials. 0 opens a new connection for each request.",
+			func(c *Config) any { return &c.Database.Pool.MaxSize }},
+		{"dbPoolIdleTimeout", "EM_DB_POOL_IDLE_TIMEOUT", "Duration after which pooled database connections are closed when unused",
+			func(c *Config) any { return &c.Database.Pool.IdleTimeout }},
+		{"jobsDirectory", "EM_JOBS_DIRECTORY", "Optional directory for storing asynchronous jobs, so that their status is available after a restart. By default jobs are only kept in memory.",
+			func(c *Config) any { return &c.Jobs.Directory }},
+		{"jobRetention", "EM_JOB_RETENTION", "Duration after which finished asynchronous jobs are deleted",
+			func(c *Config) any { return &c.Jobs.Retention }},
+		{"logLevel", "EM_LOG_LEVEL", `Log level: "error", "warn", "info", "debug" or "trace"`,
+			func(c *Config) any { return &c.Log.Level }},
+		{"logFormat", "EM_LOG_FORMAT", `Format of log messages: "text" or "json"`,
+			func(c *Config) any { return &c.Log.Format }},
+		{"traceExporter", "EM_TRACE_EXPORTER", `Exporter for OpenTelemetry spans: "none", "stdout" or "otlp" (OTLP over HTTP)`,
+			func(c *Config) any { return &c.Tracing.Exporter }},
+		{"traceOtlpEndpoint", "EM_TRACE_OTLP_ENDPOINT", `Endpoint of the OTLP collector, e.g. "localhost:4318". Default: environment variable OTEL_EXPORTER_OTLP_ENDPOINT or "localhost:4318".`,
+			func(c *Config) any { return &c.Tracing.OTLPEndpoint }},
+		{"traceOtlpInsecure", "EM_TRACE_OTLP_INSECURE", "Use HTTP instead of HTTPS for sending spans to the OTLP collector",
+			func(c *Config) any { return &c.Tracing.OTLPInsecure }},
+	}
+}
+
+// Loader loads the configuration. Settings are applied in the following order, later sources override earlier ones:
+//  1. default values, see [Defaults]
+//  2. configuration files in YAML or JSON format from environment variable EM_CONFIG_FILE (comma separated) and flag -config (repeatable)
+//  3. environment variables starting with "EM_"
+//  4. command line flags
+type Loader struct {
+	settings    []setting
+	configFiles []string
+	flagValues  []flagValue
+}
+
+// flagValue is the raw value of a flag given on the command line.
+type flagValue struct {
+	setting setting
+	value   string
+}
+
+// NewLoader creates a new [Loader] and registers flags for all settings in the given flag set.
+// Call [Loader.Load] after parsing the flags.
+func NewLoader(flagSet *flag.FlagSet) *Loader {
+	loader := &Loader{settings: getSettings(), configFiles: nil, flagValues: nil}
+	defaults := Defaults()
+	for _, s := range loader.settings {
+		flagSet.Var(&settingFlag{loader: loader, setting: s, defaultValue: formatValue(s.field(defaults))}, s.flag, s.usage+" (env "+s.env+")")
+	}
+	flagSet.Func(configFileFlag, "Configuration file in YAML or JSON format. Repeat the flag for loading multiple files, later files override earlier ones. (env "+configFileEnv+", comma separated)",
+		func(value string) error {
+			loader.configFiles = append(loader.configFiles, value)
+			return nil
+		})
+	return loader
+}
+
+// Load creates the configuration from all sources and validates it.
+// lookupEnv returns the value of an environment variable, usually this is [os.LookupEnv].
+// The returned error contains all invalid settings, not only the first one.
+func (l *Loader) Load(lookupEnv func(string) (string, bool)) (*Config, error) {
+	config := Defaults()
+	errs := make([]error, 0)
+	for _, file := range l.getConfigFiles(lookupEnv) {
+		if err := loadFile(config, file); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, s := range l.settings {
+		if value, found := lookupEnv(s.env); found {
+			if err := parseValue(s.field(config), value); err != nil {
+				errs = append(errs, fmt.Errorf("invalid value %q of environment variable %s: %w", value, s.env, err))
+			}
+		}
+	}
+	for _, f := range l.flagValues {
+		if err := parseValue(f.setting.field(config), f.value); err != nil {
+			errs = append(errs, fmt.Errorf("invalid value %q of flag -%s: %w", f.value, f.setting.flag, err))
+		}
+	}
+	errs = append(errs, config.validate()...)
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return config, nil
+}
+
+func (l *Loader) getConfigFiles(lookupEnv func(string) (string, bool)) []string {
+	files := make([]string, 0)
+	if value, found := lookupEnv(configFileEnv); found {
+		files = append(files, splitList(value)...)
+	}
+	return append(files, l.configFiles...)
+}
+
+// loadFile reads a configuration file in YAML or JSON format. Settings missing in the file keep their current value.
+func loadFile(config *Config, file string) error {
+	content, err := os.Open(file)
+	if err != nil {
+		return fmt.Errorf("failed to read configuration file: %w", err)
+	}
+	defer content.Close()
+	decoder := yaml.NewDecoder(content)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(config); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("invalid configuration file %q: %w", file, err)
+	}
+	return nil
+}
+
+// parseValue parses the given string and stores it in the field the pointer points to.
+func parseValue(field any, value string) error {
+	switch f := field.(type) {
+	case *string:
+		*f = value
+	case *bool:
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return errors.New("expected true or false")
+		}
+		*f = parsed
+	case *int:
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return errors.New("expected an integer number")
+		}
+		*f = parsed
+	case *time.Duration:
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return errors.New(`expected a duration like "30s" or "5m"`)
+		}
+		*f = parsed
+	case *[]string:
+		*f = splitList(value)
+	case *[]DatabaseTarget:
+		targets, err := restAPI.ParseDatabaseTargets(value)
+		if err != nil {
+			return err
+		}
+		*f = make([]DatabaseTarget, 0, len(targets))
+		for _, target := range targets {
+			*f = append(*f, DatabaseTarget(target))
+		}
+	default:
+		panic(fmt.Sprintf("unsupported setting type %T", field))
+	}
+	return nil
+}
+
+// formatValue formats the value of the given field in the format expected by [parseValue].
+func formatValue(field any) string {
+	switch f := field.(type) {
+	case *string:
+		return *f
+	case *bool:
+		return strconv.FormatBool(*f)
+	case *int:
+		return strconv.Itoa(*f)
+	case *time.Duration:
+		return f.String()
+	case *[]string:
+		return strings.Join(*f, ",")
+	case *[]DatabaseTarget:
+		targets := make([]string, 0, len(*f))
+		for _, target := range *f {
+			targets = append(targets, fmt.Sprintf("%s=%s:%d", target.Name, target.Host, target.Port))
+		}
+		return strings.Join(targets, ",")
+	default:
+		panic(fmt.Sprintf("unsupported setting type %T", field))
+	}
+}
+
+func splitList(value string) []string {
+	result := make([]string, 0)
+	for _, entry := range strings.Split(value, ",") {
+		if trimmed := strings.TrimSpace(entry); trimmed != "" {
+			result = append(result, trimmed)
+		}
+	}
+	return result
+}
+
+// settingFlag is a [flag.Value] that records the raw value of a setting.
+// Values are parsed in [Loader.Load], so that all invalid values are reported at once.
+type settingFlag struct {
+	loader       *Loader
+	setting      setting
+	defaultValue string
+}
+
+// String returns the default value shown in the usage. Zero values are omitted like for the standard flag types.
+func (f *settingFlag) String() string {
+	if f == nil {
+		return ""
+	}
+	switch f.defaultValue {
+	case "false", "0", "0s":
+		return ""
+	default:
+		return f.defaultValue
+	}
+}
+
+func (f *settingFlag) Set(value string) error {
+	f.loader.flagValues = append(f.loader.flagValues, flagValue{setting: f.setting, value: value})
+	return nil
+}
+
+// IsBoolFlag allows using boolean flags without value, e.g. "-dbValidateServerCertificate".
+func (f *settingFlag) IsBoolFlag() bool {
+	_, isBool := f.setting.field(Defaults()).(*bool)
+	return isBool
+}
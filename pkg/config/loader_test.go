@@ -0,0 +1,192 @@
+package config
+
+import (
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/exasol/extension-manager/pkg/restAPI"
+	"github.com/stretchr/testify/suite"
+)
+
+type LoaderSuite struct {
+	suite.Suite
+	flagSet *flag.FlagSet
+	loader  *Loader
+	env     map[string]string
+}
+
+func TestLoaderSuite(t *testing.T) {
+	suite.Run(t, new(LoaderSuite))
+}
+
+func (suite *LoaderSuite) SetupTest() {
+	suite.flagSet = flag.NewFlagSet("test", flag.ContinueOnError)
+	suite.flagSet.SetOutput(io.Discard)
+	suite.loader = NewLoader(suite.flagSet)
+	suite.env = map[string]string{"EM_EXTENSION_REGISTRY_URL": "http://registry"}
+}
+
+func (suite *LoaderSuite) lookupEnv(name string) (string, bool) {
+	value, found := suite.env[name]
+	return value, found
+}
+
+func (suite *LoaderSuite) load(args ...string) (*Config, error) {
+	suite.Require().NoError(suite.flagSet.Parse(args))
+	return suite.loader.Load(suite.lookupEnv)
+}
+
+func (suite *LoaderSuite) writeFile(name, content string) string {
+	file := filepath.Join(suite.T().TempDir(), name)
+	suite.Require().NoError(os.WriteFile(file, []byte(content), 0600))
+	return file
+}
+
+func (suite *LoaderSuite) TestDefaults() {
+	config, err := suite.load()
+	suite.Require().NoError(err)
+	expected := Defaults()
+	expected.ExtensionRegistryURL = "http://registry"
+	suite.Equal(expected, config)
+}
+
+func (suite *LoaderSuite) TestDefaultLogLevelIsInfo() {
+	config, err := suite.load()
+	suite.Require().NoError(err)
+	suite.Equal("info", config.Log.Level)
+}
+
+func (suite *LoaderSuite) TestMissingRegistryUrl() {
+	delete(suite.env, "EM_EXTENSION_REGISTRY_URL")
+	config, err := suite.load()
+	suite.Nil(config)
+	suite.EqualError(err, "extensionRegistryURL: must not be empty")
+}
+
+func (suite *LoaderSuite) TestYamlFile() {
+	delete(suite.env, "EM_EXTENSION_REGISTRY_URL")
+	file := suite.writeFile("config.yaml", `
+extensionRegistryURL: http://file-registry
+bucketFs:
+  basePaths: [/buckets/a/, /buckets/b/]
+  listingCacheTTL: 30s
+server:
+  address: localhost:9090
+  requestTimeout: 2m
+database:
+  targets:
+    - name: prod
+      host: exasol
+      port: 8563
+  pool:
+    maxSize: 5
+log:
+  level: debug
+`)
+	config, err := suite.load("-config", file)
+	suite.Require().NoError(err)
+	suite.Equal("http://file-registry", config.ExtensionRegistryURL)
+	suite.Equal([]string{"/buckets/a/", "/buckets/b/"}, config.BucketFs.BasePaths)
+	suite.Equal(30*time.Second, config.BucketFs.ListingCacheTTL)
+	suite.Equal("localhost:9090", config.Server.Address)
+	suite.Equal(2*time.Minute, config.Server.RequestTimeout)
+	suite.Equal(restAPI.DefaultReadHeaderTimeout, config.Server.ReadHeaderTimeout)
+	suite.Equal(restAPI.DefaultShutdownTimeout, config.Server.ShutdownTimeout)
+	suite.Equal(DefaultShutdownDelay, config.Server.ShutdownDelay)
+	suite.Equal([]DatabaseTarget{{Name: "prod", Host: "exasol", Port: 8563}}, config.Database.Targets)
+	suite.Equal(5, config.Database.Pool.MaxSize)
+	suite.Equal("debug", config.Log.Level)
+}
+
+func (suite *LoaderSuite) TestJsonFile() {
+	file := suite.writeFile("config.json", `{"extensionSchema": "MY_SCHEMA", "jobs": {"retention": "48h"}}`)
+	config, err := suite.load("-config", file)
+	suite.Require().NoError(err)
+	suite.Equal("MY_SCHEMA", config.ExtensionSchema)
+	suite.Equal(48*time.Hour, config.Jobs.Retention)
+}
+
+func (suite *LoaderSuite) TestLaterFileOverridesEarlierFile() {
+	first := suite.writeFile("first.yaml", "server: {address: ':1'}\nlog: {level: debug}")
+	second := suite.writeFile("second.yaml", "server: {address: ':2'}")
+	suite.env["EM_CONFIG_FILE"] = first
+	config, err := suite.load("-config", second)
+	suite.Require().NoError(err)
+	suite.Equal(":2", config.Server.Address)
+	suite.Equal("debug", config.Log.Level)
+}
+
+func (suite *LoaderSuite) TestPrecedence() {
+	file := suite.writeFile("config.yaml", "server: {address: ':1'}\nextensionSchema: FILE_SCHEMA\njobs: {directory: /file}")
+	suite.env["EM_SERVER_ADDRESS"] = ":2"
+	suite.env["EM_EXTENSION_SCHEMA"] = "ENV_SCHEMA"
+	config, err := suite.load("-config", file, "-serverAddress", ":3")
+	suite.Require().NoError(err)
+	suite.Equal(":3", config.Server.Address)
+	suite.Equal("ENV_SCHEMA", config.ExtensionSchema)
+	suite.Equal("/file", config.Jobs.Directory)
+}
+
+func (suite *LoaderSuite) TestEnvironmentVariables() {
+	suite.env["EM_BUCKETFS_BASE_PATHS"] = " /buckets/a/ ,, /buckets/b/"
+	suite.env["EM_DB_TARGETS"] = "prod=exasol:8563"
+	suite.env["EM_DB_POOL_IDLE_TIMEOUT"] = "90s"
+	suite.env["EM_DB_VALIDATE_SERVER_CERTIFICATE"] = "true"
+	config, err := suite.load()
+	suite.Require().NoError(err)
+	suite.Equal([]string{"/buckets/a/", "/buckets/b/"}, config.BucketFs.BasePaths)
+	suite.Equal([]DatabaseTarget{{Name: "prod", Host: "exasol", Port: 8563}}, config.Database.Targets)
+	suite.Equal(90*time.Second, config.Database.Pool.IdleTimeout)
+	suite.True(config.Database.ValidateServerCertificate)
+}
+
+func (suite *LoaderSuite) TestBoolFlagWithoutValue() {
+	config, err := suite.load("-addCauseToInternalServerError", "-traceOtlpInsecure=false")
+	suite.Require().NoError(err)
+	suite.True(config.Server.AddCauseToInternalServerError)
+	suite.False(config.Tracing.OTLPInsecure)
+}
+
+func (suite *LoaderSuite) TestFlagDefaultInUsage() {
+	suite.Equal(":8080", suite.flagSet.Lookup("serverAddress").DefValue)
+	suite.Equal(DefaultBucketFsBasePath, suite.flagSet.Lookup("bucketFsBasePaths").DefValue)
+	suite.Equal("1m0s", suite.flagSet.Lookup("requestTimeout").DefValue)
+}
+
+func (suite *LoaderSuite) TestReportsAllErrors() {
+	suite.env["EM_DB_POOL_MAX_SIZE"] = "many"
+	suite.env["EM_LOG_LEVEL"] = "verbose"
+	config, err := suite.load("-requestTimeout", "soon", "-traceExporter", "jaeger", "-dbDefaultTarget", "missing")
+	suite.Nil(config)
+	suite.EqualError(err, `invalid value "many" of environment variable EM_DB_POOL_MAX_SIZE: expected an integer number
+invalid value "soon" of flag -requestTimeout: expected a duration like "30s" or "5m"
+database.defaultTarget: target "missing" is not configured in database.targets
+log.level: unsupported level "verbose", use one of error, warn, info, debug or trace
+tracing.exporter: unsupported exporter "jaeger", use one of "none", "stdout" or "otlp"`)
+}
+
+func (suite *LoaderSuite) TestInvalidDatabaseTargetFlag() {
+	_, err := suite.load("-dbTargets", "prod")
+	suite.ErrorContains(err, `invalid value "prod" of flag -dbTargets: `)
+}
+
+func (suite *LoaderSuite) TestMissingFile() {
+	_, err := suite.load("-config", "/missing/config.yaml")
+	suite.ErrorContains(err, "failed to read configuration file: open /missing/config.yaml: no such file or directory")
+}
+
+func (suite *LoaderSuite) TestUnknownFieldInFile() {
+	file := suite.writeFile("config.yaml", "server: {adress: ':1'}")
+	_, err := suite.load("-config", file)
+	suite.ErrorContains(err, "field adress not found in type config.ServerConfig")
+}
+
+func (suite *LoaderSuite) TestEmptyFile() {
+	file := suite.writeFile("config.yaml", "")
+	_, err := suite.load("-config", file)
+	suite.NoError(err)
+}
@@ -0,0 +1,152 @@
+package config
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/exasol/extension-manager/pkg/tracing"
+	log "github.com/sirupsen/logrus"
+)
+
+// validate checks all settings and returns an error for each invalid setting.
+func (c *Config) validate() []error {
+	v := &validator{errs: make([]error, 0)}
+	v.require("extensionRegistryURL", c.ExtensionRegistryURL)
+	v.require("extensionSchema", c.ExtensionSchema)
+	c.validateBucketFs(v)
+	c.validateServer(v)
+	c.validateDatabase(v)
+	v.nonNegative("jobs.retention", c.Jobs.Retention)
+	c.validateLog(v)
+	c.validateTracing(v)
+	return v.errs
+}
+
+func (c *Config) validateBucketFs(v *validator) {
+	if len(c.BucketFs.BasePaths) == 0 {
+		v.add("bucketFs.basePaths", "must contain at least one path")
+	}
+	paths := make(map[string]bool)
+	for _, path := range c.BucketFs.BasePaths {
+		if path == "" {
+			v.add("bucketFs.basePaths", "must not contain empty paths")
+		} else if paths[path] {
+			v.addf("bucketFs.basePaths", "contains path %q more than once", path)
+		}
+		paths[path] = true
+	}
+	v.nonNegative("bucketFs.listingCacheTTL", c.BucketFs.ListingCacheTTL)
+}
+
+func (c *Config) validateServer(v *validator) {
+	v.require("server.address", c.Server.Address)
+	v.nonNegative("server.requestTimeout", c.Server.RequestTimeout)
+	v.nonNegative("server.readHeaderTimeout", c.Server.ReadHeaderTimeout)
+	v.nonNegative("server.shutdownTimeout", c.Server.ShutdownTimeout)
+	v.nonNegative("server.shutdownDelay", c.Server.ShutdownDelay)
+	if c.Server.ShutdownTimeout > 0 && c.Server.ShutdownDelay >= c.Server.ShutdownTimeout {
+		v.addf("server.shutdownDelay", "must be shorter than server.shutdownTimeout %v but is %v", c.Server.ShutdownTimeout, c.Server.ShutdownDelay)
+	}
+	tls := c.Server.TLS
+	if (tls.CertFile == "") != (tls.KeyFile == "") {
+		v.add("server.tls", "certFile and keyFile must be configured together")
+	}
+	if tls.ClientCAFile != "" && tls.CertFile == "" {
+		v.add("server.tls.clientCAFile", "requires certFile and keyFile")
+	}
+	v.fileExists("server.tls.certFile", tls.CertFile)
+	v.fileExists("server.tls.keyFile", tls.KeyFile)
+	v.fileExists("server.tls.clientCAFile", tls.ClientCAFile)
+}
+
+func (c *Config) validateDatabase(v *validator) {
+	db := c.Database
+	if db.CABundleFile != "" && !db.ValidateServerCertificate {
+		v.add("database.caBundleFile", "requires database.validateServerCertificate")
+	}
+	v.fileExists("database.caBundleFile", db.CABundleFile)
+	if db.CertificateFingerprint != "" {
+		if decoded, err := hex.DecodeString(db.CertificateFingerprint); err != nil || len(decoded) != 32 {
+			v.add("database.certificateFingerprint", "must be a SHA256 fingerprint with 64 hex characters")
+		}
+	}
+	names := make(map[string]bool)
+	for i, target := range db.Targets {
+		key := fmt.Sprintf("database.targets[%d]", i)
+		if target.Name == "" {
+			v.add(key, "name must not be empty")
+		} else if names[target.Name] {
+			v.addf(key, "duplicate name %q", target.Name)
+		}
+		names[target.Name] = true
+		if target.Host == "" {
+			v.add(key, "host must not be empty")
+		}
+		if target.Port < 1 || target.Port > 65535 {
+			v.addf(key, "port %d must be between 1 and 65535", target.Port)
+		}
+	}
+	if db.DefaultTarget != "" && !names[db.DefaultTarget] {
+		v.addf("database.defaultTarget", "target %q is not configured in database.targets", db.DefaultTarget)
+	}
+	if db.Pool.MaxSize < 0 {
+		v.addf("database.pool.maxSize", "must not be negative but is %d", db.Pool.MaxSize)
+	}
+	v.nonNegative("database.pool.idleTimeout", db.Pool.IdleTimeout)
+}
+
+func (c *Config) validateLog(v *validator) {
+	if _, err := log.ParseLevel(c.Log.Level); err != nil {
+		v.addf("log.level", "unsupported level %q, use one of error, warn, info, debug or trace", c.Log.Level)
+	}
+	if c.Log.Format != LogFormatText && c.Log.Format != LogFormatJSON {
+		v.addf("log.format", "unsupported format %q, use %q or %q", c.Log.Format, LogFormatText, LogFormatJSON)
+	}
+}
+
+func (c *Config) validateTracing(v *validator) {
+	switch c.Tracing.Exporter {
+	case tracing.ExporterNone, tracing.ExporterStdout, tracing.ExporterOTLP:
+	default:
+		v.addf("tracing.exporter", "unsupported exporter %q, use one of %q, %q or %q", c.Tracing.Exporter, tracing.ExporterNone, tracing.ExporterStdout, tracing.ExporterOTLP)
+	}
+}
+
+// validator collects validation errors. Each error starts with the key of the invalid setting.
+type validator struct {
+	errs []error
+}
+
+func (v *validator) add(key, message string) {
+	v.errs = append(v.errs, errors.New(key+": "+message))
+}
+
+func (v *validator) addf(key, format string, args ...any) {
+	v.add(key, fmt.Sprintf(format, args...))
+}
+
+func (v *validator) require(key, value string) {
+	if value == "" {
+		v.add(key, "must not be empty")
+	}
+}
+
+func (v *validator) nonNegative(key string, value time.Duration) {
+	if value < 0 {
+		v.addf(key, "must not be negative but is %v", value)
+	}
+}
+
+func (v *validator) fileExists(key, file string) {
+	if file == "" {
+		return
+	}
+	if info, err := os.Stat(file); err != nil {
+		v.addf(key, "file %q is not readable: %v", file, err)
+	} else if info.IsDir() {
+		v.addf(key, "%q is a directory", file)
+	}
+}
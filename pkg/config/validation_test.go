@@ -0,0 +1,130 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ValidationSuite struct {
+	suite.Suite
+	config *Config
+}
+
+func TestValidationSuite(t *testing.T) {
+	suite.Run(t, new(ValidationSuite))
+}
+
+func (suite *ValidationSuite) SetupTest() {
+	suite.config = Defaults()
+	suite.config.ExtensionRegistryURL = "http://registry"
+}
+
+func (suite *ValidationSuite) assertErrors(expected ...string) {
+	actual := make([]string, 0)
+	for _, err := range suite.config.validate() {
+		actual = append(actual, err.Error())
+	}
+	if len(expected) == 0 {
+		suite.Empty(actual)
+		return
+	}
+	suite.Equal(expected, actual)
+}
+
+func (suite *ValidationSuite) TestDefaultsWithRegistryAreValid() {
+	suite.assertErrors()
+}
+
+func (suite *ValidationSuite) TestRequiredSettings() {
+	suite.config.ExtensionRegistryURL = ""
+	suite.config.ExtensionSchema = ""
+	suite.config.Server.Address = ""
+	suite.config.BucketFs.BasePaths = nil
+	suite.assertErrors("extensionRegistryURL: must not be empty", "extensionSchema: must not be empty",
+		"bucketFs.basePaths: must contain at least one path", "server.address: must not be empty")
+}
+
+func (suite *ValidationSuite) TestBucketFsBasePaths() {
+	suite.config.BucketFs.BasePaths = []string{"/a/", "", "/a/"}
+	suite.assertErrors("bucketFs.basePaths: must not contain empty paths", `bucketFs.basePaths: contains path "/a/" more than once`)
+}
+
+func (suite *ValidationSuite) TestNegativeDurations() {
+	suite.config.BucketFs.ListingCacheTTL = -time.Second
+	suite.config.Server.RequestTimeout = -time.Second
+	suite.config.Server.ReadHeaderTimeout = -time.Second
+	suite.config.Server.ShutdownTimeout = -time.Second
+	suite.config.Server.ShutdownDelay = -time.Second
+	suite.config.Database.Pool.IdleTimeout = -time.Second
+	suite.config.Jobs.Retention = -time.Second
+	suite.assertErrors("bucketFs.listingCacheTTL: must not be negative but is -1s",
+		"server.requestTimeout: must not be negative but is -1s",
+		"server.readHeaderTimeout: must not be negative but is -1s",
+		"server.shutdownTimeout: must not be negative but is -1s",
+		"server.shutdownDelay: must not be negative but is -1s",
+		"database.pool.idleTimeout: must not be negative but is -1s",
+		"jobs.retention: must not be negative but is -1s")
+}
+
+func (suite *ValidationSuite) TestShutdownDelayLongerThanShutdownTimeout() {
+	suite.config.Server.ShutdownTimeout = 10 * time.Second
+	suite.config.Server.ShutdownDelay = 10 * time.Second
+	suite.assertErrors("server.shutdownDelay: must be shorter than server.shutdownTimeout 10s but is 10s")
+}
+
+func (suite *ValidationSuite) TestServerTls() {
+	file := filepath.Join(suite.T().TempDir(), "cert.pem")
+	suite.Require().NoError(os.WriteFile(file, []byte("cert"), 0600))
+	suite.config.Server.TLS = ServerTLSConfig{CertFile: file, KeyFile: "", ClientCAFile: "/missing/ca.pem"}
+	suite.assertErrors("server.tls: certFile and keyFile must be configured together",
+		`server.tls.clientCAFile: file "/missing/ca.pem" is not readable: stat /missing/ca.pem: no such file or directory`)
+}
+
+func (suite *ValidationSuite) TestServerTlsClientCaRequiresCertificate() {
+	suite.config.Server.TLS = ServerTLSConfig{CertFile: "", KeyFile: "", ClientCAFile: suite.T().TempDir()}
+	errs := suite.config.validate()
+	suite.Len(errs, 2)
+	suite.EqualError(errs[0], "server.tls.clientCAFile: requires certFile and keyFile")
+	suite.True(strings.HasSuffix(errs[1].Error(), "is a directory"))
+}
+
+func (suite *ValidationSuite) TestDatabaseCertificate() {
+	suite.config.Database.CABundleFile = "/missing/ca.pem"
+	suite.config.Database.CertificateFingerprint = "abc"
+	suite.assertErrors("database.caBundleFile: requires database.validateServerCertificate",
+		`database.caBundleFile: file "/missing/ca.pem" is not readable: stat /missing/ca.pem: no such file or directory`,
+		"database.certificateFingerprint: must be a SHA256 fingerprint with 64 hex characters")
+}
+
+func (suite *ValidationSuite) TestValidFingerprint() {
+	suite.config.Database.CertificateFingerprint = strings.Repeat("ab", 32)
+	suite.assertErrors()
+}
+
+func (suite *ValidationSuite) TestDatabaseTargets() {
+	suite.config.Database.Targets = []DatabaseTarget{{Name: "a", Host: "host", Port: 8563}, {Name: "a", Host: "", Port: 0}, {Name: "", Host: "host", Port: 70000}}
+	suite.config.Database.DefaultTarget = "b"
+	suite.config.Database.Pool.MaxSize = -1
+	suite.assertErrors(`database.targets[1]: duplicate name "a"`,
+		"database.targets[1]: host must not be empty",
+		"database.targets[1]: port 0 must be between 1 and 65535",
+		"database.targets[2]: name must not be empty",
+		"database.targets[2]: port 70000 must be between 1 and 65535",
+		`database.defaultTarget: target "b" is not configured in database.targets`,
+		"database.pool.maxSize: must not be negative but is -1")
+}
+
+func (suite *ValidationSuite) TestLogFormat() {
+	suite.config.Log.Format = "xml"
+	suite.assertErrors(`log.format: unsupported format "xml", use "text" or "json"`)
+}
+
+func (suite *ValidationSuite) TestLogFormatJSON() {
+	suite.config.Log.Format = LogFormatJSON
+	suite.assertErrors()
+}
@@ -1,6 +1,8 @@
 package context
 
 import (
+	goContext "context"
+
 	"github.com/exasol/extension-manager/pkg/backend"
 	"github.com/exasol/extension-manager/pkg/extensionAPI/exaMetadata"
 	"github.com/exasol/extension-manager/pkg/extensionController/transaction"
@@ -24,6 +26,7 @@ func CreateContextWithClient(extensionSchemaName string, txCtx *transaction.Tran
 			schemaName:     extensionSchemaName,
 			metadataReader: metadataReader,
 		},
+		goContext: txCtx.GetContext(),
 	}
 }
 
@@ -36,6 +39,16 @@ type ExtensionContext struct {
 	SqlClient           ContextSqlClient `json:"sqlClient"`           // Allows extensions to execute SQL queries and statements
 	BucketFs            BucketFsContext  `json:"bucketFs"`            // Allows extensions to interact with BucketFS
 	Metadata            MetadataContext  `json:"metadata"`            // Allows extensions to read Exasol metadata tables
+	goContext           goContext.Context
+}
+
+// GoContext returns the [goContext.Context] of the transaction, e.g. for creating tracing spans.
+// This is not a method of [ExtensionContext] because the JS runtime would make it available to extensions.
+func GoContext(extensionContext *ExtensionContext) goContext.Context {
+	if extensionContext == nil || extensionContext.goContext == nil {
+		return goContext.Background()
+	}
+	return extensionContext.goContext
 }
 
 // reportError panics with the given error.
@@ -26,7 +26,8 @@ func TestContextSuite(t *testing.T) {
 }
 
 const EXTENSION_SCHEMA = "EXT_SCHEMA"
-const BUCKETFS_BASE_PATH = "bucketfs-base-path"
+
+var BUCKETFS_BASE_PATHS = []string{"bucketfs-base-path"}
 
 func (suite *ContextSuite) SetupTest() {
 	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
@@ -52,6 +53,19 @@ func (suite *ContextSuite) TestCreate() {
 	suite.NotNil(ctx.SqlClient)
 }
 
+func (suite *ContextSuite) TestGoContextReturnsTransactionContext() {
+	type contextKey string
+	suite.dbMock.ExpectBegin()
+	txCtx, err := transaction.BeginTransaction(context.WithValue(context.Background(), contextKey("key"), "value"), suite.db, BUCKETFS_BASE_PATHS)
+	suite.Require().NoError(err)
+	suite.Equal("value", GoContext(CreateContext(txCtx, "EXT_SCHEMA")).Value(contextKey("key")))
+}
+
+func (suite *ContextSuite) TestGoContextWithoutContext() {
+	suite.Equal(context.Background(), GoContext(nil))
+	suite.Equal(context.Background(), GoContext(&ExtensionContext{}))
+}
+
 /* [utest -> dsn~extension-context-sql-client~1]. */
 func (suite *ContextSuite) TestSqlClientQuerySuccess() {
 	ctx := suite.createContext()
@@ -129,16 +143,83 @@ func (suite *ContextSuite) TestMetadataGetScriptByNameFails() {
 	})
 }
 
+func (suite *ContextSuite) TestMetadataGetPrivileges() {
+	ctx := suite.createContextWithClients()
+	suite.metadataReaderMock.SimulateSessionPrivileges("CREATE SCRIPT")
+	privileges := ctx.Metadata.GetPrivileges()
+	suite.True(privileges.HasSystemPrivilege("CREATE SCRIPT"))
+}
+
+func (suite *ContextSuite) TestMetadataGetPrivilegesFails() {
+	ctx := suite.createContextWithClients()
+	suite.metadataReaderMock.SimulateReadPrivilegesFails(errors.New("mock error"))
+	suite.PanicsWithError(`failed to read privileges. Caused by: mock error`, func() {
+		ctx.Metadata.GetPrivileges()
+	})
+}
+
+/* [utest -> dsn~extension-context-metadata~1]. */
+func (suite *ContextSuite) TestMetadataGetConnectionByName() {
+	ctx := suite.createContextWithClients()
+	connection := &exaMetadata.ExaConnectionRow{Name: "CON", ConnectionString: "jdbc:exa:localhost", UserName: "user", Owner: "SYS", Comment: ""}
+	suite.metadataReaderMock.SimulateGetConnectionByName("CON", connection)
+	suite.Equal(connection, ctx.Metadata.GetConnectionByName("CON"))
+}
+
+func (suite *ContextSuite) TestMetadataGetConnectionByNameNotFound() {
+	ctx := suite.createContextWithClients()
+	suite.metadataReaderMock.SimulateGetConnectionByName("CON", nil)
+	suite.Nil(ctx.Metadata.GetConnectionByName("CON"))
+}
+
+func (suite *ContextSuite) TestMetadataGetConnectionByNameFails() {
+	ctx := suite.createContextWithClients()
+	suite.metadataReaderMock.SimulateGetConnectionByNameFails("CON", errors.New("mock error"))
+	suite.PanicsWithError(`failed to find connection "CON". Caused by: mock error`, func() {
+		ctx.Metadata.GetConnectionByName("CON")
+	})
+}
+
+func (suite *ContextSuite) TestMetadataGetSchemaByName() {
+	ctx := suite.createContextWithClients()
+	schema := &exaMetadata.ExaSchemaRow{Name: "VS", Owner: "SYS", IsVirtual: true, Comment: ""}
+	suite.metadataReaderMock.SimulateGetSchemaByName("VS", schema)
+	suite.Equal(schema, ctx.Metadata.GetSchemaByName("VS"))
+}
+
+func (suite *ContextSuite) TestMetadataGetSchemaByNameFails() {
+	ctx := suite.createContextWithClients()
+	suite.metadataReaderMock.SimulateGetSchemaByNameFails("VS", errors.New("mock error"))
+	suite.PanicsWithError(`failed to find schema "VS". Caused by: mock error`, func() {
+		ctx.Metadata.GetSchemaByName("VS")
+	})
+}
+
+func (suite *ContextSuite) TestMetadataGetVirtualSchemaProperties() {
+	ctx := suite.createContextWithClients()
+	properties := []exaMetadata.ExaVirtualSchemaPropertyRow{{SchemaName: "VS", Name: "CONNECTION_NAME", Value: "CON"}}
+	suite.metadataReaderMock.SimulateGetVirtualSchemaProperties("VS", properties)
+	suite.Equal(properties, ctx.Metadata.GetVirtualSchemaProperties("VS"))
+}
+
+func (suite *ContextSuite) TestMetadataGetVirtualSchemaPropertiesFails() {
+	ctx := suite.createContextWithClients()
+	suite.metadataReaderMock.SimulateGetVirtualSchemaPropertiesFails("VS", errors.New("mock error"))
+	suite.PanicsWithError(`failed to read properties of virtual schema "VS". Caused by: mock error`, func() {
+		ctx.Metadata.GetVirtualSchemaProperties("VS")
+	})
+}
+
 func (suite *ContextSuite) createContext() *ExtensionContext {
 	suite.dbMock.ExpectBegin()
-	txCtx, err := transaction.BeginTransaction(context.Background(), suite.db, BUCKETFS_BASE_PATH)
+	txCtx, err := transaction.BeginTransaction(context.Background(), suite.db, BUCKETFS_BASE_PATHS)
 	suite.Require().NoError(err)
 	return CreateContext(txCtx, "EXT_SCHEMA")
 }
 
 func (suite *ContextSuite) createContextWithClients() *ExtensionContext {
 	suite.dbMock.ExpectBegin()
-	txCtx, err := transaction.BeginTransaction(context.Background(), suite.db, BUCKETFS_BASE_PATH)
+	txCtx, err := transaction.BeginTransaction(context.Background(), suite.db, BUCKETFS_BASE_PATHS)
 	suite.Require().NoError(err)
 	return CreateContextWithClient("EXT_SCHEMA", txCtx, nil, suite.bucketFSMock, suite.metadataReaderMock)
 }
@@ -15,6 +15,29 @@ type MetadataContext interface {
 	// The JS runtime will convert `nil` to `null` in JavaScript code, so extensions can
 	// check if a script was found by testing the result with `=== null`.
 	GetScriptByName(name string) *exaMetadata.ExaScriptRow
+
+	// Get the system privileges, roles and object privileges of the current database user.
+	//
+	// Extensions can use this to explain why an operation will fail, e.g. by checking
+	// `privileges.hasSystemPrivilege("CREATE CONNECTION")`.
+	GetPrivileges() *exaMetadata.ExaPrivileges
+
+	// Get a row from the SYS.EXA_DBA_CONNECTIONS table for the given connection name.
+	// If the database user may not read this table, EM uses SYS.EXA_ALL_CONNECTIONS instead
+	// and fields `connectionString`, `userName` and `owner` are empty.
+	//
+	// Returns `nil` when no connection exists with the given name.
+	GetConnectionByName(name string) *exaMetadata.ExaConnectionRow
+
+	// Get a row from the SYS.EXA_ALL_SCHEMAS table for the given schema name.
+	//
+	// Returns `nil` when no schema exists with the given name.
+	GetSchemaByName(name string) *exaMetadata.ExaSchemaRow
+
+	// Get the properties of the given virtual schema from table SYS.EXA_ALL_VIRTUAL_SCHEMA_PROPERTIES.
+	//
+	// Returns an empty array when the virtual schema does not exist or has no properties.
+	GetVirtualSchemaProperties(virtualSchemaName string) []exaMetadata.ExaVirtualSchemaPropertyRow
 }
 
 type metadataContextImpl struct {
@@ -31,3 +54,38 @@ func (m *metadataContextImpl) GetScriptByName(scriptName string) *exaMetadata.Ex
 	}
 	return script
 }
+
+func (m *metadataContextImpl) GetPrivileges() *exaMetadata.ExaPrivileges {
+	privileges, err := m.metadataReader.ReadPrivileges(m.transaction)
+	if err != nil {
+		reportError(fmt.Errorf("failed to read privileges. Caused by: %w", err))
+	}
+	return privileges
+}
+
+/* [impl -> dsn~extension-context-metadata~1]. */
+func (m *metadataContextImpl) GetConnectionByName(connectionName string) *exaMetadata.ExaConnectionRow {
+	connection, err := m.metadataReader.GetConnectionByName(m.transaction, connectionName)
+	if err != nil {
+		reportError(fmt.Errorf("failed to find connection %q. Caused by: %w", connectionName, err))
+	}
+	return connection
+}
+
+/* [impl -> dsn~extension-context-metadata~1]. */
+func (m *metadataContextImpl) GetSchemaByName(schemaName string) *exaMetadata.ExaSchemaRow {
+	schema, err := m.metadataReader.GetSchemaByName(m.transaction, schemaName)
+	if err != nil {
+		reportError(fmt.Errorf("failed to find schema %q. Caused by: %w", schemaName, err))
+	}
+	return schema
+}
+
+/* [impl -> dsn~extension-context-metadata~1]. */
+func (m *metadataContextImpl) GetVirtualSchemaProperties(virtualSchemaName string) []exaMetadata.ExaVirtualSchemaPropertyRow {
+	properties, err := m.metadataReader.GetVirtualSchemaProperties(m.transaction, virtualSchemaName)
+	if err != nil {
+		reportError(fmt.Errorf("failed to read properties of virtual schema %q. Caused by: %w", virtualSchemaName, err))
+	}
+	return properties
+}
@@ -2,11 +2,17 @@ package extensionAPI
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/dop251/goja"
 	"github.com/exasol/extension-manager/pkg/apiErrors"
 	"github.com/exasol/extension-manager/pkg/extensionAPI/context"
 	"github.com/exasol/extension-manager/pkg/extensionAPI/exaMetadata"
+	"github.com/exasol/extension-manager/pkg/metrics"
+	"github.com/exasol/extension-manager/pkg/secrets"
+	"github.com/exasol/extension-manager/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type JsExtension struct {
@@ -52,6 +58,9 @@ func (e *JsExtension) GetParameterDefinitions(context *context.ExtensionContext,
 	if e.extension.GetParameterDefinitions == nil {
 		return nil, e.unsupportedFunction("getParameterDefinitions")
 	}
+	defer metrics.ObserveJsExecution(e.Id, "getParameterDefinitions", time.Now())
+	span := e.startSpan(context, "getParameterDefinitions")
+	defer func() { e.endSpan(context, span, errorResult) }()
 	defer func() {
 		if err := recover(); err != nil {
 			errorResult = e.convertError(fmt.Sprintf("failed to get parameter definitions for extension %q", e.Id), err)
@@ -64,6 +73,9 @@ func (e *JsExtension) Install(context *context.ExtensionContext, version string)
 	if e.extension.Install == nil {
 		return e.unsupportedFunction("install")
 	}
+	defer metrics.ObserveJsExecution(e.Id, "install", time.Now())
+	span := e.startSpan(context, "install")
+	defer func() { e.endSpan(context, span, errorResult) }()
 	defer func() {
 		if err := recover(); err != nil {
 			errorResult = e.convertError(fmt.Sprintf("failed to install extension %q", e.Id), err)
@@ -77,6 +89,9 @@ func (e *JsExtension) Uninstall(context *context.ExtensionContext, version strin
 	if e.extension.Uninstall == nil {
 		return e.unsupportedFunction("uninstall")
 	}
+	defer metrics.ObserveJsExecution(e.Id, "uninstall", time.Now())
+	span := e.startSpan(context, "uninstall")
+	defer func() { e.endSpan(context, span, errorResult) }()
 	defer func() {
 		if err := recover(); err != nil {
 			errorResult = e.convertError(fmt.Sprintf("failed to uninstall extension %q", e.Id), err)
@@ -90,6 +105,9 @@ func (e *JsExtension) Upgrade(context *context.ExtensionContext) (result *JsUpgr
 	if e.extension.Upgrade == nil {
 		return nil, e.unsupportedFunction("upgrade")
 	}
+	defer metrics.ObserveJsExecution(e.Id, "upgrade", time.Now())
+	span := e.startSpan(context, "upgrade")
+	defer func() { e.endSpan(context, span, errorResult) }()
 	defer func() {
 		if err := recover(); err != nil {
 			errorResult = e.convertError(fmt.Sprintf("failed to upgrade extension %q", e.Id), err)
@@ -98,22 +116,34 @@ func (e *JsExtension) Upgrade(context *context.ExtensionContext) (result *JsUpgr
 	return e.extension.Upgrade(context), nil
 }
 
-func (e *JsExtension) FindInstallations(context *context.ExtensionContext, metadata *exaMetadata.ExaMetadata) (installations []*JsExtInstallation, errorResult error) {
+// FindInstallations calls the findInstallations function of the extension.
+// The metadata tables are read from the given source only when the extension accesses them.
+func (e *JsExtension) FindInstallations(context *context.ExtensionContext, metadata exaMetadata.ExaMetadataSource) (installations []*JsExtInstallation, errorResult error) {
 	if e.extension.FindInstallations == nil {
 		return nil, e.unsupportedFunction("findInstallations")
 	}
+	defer metrics.ObserveJsExecution(e.Id, "findInstallations", time.Now())
+	span := e.startSpan(context, "findInstallations")
+	defer func() { e.endSpan(context, span, errorResult) }()
+	jsMetadata, err := e.newJsMetadata(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create metadata for extension %q: %w", e.Id, err)
+	}
 	defer func() {
 		if err := recover(); err != nil {
 			errorResult = e.convertError(fmt.Sprintf("failed to find installations for extension %q", e.Id), err)
 		}
 	}()
-	return e.extension.FindInstallations(context, metadata), nil
+	return e.extension.FindInstallations(context, jsMetadata), nil
 }
 
 func (e *JsExtension) AddInstance(context *context.ExtensionContext, version string, params *ParameterValues) (instance *JsExtInstance, errorResult error) {
 	if e.extension.AddInstance == nil {
 		return nil, e.unsupportedFunction("addInstance")
 	}
+	defer metrics.ObserveJsExecution(e.Id, "addInstance", time.Now())
+	span := e.startSpan(context, "addInstance")
+	defer func() { e.endSpan(context, span, errorResult) }()
 	defer func() {
 		if err := recover(); err != nil {
 			errorResult = e.convertError(fmt.Sprintf("failed to add instance for extension %q", e.Id), err)
@@ -145,6 +175,9 @@ func (e *JsExtension) ListInstances(context *context.ExtensionContext, version s
 	if e.extension.FindInstances == nil {
 		return nil, e.unsupportedFunction("findInstances")
 	}
+	defer metrics.ObserveJsExecution(e.Id, "findInstances", time.Now())
+	span := e.startSpan(context, "findInstances")
+	defer func() { e.endSpan(context, span, errorResult) }()
 	defer func() {
 		if err := recover(); err != nil {
 			errorResult = e.convertError(fmt.Sprintf("failed to list instances for extension %q in version %q", e.Id, version), err)
@@ -157,6 +190,9 @@ func (e *JsExtension) DeleteInstance(context *context.ExtensionContext, extensio
 	if e.extension.DeleteInstance == nil {
 		return e.unsupportedFunction("deleteInstance")
 	}
+	defer metrics.ObserveJsExecution(e.Id, "deleteInstance", time.Now())
+	span := e.startSpan(context, "deleteInstance")
+	defer func() { e.endSpan(context, span, errorResult) }()
 	defer func() {
 		if err := recover(); err != nil {
 			errorResult = e.convertError(fmt.Sprintf("failed to delete instance %q for extension %q", instanceId, e.Id), err)
@@ -166,6 +202,67 @@ func (e *JsExtension) DeleteInstance(context *context.ExtensionContext, extensio
 	return
 }
 
+func (e *JsExtension) UpdateInstance(context *context.ExtensionContext, extensionVersion, instanceId string, params *ParameterValues) (instance *JsExtInstance, errorResult error) {
+	if e.extension.UpdateInstance == nil {
+		return nil, e.unsupportedFunction("updateInstance")
+	}
+	defer metrics.ObserveJsExecution(e.Id, "updateInstance", time.Now())
+	span := e.startSpan(context, "updateInstance")
+	defer func() { e.endSpan(context, span, errorResult) }()
+	defer func() {
+		if err := recover(); err != nil {
+			errorResult = e.convertError(fmt.Sprintf("failed to update instance %q for extension %q", instanceId, e.Id), err)
+		}
+	}()
+	return e.extension.UpdateInstance(context, extensionVersion, instanceId, params), nil
+}
+
+func (e *JsExtension) GetInstance(context *context.ExtensionContext, extensionVersion, instanceId string) (instance *JsExtInstanceDetails, errorResult error) {
+	if e.extension.GetInstance == nil {
+		return nil, e.unsupportedFunction("getInstance")
+	}
+	defer metrics.ObserveJsExecution(e.Id, "getInstance", time.Now())
+	span := e.startSpan(context, "getInstance")
+	defer func() { e.endSpan(context, span, errorResult) }()
+	defer func() {
+		if err := recover(); err != nil {
+			errorResult = e.convertError(fmt.Sprintf("failed to get instance %q for extension %q", instanceId, e.Id), err)
+		}
+	}()
+	return e.extension.GetInstance(context, extensionVersion, instanceId), nil
+}
+
+// SupportsPreflight returns true if the extension implements the optional function "preflight".
+func (e *JsExtension) SupportsPreflight() bool {
+	return e.extension.Preflight != nil
+}
+
+func (e *JsExtension) Preflight(context *context.ExtensionContext, version string) (checks []*JsPreflightCheck, errorResult error) {
+	if e.extension.Preflight == nil {
+		return nil, e.unsupportedFunction("preflight")
+	}
+	defer metrics.ObserveJsExecution(e.Id, "preflight", time.Now())
+	span := e.startSpan(context, "preflight")
+	defer func() { e.endSpan(context, span, errorResult) }()
+	defer func() {
+		if err := recover(); err != nil {
+			errorResult = e.convertError(fmt.Sprintf("failed to run pre-flight checks for extension %q in version %q", e.Id, version), err)
+		}
+	}()
+	return e.extension.Preflight(context, version), nil
+}
+
+// startSpan starts a span for calling the given function of the extension.
+func (e *JsExtension) startSpan(extensionContext *context.ExtensionContext, functionName string) trace.Span {
+	_, span := tracing.Start(context.GoContext(extensionContext), "JsExtension."+functionName, attribute.String("em.extension.id", e.Id))
+	return span
+}
+
+// endSpan ends the span, masking secret parameter values contained in the error message.
+func (*JsExtension) endSpan(extensionContext *context.ExtensionContext, span trace.Span, err error) {
+	tracing.End(span, secrets.MaskerFromContext(context.GoContext(extensionContext)).MaskError(err))
+}
+
 func (e *JsExtension) convertError(message string, err any) error {
 	if exception, ok := err.(*goja.Exception); ok {
 		if exception.Value() == nil {
@@ -185,7 +282,14 @@ func (e *JsExtension) convertError(message string, err any) error {
 	return basicError(message, err)
 }
 
+// basicError creates an error for the given message and recovered value.
+// The error code of Go errors raised by the context, e.g. for missing BucketFS files, is preserved.
 func basicError(message string, err any) error {
+	if cause, ok := err.(error); ok {
+		if code, found := apiErrors.GetErrorCode(cause); found {
+			return code.NewInternalErrorF("%s: %v", message, cause)
+		}
+	}
 	return fmt.Errorf("%s: %v", message, err)
 }
 
@@ -12,6 +12,11 @@ import (
 	"github.com/exasol/extension-manager/pkg/extensionAPI/exaMetadata"
 	"github.com/exasol/extension-manager/pkg/extensionController/transaction"
 	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 const mockErrorMessage = "mock error"
@@ -41,6 +46,9 @@ func (suite *ErrorHandlingExtensionSuite) SetupSuite() {
 		AddInstance:             nil,
 		FindInstances:           nil,
 		DeleteInstance:          nil,
+		UpdateInstance:          nil,
+		GetInstance:             nil,
+		Preflight:               nil,
 	}
 	suite.extension = wrapExtension(suite.rawExtension, "id", newJavaScriptVm("logPrefix>"))
 }
@@ -80,7 +88,7 @@ func createMockContext() *context.ExtensionContext {
 
 func (suite *ErrorHandlingExtensionSuite) TestFindInstallationsSuccessful() {
 	expectedInstallations := []*JsExtInstallation{{Name: "instName"}}
-	suite.rawExtension.FindInstallations = func(context *context.ExtensionContext, metadata *exaMetadata.ExaMetadata) []*JsExtInstallation {
+	suite.rawExtension.FindInstallations = func(context *context.ExtensionContext, metadata *goja.Object) []*JsExtInstallation {
 		return expectedInstallations
 	}
 	installations, err := suite.extension.FindInstallations(createMockContext(), createMetaData())
@@ -89,7 +97,7 @@ func (suite *ErrorHandlingExtensionSuite) TestFindInstallationsSuccessful() {
 }
 
 func (suite *ErrorHandlingExtensionSuite) TestFindInstallationsFailure() {
-	suite.rawExtension.FindInstallations = func(context *context.ExtensionContext, metadata *exaMetadata.ExaMetadata) []*JsExtInstallation {
+	suite.rawExtension.FindInstallations = func(context *context.ExtensionContext, metadata *goja.Object) []*JsExtInstallation {
 		panic(mockErrorMessage)
 	}
 	installations, err := suite.extension.FindInstallations(createMockContext(), createMetaData())
@@ -156,6 +164,31 @@ func (suite *ErrorHandlingExtensionSuite) TestInstallUnsupported() {
 	suite.Require().EqualError(err, `extension "id" does not support operation "install"`)
 }
 
+func (suite *ErrorHandlingExtensionSuite) TestInstallCreatesSpan() {
+	recorder := suite.recordSpans()
+	suite.rawExtension.Install = func(context *context.ExtensionContext, version string) {
+		// empty mocked function
+	}
+	suite.Require().NoError(suite.extension.Install(createMockContext(), "version"))
+	spans := recorder.Ended()
+	suite.Require().Len(spans, 1)
+	suite.Equal("JsExtension.install", spans[0].Name())
+	suite.Equal([]attribute.KeyValue{attribute.String("em.extension.id", "id")}, spans[0].Attributes())
+	suite.Equal(codes.Unset, spans[0].Status().Code)
+}
+
+func (suite *ErrorHandlingExtensionSuite) TestInstallFailureMarksSpanAsFailed() {
+	recorder := suite.recordSpans()
+	suite.rawExtension.Install = func(context *context.ExtensionContext, version string) {
+		panic(mockErrorMessage)
+	}
+	suite.Require().Error(suite.extension.Install(createMockContext(), "version"))
+	spans := recorder.Ended()
+	suite.Require().Len(spans, 1)
+	suite.Equal(codes.Error, spans[0].Status().Code)
+	suite.Equal(`failed to install extension "id": `+mockErrorMessage, spans[0].Status().Description)
+}
+
 // Uninstall
 
 func (suite *ErrorHandlingExtensionSuite) TestUninstallSuccessful() {
@@ -333,6 +366,89 @@ func (suite *ErrorHandlingExtensionSuite) TestDeleteInstanceUnsupported() {
 	suite.Require().EqualError(err, `extension "id" does not support operation "deleteInstance"`)
 }
 
+// UpdateInstance
+
+func (suite *ErrorHandlingExtensionSuite) TestUpdateInstanceSuccessful() {
+	suite.rawExtension.UpdateInstance = func(context *context.ExtensionContext, version, instanceId string, params *ParameterValues) *JsExtInstance {
+		return &JsExtInstance{Id: instanceId, Name: "updatedInstance"}
+	}
+	instance, err := suite.extension.UpdateInstance(createMockContext(), "version", "instance-id", &ParameterValues{Values: []ParameterValue{}})
+	suite.Require().NoError(err)
+	suite.Equal(&JsExtInstance{Id: "instance-id", Name: "updatedInstance"}, instance)
+}
+
+func (suite *ErrorHandlingExtensionSuite) TestUpdateInstanceFails() {
+	suite.rawExtension.UpdateInstance = func(context *context.ExtensionContext, version, instanceId string, params *ParameterValues) *JsExtInstance {
+		panic(mockErrorMessage)
+	}
+	instance, err := suite.extension.UpdateInstance(createMockContext(), "version", "instance-id", &ParameterValues{Values: []ParameterValue{}})
+	suite.Require().EqualError(err, `failed to update instance "instance-id" for extension "id": `+mockErrorMessage)
+	suite.Nil(instance)
+}
+
+func (suite *ErrorHandlingExtensionSuite) TestUpdateInstanceUnsupported() {
+	suite.rawExtension.UpdateInstance = nil
+	instance, err := suite.extension.UpdateInstance(createMockContext(), "version", "instance-id", &ParameterValues{Values: []ParameterValue{}})
+	suite.Require().EqualError(err, `extension "id" does not support operation "updateInstance"`)
+	suite.Nil(instance)
+}
+
+// GetInstance
+
+func (suite *ErrorHandlingExtensionSuite) TestGetInstanceSuccessful() {
+	suite.rawExtension.GetInstance = func(context *context.ExtensionContext, version, instanceId string) *JsExtInstanceDetails {
+		return &JsExtInstanceDetails{Id: instanceId, Name: "instance", Parameters: []ParameterValue{{Name: "p1", Value: "v1"}}}
+	}
+	instance, err := suite.extension.GetInstance(createMockContext(), "version", "instance-id")
+	suite.Require().NoError(err)
+	suite.Equal(&JsExtInstanceDetails{Id: "instance-id", Name: "instance", Parameters: []ParameterValue{{Name: "p1", Value: "v1"}}}, instance)
+}
+
+func (suite *ErrorHandlingExtensionSuite) TestGetInstanceFails() {
+	suite.rawExtension.GetInstance = func(context *context.ExtensionContext, version, instanceId string) *JsExtInstanceDetails {
+		panic(mockErrorMessage)
+	}
+	instance, err := suite.extension.GetInstance(createMockContext(), "version", "instance-id")
+	suite.Require().EqualError(err, `failed to get instance "instance-id" for extension "id": `+mockErrorMessage)
+	suite.Nil(instance)
+}
+
+func (suite *ErrorHandlingExtensionSuite) TestGetInstanceUnsupported() {
+	suite.rawExtension.GetInstance = nil
+	instance, err := suite.extension.GetInstance(createMockContext(), "version", "instance-id")
+	suite.Require().EqualError(err, `extension "id" does not support operation "getInstance"`)
+	suite.Nil(instance)
+}
+
+// Preflight
+
+func (suite *ErrorHandlingExtensionSuite) TestPreflightSuccessful() {
+	suite.rawExtension.Preflight = func(context *context.ExtensionContext, version string) []*JsPreflightCheck {
+		return []*JsPreflightCheck{{Name: "check", Success: true, Message: ""}}
+	}
+	suite.True(suite.extension.SupportsPreflight())
+	checks, err := suite.extension.Preflight(createMockContext(), "version")
+	suite.Require().NoError(err)
+	suite.Equal([]*JsPreflightCheck{{Name: "check", Success: true, Message: ""}}, checks)
+}
+
+func (suite *ErrorHandlingExtensionSuite) TestPreflightFails() {
+	suite.rawExtension.Preflight = func(context *context.ExtensionContext, version string) []*JsPreflightCheck {
+		panic(mockErrorMessage)
+	}
+	checks, err := suite.extension.Preflight(createMockContext(), "version")
+	suite.Require().EqualError(err, `failed to run pre-flight checks for extension "id" in version "version": `+mockErrorMessage)
+	suite.Nil(checks)
+}
+
+func (suite *ErrorHandlingExtensionSuite) TestPreflightUnsupported() {
+	suite.rawExtension.Preflight = nil
+	suite.False(suite.extension.SupportsPreflight())
+	checks, err := suite.extension.Preflight(createMockContext(), "version")
+	suite.Require().EqualError(err, `extension "id" does not support operation "preflight"`)
+	suite.Nil(checks)
+}
+
 // convertError
 
 func (suite *ErrorHandlingExtensionSuite) TestConvertErrorNonErrorObject() {
@@ -345,6 +461,12 @@ func (suite *ErrorHandlingExtensionSuite) TestConvertErrorErrorObject() {
 	suite.assertErrorStringError(err, "msg: "+mockErrorMessage)
 }
 
+func (suite *ErrorHandlingExtensionSuite) TestConvertErrorKeepsErrorCode() {
+	err := suite.extension.convertError("msg", apiErrors.BFS_FILE_NOT_FOUND.NewInternalErrorF("file %q not found in BucketFS", "file.jar"))
+	suite.Require().EqualError(err, `msg: file "file.jar" not found in BucketFS`)
+	suite.Equal("E-EM-BFS-4", apiErrors.UnwrapAPIError(err).ErrorCode)
+}
+
 func (suite *ErrorHandlingExtensionSuite) TestConvertErrorNilGojaException() {
 	var exception goja.Exception
 	err := suite.extension.convertError("msg", &exception)
@@ -379,6 +501,15 @@ func (suite *ErrorHandlingExtensionSuite) TestConvertErrorJavaScriptErrorWithSta
 	suite.Equal(apiErrors.NewAPIError(400, "jsError"), apiErr)
 }
 
+// recordSpans records all spans until the end of the current test.
+func (suite *ErrorHandlingExtensionSuite) recordSpans() *tracetest.SpanRecorder {
+	previousProvider := otel.GetTracerProvider()
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	suite.T().Cleanup(func() { otel.SetTracerProvider(previousProvider) })
+	return recorder
+}
+
 func (suite *ErrorHandlingExtensionSuite) getGojaException(javaScript string) *goja.Exception {
 	_, err := suite.extension.vm.RunString(javaScript)
 	suite.Require().Error(err)
@@ -0,0 +1,137 @@
+package exaMetadata
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// ExaConnectionRow is a row of table SYS.EXA_DBA_CONNECTIONS or SYS.EXA_ALL_CONNECTIONS.
+// Fields ConnectionString, UserName and Owner are only available if the user may read SYS.EXA_DBA_CONNECTIONS,
+// else they are empty.
+type ExaConnectionRow struct {
+	Name             string `json:"name"`
+	ConnectionString string `json:"connectionString"`
+	UserName         string `json:"userName"`
+	Owner            string `json:"owner"`
+	Comment          string `json:"comment"`
+}
+
+// ExaSchemaRow is a row of table SYS.EXA_ALL_SCHEMAS.
+type ExaSchemaRow struct {
+	Name      string `json:"name"`
+	Owner     string `json:"owner"`
+	IsVirtual bool   `json:"isVirtual"`
+	Comment   string `json:"comment"`
+}
+
+// ExaVirtualSchemaPropertyRow is a row of table SYS.EXA_ALL_VIRTUAL_SCHEMA_PROPERTIES.
+type ExaVirtualSchemaPropertyRow struct {
+	SchemaName string `json:"schemaName"`
+	Name       string `json:"name"`
+	Value      string `json:"value"`
+}
+
+/* [impl -> dsn~extension-context-metadata~1]. */
+func (r *metaDataReaderImpl) GetConnectionByName(tx *sql.Tx, connectionName string) (*ExaConnectionRow, error) {
+	connection, err := r.getConnectionFromDbaTable(tx, connectionName)
+	if err == nil {
+		return connection, nil
+	}
+	log.Debugf("Reading connection from %s.EXA_DBA_CONNECTIONS failed, using EXA_ALL_CONNECTIONS instead: %v", r.metaDataSchema, err)
+	return r.getConnectionFromAllTable(tx, connectionName)
+}
+
+func (r *metaDataReaderImpl) getConnectionFromDbaTable(tx *sql.Tx, connectionName string) (*ExaConnectionRow, error) {
+	// #nosec G201 Using schema as query parameter is not possible
+	query := fmt.Sprintf(`
+SELECT CONNECTION_NAME, CONNECTION_STRING, USER_NAME, OWNER, CONNECTION_COMMENT
+FROM %s.EXA_DBA_CONNECTIONS
+WHERE CONNECTION_NAME=?`, r.metaDataSchema)
+	result, err := tx.QueryContext(context.TODO(), query, connectionName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s.EXA_DBA_CONNECTIONS: %w", r.metaDataSchema, err)
+	}
+	defer result.Close()
+	if !result.Next() {
+		return nil, nil
+	}
+	var name, connectionString, userName, owner, comment sql.NullString
+	if err := result.Scan(&name, &connectionString, &userName, &owner, &comment); err != nil {
+		return nil, fmt.Errorf("failed to read row of EXA_DBA_CONNECTIONS: %w", err)
+	}
+	return &ExaConnectionRow{Name: name.String, ConnectionString: connectionString.String, UserName: userName.String, Owner: owner.String, Comment: comment.String}, nil
+}
+
+func (r *metaDataReaderImpl) getConnectionFromAllTable(tx *sql.Tx, connectionName string) (*ExaConnectionRow, error) {
+	// #nosec G201 Using schema as query parameter is not possible
+	query := fmt.Sprintf(`
+SELECT CONNECTION_NAME, CONNECTION_COMMENT
+FROM %s.EXA_ALL_CONNECTIONS
+WHERE CONNECTION_NAME=?`, r.metaDataSchema)
+	result, err := tx.QueryContext(context.TODO(), query, connectionName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s.EXA_ALL_CONNECTIONS: %w", r.metaDataSchema, err)
+	}
+	defer result.Close()
+	if !result.Next() {
+		return nil, nil
+	}
+	var name, comment sql.NullString
+	if err := result.Scan(&name, &comment); err != nil {
+		return nil, fmt.Errorf("failed to read row of EXA_ALL_CONNECTIONS: %w", err)
+	}
+	return &ExaConnectionRow{Name: name.String, ConnectionString: "", UserName: "", Owner: "", Comment: comment.String}, nil
+}
+
+/* [impl -> dsn~extension-context-metadata~1]. */
+func (r *metaDataReaderImpl) GetSchemaByName(tx *sql.Tx, schemaName string) (*ExaSchemaRow, error) {
+	// #nosec G201 Using schema as query parameter is not possible
+	query := fmt.Sprintf(`
+SELECT SCHEMA_NAME, SCHEMA_OWNER, SCHEMA_IS_VIRTUAL, SCHEMA_COMMENT
+FROM %s.EXA_ALL_SCHEMAS
+WHERE SCHEMA_NAME=?`, r.metaDataSchema)
+	result, err := tx.QueryContext(context.TODO(), query, schemaName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s.EXA_ALL_SCHEMAS: %w", r.metaDataSchema, err)
+	}
+	defer result.Close()
+	if !result.Next() {
+		return nil, nil
+	}
+	var name, owner, comment sql.NullString
+	var isVirtual sql.NullBool
+	if err := result.Scan(&name, &owner, &isVirtual, &comment); err != nil {
+		return nil, fmt.Errorf("failed to read row of EXA_ALL_SCHEMAS: %w", err)
+	}
+	return &ExaSchemaRow{Name: name.String, Owner: owner.String, IsVirtual: isVirtual.Bool, Comment: comment.String}, nil
+}
+
+/* [impl -> dsn~extension-context-metadata~1]. */
+func (r *metaDataReaderImpl) GetVirtualSchemaProperties(tx *sql.Tx, virtualSchemaName string) ([]ExaVirtualSchemaPropertyRow, error) {
+	// #nosec G201 Using schema as query parameter is not possible
+	query := fmt.Sprintf(`
+SELECT SCHEMA_NAME, PROPERTY_NAME, PROPERTY_VALUE
+FROM %s.EXA_ALL_VIRTUAL_SCHEMA_PROPERTIES
+WHERE SCHEMA_NAME=?
+ORDER BY PROPERTY_NAME`, r.metaDataSchema)
+	result, err := tx.QueryContext(context.TODO(), query, virtualSchemaName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s.EXA_ALL_VIRTUAL_SCHEMA_PROPERTIES: %w", r.metaDataSchema, err)
+	}
+	defer result.Close()
+	rows := make([]ExaVirtualSchemaPropertyRow, 0)
+	for result.Next() {
+		var schemaName, name, value sql.NullString
+		if err := result.Scan(&schemaName, &name, &value); err != nil {
+			return nil, fmt.Errorf("failed to read row of EXA_ALL_VIRTUAL_SCHEMA_PROPERTIES: %w", err)
+		}
+		rows = append(rows, ExaVirtualSchemaPropertyRow{SchemaName: schemaName.String, Name: name.String, Value: value.String})
+	}
+	if result.Err() != nil {
+		return nil, fmt.Errorf("failed to iterate %s.EXA_ALL_VIRTUAL_SCHEMA_PROPERTIES: %w", r.metaDataSchema, result.Err())
+	}
+	return rows, nil
+}
@@ -0,0 +1,121 @@
+package exaMetadata
+
+import (
+	"errors"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// GetConnectionByName
+
+/* [utest -> dsn~extension-context-metadata~1]. */
+func (suite *ExaMetadataUTestSuite) TestGetConnectionByName() {
+	tx := suite.beginTransaction()
+	suite.dbMock.ExpectQuery("(?m)SELECT .*FROM SYS.EXA_DBA_CONNECTIONS\\s+WHERE CONNECTION_NAME=\\?").WithArgs("MY_CONNECTION").
+		WillReturnRows(sqlmock.NewRows([]string{"CONNECTION_NAME", "CONNECTION_STRING", "USER_NAME", "OWNER", "CONNECTION_COMMENT"}).
+			AddRow("MY_CONNECTION", "jdbc:exa:localhost", "user", "SYS", "comment")).
+		RowsWillBeClosed()
+	result, err := CreateExaMetaDataReader().GetConnectionByName(tx, "MY_CONNECTION")
+	suite.Require().NoError(err)
+	suite.Equal(&ExaConnectionRow{Name: "MY_CONNECTION", ConnectionString: "jdbc:exa:localhost", UserName: "user", Owner: "SYS", Comment: "comment"}, result)
+}
+
+func (suite *ExaMetadataUTestSuite) TestGetConnectionByNameNoResult() {
+	tx := suite.beginTransaction()
+	suite.dbMock.ExpectQuery("(?m)SELECT .*FROM SYS.EXA_DBA_CONNECTIONS").WithArgs("MY_CONNECTION").
+		WillReturnRows(sqlmock.NewRows([]string{"CONNECTION_NAME", "CONNECTION_STRING", "USER_NAME", "OWNER", "CONNECTION_COMMENT"}))
+	result, err := CreateExaMetaDataReader().GetConnectionByName(tx, "MY_CONNECTION")
+	suite.Require().NoError(err)
+	suite.Nil(result)
+}
+
+func (suite *ExaMetadataUTestSuite) TestGetConnectionByNameFallsBackToAllConnections() {
+	tx := suite.beginTransaction()
+	suite.dbMock.ExpectQuery("(?m)SELECT .*FROM SYS.EXA_DBA_CONNECTIONS").WithArgs("MY_CONNECTION").WillReturnError(errors.New("insufficient privileges"))
+	suite.dbMock.ExpectQuery("(?m)SELECT CONNECTION_NAME, CONNECTION_COMMENT\\s+FROM SYS.EXA_ALL_CONNECTIONS\\s+WHERE CONNECTION_NAME=\\?").WithArgs("MY_CONNECTION").
+		WillReturnRows(sqlmock.NewRows([]string{"CONNECTION_NAME", "CONNECTION_COMMENT"}).AddRow("MY_CONNECTION", nil))
+	result, err := CreateExaMetaDataReader().GetConnectionByName(tx, "MY_CONNECTION")
+	suite.Require().NoError(err)
+	suite.Equal(&ExaConnectionRow{Name: "MY_CONNECTION", ConnectionString: "", UserName: "", Owner: "", Comment: ""}, result)
+}
+
+func (suite *ExaMetadataUTestSuite) TestGetConnectionByNameFails() {
+	tx := suite.beginTransaction()
+	suite.dbMock.ExpectQuery("(?m)SELECT .*FROM SYS.EXA_DBA_CONNECTIONS").WithArgs("MY_CONNECTION").WillReturnError(errors.New("insufficient privileges"))
+	suite.dbMock.ExpectQuery("(?m)SELECT .*FROM SYS.EXA_ALL_CONNECTIONS").WithArgs("MY_CONNECTION").WillReturnError(errors.New("mock error"))
+	result, err := CreateExaMetaDataReader().GetConnectionByName(tx, "MY_CONNECTION")
+	suite.Require().EqualError(err, "failed to read SYS.EXA_ALL_CONNECTIONS: mock error")
+	suite.Nil(result)
+}
+
+// GetSchemaByName
+
+/* [utest -> dsn~extension-context-metadata~1]. */
+func (suite *ExaMetadataUTestSuite) TestGetSchemaByName() {
+	tx := suite.beginTransaction()
+	suite.dbMock.ExpectQuery("(?m)SELECT SCHEMA_NAME, SCHEMA_OWNER, SCHEMA_IS_VIRTUAL, SCHEMA_COMMENT\\s+FROM SYS.EXA_ALL_SCHEMAS\\s+WHERE SCHEMA_NAME=\\?").WithArgs("MY_VS").
+		WillReturnRows(sqlmock.NewRows([]string{"SCHEMA_NAME", "SCHEMA_OWNER", "SCHEMA_IS_VIRTUAL", "SCHEMA_COMMENT"}).AddRow("MY_VS", "SYS", true, nil)).
+		RowsWillBeClosed()
+	result, err := CreateExaMetaDataReader().GetSchemaByName(tx, "MY_VS")
+	suite.Require().NoError(err)
+	suite.Equal(&ExaSchemaRow{Name: "MY_VS", Owner: "SYS", IsVirtual: true, Comment: ""}, result)
+}
+
+func (suite *ExaMetadataUTestSuite) TestGetSchemaByNameNoResult() {
+	tx := suite.beginTransaction()
+	suite.dbMock.ExpectQuery("(?m)SELECT .*FROM SYS.EXA_ALL_SCHEMAS").WithArgs("MY_VS").
+		WillReturnRows(sqlmock.NewRows([]string{"SCHEMA_NAME", "SCHEMA_OWNER", "SCHEMA_IS_VIRTUAL", "SCHEMA_COMMENT"}))
+	result, err := CreateExaMetaDataReader().GetSchemaByName(tx, "MY_VS")
+	suite.Require().NoError(err)
+	suite.Nil(result)
+}
+
+func (suite *ExaMetadataUTestSuite) TestGetSchemaByNameFails() {
+	tx := suite.beginTransaction()
+	suite.dbMock.ExpectQuery("(?m)SELECT .*FROM SYS.EXA_ALL_SCHEMAS").WithArgs("MY_VS").WillReturnError(errors.New("mock error"))
+	result, err := CreateExaMetaDataReader().GetSchemaByName(tx, "MY_VS")
+	suite.Require().EqualError(err, "failed to read SYS.EXA_ALL_SCHEMAS: mock error")
+	suite.Nil(result)
+}
+
+func (suite *ExaMetadataUTestSuite) TestGetSchemaByNameScanFails() {
+	tx := suite.beginTransaction()
+	suite.dbMock.ExpectQuery("(?m)SELECT .*FROM SYS.EXA_ALL_SCHEMAS").WithArgs("MY_VS").
+		WillReturnRows(sqlmock.NewRows([]string{"invalid"}).AddRow("invalid"))
+	result, err := CreateExaMetaDataReader().GetSchemaByName(tx, "MY_VS")
+	suite.Require().EqualError(err, "failed to read row of EXA_ALL_SCHEMAS: sql: expected 1 destination arguments in Scan, not 4")
+	suite.Nil(result)
+}
+
+// GetVirtualSchemaProperties
+
+/* [utest -> dsn~extension-context-metadata~1]. */
+func (suite *ExaMetadataUTestSuite) TestGetVirtualSchemaProperties() {
+	tx := suite.beginTransaction()
+	suite.dbMock.ExpectQuery("(?m)SELECT SCHEMA_NAME, PROPERTY_NAME, PROPERTY_VALUE\\s+FROM SYS.EXA_ALL_VIRTUAL_SCHEMA_PROPERTIES\\s+WHERE SCHEMA_NAME=\\?").WithArgs("MY_VS").
+		WillReturnRows(sqlmock.NewRows([]string{"SCHEMA_NAME", "PROPERTY_NAME", "PROPERTY_VALUE"}).
+			AddRow("MY_VS", "CONNECTION_NAME", "MY_CONNECTION").AddRow("MY_VS", "MAPPING", nil)).
+		RowsWillBeClosed()
+	result, err := CreateExaMetaDataReader().GetVirtualSchemaProperties(tx, "MY_VS")
+	suite.Require().NoError(err)
+	suite.Equal([]ExaVirtualSchemaPropertyRow{
+		{SchemaName: "MY_VS", Name: "CONNECTION_NAME", Value: "MY_CONNECTION"},
+		{SchemaName: "MY_VS", Name: "MAPPING", Value: ""}}, result)
+}
+
+func (suite *ExaMetadataUTestSuite) TestGetVirtualSchemaPropertiesNoResult() {
+	tx := suite.beginTransaction()
+	suite.dbMock.ExpectQuery("(?m)SELECT .*FROM SYS.EXA_ALL_VIRTUAL_SCHEMA_PROPERTIES").WithArgs("MY_VS").
+		WillReturnRows(sqlmock.NewRows([]string{"SCHEMA_NAME", "PROPERTY_NAME", "PROPERTY_VALUE"}))
+	result, err := CreateExaMetaDataReader().GetVirtualSchemaProperties(tx, "MY_VS")
+	suite.Require().NoError(err)
+	suite.Empty(result)
+}
+
+func (suite *ExaMetadataUTestSuite) TestGetVirtualSchemaPropertiesFails() {
+	tx := suite.beginTransaction()
+	suite.dbMock.ExpectQuery("(?m)SELECT .*FROM SYS.EXA_ALL_VIRTUAL_SCHEMA_PROPERTIES").WithArgs("MY_VS").WillReturnError(errors.New("mock error"))
+	result, err := CreateExaMetaDataReader().GetVirtualSchemaProperties(tx, "MY_VS")
+	suite.Require().EqualError(err, "failed to read SYS.EXA_ALL_VIRTUAL_SCHEMA_PROPERTIES: mock error")
+	suite.Nil(result)
+}
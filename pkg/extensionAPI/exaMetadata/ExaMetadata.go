@@ -11,10 +11,42 @@ type ExaMetadataReader interface {
 	// ReadMetadataTables reads all metadata tables.
 	ReadMetadataTables(tx *sql.Tx, schemaName string) (*ExaMetadata, error)
 
+	// ReadScripts reads the rows of table SYS.EXA_ALL_SCRIPTS for the given schema without column SCRIPT_TEXT.
+	// Field Text of the returned rows is always empty, use ReadScriptTexts to read it.
+	ReadScripts(tx *sql.Tx, schemaName string) (*ExaScriptTable, error)
+
+	// ReadScriptTexts reads the texts of all scripts in the given schema from table SYS.EXA_ALL_SCRIPTS.
+	//
+	// Returns a map of script names to script texts.
+	ReadScriptTexts(tx *sql.Tx, schemaName string) (map[string]string, error)
+
+	// ReadVirtualSchemas reads the rows of table SYS.EXA_ALL_VIRTUAL_SCHEMAS for virtual schemas using an adapter script in the given schema.
+	// If the schema is empty, it reads all virtual schemas.
+	ReadVirtualSchemas(tx *sql.Tx, adapterScriptSchema string) (*ExaVirtualSchemasTable, error)
+
 	// GetScriptByName gets a row from the SYS.EXA_ALL_SCRIPTS table for the given schema and script name.
 	//
 	// Returns `(nil, nil)` when no script exists with the given name.
 	GetScriptByName(tx *sql.Tx, schemaName, scriptName string) (*ExaScriptRow, error)
+
+	// ReadPrivileges reads the system privileges, roles and object privileges of the current user.
+	ReadPrivileges(tx *sql.Tx) (*ExaPrivileges, error)
+
+	// GetConnectionByName gets a row from the SYS.EXA_DBA_CONNECTIONS table for the given connection name.
+	// If the user is not allowed to read this table, it uses SYS.EXA_ALL_CONNECTIONS instead.
+	//
+	// Returns `(nil, nil)` when no connection exists with the given name.
+	GetConnectionByName(tx *sql.Tx, connectionName string) (*ExaConnectionRow, error)
+
+	// GetSchemaByName gets a row from the SYS.EXA_ALL_SCHEMAS table for the given schema name.
+	//
+	// Returns `(nil, nil)` when no schema exists with the given name.
+	GetSchemaByName(tx *sql.Tx, schemaName string) (*ExaSchemaRow, error)
+
+	// GetVirtualSchemaProperties gets all rows from the SYS.EXA_ALL_VIRTUAL_SCHEMA_PROPERTIES table for the given virtual schema ordered by property name.
+	//
+	// Returns an empty slice when the virtual schema does not exist or has no properties.
+	GetVirtualSchemaProperties(tx *sql.Tx, virtualSchemaName string) ([]ExaVirtualSchemaPropertyRow, error)
 }
 
 type ExaMetadata struct {
@@ -40,11 +72,11 @@ type metaDataReaderImpl struct {
 // ReadMetadataTables reads the metadata tables of the given schema.
 /* [impl -> dsn~extension-components~1]. */
 func (r *metaDataReaderImpl) ReadMetadataTables(tx *sql.Tx, schemaName string) (*ExaMetadata, error) {
-	allScripts, err := r.readExaAllScriptTable(tx, schemaName)
+	allScripts, err := r.readExaAllScriptTable(tx, schemaName, true)
 	if err != nil {
 		return nil, err
 	}
-	allVirtualSchemas, err := r.readExaAllVirtualSchemasTable(tx)
+	allVirtualSchemas, err := r.readExaAllVirtualSchemasTable(tx, "")
 	if err != nil {
 		return nil, err
 	}
@@ -73,12 +105,54 @@ WHERE SCRIPT_SCHEMA=? AND SCRIPT_NAME=?`, r.metaDataSchema)
 	return row, nil
 }
 
-func (r *metaDataReaderImpl) readExaAllScriptTable(tx *sql.Tx, schemaName string) (*ExaScriptTable, error) {
+/* [impl -> dsn~extension-components~1]. */
+func (r *metaDataReaderImpl) ReadScripts(tx *sql.Tx, schemaName string) (*ExaScriptTable, error) {
+	return r.readExaAllScriptTable(tx, schemaName, false)
+}
+
+/* [impl -> dsn~extension-components~1]. */
+func (r *metaDataReaderImpl) ReadScriptTexts(tx *sql.Tx, schemaName string) (map[string]string, error) {
 	// #nosec G201 Using schema as query parameter is not possible
 	query := fmt.Sprintf(`
-SELECT SCRIPT_SCHEMA, SCRIPT_NAME, SCRIPT_TYPE, SCRIPT_INPUT_TYPE, SCRIPT_RESULT_TYPE, SCRIPT_TEXT, SCRIPT_COMMENT
+SELECT SCRIPT_NAME, SCRIPT_TEXT
 FROM %s.EXA_ALL_SCRIPTS
 WHERE SCRIPT_SCHEMA=?`, r.metaDataSchema)
+	result, err := tx.QueryContext(context.TODO(), query, schemaName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s.EXA_ALL_SCRIPTS: %w", r.metaDataSchema, err)
+	}
+	defer result.Close()
+	texts := make(map[string]string)
+	for result.Next() {
+		var name, text sql.NullString
+		if err := result.Scan(&name, &text); err != nil {
+			return nil, fmt.Errorf("failed to read row of EXA_ALL_SCRIPTS: %w", err)
+		}
+		texts[name.String] = text.String
+	}
+	if result.Err() != nil {
+		return nil, fmt.Errorf("failed to iterate %s.EXA_ALL_SCRIPTS: %w", r.metaDataSchema, result.Err())
+	}
+	return texts, nil
+}
+
+/* [impl -> dsn~extension-components~1]. */
+func (r *metaDataReaderImpl) ReadVirtualSchemas(tx *sql.Tx, adapterScriptSchema string) (*ExaVirtualSchemasTable, error) {
+	return r.readExaAllVirtualSchemasTable(tx, adapterScriptSchema)
+}
+
+// readExaAllScriptTable reads the scripts of the given schema.
+// If withText is false, the query skips column SCRIPT_TEXT to avoid transferring potentially large script texts.
+func (r *metaDataReaderImpl) readExaAllScriptTable(tx *sql.Tx, schemaName string, withText bool) (*ExaScriptTable, error) {
+	textColumn := "NULL"
+	if withText {
+		textColumn = "SCRIPT_TEXT"
+	}
+	// #nosec G201 Using schema as query parameter is not possible
+	query := fmt.Sprintf(`
+SELECT SCRIPT_SCHEMA, SCRIPT_NAME, SCRIPT_TYPE, SCRIPT_INPUT_TYPE, SCRIPT_RESULT_TYPE, %s, SCRIPT_COMMENT
+FROM %s.EXA_ALL_SCRIPTS
+WHERE SCRIPT_SCHEMA=?`, textColumn, r.metaDataSchema)
 	result, err := tx.QueryContext(context.TODO(), query, schemaName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read %s.EXA_ALL_SCRIPTS: %w", r.metaDataSchema, err)
@@ -122,12 +196,18 @@ func readScriptRow(result *sql.Rows) (*ExaScriptRow, error) {
 	return &row, nil
 }
 
-func (r *metaDataReaderImpl) readExaAllVirtualSchemasTable(tx *sql.Tx) (*ExaVirtualSchemasTable, error) {
+// readExaAllVirtualSchemasTable reads all virtual schemas or only the ones using an adapter script in the given schema if it is not empty.
+func (r *metaDataReaderImpl) readExaAllVirtualSchemasTable(tx *sql.Tx, adapterScriptSchema string) (*ExaVirtualSchemasTable, error) {
 	// #nosec G201 Using schema as query parameter is not possible
 	query := fmt.Sprintf(`
 SELECT SCHEMA_NAME, SCHEMA_OWNER, ADAPTER_SCRIPT_SCHEMA, ADAPTER_SCRIPT_NAME, ADAPTER_NOTES
 FROM %s.EXA_ALL_VIRTUAL_SCHEMAS`, r.metaDataSchema)
-	result, err := tx.QueryContext(context.TODO(), query)
+	args := []any{}
+	if adapterScriptSchema != "" {
+		query += "\nWHERE ADAPTER_SCRIPT_SCHEMA=?"
+		args = append(args, adapterScriptSchema)
+	}
+	result, err := tx.QueryContext(context.TODO(), query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read %s.EXA_ALL_VIRTUAL_SCHEMAS: %w", r.metaDataSchema, err)
 	}
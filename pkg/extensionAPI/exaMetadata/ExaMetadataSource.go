@@ -0,0 +1,134 @@
+package exaMetadata
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// ExaMetadataSource provides the metadata tables passed to the findInstallations function of extensions.
+type ExaMetadataSource interface {
+	// GetAllScripts returns the scripts of the extension schema. Field Text of the rows may be empty, use GetScriptText to get the text.
+	GetAllScripts() (*ExaScriptTable, error)
+
+	// GetScriptText returns the text of the given script in the extension schema.
+	GetScriptText(scriptName string) (string, error)
+
+	// GetAllVirtualSchemas returns all virtual schemas visible to the database user.
+	GetAllVirtualSchemas() (*ExaVirtualSchemasTable, error)
+
+	// GetExtensionVirtualSchemas returns the virtual schemas using an adapter script of the extension schema.
+	GetExtensionVirtualSchemas() (*ExaVirtualSchemasTable, error)
+}
+
+// GetAllScripts returns the scripts including their text.
+func (m *ExaMetadata) GetAllScripts() (*ExaScriptTable, error) {
+	return &m.AllScripts, nil
+}
+
+// GetScriptText returns the text of the given script.
+func (m *ExaMetadata) GetScriptText(scriptName string) (string, error) {
+	for _, script := range m.AllScripts.Rows {
+		if script.Name == scriptName {
+			return script.Text, nil
+		}
+	}
+	return "", fmt.Errorf("script %q not found", scriptName)
+}
+
+// GetAllVirtualSchemas returns the virtual schemas.
+func (m *ExaMetadata) GetAllVirtualSchemas() (*ExaVirtualSchemasTable, error) {
+	return &m.AllVirtualSchemas, nil
+}
+
+// GetExtensionVirtualSchemas returns the virtual schemas using one of the scripts as adapter script.
+func (m *ExaMetadata) GetExtensionVirtualSchemas() (*ExaVirtualSchemasTable, error) {
+	rows := make([]ExaVirtualSchemaRow, 0)
+	for _, virtualSchema := range m.AllVirtualSchemas.Rows {
+		for _, script := range m.AllScripts.Rows {
+			if virtualSchema.AdapterScriptSchema == script.Schema && virtualSchema.AdapterScriptName == script.Name {
+				rows = append(rows, virtualSchema)
+				break
+			}
+		}
+	}
+	return &ExaVirtualSchemasTable{Rows: rows}, nil
+}
+
+// LazyExaMetadata reads metadata tables only when they are accessed for the first time and caches them.
+// It reads scripts without their text and reads the texts of all scripts only when the first text is requested.
+// Virtual schemas of the extension schema are read with a filtered query, independent of all virtual schemas.
+// LazyExaMetadata is not safe for concurrent use.
+type LazyExaMetadata struct {
+	reader                  ExaMetadataReader
+	tx                      *sql.Tx
+	schemaName              string
+	allScripts              *ExaScriptTable
+	scriptTexts             map[string]string
+	allVirtualSchemas       *ExaVirtualSchemasTable
+	extensionVirtualSchemas *ExaVirtualSchemasTable
+}
+
+// NewLazyExaMetadata creates a new [LazyExaMetadata] reading the metadata of the given extension schema.
+func NewLazyExaMetadata(reader ExaMetadataReader, tx *sql.Tx, schemaName string) *LazyExaMetadata {
+	return &LazyExaMetadata{
+		reader:                  reader,
+		tx:                      tx,
+		schemaName:              schemaName,
+		allScripts:              nil,
+		scriptTexts:             nil,
+		allVirtualSchemas:       nil,
+		extensionVirtualSchemas: nil,
+	}
+}
+
+/* [impl -> dsn~extension-components~1]. */
+func (m *LazyExaMetadata) GetAllScripts() (*ExaScriptTable, error) {
+	if m.allScripts == nil {
+		scripts, err := m.reader.ReadScripts(m.tx, m.schemaName)
+		if err != nil {
+			return nil, err
+		}
+		m.allScripts = scripts
+	}
+	return m.allScripts, nil
+}
+
+/* [impl -> dsn~extension-components~1]. */
+func (m *LazyExaMetadata) GetScriptText(scriptName string) (string, error) {
+	if m.scriptTexts == nil {
+		texts, err := m.reader.ReadScriptTexts(m.tx, m.schemaName)
+		if err != nil {
+			return "", err
+		}
+		m.scriptTexts = texts
+	}
+	text, found := m.scriptTexts[scriptName]
+	if !found {
+		return "", fmt.Errorf("script %q not found", scriptName)
+	}
+	return text, nil
+}
+
+/* [impl -> dsn~extension-components~1]. */
+func (m *LazyExaMetadata) GetAllVirtualSchemas() (*ExaVirtualSchemasTable, error) {
+	if m.allVirtualSchemas == nil {
+		virtualSchemas, err := m.reader.ReadVirtualSchemas(m.tx, "")
+		if err != nil {
+			return nil, err
+		}
+		m.allVirtualSchemas = virtualSchemas
+	}
+	return m.allVirtualSchemas, nil
+}
+
+/* [impl -> dsn~extension-components~1]. */
+func (m *LazyExaMetadata) GetExtensionVirtualSchemas() (*ExaVirtualSchemasTable, error) {
+	if m.extensionVirtualSchemas == nil {
+		virtualSchemas, err := m.reader.ReadVirtualSchemas(m.tx, m.schemaName)
+		if err != nil {
+			return nil, err
+		}
+		m.extensionVirtualSchemas = virtualSchemas
+	}
+	return m.extensionVirtualSchemas, nil
+}
@@ -0,0 +1,137 @@
+package exaMetadata
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type LazyExaMetadataUTestSuite struct {
+	suite.Suite
+	readerMock *ExaMetaDataReaderMock
+	metadata   *LazyExaMetadata
+}
+
+func TestLazyExaMetadataUTestSuite(t *testing.T) {
+	suite.Run(t, new(LazyExaMetadataUTestSuite))
+}
+
+func (suite *LazyExaMetadataUTestSuite) SetupTest() {
+	suite.readerMock = CreateExaMetaDataReaderMock(SCHEMA_NAME)
+	suite.metadata = NewLazyExaMetadata(suite.readerMock, nil, SCHEMA_NAME)
+}
+
+func (suite *LazyExaMetadataUTestSuite) AfterTest(_suiteName, _testName string) {
+	suite.readerMock.AssertExpectations(suite.T())
+}
+
+func (suite *LazyExaMetadataUTestSuite) TestDoesNotReadTablesWithoutAccess() {
+	suite.simulateMetadata()
+	suite.readerMock.AssertNotCalled(suite.T(), "ReadScripts")
+	suite.readerMock.AssertNotCalled(suite.T(), "ReadScriptTexts")
+	suite.readerMock.AssertNotCalled(suite.T(), "ReadVirtualSchemas")
+}
+
+func (suite *LazyExaMetadataUTestSuite) TestGetAllScriptsReadsScriptsOnlyOnce() {
+	suite.simulateMetadata()
+	for i := 0; i < 2; i++ {
+		scripts, err := suite.metadata.GetAllScripts()
+		suite.Require().NoError(err)
+		suite.Equal(&ExaScriptTable{Rows: []ExaScriptRow{{Schema: SCHEMA_NAME, Name: "script1", Type: "", InputType: "", ResultType: "", Text: "", Comment: ""}}}, scripts)
+	}
+	suite.readerMock.AssertNumberOfCalls(suite.T(), "ReadScripts", 1)
+	suite.readerMock.AssertNotCalled(suite.T(), "ReadScriptTexts")
+}
+
+func (suite *LazyExaMetadataUTestSuite) TestGetAllScriptsFails() {
+	suite.readerMock.SimulateReadScriptsFails(errors.New("mock error"))
+	scripts, err := suite.metadata.GetAllScripts()
+	suite.EqualError(err, "mock error")
+	suite.Nil(scripts)
+}
+
+func (suite *LazyExaMetadataUTestSuite) TestGetScriptTextReadsTextsOnlyOnce() {
+	suite.simulateMetadata()
+	for i := 0; i < 2; i++ {
+		text, err := suite.metadata.GetScriptText("script1")
+		suite.Require().NoError(err)
+		suite.Equal("text1", text)
+	}
+	suite.readerMock.AssertNumberOfCalls(suite.T(), "ReadScriptTexts", 1)
+}
+
+func (suite *LazyExaMetadataUTestSuite) TestGetScriptTextUnknownScript() {
+	suite.simulateMetadata()
+	text, err := suite.metadata.GetScriptText("unknown")
+	suite.EqualError(err, `script "unknown" not found`)
+	suite.Empty(text)
+}
+
+func (suite *LazyExaMetadataUTestSuite) TestGetAllVirtualSchemasReadsVirtualSchemasOnlyOnce() {
+	suite.simulateMetadata()
+	for i := 0; i < 2; i++ {
+		virtualSchemas, err := suite.metadata.GetAllVirtualSchemas()
+		suite.Require().NoError(err)
+		suite.Len(virtualSchemas.Rows, 2)
+	}
+	suite.readerMock.AssertNumberOfCalls(suite.T(), "ReadVirtualSchemas", 1)
+}
+
+func (suite *LazyExaMetadataUTestSuite) TestGetAllVirtualSchemasReturnsVirtualSchemasOfAllSchemas() {
+	suite.simulateMetadata()
+	virtualSchemas, err := suite.metadata.GetAllVirtualSchemas()
+	suite.Require().NoError(err)
+	suite.Equal([]string{"vs1", "vs2"}, virtualSchemaNames(virtualSchemas))
+	suite.readerMock.AssertCalled(suite.T(), "ReadVirtualSchemas", mock.Anything, "")
+}
+
+func (suite *LazyExaMetadataUTestSuite) TestGetExtensionVirtualSchemasReadsVirtualSchemasOnlyOnce() {
+	suite.simulateMetadata()
+	for i := 0; i < 2; i++ {
+		virtualSchemas, err := suite.metadata.GetExtensionVirtualSchemas()
+		suite.Require().NoError(err)
+		suite.Equal([]string{"vs1"}, virtualSchemaNames(virtualSchemas))
+	}
+	suite.readerMock.AssertNumberOfCalls(suite.T(), "ReadVirtualSchemas", 1)
+	suite.readerMock.AssertCalled(suite.T(), "ReadVirtualSchemas", mock.Anything, SCHEMA_NAME)
+}
+
+func (suite *LazyExaMetadataUTestSuite) TestExaMetadataGetExtensionVirtualSchemas() {
+	metadata := &ExaMetadata{AllScripts: ExaScriptTable{Rows: []ExaScriptRow{{Schema: SCHEMA_NAME, Name: "script1", Type: "", InputType: "", ResultType: "", Text: "", Comment: ""}}},
+		AllVirtualSchemas: ExaVirtualSchemasTable{Rows: []ExaVirtualSchemaRow{
+			{Name: "vs1", Owner: "owner", AdapterScriptSchema: SCHEMA_NAME, AdapterScriptName: "script1", AdapterNotes: ""},
+			{Name: "vs2", Owner: "owner", AdapterScriptSchema: "OTHER_SCHEMA", AdapterScriptName: "script1", AdapterNotes: ""},
+			{Name: "vs3", Owner: "owner", AdapterScriptSchema: SCHEMA_NAME, AdapterScriptName: "other", AdapterNotes: ""}}}}
+	virtualSchemas, err := metadata.GetExtensionVirtualSchemas()
+	suite.Require().NoError(err)
+	suite.Equal([]string{"vs1"}, virtualSchemaNames(virtualSchemas))
+}
+
+func (suite *LazyExaMetadataUTestSuite) TestExaMetadataGetScriptText() {
+	metadata := &ExaMetadata{AllScripts: ExaScriptTable{Rows: []ExaScriptRow{{Schema: SCHEMA_NAME, Name: "script1", Type: "", InputType: "", ResultType: "", Text: "text1", Comment: ""}}},
+		AllVirtualSchemas: ExaVirtualSchemasTable{Rows: nil}}
+	text, err := metadata.GetScriptText("script1")
+	suite.Require().NoError(err)
+	suite.Equal("text1", text)
+	_, err = metadata.GetScriptText("unknown")
+	suite.EqualError(err, `script "unknown" not found`)
+}
+
+func (suite *LazyExaMetadataUTestSuite) simulateMetadata() {
+	suite.readerMock.SimulateExaMetaData(ExaMetadata{
+		AllScripts: ExaScriptTable{Rows: []ExaScriptRow{{Schema: SCHEMA_NAME, Name: "script1", Type: "", InputType: "", ResultType: "", Text: "text1", Comment: ""}}},
+		AllVirtualSchemas: ExaVirtualSchemasTable{Rows: []ExaVirtualSchemaRow{
+			{Name: "vs1", Owner: "owner", AdapterScriptSchema: SCHEMA_NAME, AdapterScriptName: "script1", AdapterNotes: ""},
+			{Name: "vs2", Owner: "owner", AdapterScriptSchema: "OTHER_SCHEMA", AdapterScriptName: "script1", AdapterNotes: ""}}},
+	})
+}
+
+func virtualSchemaNames(virtualSchemas *ExaVirtualSchemasTable) []string {
+	names := make([]string, 0, len(virtualSchemas.Rows))
+	for _, row := range virtualSchemas.Rows {
+		names = append(names, row.Name)
+	}
+	return names
+}
@@ -112,6 +112,35 @@ func (suite *ExaMetadataITestSuite) TestReadMetadataScriptsSingleEntryNullValues
 		{Schema: "TEST", Name: "", Type: "", InputType: "", ResultType: "", Text: "", Comment: ""}}}, result.AllScripts)
 }
 
+func (suite *ExaMetadataITestSuite) TestReadScriptsWithoutText() {
+	fixture := integrationTesting.CreateJavaAdapterScriptFixture(suite.exasol.GetConnection())
+	fixture.Cleanup(suite.T())
+	tx, err := suite.exasol.GetConnection().Begin()
+	suite.Require().NoError(err)
+	result, err := exaMetadata.CreateExaMetaDataReader().ReadScripts(tx, fixture.GetSchemaName())
+	suite.Require().NoError(err)
+	suite.Equal(&exaMetadata.ExaScriptTable{Rows: []exaMetadata.ExaScriptRow{{
+		Schema: "TEST", Name: "VS_ADAPTER", Type: "ADAPTER", InputType: "", ResultType: "", Text: "", Comment: ""}}}, result)
+	texts, err := exaMetadata.CreateExaMetaDataReader().ReadScriptTexts(tx, fixture.GetSchemaName())
+	suite.Require().NoError(err)
+	suite.Equal(map[string]string{"VS_ADAPTER": "CREATE JAVA  ADAPTER SCRIPT \"VS_ADAPTER\" AS\n%scriptclass com.exasol.adapter.RequestDispatcher;\n%jar /buckets/bfsdefault/default/vs.jar;"}, texts)
+}
+
+func (suite *ExaMetadataITestSuite) TestReadVirtualSchemasFiltersByAdapterScriptSchema() {
+	fixture := integrationTesting.CreateVirtualSchemaFixture(suite.exasol.GetConnection())
+	fixture.Cleanup(suite.T())
+	tx, err := suite.exasol.GetConnection().Begin()
+	suite.Require().NoError(err)
+	reader := exaMetadata.CreateExaMetaDataReaderForCustomMetadataSchema(fixture.GetMetaDataSchemaName())
+	result, err := reader.ReadVirtualSchemas(tx, "TEST")
+	suite.Require().NoError(err)
+	suite.Equal(&exaMetadata.ExaVirtualSchemasTable{Rows: []exaMetadata.ExaVirtualSchemaRow{
+		{Name: "schema1", Owner: "owner1", AdapterScriptSchema: "TEST", AdapterScriptName: "script1", AdapterNotes: "notes1"}}}, result)
+	result, err = reader.ReadVirtualSchemas(tx, "OTHER")
+	suite.Require().NoError(err)
+	suite.Empty(result.Rows)
+}
+
 /* [itest -> dsn~extension-context-metadata~1]. */
 func (suite *ExaMetadataITestSuite) TestGetScriptByName() {
 	fixture := integrationTesting.CreateJavaAdapterScriptFixture(suite.exasol.GetConnection())
@@ -135,6 +164,34 @@ func (suite *ExaMetadataITestSuite) TestGetScriptByNameNoResult() {
 	suite.Nil(result)
 }
 
+func (suite *ExaMetadataITestSuite) TestReadPrivilegesOfSysUser() {
+	tx, err := suite.exasol.GetConnection().Begin()
+	suite.Require().NoError(err)
+	privileges, err := exaMetadata.CreateExaMetaDataReader().ReadPrivileges(tx)
+	suite.Require().NoError(err)
+	suite.True(privileges.HasSystemPrivilege("CREATE SCRIPT"))
+	suite.True(privileges.HasSystemPrivilege("CREATE CONNECTION"))
+	suite.Contains(privileges.RoleNames(), "DBA")
+}
+
+func (suite *ExaMetadataITestSuite) TestGetSchemaByName() {
+	fixture := integrationTesting.CreateLuaScriptFixture(suite.exasol.GetConnection())
+	fixture.Cleanup(suite.T())
+	tx, err := suite.exasol.GetConnection().Begin()
+	suite.Require().NoError(err)
+	result, err := exaMetadata.CreateExaMetaDataReader().GetSchemaByName(tx, fixture.GetSchemaName())
+	suite.Require().NoError(err)
+	suite.Equal(&exaMetadata.ExaSchemaRow{Name: "TEST", Owner: "SYS", IsVirtual: false, Comment: ""}, result)
+}
+
+func (suite *ExaMetadataITestSuite) TestGetConnectionByNameNoResult() {
+	tx, err := suite.exasol.GetConnection().Begin()
+	suite.Require().NoError(err)
+	result, err := exaMetadata.CreateExaMetaDataReader().GetConnectionByName(tx, "NO_SUCH_CONNECTION")
+	suite.Require().NoError(err)
+	suite.Nil(result)
+}
+
 func (suite *ExaMetadataITestSuite) readMetaDataTables(schemaName string) *exaMetadata.ExaMetadata {
 	tx, err := suite.exasol.GetConnection().Begin()
 	suite.Require().NoError(err)
@@ -22,8 +22,39 @@ func (m *ExaMetaDataReaderMock) SimulateExaAllScripts(scripts []ExaScriptRow) {
 		AllVirtualSchemas: ExaVirtualSchemasTable{Rows: []ExaVirtualSchemaRow{}}})
 }
 
+// SimulateExaMetaData simulates the given metadata for ReadMetadataTables and for the lazy reading functions
+// ReadScripts, ReadScriptTexts and ReadVirtualSchemas. The lazy functions are optional because extensions may not access all tables.
 func (m *ExaMetaDataReaderMock) SimulateExaMetaData(metaData ExaMetadata) {
-	m.On("ReadMetadataTables", mock.Anything, m.extensionSchema).Return(&metaData, nil)
+	m.On("ReadMetadataTables", mock.Anything, m.extensionSchema).Return(&metaData, nil).Maybe()
+	scripts := make([]ExaScriptRow, 0, len(metaData.AllScripts.Rows))
+	texts := make(map[string]string)
+	for _, script := range metaData.AllScripts.Rows {
+		texts[script.Name] = script.Text
+		script.Text = ""
+		scripts = append(scripts, script)
+	}
+	m.On("ReadScripts", mock.Anything, m.extensionSchema).Return(&ExaScriptTable{Rows: scripts}, nil).Maybe()
+	m.On("ReadScriptTexts", mock.Anything, m.extensionSchema).Return(texts, nil).Maybe()
+	extensionVirtualSchemas := make([]ExaVirtualSchemaRow, 0)
+	for _, virtualSchema := range metaData.AllVirtualSchemas.Rows {
+		if virtualSchema.AdapterScriptSchema == m.extensionSchema {
+			extensionVirtualSchemas = append(extensionVirtualSchemas, virtualSchema)
+		}
+	}
+	m.On("ReadVirtualSchemas", mock.Anything, "").Return(&metaData.AllVirtualSchemas, nil).Maybe()
+	m.On("ReadVirtualSchemas", mock.Anything, m.extensionSchema).Return(&ExaVirtualSchemasTable{Rows: extensionVirtualSchemas}, nil).Maybe()
+}
+
+// SimulateReadScriptTexts simulates the given script texts for successive calls of ReadScriptTexts, one map per call.
+// Call this before [ExaMetaDataReaderMock.SimulateExaMetaData], which simulates the texts for all other calls.
+func (m *ExaMetaDataReaderMock) SimulateReadScriptTexts(texts ...map[string]string) {
+	for _, t := range texts {
+		m.On("ReadScriptTexts", mock.Anything, m.extensionSchema).Return(t, nil).Once()
+	}
+}
+
+func (m *ExaMetaDataReaderMock) SimulateReadScriptsFails(err error) {
+	m.On("ReadScripts", mock.Anything, m.extensionSchema).Return(nil, err)
 }
 
 func (mock *ExaMetaDataReaderMock) ReadMetadataTables(tx *sql.Tx, schemaName string) (*ExaMetadata, error) {
@@ -34,6 +65,30 @@ func (mock *ExaMetaDataReaderMock) ReadMetadataTables(tx *sql.Tx, schemaName str
 	return nil, args.Error(1)
 }
 
+func (mock *ExaMetaDataReaderMock) ReadScripts(tx *sql.Tx, schemaName string) (*ExaScriptTable, error) {
+	args := mock.Called(tx, schemaName)
+	if scripts, ok := args.Get(0).(*ExaScriptTable); ok {
+		return scripts, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (mock *ExaMetaDataReaderMock) ReadScriptTexts(tx *sql.Tx, schemaName string) (map[string]string, error) {
+	args := mock.Called(tx, schemaName)
+	if texts, ok := args.Get(0).(map[string]string); ok {
+		return texts, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (mock *ExaMetaDataReaderMock) ReadVirtualSchemas(tx *sql.Tx, adapterScriptSchema string) (*ExaVirtualSchemasTable, error) {
+	args := mock.Called(tx, adapterScriptSchema)
+	if virtualSchemas, ok := args.Get(0).(*ExaVirtualSchemasTable); ok {
+		return virtualSchemas, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
 func (m *ExaMetaDataReaderMock) SimulateGetScriptByNameScriptText(scriptName string, scriptText string) {
 	script := &ExaScriptRow{
 		Schema:     "?",
@@ -62,3 +117,77 @@ func (mock *ExaMetaDataReaderMock) GetScriptByName(tx *sql.Tx, schemaName, scrip
 	}
 	return nil, args.Error(1)
 }
+
+// SimulateSessionPrivileges simulates privileges with the given session privileges and no other privileges or roles.
+func (m *ExaMetaDataReaderMock) SimulateSessionPrivileges(sessionPrivileges ...string) {
+	m.SimulatePrivileges(&ExaPrivileges{
+		SystemPrivileges:  ExaSystemPrivilegesTable{Rows: []ExaSystemPrivilegeRow{}},
+		Roles:             ExaRolesTable{Rows: []ExaRoleRow{}},
+		ObjectPrivileges:  ExaObjectPrivilegesTable{Rows: []ExaObjectPrivilegeRow{}},
+		SessionPrivileges: sessionPrivileges,
+	})
+}
+
+func (m *ExaMetaDataReaderMock) SimulatePrivileges(privileges *ExaPrivileges) {
+	m.On("ReadPrivileges", mock.Anything).Return(privileges, nil)
+}
+
+func (m *ExaMetaDataReaderMock) SimulateReadPrivilegesFails(err error) {
+	m.On("ReadPrivileges", mock.Anything).Return(nil, err)
+}
+
+func (mock *ExaMetaDataReaderMock) ReadPrivileges(tx *sql.Tx) (*ExaPrivileges, error) {
+	args := mock.Called(tx)
+	if privileges, ok := args.Get(0).(*ExaPrivileges); ok {
+		return privileges, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *ExaMetaDataReaderMock) SimulateGetConnectionByName(connectionName string, connection *ExaConnectionRow) {
+	m.On("GetConnectionByName", mock.Anything, connectionName).Return(connection, nil)
+}
+
+func (m *ExaMetaDataReaderMock) SimulateGetConnectionByNameFails(connectionName string, err error) {
+	m.On("GetConnectionByName", mock.Anything, connectionName).Return(nil, err)
+}
+
+func (mock *ExaMetaDataReaderMock) GetConnectionByName(tx *sql.Tx, connectionName string) (*ExaConnectionRow, error) {
+	args := mock.Called(tx, connectionName)
+	if connection, ok := args.Get(0).(*ExaConnectionRow); ok {
+		return connection, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *ExaMetaDataReaderMock) SimulateGetSchemaByName(schemaName string, schema *ExaSchemaRow) {
+	m.On("GetSchemaByName", mock.Anything, schemaName).Return(schema, nil)
+}
+
+func (m *ExaMetaDataReaderMock) SimulateGetSchemaByNameFails(schemaName string, err error) {
+	m.On("GetSchemaByName", mock.Anything, schemaName).Return(nil, err)
+}
+
+func (mock *ExaMetaDataReaderMock) GetSchemaByName(tx *sql.Tx, schemaName string) (*ExaSchemaRow, error) {
+	args := mock.Called(tx, schemaName)
+	if schema, ok := args.Get(0).(*ExaSchemaRow); ok {
+		return schema, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *ExaMetaDataReaderMock) SimulateGetVirtualSchemaProperties(virtualSchemaName string, properties []ExaVirtualSchemaPropertyRow) {
+	m.On("GetVirtualSchemaProperties", mock.Anything, virtualSchemaName).Return(properties, nil)
+}
+
+func (m *ExaMetaDataReaderMock) SimulateGetVirtualSchemaPropertiesFails(virtualSchemaName string, err error) {
+	m.On("GetVirtualSchemaProperties", mock.Anything, virtualSchemaName).Return(nil, err)
+}
+
+func (mock *ExaMetaDataReaderMock) GetVirtualSchemaProperties(tx *sql.Tx, virtualSchemaName string) ([]ExaVirtualSchemaPropertyRow, error) {
+	args := mock.Called(tx, virtualSchemaName)
+	if properties, ok := args.Get(0).([]ExaVirtualSchemaPropertyRow); ok {
+		return properties, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
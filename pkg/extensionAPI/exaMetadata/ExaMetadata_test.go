@@ -89,7 +89,7 @@ func (suite *ExaMetadataUTestSuite) TestReadMetadataTablesAllVirtualSchemasFails
 func (suite *ExaMetadataUTestSuite) TestReadExaAllScriptTableQueryFails() {
 	tx := suite.beginTransaction()
 	suite.dbMock.ExpectQuery("(?m)SELECT .*FROM SYS.EXA_ALL_SCRIPTS .*").WithArgs(SCHEMA_NAME).WillReturnError(errors.New("mock error"))
-	result, err := testee().readExaAllScriptTable(tx, SCHEMA_NAME)
+	result, err := testee().readExaAllScriptTable(tx, SCHEMA_NAME, true)
 	suite.Require().EqualError(err, "failed to read SYS.EXA_ALL_SCRIPTS: mock error")
 	suite.Nil(result)
 }
@@ -103,7 +103,7 @@ func (suite *ExaMetadataUTestSuite) TestReadExaAllScriptTableScanFails() {
 	suite.dbMock.ExpectQuery("(?m)SELECT .*FROM SYS.EXA_ALL_SCRIPTS .*").WithArgs(SCHEMA_NAME).
 		WillReturnRows(sqlmock.NewRows([]string{"WRONG_COL"}).AddRow("Wrong")).
 		RowsWillBeClosed()
-	result, err := testee().readExaAllScriptTable(tx, SCHEMA_NAME)
+	result, err := testee().readExaAllScriptTable(tx, SCHEMA_NAME, true)
 	suite.Require().EqualError(err, "failed to read row of EXA_ALL_SCRIPTS: sql: expected 1 destination arguments in Scan, not 7")
 	suite.Nil(result)
 }
@@ -111,7 +111,7 @@ func (suite *ExaMetadataUTestSuite) TestReadExaAllScriptTableScanFails() {
 func (suite *ExaMetadataUTestSuite) TestReadExaAllVirtualSchemasTableFails() {
 	tx := suite.beginTransaction()
 	suite.dbMock.ExpectQuery("(?m)SELECT .* FROM SYS.EXA_ALL_VIRTUAL_SCHEMAS").WillReturnError(errors.New("mock error"))
-	result, err := testee().readExaAllVirtualSchemasTable(tx)
+	result, err := testee().readExaAllVirtualSchemasTable(tx, "")
 	suite.Require().EqualError(err, "failed to read SYS.EXA_ALL_VIRTUAL_SCHEMAS: mock error")
 	suite.Nil(result)
 }
@@ -119,11 +119,71 @@ func (suite *ExaMetadataUTestSuite) TestReadExaAllVirtualSchemasTableFails() {
 func (suite *ExaMetadataUTestSuite) TestReadExaAllVirtualSchemasTableScanFails() {
 	tx := suite.beginTransaction()
 	suite.dbMock.ExpectQuery("(?m)SELECT .* FROM SYS.EXA_ALL_VIRTUAL_SCHEMAS").WillReturnRows(sqlmock.NewRows([]string{"wrong"}).AddRow("wrong"))
-	result, err := testee().readExaAllVirtualSchemasTable(tx)
+	result, err := testee().readExaAllVirtualSchemasTable(tx, "")
 	suite.Require().EqualError(err, "failed to read row of EXA_ALL_VIRTUAL_SCHEMAS: sql: expected 1 destination arguments in Scan, not 5")
 	suite.Nil(result)
 }
 
+// ReadScripts, ReadScriptTexts, ReadVirtualSchemas
+
+func (suite *ExaMetadataUTestSuite) TestReadScriptsSkipsScriptText() {
+	tx := suite.beginTransaction()
+	suite.dbMock.ExpectQuery(`SELECT SCRIPT_SCHEMA, SCRIPT_NAME, SCRIPT_TYPE, SCRIPT_INPUT_TYPE, SCRIPT_RESULT_TYPE, NULL, SCRIPT_COMMENT\s+FROM SYS.EXA_ALL_SCRIPTS\s+WHERE SCRIPT_SCHEMA=\?`).WithArgs(SCHEMA_NAME).
+		WillReturnRows(sqlmock.
+			NewRows([]string{"SCRIPT_SCHEMA", "SCRIPT_NAME", "SCRIPT_TYPE", "SCRIPT_INPUT_TYPE", "SCRIPT_RESULT_TYPE", "NULL", "SCRIPT_COMMENT"}).
+			AddRow("schema1", "script1", "type1", "input_type1", "result_type1", nil, "comment1")).
+		RowsWillBeClosed()
+	scripts, err := testee().ReadScripts(tx, SCHEMA_NAME)
+	suite.Require().NoError(err)
+	suite.Equal(&ExaScriptTable{Rows: []ExaScriptRow{
+		{Schema: "schema1", Name: "script1", Type: "type1", InputType: "input_type1", ResultType: "result_type1", Text: "", Comment: "comment1"},
+	}}, scripts)
+}
+
+func (suite *ExaMetadataUTestSuite) TestReadScriptTexts() {
+	tx := suite.beginTransaction()
+	suite.dbMock.ExpectQuery(`SELECT SCRIPT_NAME, SCRIPT_TEXT\s+FROM SYS.EXA_ALL_SCRIPTS\s+WHERE SCRIPT_SCHEMA=\?`).WithArgs(SCHEMA_NAME).
+		WillReturnRows(sqlmock.NewRows([]string{"SCRIPT_NAME", "SCRIPT_TEXT"}).
+			AddRow("script1", "text1").
+			AddRow("script2", nil)).
+		RowsWillBeClosed()
+	texts, err := testee().ReadScriptTexts(tx, SCHEMA_NAME)
+	suite.Require().NoError(err)
+	suite.Equal(map[string]string{"script1": "text1", "script2": ""}, texts)
+}
+
+func (suite *ExaMetadataUTestSuite) TestReadScriptTextsQueryFails() {
+	tx := suite.beginTransaction()
+	suite.dbMock.ExpectQuery(`SELECT SCRIPT_NAME, SCRIPT_TEXT`).WithArgs(SCHEMA_NAME).WillReturnError(errors.New("mock error"))
+	texts, err := testee().ReadScriptTexts(tx, SCHEMA_NAME)
+	suite.Require().EqualError(err, "failed to read SYS.EXA_ALL_SCRIPTS: mock error")
+	suite.Nil(texts)
+}
+
+func (suite *ExaMetadataUTestSuite) TestReadScriptTextsScanFails() {
+	tx := suite.beginTransaction()
+	suite.dbMock.ExpectQuery(`SELECT SCRIPT_NAME, SCRIPT_TEXT`).WithArgs(SCHEMA_NAME).
+		WillReturnRows(sqlmock.NewRows([]string{"WRONG_COL"}).AddRow("Wrong")).
+		RowsWillBeClosed()
+	texts, err := testee().ReadScriptTexts(tx, SCHEMA_NAME)
+	suite.Require().EqualError(err, "failed to read row of EXA_ALL_SCRIPTS: sql: expected 1 destination arguments in Scan, not 2")
+	suite.Nil(texts)
+}
+
+func (suite *ExaMetadataUTestSuite) TestReadVirtualSchemasFiltersByAdapterScriptSchema() {
+	tx := suite.beginTransaction()
+	suite.dbMock.ExpectQuery(`SELECT SCHEMA_NAME, SCHEMA_OWNER, ADAPTER_SCRIPT_SCHEMA, ADAPTER_SCRIPT_NAME, ADAPTER_NOTES\s+FROM SYS.EXA_ALL_VIRTUAL_SCHEMAS\s+WHERE ADAPTER_SCRIPT_SCHEMA=\?`).WithArgs(SCHEMA_NAME).
+		WillReturnRows(sqlmock.
+			NewRows([]string{"SCHEMA_NAME", "SCHEMA_OWNER", "ADAPTER_SCRIPT_SCHEMA", "ADAPTER_SCRIPT_NAME", "ADAPTER_NOTES"}).
+			AddRow("vs1", "owner1", SCHEMA_NAME, "script1", "notes1")).
+		RowsWillBeClosed()
+	virtualSchemas, err := testee().ReadVirtualSchemas(tx, SCHEMA_NAME)
+	suite.Require().NoError(err)
+	suite.Equal(&ExaVirtualSchemasTable{Rows: []ExaVirtualSchemaRow{
+		{Name: "vs1", Owner: "owner1", AdapterScriptSchema: SCHEMA_NAME, AdapterScriptName: "script1", AdapterNotes: "notes1"},
+	}}, virtualSchemas)
+}
+
 // GetScriptByName
 
 /* [utest -> dsn~extension-context-metadata~1]. */
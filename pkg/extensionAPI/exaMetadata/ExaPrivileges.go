@@ -0,0 +1,204 @@
+package exaMetadata
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// ExaPrivileges contains the privileges of the current database user.
+type ExaPrivileges struct {
+	SystemPrivileges  ExaSystemPrivilegesTable `json:"systemPrivileges"`  // System privileges granted directly to the user (SYS.EXA_USER_SYS_PRIVS)
+	Roles             ExaRolesTable            `json:"roles"`             // Roles granted to the user (SYS.EXA_DBA_ROLE_PRIVS)
+	ObjectPrivileges  ExaObjectPrivilegesTable `json:"objectPrivileges"`  // Object privileges granted to the user (SYS.EXA_USER_OBJ_PRIVS_RECD)
+	SessionPrivileges []string                 `json:"sessionPrivileges"` // All system privileges of the current session including privileges granted via roles (SYS.EXA_SESSION_PRIVS)
+}
+
+// HasSystemPrivilege returns true if the current session has the given system privilege, either granted directly or via a role.
+func (p *ExaPrivileges) HasSystemPrivilege(privilege string) bool {
+	for _, sessionPrivilege := range p.SessionPrivileges {
+		if strings.EqualFold(sessionPrivilege, privilege) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasObjectPrivilege returns true if the user was granted the given privilege on the given object,
+// e.g. privilege "EXECUTE" on script "MY_SCRIPT" in schema "EXA_EXTENSIONS".
+// Use an empty schema for objects without schema like connections.
+func (p *ExaPrivileges) HasObjectPrivilege(privilege, schema, objectName string) bool {
+	for _, row := range p.ObjectPrivileges.Rows {
+		if strings.EqualFold(row.Privilege, privilege) && row.ObjectSchema == schema && row.ObjectName == objectName {
+			return true
+		}
+	}
+	return false
+}
+
+// RoleNames returns the names of all roles granted to the user.
+func (p *ExaPrivileges) RoleNames() []string {
+	names := make([]string, 0, len(p.Roles.Rows))
+	for _, role := range p.Roles.Rows {
+		names = append(names, role.Role)
+	}
+	return names
+}
+
+type ExaSystemPrivilegesTable struct {
+	Rows []ExaSystemPrivilegeRow `json:"rows"`
+}
+
+type ExaSystemPrivilegeRow struct {
+	Privilege   string `json:"privilege"`
+	AdminOption bool   `json:"adminOption"`
+}
+
+type ExaRolesTable struct {
+	Rows []ExaRoleRow `json:"rows"`
+}
+
+type ExaRoleRow struct {
+	Role        string `json:"role"`
+	AdminOption bool   `json:"adminOption"`
+}
+
+type ExaObjectPrivilegesTable struct {
+	Rows []ExaObjectPrivilegeRow `json:"rows"`
+}
+
+type ExaObjectPrivilegeRow struct {
+	ObjectSchema string `json:"objectSchema"`
+	ObjectName   string `json:"objectName"`
+	ObjectType   string `json:"objectType"`
+	Privilege    string `json:"privilege"`
+}
+
+/* [impl -> dsn~extension-context-metadata~1]. */
+func (r *metaDataReaderImpl) ReadPrivileges(tx *sql.Tx) (*ExaPrivileges, error) {
+	systemPrivileges, err := r.readSystemPrivileges(tx)
+	if err != nil {
+		return nil, err
+	}
+	roles, err := r.readRoles(tx)
+	if err != nil {
+		return nil, err
+	}
+	objectPrivileges, err := r.readObjectPrivileges(tx)
+	if err != nil {
+		return nil, err
+	}
+	sessionPrivileges, err := r.readSessionPrivileges(tx)
+	if err != nil {
+		return nil, err
+	}
+	return &ExaPrivileges{SystemPrivileges: *systemPrivileges, Roles: *roles, ObjectPrivileges: *objectPrivileges, SessionPrivileges: sessionPrivileges}, nil
+}
+
+func (r *metaDataReaderImpl) readSystemPrivileges(tx *sql.Tx) (*ExaSystemPrivilegesTable, error) {
+	// #nosec G201 Using schema as query parameter is not possible
+	query := fmt.Sprintf(`SELECT PRIVILEGE, ADMIN_OPTION FROM %s.EXA_USER_SYS_PRIVS`, r.metaDataSchema)
+	result, err := tx.QueryContext(context.TODO(), query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s.EXA_USER_SYS_PRIVS: %w", r.metaDataSchema, err)
+	}
+	defer result.Close()
+	rows := make([]ExaSystemPrivilegeRow, 0)
+	for result.Next() {
+		var privilege sql.NullString
+		var adminOption sql.NullBool
+		if err := result.Scan(&privilege, &adminOption); err != nil {
+			return nil, fmt.Errorf("failed to read row of EXA_USER_SYS_PRIVS: %w", err)
+		}
+		rows = append(rows, ExaSystemPrivilegeRow{Privilege: privilege.String, AdminOption: adminOption.Bool})
+	}
+	if result.Err() != nil {
+		return nil, fmt.Errorf("failed to iterate %s.EXA_USER_SYS_PRIVS: %w", r.metaDataSchema, result.Err())
+	}
+	return &ExaSystemPrivilegesTable{Rows: rows}, nil
+}
+
+// readRoles reads the roles granted to the current user from EXA_DBA_ROLE_PRIVS.
+// Reading this table requires privilege SELECT ANY DICTIONARY, so this falls back to EXA_USER_ROLE_PRIVS
+// which only contains roles granted directly to the user.
+func (r *metaDataReaderImpl) readRoles(tx *sql.Tx) (*ExaRolesTable, error) {
+	roles, err := r.readRolesFromTable(tx, "EXA_DBA_ROLE_PRIVS")
+	if err == nil {
+		return roles, nil
+	}
+	log.Debugf("Reading roles from %s.EXA_DBA_ROLE_PRIVS failed, using EXA_USER_ROLE_PRIVS instead: %v", r.metaDataSchema, err)
+	return r.readRolesFromTable(tx, "EXA_USER_ROLE_PRIVS")
+}
+
+func (r *metaDataReaderImpl) readRolesFromTable(tx *sql.Tx, tableName string) (*ExaRolesTable, error) {
+	// #nosec G201 Using schema and table as query parameter is not possible
+	query := fmt.Sprintf(`SELECT GRANTED_ROLE, ADMIN_OPTION FROM %s.%s WHERE GRANTEE = CURRENT_USER`, r.metaDataSchema, tableName)
+	result, err := tx.QueryContext(context.TODO(), query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s.%s: %w", r.metaDataSchema, tableName, err)
+	}
+	defer result.Close()
+	rows := make([]ExaRoleRow, 0)
+	for result.Next() {
+		var role sql.NullString
+		var adminOption sql.NullBool
+		if err := result.Scan(&role, &adminOption); err != nil {
+			return nil, fmt.Errorf("failed to read row of %s: %w", tableName, err)
+		}
+		rows = append(rows, ExaRoleRow{Role: role.String, AdminOption: adminOption.Bool})
+	}
+	if result.Err() != nil {
+		return nil, fmt.Errorf("failed to iterate %s.%s: %w", r.metaDataSchema, tableName, result.Err())
+	}
+	return &ExaRolesTable{Rows: rows}, nil
+}
+
+func (r *metaDataReaderImpl) readObjectPrivileges(tx *sql.Tx) (*ExaObjectPrivilegesTable, error) {
+	// #nosec G201 Using schema as query parameter is not possible
+	query := fmt.Sprintf(`SELECT OBJECT_SCHEMA, OBJECT_NAME, OBJECT_TYPE, PRIVILEGE FROM %s.EXA_USER_OBJ_PRIVS_RECD`, r.metaDataSchema)
+	result, err := tx.QueryContext(context.TODO(), query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s.EXA_USER_OBJ_PRIVS_RECD: %w", r.metaDataSchema, err)
+	}
+	defer result.Close()
+	rows := make([]ExaObjectPrivilegeRow, 0)
+	for result.Next() {
+		var objectSchema sql.NullString
+		var objectName sql.NullString
+		var objectType sql.NullString
+		var privilege sql.NullString
+		if err := result.Scan(&objectSchema, &objectName, &objectType, &privilege); err != nil {
+			return nil, fmt.Errorf("failed to read row of EXA_USER_OBJ_PRIVS_RECD: %w", err)
+		}
+		rows = append(rows, ExaObjectPrivilegeRow{ObjectSchema: objectSchema.String, ObjectName: objectName.String, ObjectType: objectType.String, Privilege: privilege.String})
+	}
+	if result.Err() != nil {
+		return nil, fmt.Errorf("failed to iterate %s.EXA_USER_OBJ_PRIVS_RECD: %w", r.metaDataSchema, result.Err())
+	}
+	return &ExaObjectPrivilegesTable{Rows: rows}, nil
+}
+
+func (r *metaDataReaderImpl) readSessionPrivileges(tx *sql.Tx) ([]string, error) {
+	// #nosec G201 Using schema as query parameter is not possible
+	query := fmt.Sprintf(`SELECT PRIVILEGE FROM %s.EXA_SESSION_PRIVS`, r.metaDataSchema)
+	result, err := tx.QueryContext(context.TODO(), query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s.EXA_SESSION_PRIVS: %w", r.metaDataSchema, err)
+	}
+	defer result.Close()
+	privileges := make([]string, 0)
+	for result.Next() {
+		var privilege sql.NullString
+		if err := result.Scan(&privilege); err != nil {
+			return nil, fmt.Errorf("failed to read row of EXA_SESSION_PRIVS: %w", err)
+		}
+		privileges = append(privileges, privilege.String)
+	}
+	if result.Err() != nil {
+		return nil, fmt.Errorf("failed to iterate %s.EXA_SESSION_PRIVS: %w", r.metaDataSchema, result.Err())
+	}
+	return privileges, nil
+}
@@ -0,0 +1,124 @@
+package exaMetadata
+
+import (
+	"errors"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func (suite *ExaMetadataUTestSuite) TestReadPrivileges() {
+	tx := suite.beginTransaction()
+	suite.dbMock.ExpectQuery("SELECT PRIVILEGE, ADMIN_OPTION FROM SYS.EXA_USER_SYS_PRIVS").
+		WillReturnRows(sqlmock.NewRows([]string{"PRIVILEGE", "ADMIN_OPTION"}).AddRow("CREATE SCRIPT", true).AddRow("CREATE SESSION", nil)).
+		RowsWillBeClosed()
+	suite.dbMock.ExpectQuery("SELECT GRANTED_ROLE, ADMIN_OPTION FROM SYS.EXA_DBA_ROLE_PRIVS WHERE GRANTEE = CURRENT_USER").
+		WillReturnRows(sqlmock.NewRows([]string{"GRANTED_ROLE", "ADMIN_OPTION"}).AddRow("PUBLIC", false).AddRow("EXTENSION_ADMIN", true)).
+		RowsWillBeClosed()
+	suite.dbMock.ExpectQuery("SELECT OBJECT_SCHEMA, OBJECT_NAME, OBJECT_TYPE, PRIVILEGE FROM SYS.EXA_USER_OBJ_PRIVS_RECD").
+		WillReturnRows(sqlmock.NewRows([]string{"OBJECT_SCHEMA", "OBJECT_NAME", "OBJECT_TYPE", "PRIVILEGE"}).
+			AddRow("EXA_EXTENSIONS", "ADAPTER", "SCRIPT", "EXECUTE").AddRow(nil, "MY_CONNECTION", "CONNECTION", "ACCESS")).
+		RowsWillBeClosed()
+	suite.dbMock.ExpectQuery("SELECT PRIVILEGE FROM SYS.EXA_SESSION_PRIVS").
+		WillReturnRows(sqlmock.NewRows([]string{"PRIVILEGE"}).AddRow("CREATE SCRIPT").AddRow("CREATE SESSION").AddRow("CREATE CONNECTION")).
+		RowsWillBeClosed()
+
+	privileges, err := CreateExaMetaDataReader().ReadPrivileges(tx)
+	suite.Require().NoError(err)
+	suite.Equal(&ExaPrivileges{
+		SystemPrivileges: ExaSystemPrivilegesTable{Rows: []ExaSystemPrivilegeRow{{Privilege: "CREATE SCRIPT", AdminOption: true}, {Privilege: "CREATE SESSION", AdminOption: false}}},
+		Roles:            ExaRolesTable{Rows: []ExaRoleRow{{Role: "PUBLIC", AdminOption: false}, {Role: "EXTENSION_ADMIN", AdminOption: true}}},
+		ObjectPrivileges: ExaObjectPrivilegesTable{Rows: []ExaObjectPrivilegeRow{
+			{ObjectSchema: "EXA_EXTENSIONS", ObjectName: "ADAPTER", ObjectType: "SCRIPT", Privilege: "EXECUTE"},
+			{ObjectSchema: "", ObjectName: "MY_CONNECTION", ObjectType: "CONNECTION", Privilege: "ACCESS"}}},
+		SessionPrivileges: []string{"CREATE SCRIPT", "CREATE SESSION", "CREATE CONNECTION"},
+	}, privileges)
+}
+
+func (suite *ExaMetadataUTestSuite) TestReadPrivilegesFallsBackToUserRolePrivs() {
+	tx := suite.beginTransaction()
+	suite.dbMock.ExpectQuery("SELECT PRIVILEGE, ADMIN_OPTION FROM SYS.EXA_USER_SYS_PRIVS").
+		WillReturnRows(sqlmock.NewRows([]string{"PRIVILEGE", "ADMIN_OPTION"}))
+	suite.dbMock.ExpectQuery("SELECT GRANTED_ROLE, ADMIN_OPTION FROM SYS.EXA_DBA_ROLE_PRIVS").WillReturnError(errors.New("insufficient privileges"))
+	suite.dbMock.ExpectQuery("SELECT GRANTED_ROLE, ADMIN_OPTION FROM SYS.EXA_USER_ROLE_PRIVS WHERE GRANTEE = CURRENT_USER").
+		WillReturnRows(sqlmock.NewRows([]string{"GRANTED_ROLE", "ADMIN_OPTION"}).AddRow("PUBLIC", false))
+	suite.dbMock.ExpectQuery("SELECT OBJECT_SCHEMA, OBJECT_NAME, OBJECT_TYPE, PRIVILEGE FROM SYS.EXA_USER_OBJ_PRIVS_RECD").
+		WillReturnRows(sqlmock.NewRows([]string{"OBJECT_SCHEMA", "OBJECT_NAME", "OBJECT_TYPE", "PRIVILEGE"}))
+	suite.dbMock.ExpectQuery("SELECT PRIVILEGE FROM SYS.EXA_SESSION_PRIVS").
+		WillReturnRows(sqlmock.NewRows([]string{"PRIVILEGE"}))
+
+	privileges, err := CreateExaMetaDataReader().ReadPrivileges(tx)
+	suite.Require().NoError(err)
+	suite.Equal([]string{"PUBLIC"}, privileges.RoleNames())
+}
+
+func (suite *ExaMetadataUTestSuite) TestReadPrivilegesFails() {
+	var tests = []struct {
+		name          string
+		failingQuery  string
+		expectedError string
+	}{
+		{"system privileges", "EXA_USER_SYS_PRIVS", "failed to read SYS.EXA_USER_SYS_PRIVS: mock error"},
+		{"roles", "EXA_USER_ROLE_PRIVS", "failed to read SYS.EXA_USER_ROLE_PRIVS: mock error"},
+		{"object privileges", "EXA_USER_OBJ_PRIVS_RECD", "failed to read SYS.EXA_USER_OBJ_PRIVS_RECD: mock error"},
+		{"session privileges", "EXA_SESSION_PRIVS", "failed to read SYS.EXA_SESSION_PRIVS: mock error"},
+	}
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			suite.SetupTest()
+			tx := suite.beginTransaction()
+			suite.expectPrivilegeQueriesUntil(test.failingQuery)
+			privileges, err := CreateExaMetaDataReader().ReadPrivileges(tx)
+			suite.Require().EqualError(err, test.expectedError)
+			suite.Nil(privileges)
+			suite.NoError(suite.dbMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (suite *ExaMetadataUTestSuite) expectPrivilegeQueriesUntil(failingTable string) {
+	queries := []struct {
+		table   string
+		columns []string
+	}{
+		{"EXA_USER_SYS_PRIVS", []string{"PRIVILEGE", "ADMIN_OPTION"}},
+		{"EXA_DBA_ROLE_PRIVS", nil},
+		{"EXA_USER_ROLE_PRIVS", []string{"GRANTED_ROLE", "ADMIN_OPTION"}},
+		{"EXA_USER_OBJ_PRIVS_RECD", []string{"OBJECT_SCHEMA", "OBJECT_NAME", "OBJECT_TYPE", "PRIVILEGE"}},
+		{"EXA_SESSION_PRIVS", []string{"PRIVILEGE"}},
+	}
+	for _, query := range queries {
+		expectation := suite.dbMock.ExpectQuery("SELECT .* FROM SYS." + query.table)
+		if query.table == failingTable || query.columns == nil {
+			expectation.WillReturnError(errors.New("mock error"))
+		} else {
+			expectation.WillReturnRows(sqlmock.NewRows(query.columns))
+		}
+		if query.table == failingTable {
+			return
+		}
+	}
+}
+
+func (suite *ExaMetadataUTestSuite) TestReadSystemPrivilegesScanFails() {
+	tx := suite.beginTransaction()
+	suite.dbMock.ExpectQuery("SELECT .* FROM SYS.EXA_USER_SYS_PRIVS").WillReturnRows(sqlmock.NewRows([]string{"wrong"}).AddRow("wrong"))
+	result, err := testee().readSystemPrivileges(tx)
+	suite.Require().EqualError(err, "failed to read row of EXA_USER_SYS_PRIVS: sql: expected 1 destination arguments in Scan, not 2")
+	suite.Nil(result)
+}
+
+func (suite *ExaMetadataUTestSuite) TestHasSystemPrivilege() {
+	privileges := &ExaPrivileges{SessionPrivileges: []string{"CREATE SCRIPT"}} //nolint:exhaustruct // Only session privileges are relevant
+	suite.True(privileges.HasSystemPrivilege("CREATE SCRIPT"))
+	suite.True(privileges.HasSystemPrivilege("create script"))
+	suite.False(privileges.HasSystemPrivilege("CREATE CONNECTION"))
+}
+
+func (suite *ExaMetadataUTestSuite) TestHasObjectPrivilege() {
+	//nolint:exhaustruct // Only object privileges are relevant
+	privileges := &ExaPrivileges{ObjectPrivileges: ExaObjectPrivilegesTable{Rows: []ExaObjectPrivilegeRow{
+		{ObjectSchema: "EXA_EXTENSIONS", ObjectName: "ADAPTER", ObjectType: "SCRIPT", Privilege: "EXECUTE"}}}}
+	suite.True(privileges.HasObjectPrivilege("EXECUTE", "EXA_EXTENSIONS", "ADAPTER"))
+	suite.False(privileges.HasObjectPrivilege("EXECUTE", "OTHER_SCHEMA", "ADAPTER"))
+	suite.False(privileges.HasObjectPrivilege("ALTER", "EXA_EXTENSIONS", "ADAPTER"))
+}
@@ -2,10 +2,13 @@ package extensionAPI
 
 import (
 	"fmt"
+	"math"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/exasol/extension-manager/pkg/extensionAPI/context"
-	"github.com/exasol/extension-manager/pkg/extensionAPI/exaMetadata"
+	"github.com/exasol/extension-manager/pkg/metrics"
 	log "github.com/sirupsen/logrus"
 
 	"github.com/dop251/goja"
@@ -17,6 +20,7 @@ import (
 /* [impl -> dsn~extension-definition~1]. */
 func LoadExtension(id, content string) (*JsExtension, error) {
 	t0 := time.Now()
+	defer metrics.ObserveJsExecution(id, "load", t0)
 	logPrefix := fmt.Sprintf("JS:%s>", id)
 	vm := newJavaScriptVm(logPrefix)
 	extensionJs, err := loadExtension(vm, id, content)
@@ -86,14 +90,17 @@ type rawJsExtension struct {
 	InstallableVersions []rawJsExtensionVersion `json:"installableVersions"`
 	// [impl -> dsn~parameter-versioning~1]
 	// [impl -> dsn~configuration-parameters~1]
-	GetParameterDefinitions func(context *context.ExtensionContext, version string) []interface{}                           `json:"getInstanceParameters"`
-	Install                 func(context *context.ExtensionContext, version string)                                         `json:"install"`
-	Uninstall               func(context *context.ExtensionContext, version string)                                         `json:"uninstall"`
-	Upgrade                 func(context *context.ExtensionContext) *JsUpgradeResult                                        `json:"upgrade"`
-	FindInstallations       func(context *context.ExtensionContext, metadata *exaMetadata.ExaMetadata) []*JsExtInstallation `json:"findInstallations"`
-	AddInstance             func(context *context.ExtensionContext, version string, params *ParameterValues) *JsExtInstance `json:"addInstance"`
-	FindInstances           func(context *context.ExtensionContext, version string) []*JsExtInstance                        `json:"findInstances"`
-	DeleteInstance          func(context *context.ExtensionContext, version, instanceId string)                             `json:"deleteInstance"`
+	GetParameterDefinitions func(context *context.ExtensionContext, version string) []interface{}                                       `json:"getInstanceParameters"`
+	Install                 func(context *context.ExtensionContext, version string)                                                     `json:"install"`
+	Uninstall               func(context *context.ExtensionContext, version string)                                                     `json:"uninstall"`
+	Upgrade                 func(context *context.ExtensionContext) *JsUpgradeResult                                                    `json:"upgrade"`
+	FindInstallations       func(context *context.ExtensionContext, metadata *goja.Object) []*JsExtInstallation                         `json:"findInstallations"`
+	AddInstance             func(context *context.ExtensionContext, version string, params *ParameterValues) *JsExtInstance             `json:"addInstance"`
+	FindInstances           func(context *context.ExtensionContext, version string) []*JsExtInstance                                    `json:"findInstances"`
+	DeleteInstance          func(context *context.ExtensionContext, version, instanceId string)                                         `json:"deleteInstance"`
+	UpdateInstance          func(context *context.ExtensionContext, version, instanceId string, params *ParameterValues) *JsExtInstance `json:"updateInstance"`
+	GetInstance             func(context *context.ExtensionContext, version, instanceId string) *JsExtInstanceDetails                   `json:"getInstance"`
+	Preflight               func(context *context.ExtensionContext, version string) []*JsPreflightCheck                                 `json:"preflight"`
 }
 
 type rawJsExtensionVersion struct {
@@ -126,6 +133,36 @@ type JsExtInstance struct {
 	Name string `json:"name"`
 }
 
+// JsExtInstanceDetails contains an instance together with its current parameter values,
+// information about its creation and the database objects that belong to it.
+type JsExtInstanceDetails struct {
+	Id         string                 `json:"id"`
+	Name       string                 `json:"name"`
+	Parameters []ParameterValue       `json:"parameters"`
+	Metadata   *JsExtInstanceMetadata `json:"metadata"`  // Optional
+	DbObjects  []JsExtDbObject        `json:"dbObjects"` // Optional
+}
+
+// JsExtInstanceMetadata contains information about the creation of an instance.
+type JsExtInstanceMetadata struct {
+	CreatedAt string `json:"createdAt"` // Creation timestamp as returned by the database, e.g. from EXA_ALL_OBJECTS.CREATED
+	CreatedBy string `json:"createdBy"` // Name of the user who created the instance
+}
+
+// JsExtDbObject is a database object belonging to an instance, e.g. a virtual schema or connection.
+type JsExtDbObject struct {
+	Type   string `json:"type"`   // Object type, e.g. "VIRTUAL SCHEMA" or "CONNECTION"
+	Schema string `json:"schema"` // Schema containing the object, empty for objects without schema
+	Name   string `json:"name"`   // Name of the object
+}
+
+// JsPreflightCheck is the result of a check executed by an extension before installing it, e.g. verifying that a required script does not exist yet.
+type JsPreflightCheck struct {
+	Name    string `json:"name"`    // Description of what was checked
+	Success bool   `json:"success"` // True if the check passed
+	Message string `json:"message"` // Optional reason why the check failed
+}
+
 type ParameterValues struct {
 	Values []ParameterValue `json:"values"`
 }
@@ -138,10 +175,63 @@ func (pv ParameterValues) Find(id string) (value ParameterValue, found bool) {
 			return v, true
 		}
 	}
-	return ParameterValue{Name: "", Value: ""}, false
+	return ParameterValue{Name: "", Value: nil}, false
 }
 
+// ParameterValue contains the value of a parameter. The value is a string, bool, number or a list of values.
 type ParameterValue struct {
-	Name  string `json:"name"`
-	Value string `json:"value"`
+	Name  string      `json:"name"`
+	Value interface{} `json:"value"`
+}
+
+// StringValue returns the value as string, converting it like JavaScript's String().
+// Lists are joined with commas. A missing value is converted to an empty string.
+func (p ParameterValue) StringValue() string {
+	return FormatValue(p.Value)
+}
+
+// FormatValue converts a parameter value to a string, see [ParameterValue.StringValue].
+func FormatValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return formatNumber(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case []interface{}:
+		elements := make([]string, 0, len(v))
+		for _, element := range v {
+			elements = append(elements, FormatValue(element))
+		}
+		return strings.Join(elements, ",")
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// formatNumber converts a number to a string like JavaScript's Number.prototype.toString():
+// numbers from 1e-6 (inclusive) to 1e21 (exclusive) in decimal notation, others in exponential notation like "1e+21" or "1.5e-7".
+func formatNumber(value float64) string {
+	switch {
+	case math.IsNaN(value):
+		return "NaN"
+	case math.IsInf(value, 1):
+		return "Infinity"
+	case math.IsInf(value, -1):
+		return "-Infinity"
+	case value == 0:
+		return "0"
+	}
+	if abs := math.Abs(value); abs >= 1e-6 && abs < 1e21 {
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+	// Go pads the exponent to two digits, JavaScript does not.
+	mantissa, exponent, _ := strings.Cut(strconv.FormatFloat(value, 'e', -1, 64), "e")
+	sign, digits := exponent[:1], strings.TrimLeft(exponent[1:], "0")
+	return mantissa + "e" + sign + digits
 }
@@ -2,6 +2,9 @@ package extensionAPI
 
 import (
 	"errors"
+	"fmt"
+	"math"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -10,6 +13,9 @@ import (
 	"github.com/exasol/extension-manager/pkg/extensionAPI/exaMetadata"
 	"github.com/exasol/extension-manager/pkg/integrationTesting"
 
+	"github.com/dop251/goja"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -177,6 +183,17 @@ func (suite *ExtensionApiSuite) TestAddInstanceValidParameters() {
 	suite.Equal(&JsExtInstance{Id: "instId", Name: "instance_extensionVersion_p1_v1"}, instance)
 }
 
+func (suite *ExtensionApiSuite) TestAddInstanceTypedParameters() {
+	extensionContent := integrationTesting.CreateTestExtensionBuilder(suite.T()).
+		WithAddInstanceFunc("return {id: 'instId', name: params.values.map(p => `${p.name}:${typeof p.value}:${p.value}`).join(';')};").
+		Build().AsString()
+	extension := suite.loadExtension(extensionContent)
+	instance, err := extension.AddInstance(suite.mockContext(), "extensionVersion", &ParameterValues{Values: []ParameterValue{
+		{Name: "string", Value: "v1"}, {Name: "bool", Value: true}, {Name: "number", Value: 1.5}, {Name: "list", Value: []interface{}{"a", "b"}}}})
+	suite.Require().NoError(err)
+	suite.Equal(&JsExtInstance{Id: "instId", Name: "string:string:v1;bool:boolean:true;number:number:1.5;list:object:a,b"}, instance)
+}
+
 func (suite *ExtensionApiSuite) TestListInstancesEmptyResult() {
 	extensionContent := integrationTesting.CreateTestExtensionBuilder(suite.T()).
 		Build().AsString()
@@ -206,6 +223,60 @@ func (suite *ExtensionApiSuite) TestDeleteInstance() {
 	suite.Require().NoError(err)
 }
 
+func (suite *ExtensionApiSuite) TestUpdateInstance() {
+	extensionContent := integrationTesting.CreateTestExtensionBuilder(suite.T()).
+		WithUpdateInstanceFunc("context.sqlClient.execute(`update instance ${instanceId}`);\n" +
+			"return {id: instanceId, name: `instance_${extensionVersion}_${params.values[0].name}_${params.values[0].value}`};").
+		Build().AsString()
+	extension := suite.loadExtension(extensionContent)
+	suite.mockSQLClient.SimulateExecuteSuccess("update instance instId")
+	instance, err := extension.UpdateInstance(suite.mockContext(), "extVersion", "instId", &ParameterValues{Values: []ParameterValue{{Name: "p1", Value: "v1"}}})
+	suite.Require().NoError(err)
+	suite.Equal(&JsExtInstance{Id: "instId", Name: "instance_extVersion_p1_v1"}, instance)
+}
+
+func (suite *ExtensionApiSuite) TestGetInstance() {
+	extensionContent := integrationTesting.CreateTestExtensionBuilder(suite.T()).
+		WithGetInstanceFunc(`return {id: instanceId, name: "instName", parameters: [{name: "p1", value: "v1"}]}`).
+		Build().AsString()
+	extension := suite.loadExtension(extensionContent)
+	instance, err := extension.GetInstance(suite.mockContext(), "extVersion", "instId")
+	suite.Require().NoError(err)
+	suite.Equal(&JsExtInstanceDetails{Id: "instId", Name: "instName", Parameters: []ParameterValue{{Name: "p1", Value: "v1"}}}, instance)
+}
+
+func (suite *ExtensionApiSuite) TestGetInstanceWithMetadataAndDbObjects() {
+	extensionContent := integrationTesting.CreateTestExtensionBuilder(suite.T()).
+		WithGetInstanceFunc(`return {id: instanceId, name: "instName", parameters: [],
+			metadata: {createdAt: "2023-01-02 03:04:05.000000", createdBy: "SYS"},
+			dbObjects: [{type: "VIRTUAL SCHEMA", name: "VS"}, {type: "CONNECTION", name: "VS_CONNECTION"}, {type: "SCRIPT", schema: "EXA_EXTENSIONS", name: "ADAPTER"}]}`).
+		Build().AsString()
+	extension := suite.loadExtension(extensionContent)
+	instance, err := extension.GetInstance(suite.mockContext(), "extVersion", "instId")
+	suite.Require().NoError(err)
+	suite.Equal(&JsExtInstanceDetails{Id: "instId", Name: "instName", Parameters: []ParameterValue{},
+		Metadata: &JsExtInstanceMetadata{CreatedAt: "2023-01-02 03:04:05.000000", CreatedBy: "SYS"},
+		DbObjects: []JsExtDbObject{{Type: "VIRTUAL SCHEMA", Schema: "", Name: "VS"}, {Type: "CONNECTION", Schema: "", Name: "VS_CONNECTION"},
+			{Type: "SCRIPT", Schema: "EXA_EXTENSIONS", Name: "ADAPTER"}}}, instance)
+}
+
+func (suite *ExtensionApiSuite) TestPreflight() {
+	extensionContent := integrationTesting.CreateTestExtensionBuilder(suite.T()).
+		WithPreflightFunc(`return [{name: "check for version " + version, success: false, message: "failed"}]`).
+		Build().AsString()
+	extension := suite.loadExtension(extensionContent)
+	suite.True(extension.SupportsPreflight())
+	checks, err := extension.Preflight(suite.mockContext(), "extVersion")
+	suite.Require().NoError(err)
+	suite.Equal([]*JsPreflightCheck{{Name: "check for version extVersion", Success: false, Message: "failed"}}, checks)
+}
+
+func (suite *ExtensionApiSuite) TestPreflightNotSupported() {
+	extensionContent := integrationTesting.CreateTestExtensionBuilder(suite.T()).Build().AsString()
+	extension := suite.loadExtension(extensionContent)
+	suite.False(extension.SupportsPreflight())
+}
+
 func createMockMetadata() *exaMetadata.ExaMetadata {
 	return &exaMetadata.ExaMetadata{
 		AllScripts: exaMetadata.ExaScriptTable{Rows: []exaMetadata.ExaScriptRow{
@@ -235,6 +306,81 @@ func (suite *ExtensionApiSuite) TestFindInstallationsReturningParameters() {
 	suite.Require().NoError(err)
 }
 
+func (suite *ExtensionApiSuite) TestFindInstallationsReadsScriptTextOnDemand() {
+	extensionContent := integrationTesting.CreateTestExtensionBuilder(suite.T()).
+		WithFindInstallationsFunc(`
+		return metadata.allScripts.rows.map(row => {
+			return {name: row.name, version: row.text}
+		});`).Build().AsString()
+	extension := suite.loadExtension(extensionContent)
+	suite.mockMetadataReader.SimulateExaMetaData(*createMockMetadata())
+	result, err := extension.FindInstallations(suite.mockContext(), exaMetadata.NewLazyExaMetadata(suite.mockMetadataReader, nil, EXTENSION_SCHEMA))
+	suite.Require().NoError(err)
+	suite.Equal([]*JsExtInstallation{{ID: "", Name: "test", Version: "text"}}, result)
+	suite.mockMetadataReader.AssertNumberOfCalls(suite.T(), "ReadScriptTexts", 1)
+	suite.mockMetadataReader.AssertNotCalled(suite.T(), "ReadVirtualSchemas")
+}
+
+func (suite *ExtensionApiSuite) TestFindInstallationsDoesNotReadScriptTextIfNotAccessed() {
+	extensionContent := integrationTesting.CreateTestExtensionBuilder(suite.T()).
+		WithFindInstallationsFunc(`
+		return metadata.allScripts.rows.map(row => {
+			return {name: row.name, version: "0.1.0"}
+		});`).Build().AsString()
+	extension := suite.loadExtension(extensionContent)
+	suite.mockMetadataReader.SimulateExaMetaData(*createMockMetadata())
+	result, err := extension.FindInstallations(suite.mockContext(), exaMetadata.NewLazyExaMetadata(suite.mockMetadataReader, nil, EXTENSION_SCHEMA))
+	suite.Require().NoError(err)
+	suite.Equal([]*JsExtInstallation{{ID: "", Name: "test", Version: "0.1.0"}}, result)
+	suite.mockMetadataReader.AssertNotCalled(suite.T(), "ReadScriptTexts")
+}
+
+func (suite *ExtensionApiSuite) TestFindInstallationsGetVirtualSchemasByAdapterScript() {
+	extensionContent := integrationTesting.CreateTestExtensionBuilder(suite.T()).
+		WithFindInstallationsFunc(`
+		return metadata.getVirtualSchemasByAdapterScript("adapter").map(vs => {
+			return {name: vs.name, version: "0.1.0"}
+		});`).Build().AsString()
+	extension := suite.loadExtension(extensionContent)
+	suite.mockMetadataReader.SimulateExaMetaData(exaMetadata.ExaMetadata{
+		AllScripts: exaMetadata.ExaScriptTable{Rows: []exaMetadata.ExaScriptRow{}},
+		AllVirtualSchemas: exaMetadata.ExaVirtualSchemasTable{Rows: []exaMetadata.ExaVirtualSchemaRow{
+			{Name: "vs1", Owner: "owner", AdapterScriptSchema: EXTENSION_SCHEMA, AdapterScriptName: "adapter", AdapterNotes: ""},
+			{Name: "vs2", Owner: "owner", AdapterScriptSchema: EXTENSION_SCHEMA, AdapterScriptName: "other", AdapterNotes: ""},
+			{Name: "vs3", Owner: "owner", AdapterScriptSchema: "OTHER_SCHEMA", AdapterScriptName: "adapter", AdapterNotes: ""}}}})
+	result, err := extension.FindInstallations(suite.mockContext(), exaMetadata.NewLazyExaMetadata(suite.mockMetadataReader, nil, EXTENSION_SCHEMA))
+	suite.Require().NoError(err)
+	suite.Equal([]*JsExtInstallation{{ID: "", Name: "vs1", Version: "0.1.0"}}, result)
+	suite.mockMetadataReader.AssertNotCalled(suite.T(), "ReadScripts")
+}
+
+func (suite *ExtensionApiSuite) TestFindInstallationsAllVirtualSchemasContainsVirtualSchemasOfOtherSchemas() {
+	extensionContent := integrationTesting.CreateTestExtensionBuilder(suite.T()).
+		WithFindInstallationsFunc(`
+		return metadata.allVirtualSchemas.rows.map(vs => {
+			return {name: vs.name, version: "0.1.0"}
+		});`).Build().AsString()
+	extension := suite.loadExtension(extensionContent)
+	suite.mockMetadataReader.SimulateExaMetaData(exaMetadata.ExaMetadata{
+		AllScripts: exaMetadata.ExaScriptTable{Rows: []exaMetadata.ExaScriptRow{}},
+		AllVirtualSchemas: exaMetadata.ExaVirtualSchemasTable{Rows: []exaMetadata.ExaVirtualSchemaRow{
+			{Name: "vs1", Owner: "owner", AdapterScriptSchema: EXTENSION_SCHEMA, AdapterScriptName: "adapter", AdapterNotes: ""},
+			{Name: "vs2", Owner: "owner", AdapterScriptSchema: "OTHER_SCHEMA", AdapterScriptName: "adapter", AdapterNotes: ""}}}})
+	result, err := extension.FindInstallations(suite.mockContext(), exaMetadata.NewLazyExaMetadata(suite.mockMetadataReader, nil, EXTENSION_SCHEMA))
+	suite.Require().NoError(err)
+	suite.Equal([]*JsExtInstallation{{ID: "", Name: "vs1", Version: "0.1.0"}, {ID: "", Name: "vs2", Version: "0.1.0"}}, result)
+}
+
+func (suite *ExtensionApiSuite) TestFindInstallationsReadingScriptsFails() {
+	extensionContent := integrationTesting.CreateTestExtensionBuilder(suite.T()).
+		WithFindInstallationsFunc(`return metadata.allScripts.rows.map(row => ({name: row.name, version: "0.1.0"}));`).Build().AsString()
+	extension := suite.loadExtension(extensionContent)
+	suite.mockMetadataReader.SimulateReadScriptsFails(errors.New("mock error"))
+	result, err := extension.FindInstallations(suite.mockContext(), exaMetadata.NewLazyExaMetadata(suite.mockMetadataReader, nil, EXTENSION_SCHEMA))
+	suite.Require().EqualError(err, `failed to find installations for extension "ext-id": failed to read scripts. Cause: mock error`)
+	suite.Nil(result)
+}
+
 /* [itest -> dsn~extension-context-metadata~1]. */
 func (suite *ExtensionApiSuite) TestUpgradeReadsMetadata() {
 	extensionContent := integrationTesting.CreateTestExtensionBuilder(suite.T()).
@@ -256,6 +402,35 @@ func (suite *ExtensionApiSuite) TestUpgradeReadMetadataFails() {
 	suite.Nil(result)
 }
 
+/* [itest -> dsn~extension-context-metadata~1]. */
+func (suite *ExtensionApiSuite) TestPreflightReadsConnectionsAndSchemas() {
+	extensionContent := integrationTesting.CreateTestExtensionBuilder(suite.T()).
+		WithPreflightFunc(`const connection = context.metadata.getConnectionByName("CON");
+			const schema = context.metadata.getSchemaByName("VS");
+			const properties = context.metadata.getVirtualSchemaProperties("VS");
+			return [{name: "objects exist", success: connection === null && schema.isVirtual, message: properties.map(p => p.name + "=" + p.value).join(",")}]`).
+		Build().AsString()
+	extension := suite.loadExtension(extensionContent)
+	suite.mockMetadataReader.SimulateGetConnectionByName("CON", nil)
+	suite.mockMetadataReader.SimulateGetSchemaByName("VS", &exaMetadata.ExaSchemaRow{Name: "VS", Owner: "SYS", IsVirtual: true, Comment: ""})
+	suite.mockMetadataReader.SimulateGetVirtualSchemaProperties("VS", []exaMetadata.ExaVirtualSchemaPropertyRow{{SchemaName: "VS", Name: "CONNECTION_NAME", Value: "CON"}})
+	checks, err := extension.Preflight(suite.mockContext(), "extVersion")
+	suite.Require().NoError(err)
+	suite.Equal([]*JsPreflightCheck{{Name: "objects exist", Success: true, Message: "CONNECTION_NAME=CON"}}, checks)
+}
+
+func (suite *ExtensionApiSuite) TestPreflightReadsPrivileges() {
+	extensionContent := integrationTesting.CreateTestExtensionBuilder(suite.T()).
+		WithPreflightFunc(`const privileges = context.metadata.getPrivileges();
+			return [{name: "create connection", success: privileges.hasSystemPrivilege("CREATE CONNECTION"), message: privileges.sessionPrivileges.join(",")}]`).
+		Build().AsString()
+	extension := suite.loadExtension(extensionContent)
+	suite.mockMetadataReader.SimulateSessionPrivileges("CREATE SCRIPT", "CREATE CONNECTION")
+	checks, err := extension.Preflight(suite.mockContext(), "extVersion")
+	suite.Require().NoError(err)
+	suite.Equal([]*JsPreflightCheck{{Name: "create connection", Success: true, Message: "CREATE SCRIPT,CREATE CONNECTION"}}, checks)
+}
+
 /* [itest -> dsn~extension-compatibility~1]. */
 func (suite *ExtensionApiSuite) TestLoadExtensionWithCompatibleApiVersion() {
 	extensionContent := minimalExtension("0.1.15")
@@ -334,3 +509,51 @@ func (suite *ExtensionApiSuite) loadExtension(content string) *JsExtension {
 	}
 	return extension
 }
+
+func TestFormatValue(t *testing.T) {
+	var tests = []struct {
+		value    interface{}
+		expected string
+	}{
+		{nil, ""},
+		{"", ""},
+		{"value", "value"},
+		{true, "true"},
+		{false, "false"},
+		{1.0, "1"},
+		{1.5, "1.5"},
+		{-2.5, "-2.5"},
+		{0.1, "0.1"},
+		{123456789012345680000.0, "123456789012345680000"},
+		{1e21, "1e+21"},
+		{1.5e300, "1.5e+300"},
+		{-1e21, "-1e+21"},
+		{1e-6, "0.000001"},
+		{1e-7, "1e-7"},
+		{1.5e-10, "1.5e-10"},
+		{math.Copysign(0, -1), "0"},
+		{math.NaN(), "NaN"},
+		{math.Inf(1), "Infinity"},
+		{math.Inf(-1), "-Infinity"},
+		{int64(42), "42"},
+		{[]interface{}{}, ""},
+		{[]interface{}{"a", true, 2.0}, "a,true,2"},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%v", test.value), func(t *testing.T) {
+			assert.Equal(t, test.expected, FormatValue(test.value))
+			assert.Equal(t, test.expected, ParameterValue{Name: "name", Value: test.value}.StringValue())
+		})
+	}
+}
+
+func TestFormatValueFormatsNumbersLikeJavaScript(t *testing.T) {
+	vm := goja.New()
+	for _, value := range []float64{0, 1, -1, 0.5, 1 / 3.0, 2e20, 1e21, 1.2345e21, 1e-6, 1.5e-6, 1e-7, 9.99e-8, math.MaxFloat64, math.SmallestNonzeroFloat64, -123.456e-10} {
+		t.Run(fmt.Sprintf("%v", value), func(t *testing.T) {
+			expected, err := vm.RunString(fmt.Sprintf("String(%v)", strconv.FormatFloat(value, 'g', -1, 64)))
+			require.NoError(t, err)
+			assert.Equal(t, expected.String(), FormatValue(value))
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package extensionAPI
+
+import (
+	"fmt"
+
+	"github.com/dop251/goja"
+	"github.com/exasol/extension-manager/pkg/apiErrors"
+	"github.com/exasol/extension-manager/pkg/extensionAPI/exaMetadata"
+)
+
+// newJsMetadata creates the metadata object passed to the findInstallations function of an extension.
+//
+// Properties allScripts and allVirtualSchemas are read from the source only when the extension accesses them.
+// Function getVirtualSchemasByAdapterScript reads only the virtual schemas using an adapter script in the extension schema.
+// The text of a script is read only when the extension accesses property text of a script row.
+/* [impl -> dsn~extension-components~1]. */
+func (e *JsExtension) newJsMetadata(source exaMetadata.ExaMetadataSource) (*goja.Object, error) {
+	metadata := e.vm.NewObject()
+	err := e.defineLazyProperty(metadata, "allScripts", func() goja.Value {
+		return e.newJsScriptTable(source)
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = e.defineLazyProperty(metadata, "allVirtualSchemas", func() goja.Value {
+		return e.vm.ToValue(getAllVirtualSchemas(source))
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = metadata.Set("getVirtualSchemasByAdapterScript", func(adapterScriptName string) []exaMetadata.ExaVirtualSchemaRow {
+		rows := make([]exaMetadata.ExaVirtualSchemaRow, 0)
+		for _, row := range getExtensionVirtualSchemas(source).Rows {
+			if row.AdapterScriptName == adapterScriptName {
+				rows = append(rows, row)
+			}
+		}
+		return rows
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to define function getVirtualSchemasByAdapterScript: %w", err)
+	}
+	return metadata, nil
+}
+
+func (e *JsExtension) newJsScriptTable(source exaMetadata.ExaMetadataSource) goja.Value {
+	scripts, err := source.GetAllScripts()
+	if err != nil {
+		reportMetadataError(fmt.Errorf("failed to read scripts. Cause: %w", err))
+	}
+	rows := make([]interface{}, 0, len(scripts.Rows))
+	for _, script := range scripts.Rows {
+		row, err := e.newJsScriptRow(source, script)
+		if err != nil {
+			reportMetadataError(err)
+		}
+		rows = append(rows, row)
+	}
+	table := e.vm.NewObject()
+	if err := table.Set("rows", e.vm.NewArray(rows...)); err != nil {
+		reportMetadataError(fmt.Errorf("failed to set rows of script table: %w", err))
+	}
+	return table
+}
+
+func (e *JsExtension) newJsScriptRow(source exaMetadata.ExaMetadataSource, script exaMetadata.ExaScriptRow) (*goja.Object, error) {
+	row := e.vm.NewObject()
+	fields := []struct {
+		name  string
+		value string
+	}{
+		{"schema", script.Schema}, {"name", script.Name}, {"type", script.Type},
+		{"inputType", script.InputType}, {"resultType", script.ResultType}, {"comment", script.Comment},
+	}
+	for _, field := range fields {
+		if err := row.Set(field.name, field.value); err != nil {
+			return nil, fmt.Errorf("failed to set field %q of script row: %w", field.name, err)
+		}
+	}
+	err := e.defineLazyProperty(row, "text", func() goja.Value {
+		text, err := source.GetScriptText(script.Name)
+		if err != nil {
+			reportMetadataError(fmt.Errorf("failed to read text of script %q. Cause: %w", script.Name, err))
+		}
+		return e.vm.ToValue(text)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return row, nil
+}
+
+func getAllVirtualSchemas(source exaMetadata.ExaMetadataSource) *exaMetadata.ExaVirtualSchemasTable {
+	virtualSchemas, err := source.GetAllVirtualSchemas()
+	if err != nil {
+		reportMetadataError(fmt.Errorf("failed to read virtual schemas. Cause: %w", err))
+	}
+	return virtualSchemas
+}
+
+func getExtensionVirtualSchemas(source exaMetadata.ExaMetadataSource) *exaMetadata.ExaVirtualSchemasTable {
+	virtualSchemas, err := source.GetExtensionVirtualSchemas()
+	if err != nil {
+		reportMetadataError(fmt.Errorf("failed to read virtual schemas of the extension schema. Cause: %w", err))
+	}
+	return virtualSchemas
+}
+
+// defineLazyProperty defines an enumerable read-only property that calls the given function on first access and caches the result.
+func (e *JsExtension) defineLazyProperty(object *goja.Object, name string, load func() goja.Value) error {
+	var value goja.Value
+	getter := e.vm.ToValue(func(goja.FunctionCall) goja.Value {
+		if value == nil {
+			value = load()
+		}
+		return value
+	})
+	if err := object.DefineAccessorProperty(name, getter, nil, goja.FLAG_FALSE, goja.FLAG_TRUE); err != nil {
+		return fmt.Errorf("failed to define property %q: %w", name, err)
+	}
+	return nil
+}
+
+// reportMetadataError reports an error while reading metadata tables.
+// Metadata properties are accessed by JavaScript code. The only way to report a failure is to panic.
+func reportMetadataError(err error) {
+	panic(apiErrors.CTRL_READING_METADATA_FAILED.NewInternalErrorF("%w", err))
+}
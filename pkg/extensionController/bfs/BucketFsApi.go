@@ -4,21 +4,26 @@ import (
 	"context"
 	"database/sql"
 	_ "embed" // Embedding file df/list_files_udf.py
-	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/sirupsen/logrus"
+
+	"github.com/exasol/extension-manager/pkg/apiErrors"
+	"github.com/exasol/extension-manager/pkg/metrics"
 )
 
 // BucketFsAPI allows access to BucketFS.
 // Users must call the [BucketFsAPI.Close] method to release resources after using the BucketFS API.
 type BucketFsAPI interface {
-	// ListFiles lists all files in the configured directory recursively.
+	// ListFiles lists all files in the configured directories recursively.
+	// The result contains the files of all base paths in the configured order.
 	ListFiles() ([]BfsFile, error)
 
 	// FindAbsolutePath searches for a file with the given name in BucketFS and returns its absolute path.
-	// If multiple files with the same name exist in different folders, this picks an arbitrary file and returns its path.
+	// Base paths are searched in the configured order.
+	// If multiple files with the same name exist in different folders of the same base path, this picks the first file ordered by path.
 	// If no file with the given name exists, this will return an error.
 	FindAbsolutePath(fileName string) (string, error)
 
@@ -28,9 +33,10 @@ type BucketFsAPI interface {
 
 // BfsFile represents a file in BucketFS.
 type BfsFile struct {
-	Path string // Absolute path in BucketFS, starting with the base path, e.g. "/buckets/bfsdefault/default/"
-	Name string // File name
-	Size int    // File size in bytes
+	Path     string // Absolute path in BucketFS, starting with the base path, e.g. "/buckets/bfsdefault/default/"
+	Name     string // File name
+	Size     int    // File size in bytes
+	BasePath string // BucketFS base path in which the file was found, e.g. "/buckets/bfsdefault/default/"
 }
 
 // CreateBucketFsAPI creates an instance of BucketFsAPI.
@@ -39,78 +45,170 @@ type BfsFile struct {
 // Call the [BucketFsAPI.Close] method to release resources after using the BucketFS API.
 /* [impl -> dsn~configure-bucketfs-path~1]. */
 func CreateBucketFsAPI(bucketFsBasePath string, ctx context.Context, db *sql.DB) (BucketFsAPI, error) {
-	if bucketFsBasePath == "" {
-		return nil, errors.New("bucketFsBasePath is empty")
+	return CreateCachingBucketFsAPI([]string{bucketFsBasePath}, ctx, db, nil)
+}
+
+// CreateCachingBucketFsAPI creates an instance of BucketFsAPI that searches the given base paths in the given order
+// and shares file listings using the given [ListingCache].
+//
+// The cache is only used if the context contains the database host, see [WithDatabaseHost].
+// The listing cache may be nil, then listings are only reused by the returned instance.
+// The UDF for listing files is only created when a listing is not found in the cache.
+/* [impl -> dsn~configure-bucketfs-path~1]. */
+func CreateCachingBucketFsAPI(bucketFsBasePaths []string, ctx context.Context, db *sql.DB, cache *ListingCache) (BucketFsAPI, error) {
+	if err := ValidateBasePaths(bucketFsBasePaths); err != nil {
+		return nil, err
 	}
-	transaction, err := db.BeginTx(ctx, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create a transaction. Cause: %w", err)
+	return &bucketFsAPIImpl{
+		ctx:               ctx,
+		db:                db,
+		bucketFsBasePaths: bucketFsBasePaths,
+		udfScriptName:     "",
+		transaction:       nil,
+		files:             nil,
+		cache:             cache,
+	}, nil
+}
+
+// ValidateBasePaths verifies that the given list of BucketFS base paths is not empty
+// and contains neither empty nor duplicate entries.
+func ValidateBasePaths(bucketFsBasePaths []string) error {
+	if len(bucketFsBasePaths) == 0 {
+		return apiErrors.BFS_EMPTY_BASE_PATH.NewInternalErrorF("bucketFsBasePath is empty")
 	}
-	udfScriptName, err := createUdfScript(ctx, transaction)
-	if err != nil {
-		_ = transaction.Rollback()
-		return nil, err
+	for i, basePath := range bucketFsBasePaths {
+		if basePath == "" {
+			return apiErrors.BFS_EMPTY_BASE_PATH.NewInternalErrorF("bucketFsBasePath is empty")
+		}
+		if slices.Contains(bucketFsBasePaths[:i], basePath) {
+			return apiErrors.BFS_DUPLICATE_BASE_PATH.NewInternalErrorF("bucketFsBasePath %q is configured more than once", basePath)
+		}
 	}
-	return &bucketFsAPIImpl{bucketFsBasePath: bucketFsBasePath, udfScriptName: udfScriptName, transaction: transaction}, nil
+	return nil
 }
 
 type bucketFsAPIImpl struct {
-	bucketFsBasePath string
-	udfScriptName    string
-	transaction      *sql.Tx
+	ctx               context.Context
+	db                *sql.DB
+	bucketFsBasePaths []string
+	udfScriptName     string  // Name of the UDF for listing files, empty until the first listing is read from the database
+	transaction       *sql.Tx // Transaction containing the UDF, nil until the first listing is read from the database
+	files             []BfsFile
+	cache             *ListingCache
 }
 
+// ListFiles lists all files under the base paths.
+// The listing is only read once and reused for subsequent calls of [BucketFsAPI.ListFiles] and [BucketFsAPI.FindAbsolutePath].
+// If a [ListingCache] is configured, the listing is also shared with other instances for the same database and base path.
 /* [impl -> dsn~extension-components~1]. */
-func (bfs bucketFsAPIImpl) ListFiles() ([]BfsFile, error) {
+func (bfs *bucketFsAPIImpl) ListFiles() ([]BfsFile, error) {
+	if bfs.files != nil {
+		return slices.Clone(bfs.files), nil
+	}
+	files := make([]BfsFile, 0)
+	knownPaths := make(map[string]struct{})
+	for _, basePath := range bfs.bucketFsBasePaths {
+		filesInBasePath, err := bfs.listFilesInBasePath(basePath)
+		if err != nil {
+			return nil, err
+		}
+		files = appendNewFiles(files, knownPaths, filesInBasePath)
+	}
+	bfs.files = files
+	return slices.Clone(files), nil
+}
+
+// appendNewFiles appends files not yet contained in the list.
+// This avoids duplicate entries in case base paths are nested.
+// knownPaths contains the paths of all files in the list and is updated with the appended files.
+func appendNewFiles(files []BfsFile, knownPaths map[string]struct{}, newFiles []BfsFile) []BfsFile {
+	for _, file := range newFiles {
+		if _, known := knownPaths[file.Path]; !known {
+			knownPaths[file.Path] = struct{}{}
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+func (bfs *bucketFsAPIImpl) listFilesInBasePath(basePath string) ([]BfsFile, error) {
+	cacheKey := newListingCacheKey(bfs.ctx, basePath)
+	if files, found := bfs.cache.get(cacheKey); found {
+		logrus.Debugf("Using cached listing of %d files under %q", len(files), basePath)
+		return files, nil
+	}
+	start := time.Now()
+	files, err := bfs.readFiles(basePath)
+	metrics.ObserveBucketFsListing(start, err)
+	if err != nil {
+		return nil, err
+	}
+	bfs.cache.put(cacheKey, files)
+	return files, nil
+}
+
+func (bfs *bucketFsAPIImpl) readFiles(basePath string) ([]BfsFile, error) {
+	if err := bfs.createUdfScriptIfMissing(); err != nil {
+		return nil, err
+	}
 	t0 := time.Now()
-	statement, err := bfs.transaction.PrepareContext(context.TODO(), "SELECT "+bfs.udfScriptName+"(?) ORDER BY FULL_PATH") //nolint:gosec // SQL string concatenation is safe here
+	statement, err := bfs.transaction.PrepareContext(bfs.ctx, "SELECT "+bfs.udfScriptName+"(?) ORDER BY FULL_PATH") //nolint:gosec // SQL string concatenation is safe here
 	if err != nil {
-		return nil, fmt.Errorf("failed to create prepared statement for listing files. Cause: %w", err)
+		return nil, apiErrors.BFS_LISTING_FAILED.NewInternalErrorF("failed to create prepared statement for listing files. Cause: %w", err)
 	}
 	defer statement.Close()
-	result, err := statement.QueryContext(context.TODO(), bfs.bucketFsBasePath)
+	result, err := statement.QueryContext(bfs.ctx, basePath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list files. Cause: %w", err)
+		return nil, apiErrors.BFS_LISTING_FAILED.NewInternalErrorF("failed to list files. Cause: %w", err)
 	}
 	defer result.Close()
-	files, err := readQueryResult(result)
+	files, err := readQueryResult(result, basePath)
+	if err != nil {
+		return nil, err
+	}
 	if logrus.IsLevelEnabled(logrus.TraceLevel) {
 		for _, file := range files {
 			logrus.Tracef("- Found file %q with size %d", file.Path, file.Size)
 		}
 	}
-	logrus.Debugf("Listed %d files under %q in %dms", len(files), bfs.bucketFsBasePath, time.Since(t0).Milliseconds())
-	return files, err
+	logrus.Debugf("Listed %d files under %q in %dms", len(files), basePath, time.Since(t0).Milliseconds())
+	return files, nil
 }
 
 /* [impl -> dsn~resolving-files-in-bucketfs~1]. */
 /* [impl -> dsn~extension-context-bucketfs~1]. */
-func (bfs bucketFsAPIImpl) FindAbsolutePath(fileName string) (string, error) {
+func (bfs *bucketFsAPIImpl) FindAbsolutePath(fileName string) (string, error) {
 	t0 := time.Now()
-	query := fmt.Sprintf(`SELECT FULL_PATH FROM (SELECT %s(?)) WHERE FILE_NAME = ? ORDER BY FULL_PATH LIMIT 1`, bfs.udfScriptName) //nolint:gosec // SQL string concatenation is safe here
-	statement, err := bfs.transaction.PrepareContext(context.TODO(), query)
+	files, err := bfs.ListFiles()
 	if err != nil {
-		return "", fmt.Errorf("failed to create prepared statement for running list files UDF. Cause: %w", err)
+		return "", fmt.Errorf("failed to find absolute path in BucketFS. Cause: %w", err)
 	}
-	defer statement.Close()
-	result, err := statement.QueryContext(context.TODO(), bfs.bucketFsBasePath, fileName)
-	if err != nil {
-		return "", fmt.Errorf("failed to find absolute path in BucketFS using UDF. Cause: %w", err)
-	}
-	defer result.Close()
-	if !result.Next() {
-		if result.Err() != nil {
-			return "", fmt.Errorf("failed iterating absolute path results. Cause: %w", result.Err())
+	for _, file := range files {
+		if file.Name == fileName {
+			logrus.Tracef("Found absolute path %q for file %q in %dms", file.Path, fileName, time.Since(t0).Milliseconds())
+			return file.Path, nil
 		}
-		return "", fmt.Errorf("file %q not found in BucketFS", fileName)
 	}
-	var absolutePath string
-	err = result.Scan(&absolutePath)
+	return "", apiErrors.BFS_FILE_NOT_FOUND.NewInternalErrorF("file %q not found in BucketFS", fileName)
+}
+
+// createUdfScriptIfMissing starts a transaction and creates the UDF for listing files if this was not done before.
+func (bfs *bucketFsAPIImpl) createUdfScriptIfMissing() error {
+	if bfs.transaction != nil {
+		return nil
+	}
+	transaction, err := bfs.db.BeginTx(bfs.ctx, nil)
+	if err != nil {
+		return apiErrors.BFS_LISTING_FAILED.NewInternalErrorF("failed to create a transaction. Cause: %w", err)
+	}
+	udfScriptName, err := createUdfScript(bfs.ctx, transaction)
 	if err != nil {
-		return "", fmt.Errorf("failed reading absolute path. Cause: %w", err)
+		_ = transaction.Rollback()
+		return err
 	}
-	logrus.Tracef("Found absolute path %q for file %q in %dms", absolutePath, fileName, time.Since(t0).Milliseconds())
-	return absolutePath, nil
+	bfs.transaction = transaction
+	bfs.udfScriptName = udfScriptName
+	return nil
 }
 
 //go:embed udf/list_files_udf.py
@@ -121,7 +219,7 @@ func createUdfScript(ctx context.Context, transaction *sql.Tx) (string, error) {
 	schemaName := fmt.Sprintf("INTERNAL_%v", t0.Unix())
 	_, err := transaction.ExecContext(ctx, "CREATE SCHEMA "+schemaName)
 	if err != nil {
-		return "", fmt.Errorf("failed to create a schema for BucketFS list script. Cause: %w", err)
+		return "", apiErrors.BFS_CREATING_LIST_UDF_FAILED.NewInternalErrorF("failed to create a schema for BucketFS list script. Cause: %w", err)
 	}
 	udfScriptName := fmt.Sprintf(`"%s"."LIST_RECURSIVELY"`, schemaName)
 	script := fmt.Sprintf(`CREATE OR REPLACE PYTHON3 SCALAR SCRIPT %s ("path" VARCHAR(100))
@@ -130,30 +228,34 @@ func createUdfScript(ctx context.Context, transaction *sql.Tx) (string, error) {
 /`, udfScriptName, listFilesRecursivelyUdfContent)
 	_, err = transaction.ExecContext(ctx, script)
 	if err != nil {
-		return "", fmt.Errorf("failed to create UDF script for listing bucket. Cause: %w", err)
+		return "", apiErrors.BFS_CREATING_LIST_UDF_FAILED.NewInternalErrorF("failed to create UDF script for listing bucket. Cause: %w", err)
 	}
 	logrus.Debugf("Created UDF script %s in %dms", udfScriptName, time.Since(t0).Milliseconds())
 	return udfScriptName, nil
 }
 
-func readQueryResult(result *sql.Rows) ([]BfsFile, error) {
-	var files []BfsFile
+func readQueryResult(result *sql.Rows, basePath string) ([]BfsFile, error) {
+	files := make([]BfsFile, 0)
 	for result.Next() {
 		var file BfsFile
 		var fileSize float64
 		err := result.Scan(&file.Name, &file.Path, &fileSize)
 		if err != nil {
-			return nil, fmt.Errorf("failed reading result of BucketFS list UDF. Cause: %w", err)
+			return nil, apiErrors.BFS_LISTING_FAILED.NewInternalErrorF("failed reading result of BucketFS list UDF. Cause: %w", err)
 		}
 		file.Size = int(fileSize)
+		file.BasePath = basePath
 		files = append(files, file)
 	}
 	return files, nil
 }
 
-func (bfs bucketFsAPIImpl) Close() error {
+func (bfs *bucketFsAPIImpl) Close() error {
+	if bfs.transaction == nil {
+		return nil
+	}
 	if err := bfs.transaction.Rollback(); err != nil {
-		return fmt.Errorf("failed to rollback transaction to cleanup resources. Cause: %w", err)
+		return apiErrors.BFS_CLEANUP_FAILED.NewInternalErrorF("failed to rollback transaction to cleanup resources. Cause: %w", err)
 	}
 	return nil
 }
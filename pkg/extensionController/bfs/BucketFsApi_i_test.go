@@ -29,11 +29,18 @@ func (suite *BucketFsClientITestSuite) SetupSuite() {
 	logrus.SetLevel(logrus.DebugLevel)
 	suite.exasol = *integrationTesting.StartDbSetup(&suite.Suite)
 	suite.exasol.CreateConnection()
+}
+
+// SetupTest creates a new client for each test because the client reuses its listing of BucketFS.
+func (suite *BucketFsClientITestSuite) SetupTest() {
 	suite.bfsClient = suite.createBucketFsClient()
 }
 
+func (suite *BucketFsClientITestSuite) TearDownTest() {
+	suite.NoError(suite.bfsClient.Close())
+}
+
 func (suite *BucketFsClientITestSuite) TeardownSuite() {
-	suite.bfsClient.Close()
 	suite.exasol.CloseConnection()
 	suite.exasol.StopDb()
 }
@@ -52,7 +59,7 @@ func (suite *BucketFsClientITestSuite) TestListSingleFile() {
 	result, err := suite.listFiles()
 	suite.Require().NoError(err)
 	suite.Len(result, 1)
-	suite.Equal([]bfs.BfsFile{{Name: fileName, Path: DEFAULT_BUCKET_PATH + fileName, Size: 5}}, result)
+	suite.Equal([]bfs.BfsFile{{Name: fileName, Path: DEFAULT_BUCKET_PATH + fileName, Size: 5, BasePath: DEFAULT_BUCKET_PATH}}, result)
 }
 
 func (suite *BucketFsClientITestSuite) TestListFilesRecursively() {
@@ -66,9 +73,9 @@ func (suite *BucketFsClientITestSuite) TestListFilesRecursively() {
 	suite.Require().NoError(err)
 	suite.Len(result, 3)
 	suite.Equal([]bfs.BfsFile{
-		{Name: "file2", Path: DEFAULT_BUCKET_PATH + file2, Size: 2},
-		{Name: "file2", Path: DEFAULT_BUCKET_PATH + file3, Size: 3},
-		{Name: "file1", Path: DEFAULT_BUCKET_PATH + file1, Size: 1}}, result)
+		{Name: "file2", Path: DEFAULT_BUCKET_PATH + file2, Size: 2, BasePath: DEFAULT_BUCKET_PATH},
+		{Name: "file2", Path: DEFAULT_BUCKET_PATH + file3, Size: 3, BasePath: DEFAULT_BUCKET_PATH},
+		{Name: "file1", Path: DEFAULT_BUCKET_PATH + file1, Size: 1, BasePath: DEFAULT_BUCKET_PATH}}, result)
 }
 
 func (suite *BucketFsClientITestSuite) TestFindAbsolutePathNoFileFound() {
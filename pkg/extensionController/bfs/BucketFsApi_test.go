@@ -5,8 +5,10 @@ import (
 	"database/sql"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/exasol/extension-manager/pkg/apiErrors"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -17,8 +19,9 @@ var errMock = errors.New("mock error")
 
 type BucketFsClientUTestSuite struct {
 	suite.Suite
-	db     *sql.DB
-	dbMock sqlmock.Sqlmock
+	db         *sql.DB
+	dbMock     sqlmock.Sqlmock
+	udfCreated bool // true if the test expects that the current client already created the UDF
 }
 
 func TestBucketFsApiUTestSuite(t *testing.T) {
@@ -39,65 +42,116 @@ func (suite *BucketFsClientUTestSuite) AfterTest(_suiteName, _testName string) {
 
 // CreateBucketFsAPI
 
-func (suite *BucketFsClientUTestSuite) TestCreateBucketFsAPI() {
-	suite.dbMock.ExpectBegin()
-	suite.dbMock.ExpectExec("CREATE SCHEMA INTERNAL_\\d+").WillReturnResult(sqlmock.NewResult(0, 1))
-	suite.dbMock.ExpectExec("(?m)CREATE OR REPLACE PYTHON3 SCALAR SCRIPT.*").WillReturnResult(sqlmock.NewResult(0, 1))
+func (suite *BucketFsClientUTestSuite) TestCreateBucketFsAPIDoesNotAccessDatabase() {
 	client, err := CreateBucketFsAPI(BUCKETFS_BASE_PATH, context.Background(), suite.db)
 	suite.Require().NoError(err)
 	suite.NotNil(client)
 }
 
-func (suite *BucketFsClientUTestSuite) TestCreateBucketFsAPIFailsCreatingTransaction() {
+func (suite *BucketFsClientUTestSuite) TestCreateBucketFsAPIFailsInvalidBasePath() {
+	client, err := CreateBucketFsAPI("", context.Background(), suite.db)
+	suite.Require().EqualError(err, "bucketFsBasePath is empty")
+	suite.Nil(client)
+}
+
+func (suite *BucketFsClientUTestSuite) TestCreateCachingBucketFsAPIFailsWithoutBasePaths() {
+	client, err := CreateCachingBucketFsAPI([]string{}, context.Background(), suite.db, nil)
+	suite.Require().EqualError(err, "bucketFsBasePath is empty")
+	suite.Nil(client)
+}
+
+func (suite *BucketFsClientUTestSuite) TestCreateCachingBucketFsAPIFailsWithDuplicateBasePaths() {
+	client, err := CreateCachingBucketFsAPI([]string{"/path1/", "/path2/", "/path1/"}, context.Background(), suite.db, nil)
+	suite.Require().EqualError(err, `bucketFsBasePath "/path1/" is configured more than once`)
+	suite.Nil(client)
+}
+
+// ListFiles
+
+/* [utest -> dsn~configure-bucketfs-path~1]. */
+func (suite *BucketFsClientUTestSuite) TestListFiles() {
+	client := suite.createBucketFsClientHandleError()
+	suite.expectCreateUdf()
+	suite.dbMock.ExpectPrepare(`SELECT "INTERNAL_.* ORDER BY FULL_PATH`).
+		WillBeClosed().
+		ExpectQuery().WithArgs(BUCKETFS_BASE_PATH).WillReturnRows(sqlmock.NewRows([]string{"FILE_NAME", "FULL_PATH", "SIZE"}).
+		AddRow("file1.txt", "/base/file1.txt", 10).
+		AddRow("file2.txt", "/base2/file2.txt", 20)).
+		RowsWillBeClosed()
+	result, err := client.ListFiles()
+	suite.Require().NoError(err)
+	suite.Equal([]BfsFile{{Name: "file1.txt", Path: "/base/file1.txt", Size: 10, BasePath: BUCKETFS_BASE_PATH}, {Name: "file2.txt", Path: "/base2/file2.txt", Size: 20, BasePath: BUCKETFS_BASE_PATH}}, result)
+}
+
+func (suite *BucketFsClientUTestSuite) TestListFilesFailsCreatingTransaction() {
+	client := suite.createBucketFsClientHandleError()
 	suite.dbMock.ExpectBegin().WillReturnError(errMock)
-	client, err := CreateBucketFsAPI(BUCKETFS_BASE_PATH, context.Background(), suite.db)
+	result, err := client.ListFiles()
 	suite.Require().EqualError(err, "failed to create a transaction. Cause: mock error")
-	suite.Nil(client)
+	suite.Empty(result)
 }
 
-func (suite *BucketFsClientUTestSuite) TestCreateBucketFsAPIFailsCreatingSchema() {
+func (suite *BucketFsClientUTestSuite) TestListFilesFailsCreatingSchema() {
+	client := suite.createBucketFsClientHandleError()
 	suite.dbMock.ExpectBegin()
 	suite.dbMock.ExpectExec("CREATE SCHEMA INTERNAL_\\d+").WillReturnError(errMock)
 	suite.dbMock.ExpectRollback()
-	client, err := CreateBucketFsAPI(BUCKETFS_BASE_PATH, context.Background(), suite.db)
+	result, err := client.ListFiles()
 	suite.Require().EqualError(err, "failed to create a schema for BucketFS list script. Cause: mock error")
-	suite.Nil(client)
+	suite.Empty(result)
+	suite.NoError(client.Close())
 }
 
-func (suite *BucketFsClientUTestSuite) TestCreateBucketFsAPIFailsCreatingUDFScript() {
+func (suite *BucketFsClientUTestSuite) TestListFilesFailsCreatingUDFScript() {
+	client := suite.createBucketFsClientHandleError()
 	suite.dbMock.ExpectBegin()
 	suite.dbMock.ExpectExec("CREATE SCHEMA INTERNAL_\\d+").WillReturnResult(sqlmock.NewResult(0, 1))
 	suite.dbMock.ExpectExec("(?m)CREATE OR REPLACE PYTHON3 SCALAR SCRIPT.*").WillReturnError(errMock)
 	suite.dbMock.ExpectRollback()
-	client, err := CreateBucketFsAPI(BUCKETFS_BASE_PATH, context.Background(), suite.db)
+	result, err := client.ListFiles()
 	suite.Require().EqualError(err, "failed to create UDF script for listing bucket. Cause: mock error")
-	suite.Nil(client)
+	suite.Empty(result)
 }
 
-func (suite *BucketFsClientUTestSuite) TestCreateBucketFsAPIFailsInvalidBasePath() {
-	client, err := CreateBucketFsAPI("", context.Background(), suite.db)
-	suite.Require().EqualError(err, "bucketFsBasePath is empty")
-	suite.Nil(client)
+func (suite *BucketFsClientUTestSuite) TestListFilesMultipleBasePaths() {
+	client := suite.createMultiPathBucketFsClient("/path1/", "/path2/")
+	suite.expectListFilesInBasePath("/path1/", sqlmock.NewRows([]string{"FILE_NAME", "FULL_PATH", "SIZE"}).AddRow("file1.txt", "/path1/file1.txt", 10))
+	suite.expectListFilesInBasePath("/path2/", sqlmock.NewRows([]string{"FILE_NAME", "FULL_PATH", "SIZE"}).AddRow("file2.txt", "/path2/file2.txt", 20))
+	result, err := client.ListFiles()
+	suite.Require().NoError(err)
+	suite.Equal([]BfsFile{
+		{Name: "file1.txt", Path: "/path1/file1.txt", Size: 10, BasePath: "/path1/"},
+		{Name: "file2.txt", Path: "/path2/file2.txt", Size: 20, BasePath: "/path2/"}}, result)
 }
 
-// ListFiles
+func (suite *BucketFsClientUTestSuite) TestListFilesNestedBasePathsSkipsDuplicates() {
+	client := suite.createMultiPathBucketFsClient("/path/nested/", "/path/")
+	suite.expectListFilesInBasePath("/path/nested/", sqlmock.NewRows([]string{"FILE_NAME", "FULL_PATH", "SIZE"}).AddRow("file1.txt", "/path/nested/file1.txt", 10))
+	suite.expectListFilesInBasePath("/path/", sqlmock.NewRows([]string{"FILE_NAME", "FULL_PATH", "SIZE"}).
+		AddRow("file1.txt", "/path/nested/file1.txt", 10).
+		AddRow("file2.txt", "/path/file2.txt", 20))
+	result, err := client.ListFiles()
+	suite.Require().NoError(err)
+	suite.Equal([]BfsFile{
+		{Name: "file1.txt", Path: "/path/nested/file1.txt", Size: 10, BasePath: "/path/nested/"},
+		{Name: "file2.txt", Path: "/path/file2.txt", Size: 20, BasePath: "/path/"}}, result)
+}
 
-/* [utest -> dsn~configure-bucketfs-path~1]. */
-func (suite *BucketFsClientUTestSuite) TestListFiles() {
-	client := suite.createBucketFsClientHandleError()
+func (suite *BucketFsClientUTestSuite) TestListFilesSecondBasePathFails() {
+	client := suite.createMultiPathBucketFsClient("/path1/", "/path2/")
+	suite.expectListFilesInBasePath("/path1/", sqlmock.NewRows([]string{"FILE_NAME", "FULL_PATH", "SIZE"}).AddRow("file1.txt", "/path1/file1.txt", 10))
+	suite.expectCreateUdf()
 	suite.dbMock.ExpectPrepare(`SELECT "INTERNAL_.* ORDER BY FULL_PATH`).
 		WillBeClosed().
-		ExpectQuery().WithArgs(BUCKETFS_BASE_PATH).WillReturnRows(sqlmock.NewRows([]string{"FILE_NAME", "FULL_PATH", "SIZE"}).
-		AddRow("file1.txt", "/base/file1.txt", 10).
-		AddRow("file2.txt", "/base2/file2.txt", 20)).
-		RowsWillBeClosed()
+		ExpectQuery().WithArgs("/path2/").WillReturnError(errMock)
 	result, err := client.ListFiles()
-	suite.Require().NoError(err)
-	suite.Equal([]BfsFile{{Name: "file1.txt", Path: "/base/file1.txt", Size: 10}, {Name: "file2.txt", Path: "/base2/file2.txt", Size: 20}}, result)
+	suite.Require().EqualError(err, "failed to list files. Cause: mock error")
+	suite.Empty(result)
 }
 
 func (suite *BucketFsClientUTestSuite) TestListFilesPrepareQueryFails() {
 	client := suite.createBucketFsClientHandleError()
+	suite.expectCreateUdf()
 	suite.dbMock.ExpectPrepare(`SELECT "INTERNAL_.* ORDER BY FULL_PATH`).WillReturnError(errMock)
 	result, err := client.ListFiles()
 	suite.Require().EqualError(err, "failed to create prepared statement for listing files. Cause: mock error")
@@ -106,6 +160,7 @@ func (suite *BucketFsClientUTestSuite) TestListFilesPrepareQueryFails() {
 
 func (suite *BucketFsClientUTestSuite) TestListFilesExecuteQueryFails() {
 	client := suite.createBucketFsClientHandleError()
+	suite.expectCreateUdf()
 	suite.dbMock.ExpectPrepare(`SELECT "INTERNAL_.* ORDER BY FULL_PATH`).
 		WillBeClosed().
 		ExpectQuery().WillReturnError(errMock)
@@ -116,6 +171,7 @@ func (suite *BucketFsClientUTestSuite) TestListFilesExecuteQueryFails() {
 
 func (suite *BucketFsClientUTestSuite) TestListFilesWrongResultColumnCount() {
 	client := suite.createBucketFsClientHandleError()
+	suite.expectCreateUdf()
 	suite.dbMock.ExpectPrepare(`SELECT "INTERNAL_.* ORDER BY FULL_PATH`).
 		WillBeClosed().
 		ExpectQuery().WithArgs(BUCKETFS_BASE_PATH).WillReturnRows(sqlmock.NewRows([]string{"FILE_NAME", "FULL_PATH"}).
@@ -127,68 +183,126 @@ func (suite *BucketFsClientUTestSuite) TestListFilesWrongResultColumnCount() {
 	suite.Empty(result)
 }
 
+func (suite *BucketFsClientUTestSuite) TestListFilesTwiceReusesListing() {
+	client := suite.createBucketFsClientHandleError()
+	suite.expectListFiles(sqlmock.NewRows([]string{"FILE_NAME", "FULL_PATH", "SIZE"}).AddRow("file1.txt", "/base/file1.txt", 10))
+	result1, err := client.ListFiles()
+	suite.Require().NoError(err)
+	result2, err := client.ListFiles()
+	suite.Require().NoError(err)
+	suite.Equal(result1, result2)
+}
+
+func (suite *BucketFsClientUTestSuite) TestListFilesUsesListingCache() {
+	cache := NewListingCache(time.Minute)
+	ctx := WithDatabaseHost(context.Background(), "host:8563")
+	client1 := suite.createCachingBucketFsClient(ctx, cache)
+	suite.expectListFiles(sqlmock.NewRows([]string{"FILE_NAME", "FULL_PATH", "SIZE"}).AddRow("file1.txt", "/base/file1.txt", 10))
+	result1, err := client1.ListFiles()
+	suite.Require().NoError(err)
+
+	client2 := suite.createCachingBucketFsClient(ctx, cache)
+	result2, err := client2.ListFiles()
+	suite.Require().NoError(err)
+	suite.Equal(result1, result2)
+	suite.NoError(client2.Close())
+}
+
+func (suite *BucketFsClientUTestSuite) TestListFilesReturnsCopyOfListing() {
+	cache := NewListingCache(time.Minute)
+	ctx := WithDatabaseHost(context.Background(), "host:8563")
+	client1 := suite.createCachingBucketFsClient(ctx, cache)
+	suite.expectListFiles(sqlmock.NewRows([]string{"FILE_NAME", "FULL_PATH", "SIZE"}).AddRow("file1.txt", "/base/file1.txt", 10))
+	result1, err := client1.ListFiles()
+	suite.Require().NoError(err)
+	result1[0].Path = "/modified"
+
+	result2, err := client1.ListFiles()
+	suite.Require().NoError(err)
+	result3, err := suite.createCachingBucketFsClient(ctx, cache).ListFiles()
+	suite.Require().NoError(err)
+	expected := []BfsFile{{Name: "file1.txt", Path: "/base/file1.txt", Size: 10, BasePath: BUCKETFS_BASE_PATH}}
+	suite.Equal(expected, result2)
+	suite.Equal(expected, result3)
+}
+
+func (suite *BucketFsClientUTestSuite) TestListFilesIgnoresListingCacheWithoutDatabaseHost() {
+	cache := NewListingCache(time.Minute)
+	client1 := suite.createCachingBucketFsClient(context.Background(), cache)
+	suite.expectListFiles(sqlmock.NewRows([]string{"FILE_NAME", "FULL_PATH", "SIZE"}).AddRow("file1.txt", "/base/file1.txt", 10))
+	_, err := client1.ListFiles()
+	suite.Require().NoError(err)
+
+	client2 := suite.createCachingBucketFsClient(context.Background(), cache)
+	suite.expectListFiles(sqlmock.NewRows([]string{"FILE_NAME", "FULL_PATH", "SIZE"}).AddRow("file2.txt", "/base/file2.txt", 20))
+	result, err := client2.ListFiles()
+	suite.Require().NoError(err)
+	suite.Equal([]BfsFile{{Name: "file2.txt", Path: "/base/file2.txt", Size: 20, BasePath: BUCKETFS_BASE_PATH}}, result)
+}
+
 // FindAbsolutePath
 
 /* [utest -> dsn~configure-bucketfs-path~1] */
 /* [utest -> dsn~resolving-files-in-bucketfs~1]. */
 func (suite *BucketFsClientUTestSuite) TestFindAbsolutePath() {
 	client := suite.createBucketFsClientHandleError()
-	suite.dbMock.ExpectPrepare(`SELECT FULL_PATH FROM.*`).
-		WillBeClosed().
-		ExpectQuery().WithArgs(BUCKETFS_BASE_PATH, FILE_NAME).WillReturnRows(sqlmock.NewRows([]string{"FULL_PATH"}).AddRow("/abs/path/file.txt")).
-		RowsWillBeClosed()
+	suite.expectListFiles(sqlmock.NewRows([]string{"FILE_NAME", "FULL_PATH", "SIZE"}).
+		AddRow("other.txt", "/abs/other.txt", 10).
+		AddRow(FILE_NAME, "/abs/path/file.txt", 10).
+		AddRow(FILE_NAME, "/abs/path2/file.txt", 10))
 	result, err := client.FindAbsolutePath(FILE_NAME)
 	suite.Require().NoError(err)
 	suite.Equal("/abs/path/file.txt", result)
 }
 
-func (suite *BucketFsClientUTestSuite) TestFindAbsolutePathPrepareQueryFails() {
-	client := suite.createBucketFsClientHandleError()
-	suite.dbMock.ExpectPrepare(`SELECT FULL_PATH FROM.*`).WillReturnError(errMock)
+func (suite *BucketFsClientUTestSuite) TestFindAbsolutePathPrefersFirstBasePath() {
+	client := suite.createMultiPathBucketFsClient("/path2/", "/path1/")
+	suite.expectListFilesInBasePath("/path2/", sqlmock.NewRows([]string{"FILE_NAME", "FULL_PATH", "SIZE"}).AddRow(FILE_NAME, "/path2/file.txt", 10))
+	suite.expectListFilesInBasePath("/path1/", sqlmock.NewRows([]string{"FILE_NAME", "FULL_PATH", "SIZE"}).AddRow(FILE_NAME, "/path1/file.txt", 10))
 	result, err := client.FindAbsolutePath(FILE_NAME)
-	suite.Require().EqualError(err, "failed to create prepared statement for running list files UDF. Cause: mock error")
-	suite.Empty(result)
+	suite.Require().NoError(err)
+	suite.Equal("/path2/file.txt", result)
 }
 
-func (suite *BucketFsClientUTestSuite) TestFindAbsolutePathExecuteQueryFails() {
+func (suite *BucketFsClientUTestSuite) TestFindAbsolutePathReusesListing() {
 	client := suite.createBucketFsClientHandleError()
-	suite.dbMock.ExpectPrepare(`SELECT FULL_PATH FROM.*`).
-		WillBeClosed().
-		ExpectQuery().WithArgs(BUCKETFS_BASE_PATH, FILE_NAME).WillReturnError(errMock)
+	suite.expectListFiles(sqlmock.NewRows([]string{"FILE_NAME", "FULL_PATH", "SIZE"}).
+		AddRow(FILE_NAME, "/abs/path/file.txt", 10).
+		AddRow("other.txt", "/abs/other.txt", 10))
 	result, err := client.FindAbsolutePath(FILE_NAME)
-	suite.Require().EqualError(err, "failed to find absolute path in BucketFS using UDF. Cause: mock error")
-	suite.Empty(result)
+	suite.Require().NoError(err)
+	suite.Equal("/abs/path/file.txt", result)
+	result, err = client.FindAbsolutePath("other.txt")
+	suite.Require().NoError(err)
+	suite.Equal("/abs/other.txt", result)
 }
 
-func (suite *BucketFsClientUTestSuite) TestFindAbsolutePathNoResult() {
+func (suite *BucketFsClientUTestSuite) TestFindAbsolutePathPrepareQueryFails() {
 	client := suite.createBucketFsClientHandleError()
-	suite.dbMock.ExpectPrepare(`SELECT FULL_PATH FROM.*`).
-		WillBeClosed().
-		ExpectQuery().WithArgs(BUCKETFS_BASE_PATH, FILE_NAME).WillReturnRows(sqlmock.NewRows([]string{"FULL_PATH"})).RowsWillBeClosed()
+	suite.expectCreateUdf()
+	suite.dbMock.ExpectPrepare(`SELECT "INTERNAL_.* ORDER BY FULL_PATH`).WillReturnError(errMock)
 	result, err := client.FindAbsolutePath(FILE_NAME)
-	suite.Require().EqualError(err, `file "file.txt" not found in BucketFS`)
+	suite.Require().EqualError(err, "failed to find absolute path in BucketFS. Cause: failed to create prepared statement for listing files. Cause: mock error")
 	suite.Empty(result)
 }
 
-func (suite *BucketFsClientUTestSuite) TestFindAbsolutePathRowError() {
+func (suite *BucketFsClientUTestSuite) TestFindAbsolutePathExecuteQueryFails() {
 	client := suite.createBucketFsClientHandleError()
-	suite.dbMock.ExpectPrepare(`SELECT FULL_PATH FROM.*`).
+	suite.expectCreateUdf()
+	suite.dbMock.ExpectPrepare(`SELECT "INTERNAL_.* ORDER BY FULL_PATH`).
 		WillBeClosed().
-		ExpectQuery().WithArgs(BUCKETFS_BASE_PATH, FILE_NAME).WillReturnRows(sqlmock.NewRows([]string{"FULL_PATH"}).AddRow("/abs/path/file.txt").RowError(0, errMock)).
-		RowsWillBeClosed()
+		ExpectQuery().WithArgs(BUCKETFS_BASE_PATH).WillReturnError(errMock)
 	result, err := client.FindAbsolutePath(FILE_NAME)
-	suite.Require().EqualError(err, "failed iterating absolute path results. Cause: mock error")
+	suite.Require().EqualError(err, "failed to find absolute path in BucketFS. Cause: failed to list files. Cause: mock error")
 	suite.Empty(result)
 }
 
-func (suite *BucketFsClientUTestSuite) TestFindAbsolutePathWrongResultColumnCount() {
+func (suite *BucketFsClientUTestSuite) TestFindAbsolutePathNoResult() {
 	client := suite.createBucketFsClientHandleError()
-	suite.dbMock.ExpectPrepare(`SELECT FULL_PATH FROM.*`).
-		WillBeClosed().
-		ExpectQuery().WithArgs(BUCKETFS_BASE_PATH, FILE_NAME).WillReturnRows(sqlmock.NewRows([]string{"FULL_PATH", "Additional Column"}).AddRow("/abs/path/file.txt", "a")).
-		RowsWillBeClosed()
+	suite.expectListFiles(sqlmock.NewRows([]string{"FILE_NAME", "FULL_PATH", "SIZE"}).AddRow("other.txt", "/abs/other.txt", 10))
 	result, err := client.FindAbsolutePath(FILE_NAME)
-	suite.Require().EqualError(err, `failed reading absolute path. Cause: sql: expected 2 destination arguments in Scan, not 1`)
+	suite.Require().EqualError(err, `file "file.txt" not found in BucketFS`)
+	suite.Equal("E-EM-BFS-4", apiErrors.UnwrapAPIError(err).ErrorCode)
 	suite.Empty(result)
 }
 
@@ -196,12 +310,23 @@ func (suite *BucketFsClientUTestSuite) TestFindAbsolutePathWrongResultColumnCoun
 
 func (suite *BucketFsClientUTestSuite) TestClose() {
 	bfsClient := suite.createBucketFsClientHandleError()
+	suite.expectListFiles(sqlmock.NewRows([]string{"FILE_NAME", "FULL_PATH", "SIZE"}))
+	_, err := bfsClient.ListFiles()
+	suite.Require().NoError(err)
 	suite.dbMock.ExpectRollback()
 	suite.NoError(bfsClient.Close())
 }
 
+func (suite *BucketFsClientUTestSuite) TestCloseWithoutListingDoesNotAccessDatabase() {
+	bfsClient := suite.createBucketFsClientHandleError()
+	suite.NoError(bfsClient.Close())
+}
+
 func (suite *BucketFsClientUTestSuite) TestCloseFails() {
 	bfsClient := suite.createBucketFsClientHandleError()
+	suite.expectListFiles(sqlmock.NewRows([]string{"FILE_NAME", "FULL_PATH", "SIZE"}))
+	_, err := bfsClient.ListFiles()
+	suite.Require().NoError(err)
 	suite.dbMock.ExpectRollback().WillReturnError(errMock)
 	suite.Require().EqualError(bfsClient.Close(), "failed to rollback transaction to cleanup resources. Cause: mock error")
 }
@@ -214,9 +339,44 @@ func (suite *BucketFsClientUTestSuite) createBucketFsClientHandleError() BucketF
 	return bfsClient
 }
 
-func (suite *BucketFsClientUTestSuite) createBucketFsClient() (BucketFsAPI, error) {
+func (suite *BucketFsClientUTestSuite) createCachingBucketFsClient(ctx context.Context, cache *ListingCache) BucketFsAPI {
+	suite.udfCreated = false
+	bfsClient, err := CreateCachingBucketFsAPI([]string{BUCKETFS_BASE_PATH}, ctx, suite.db, cache)
+	suite.Require().NoError(err)
+	return bfsClient
+}
+
+func (suite *BucketFsClientUTestSuite) createMultiPathBucketFsClient(basePaths ...string) BucketFsAPI {
+	suite.udfCreated = false
+	bfsClient, err := CreateCachingBucketFsAPI(basePaths, context.Background(), suite.db, nil)
+	suite.Require().NoError(err)
+	return bfsClient
+}
+
+func (suite *BucketFsClientUTestSuite) expectListFiles(rows *sqlmock.Rows) {
+	suite.expectListFilesInBasePath(BUCKETFS_BASE_PATH, rows)
+}
+
+func (suite *BucketFsClientUTestSuite) expectListFilesInBasePath(basePath string, rows *sqlmock.Rows) {
+	suite.expectCreateUdf()
+	suite.dbMock.ExpectPrepare(`SELECT "INTERNAL_.* ORDER BY FULL_PATH`).
+		WillBeClosed().
+		ExpectQuery().WithArgs(basePath).WillReturnRows(rows).
+		RowsWillBeClosed()
+}
+
+// expectCreateUdf expects that the client creates the UDF for listing files unless it already did so.
+func (suite *BucketFsClientUTestSuite) expectCreateUdf() {
+	if suite.udfCreated {
+		return
+	}
+	suite.udfCreated = true
 	suite.dbMock.ExpectBegin()
 	suite.dbMock.ExpectExec("CREATE SCHEMA INTERNAL_\\d+").WillReturnResult(sqlmock.NewResult(0, 1))
 	suite.dbMock.ExpectExec("(?m)CREATE OR REPLACE PYTHON3 SCALAR SCRIPT.*").WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
+func (suite *BucketFsClientUTestSuite) createBucketFsClient() (BucketFsAPI, error) {
+	suite.udfCreated = false
 	return CreateBucketFsAPI(BUCKETFS_BASE_PATH, context.Background(), suite.db)
 }
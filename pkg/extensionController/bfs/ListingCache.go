@@ -0,0 +1,87 @@
+package bfs
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// ListingCache stores BucketFS file listings for a limited time so that they can be reused across requests.
+// A nil *ListingCache is valid and never caches anything.
+type ListingCache struct {
+	ttl     time.Duration
+	mutex   sync.Mutex
+	entries map[ListingCacheKey]listingCacheEntry
+	now     func() time.Time
+}
+
+// ListingCacheKey identifies a cached listing by database host and BucketFS base path.
+type ListingCacheKey struct {
+	DatabaseHost string
+	BasePath     string
+}
+
+type listingCacheEntry struct {
+	files   []BfsFile
+	expires time.Time
+}
+
+// NewListingCache creates a new cache that keeps listings for the given duration.
+func NewListingCache(ttl time.Duration) *ListingCache {
+	return &ListingCache{
+		ttl:     ttl,
+		mutex:   sync.Mutex{},
+		entries: make(map[ListingCacheKey]listingCacheEntry),
+		now:     time.Now,
+	}
+}
+
+func (c *ListingCache) get(key ListingCacheKey) ([]BfsFile, bool) {
+	if c == nil || key.DatabaseHost == "" {
+		return nil, false
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	entry, found := c.entries[key]
+	if !found {
+		return nil, false
+	}
+	if !c.now().Before(entry.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return slices.Clone(entry.files), true
+}
+
+func (c *ListingCache) put(key ListingCacheKey, files []BfsFile) {
+	if c == nil || key.DatabaseHost == "" {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	now := c.now()
+	c.removeExpiredEntries(now)
+	c.entries[key] = listingCacheEntry{files: slices.Clone(files), expires: now.Add(c.ttl)}
+}
+
+func (c *ListingCache) removeExpiredEntries(now time.Time) {
+	for key, entry := range c.entries {
+		if !now.Before(entry.expires) {
+			delete(c.entries, key)
+		}
+	}
+}
+
+type databaseHostKey struct{}
+
+// WithDatabaseHost returns a copy of the context containing the host of the database, e.g. "exasol.example.com:8563".
+// The [ListingCache] uses the host to distinguish listings of different databases.
+func WithDatabaseHost(ctx context.Context, databaseHost string) context.Context {
+	return context.WithValue(ctx, databaseHostKey{}, databaseHost)
+}
+
+func newListingCacheKey(ctx context.Context, basePath string) ListingCacheKey {
+	host, _ := ctx.Value(databaseHostKey{}).(string)
+	return ListingCacheKey{DatabaseHost: host, BasePath: basePath}
+}
@@ -0,0 +1,101 @@
+package bfs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ListingCacheUTestSuite struct {
+	suite.Suite
+	cache *ListingCache
+	now   time.Time
+}
+
+func TestListingCacheUTestSuite(t *testing.T) {
+	suite.Run(t, new(ListingCacheUTestSuite))
+}
+
+var testFiles = []BfsFile{{Name: "file.txt", Path: "/base/file.txt", Size: 10}}
+
+func (suite *ListingCacheUTestSuite) SetupTest() {
+	suite.now = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	suite.cache = NewListingCache(10 * time.Second)
+	suite.cache.now = func() time.Time { return suite.now }
+}
+
+func (suite *ListingCacheUTestSuite) TestGetEmptyCache() {
+	files, found := suite.cache.get(ListingCacheKey{DatabaseHost: "host", BasePath: "/base/"})
+	suite.False(found)
+	suite.Nil(files)
+}
+
+func (suite *ListingCacheUTestSuite) TestGetCachedEntry() {
+	key := ListingCacheKey{DatabaseHost: "host", BasePath: "/base/"}
+	suite.cache.put(key, testFiles)
+	files, found := suite.cache.get(key)
+	suite.True(found)
+	suite.Equal(testFiles, files)
+}
+
+func (suite *ListingCacheUTestSuite) TestGetDifferentDatabaseHost() {
+	suite.cache.put(ListingCacheKey{DatabaseHost: "host1", BasePath: "/base/"}, testFiles)
+	_, found := suite.cache.get(ListingCacheKey{DatabaseHost: "host2", BasePath: "/base/"})
+	suite.False(found)
+}
+
+func (suite *ListingCacheUTestSuite) TestGetDifferentBasePath() {
+	suite.cache.put(ListingCacheKey{DatabaseHost: "host", BasePath: "/base1/"}, testFiles)
+	_, found := suite.cache.get(ListingCacheKey{DatabaseHost: "host", BasePath: "/base2/"})
+	suite.False(found)
+}
+
+func (suite *ListingCacheUTestSuite) TestGetExpiredEntry() {
+	key := ListingCacheKey{DatabaseHost: "host", BasePath: "/base/"}
+	suite.cache.put(key, testFiles)
+	suite.now = suite.now.Add(10 * time.Second)
+	_, found := suite.cache.get(key)
+	suite.False(found)
+	suite.Empty(suite.cache.entries)
+}
+
+func (suite *ListingCacheUTestSuite) TestGetEntryBeforeExpiry() {
+	key := ListingCacheKey{DatabaseHost: "host", BasePath: "/base/"}
+	suite.cache.put(key, testFiles)
+	suite.now = suite.now.Add(9 * time.Second)
+	_, found := suite.cache.get(key)
+	suite.True(found)
+}
+
+func (suite *ListingCacheUTestSuite) TestPutRemovesExpiredEntries() {
+	suite.cache.put(ListingCacheKey{DatabaseHost: "host1", BasePath: "/base/"}, testFiles)
+	suite.now = suite.now.Add(time.Minute)
+	suite.cache.put(ListingCacheKey{DatabaseHost: "host2", BasePath: "/base/"}, testFiles)
+	suite.Len(suite.cache.entries, 1)
+}
+
+func (suite *ListingCacheUTestSuite) TestPutIgnoresEmptyDatabaseHost() {
+	key := ListingCacheKey{DatabaseHost: "", BasePath: "/base/"}
+	suite.cache.put(key, testFiles)
+	_, found := suite.cache.get(key)
+	suite.False(found)
+}
+
+func (suite *ListingCacheUTestSuite) TestNilCache() {
+	var cache *ListingCache
+	key := ListingCacheKey{DatabaseHost: "host", BasePath: "/base/"}
+	cache.put(key, testFiles)
+	_, found := cache.get(key)
+	suite.False(found)
+}
+
+func (suite *ListingCacheUTestSuite) TestCacheKeyFromContext() {
+	ctx := WithDatabaseHost(context.Background(), "host:8563")
+	suite.Equal(ListingCacheKey{DatabaseHost: "host:8563", BasePath: "/base/"}, newListingCacheKey(ctx, "/base/"))
+}
+
+func (suite *ListingCacheUTestSuite) TestCacheKeyFromContextWithoutHost() {
+	suite.Equal(ListingCacheKey{DatabaseHost: "", BasePath: "/base/"}, newListingCacheKey(context.Background(), "/base/"))
+}
@@ -1,6 +1,7 @@
 package extensionController
 
 import (
+	goContext "context"
 	"fmt"
 	"strings"
 	"time"
@@ -16,12 +17,18 @@ import (
 	"github.com/exasol/extension-manager/pkg/extensionController/transaction"
 
 	"github.com/exasol/extension-manager/pkg/parameterValidator"
+	"github.com/exasol/extension-manager/pkg/secrets"
+	"github.com/exasol/extension-manager/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // controller is the core part of the extension-manager that provides the extension handling functionality.
 type controller interface {
+	// CheckReadiness verifies that the components required for processing requests are available.
+	CheckReadiness(ctx goContext.Context) []ComponentStatus
+
 	// GetAllExtensions reports all extension definitions.
-	GetAllExtensions(bfsFiles []bfs.BfsFile) ([]*Extension, error)
+	GetAllExtensions(ctx goContext.Context, bfsFiles []bfs.BfsFile) ([]*Extension, error)
 
 	// GetAllInstallations searches for installations of any extensions.
 	GetAllInstallations(txCtx *transaction.TransactionContext) ([]*extensionAPI.JsExtInstallation, error)
@@ -34,6 +41,9 @@ type controller interface {
 	// extensionVersion is the version of the extension to install
 	InstallExtension(txCtx *transaction.TransactionContext, extensionId string, extensionVersion string) error
 
+	// CheckInstall checks if the given extension version can be installed. The caller must roll back the transaction.
+	CheckInstall(txCtx *transaction.TransactionContext, extensionId string, extensionVersion string) (*InstallCheckResult, error)
+
 	// UninstallExtension removes an extension.
 	// extensionId is the ID of the extension to uninstall
 	// extensionVersion is the version of the extension to uninstall
@@ -51,12 +61,19 @@ type controller interface {
 
 	// DeleteInstance deletes instance with the given ID.
 	DeleteInstance(txCtx *transaction.TransactionContext, extensionId, extensionVersion, instanceId string) error
+
+	// UpdateInstance changes the parameters of an existing instance and returns the updated instance.
+	UpdateInstance(txCtx *transaction.TransactionContext, extensionId, extensionVersion, instanceId string, parameterValues []ParameterValue) (*extensionAPI.JsExtInstance, error)
+
+	// GetInstance returns the instance with the given ID including its current parameter values. Values of secret parameters are masked.
+	GetInstance(txCtx *transaction.TransactionContext, extensionId, extensionVersion, instanceId string) (*extensionAPI.JsExtInstanceDetails, error)
 }
 
 type controllerImpl struct {
 	registry       registry.Registry
 	config         ExtensionManagerConfig
 	metaDataReader exaMetadata.ExaMetadataReader
+	registryCheck  *cachedCheck
 }
 
 func createImpl(config ExtensionManagerConfig) controller {
@@ -64,52 +81,60 @@ func createImpl(config ExtensionManagerConfig) controller {
 		registry:       registry.NewRegistry(config.ExtensionRegistryURL),
 		metaDataReader: exaMetadata.CreateExaMetaDataReader(),
 		config:         config,
+		registryCheck:  newCachedCheck(registryCheckInterval),
 	}
 }
 
 /* [impl -> dsn~list-extensions~1]. */
-func (c *controllerImpl) GetAllExtensions(bfsFiles []bfs.BfsFile) ([]*Extension, error) {
-	jsExtensions, err := c.getAllExtensions()
+func (c *controllerImpl) GetAllExtensions(ctx goContext.Context, bfsFiles []bfs.BfsFile) ([]*Extension, error) {
+	jsExtensions, err := c.getAllExtensions(ctx)
 	if err != nil {
 		return nil, err
 	}
 	var extensions []*Extension
 	for _, jsExtension := range jsExtensions {
-		if c.requiredFilesAvailable(jsExtension, bfsFiles) {
-			extensions = append(extensions, convertExtension(jsExtension))
+		if requiredFiles, available := c.findRequiredFiles(jsExtension, bfsFiles); available {
+			extensions = append(extensions, convertExtension(jsExtension, requiredFiles))
 		}
 	}
 	log.Infof("Found %d of %d extensions with required files (%d files available in total)", len(extensions), len(jsExtensions), len(bfsFiles))
 	return extensions, nil
 }
 
-func convertExtension(jsExtension *extensionAPI.JsExtension) *Extension {
+func convertExtension(jsExtension *extensionAPI.JsExtension, bucketFsFiles []bfs.BfsFile) *Extension {
 	return &Extension{
 		Id:                  jsExtension.Id,
 		Name:                jsExtension.Name,
 		Category:            jsExtension.Category,
 		Description:         jsExtension.Description,
-		InstallableVersions: jsExtension.InstallableVersions}
+		InstallableVersions: jsExtension.InstallableVersions,
+		BucketFsFiles:       bucketFsFiles}
 }
 
-func (c *controllerImpl) requiredFilesAvailable(extension *extensionAPI.JsExtension, bfsFiles []bfs.BfsFile) bool {
+// findRequiredFiles returns the BucketFS files matching the required files of the extension
+// and a flag indicating if all required files are available.
+func (c *controllerImpl) findRequiredFiles(extension *extensionAPI.JsExtension, bfsFiles []bfs.BfsFile) ([]bfs.BfsFile, bool) {
+	requiredFiles := make([]bfs.BfsFile, 0, len(extension.BucketFsUploads))
 	for _, requiredFile := range extension.BucketFsUploads {
-		if !existsFileInBfs(bfsFiles, requiredFile) {
+		file, found := findFileInBfs(bfsFiles, requiredFile)
+		if !found {
 			log.Debugf("Ignoring extension %q since the required file %q does not exist or has a wrong file size.\n", extension.Name, requiredFile.BucketFsFilename)
-			return false
+			return nil, false
 		}
+		requiredFiles = append(requiredFiles, file)
 	}
-	return true
+	return requiredFiles, true
 }
 
-func existsFileInBfs(bfsFiles []bfs.BfsFile, requiredFile extensionAPI.BucketFsUpload) bool {
+// findFileInBfs returns the first matching file. Files are ordered by the configured BucketFS base paths.
+func findFileInBfs(bfsFiles []bfs.BfsFile, requiredFile extensionAPI.BucketFsUpload) (bfs.BfsFile, bool) {
 	for _, existingFile := range bfsFiles {
 		if fileMatches(requiredFile, existingFile) {
-			return true
+			return existingFile, true
 		}
 	}
 	log.Tracef("Required file %q of size %db not found", requiredFile.Name, requiredFile.FileSize)
-	return false
+	return bfs.BfsFile{}, false
 }
 
 func fileMatches(requiredFile extensionAPI.BucketFsUpload, existingFile bfs.BfsFile) bool {
@@ -128,7 +153,7 @@ func fileMatches(requiredFile extensionAPI.BucketFsUpload, existingFile bfs.BfsF
 	return false
 }
 
-func (c *controllerImpl) getAllExtensions() ([]*extensionAPI.JsExtension, error) {
+func (c *controllerImpl) getAllExtensions(ctx goContext.Context) ([]*extensionAPI.JsExtension, error) {
 	t0 := time.Now()
 	extensionIds, err := c.registry.FindExtensions()
 	if err != nil {
@@ -136,7 +161,7 @@ func (c *controllerImpl) getAllExtensions() ([]*extensionAPI.JsExtension, error)
 	}
 	extensions := make([]*extensionAPI.JsExtension, 0, len(extensionIds))
 	for _, id := range extensionIds {
-		extension, err := c.loadExtensionById(id)
+		extension, err := c.loadExtensionById(ctx, id)
 		if err != nil {
 			return nil, fmt.Errorf("failed to load extension %q: %w", id, err)
 		}
@@ -146,8 +171,8 @@ func (c *controllerImpl) getAllExtensions() ([]*extensionAPI.JsExtension, error)
 	return extensions, nil
 }
 
-func (c *controllerImpl) loadExtensionById(id string) (*extensionAPI.JsExtension, error) {
-	content, err := c.registry.ReadExtension(id)
+func (c *controllerImpl) loadExtensionById(ctx goContext.Context, id string) (*extensionAPI.JsExtension, error) {
+	content, err := c.readExtension(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -158,15 +183,19 @@ func (c *controllerImpl) loadExtensionById(id string) (*extensionAPI.JsExtension
 	return extension, nil
 }
 
+func (c *controllerImpl) readExtension(ctx goContext.Context, id string) (content string, err error) {
+	_, span := tracing.Start(ctx, "registry.ReadExtension", attribute.String("em.extension.id", id))
+	defer func() { tracing.End(span, err) }()
+	return c.registry.ReadExtension(id)
+}
+
 func (c *controllerImpl) GetAllInstallations(txCtx *transaction.TransactionContext) ([]*extensionAPI.JsExtInstallation, error) {
-	metadata, err := c.metaDataReader.ReadMetadataTables(txCtx.GetTransaction(), c.config.ExtensionSchema)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read metadata tables. Cause: %w", err)
-	}
-	extensions, err := c.getAllExtensions()
+	extensions, err := c.getAllExtensions(txCtx.GetContext())
 	if err != nil {
 		return nil, err
 	}
+	// All extensions share the same metadata, so each table is read at most once.
+	metadata := exaMetadata.NewLazyExaMetadata(c.metaDataReader, txCtx.GetTransaction(), c.config.ExtensionSchema)
 	extensionContext := c.createExtensionContext(txCtx)
 	var allInstallations []*extensionAPI.JsExtInstallation
 	for _, extension := range extensions {
@@ -201,7 +230,7 @@ func (*controllerImpl) logInstallations(extension *extensionAPI.JsExtension, ins
 }
 
 func (c *controllerImpl) GetParameterDefinitions(txCtx *transaction.TransactionContext, extensionId string, extensionVersion string) ([]parameterValidator.ParameterDefinition, error) {
-	extension, err := c.loadExtensionById(extensionId)
+	extension, err := c.loadExtensionById(txCtx.GetContext(), extensionId)
 	if err != nil {
 		return nil, extensionLoadingFailed(extensionId, err)
 	}
@@ -217,7 +246,7 @@ func (c *controllerImpl) GetParameterDefinitions(txCtx *transaction.TransactionC
 }
 
 func (c *controllerImpl) InstallExtension(txCtx *transaction.TransactionContext, extensionId string, extensionVersion string) error {
-	extension, err := c.loadExtensionById(extensionId)
+	extension, err := c.loadExtensionById(txCtx.GetContext(), extensionId)
 	if err != nil {
 		return extensionLoadingFailed(extensionId, err)
 	}
@@ -229,7 +258,7 @@ func (c *controllerImpl) InstallExtension(txCtx *transaction.TransactionContext,
 }
 
 func (c *controllerImpl) UninstallExtension(txCtx *transaction.TransactionContext, extensionId string, extensionVersion string) error {
-	extension, err := c.loadExtensionById(extensionId)
+	extension, err := c.loadExtensionById(txCtx.GetContext(), extensionId)
 	if err != nil {
 		return extensionLoadingFailed(extensionId, err)
 	}
@@ -251,7 +280,7 @@ func (*controllerImpl) verifyNoInstances(extension *extensionAPI.JsExtension, ex
 	}
 	if len(instances) > 0 {
 		instanceNames := concatInstanceNames(instances)
-		return apiErrors.NewBadRequestErrorF("cannot uninstall extension because %d instance(s) still exist: %s", len(instances), instanceNames)
+		return apiErrors.CTRL_INSTANCES_EXIST.NewErrorF("cannot uninstall extension because %d instance(s) still exist: %s", len(instances), instanceNames)
 	}
 	return nil
 }
@@ -269,7 +298,7 @@ func concatInstanceNames(instances []*extensionAPI.JsExtInstance) string {
 
 /* [impl -> dsn~upgrade-extension~1]. */
 func (c *controllerImpl) UpgradeExtension(txCtx *transaction.TransactionContext, extensionId string) (*extensionAPI.JsUpgradeResult, error) {
-	extension, err := c.loadExtensionById(extensionId)
+	extension, err := c.loadExtensionById(txCtx.GetContext(), extensionId)
 	if err != nil {
 		return nil, extensionLoadingFailed(extensionId, err)
 	}
@@ -277,7 +306,7 @@ func (c *controllerImpl) UpgradeExtension(txCtx *transaction.TransactionContext,
 }
 
 func (c *controllerImpl) CreateInstance(txCtx *transaction.TransactionContext, extensionId string, extensionVersion string, parameterValues []ParameterValue) (*extensionAPI.JsExtInstance, error) {
-	extension, err := c.loadExtensionById(extensionId)
+	extension, err := c.loadExtensionById(txCtx.GetContext(), extensionId)
 	if err != nil {
 		return nil, extensionLoadingFailed(extensionId, err)
 	}
@@ -286,33 +315,50 @@ func (c *controllerImpl) CreateInstance(txCtx *transaction.TransactionContext, e
 		return nil, err
 	}
 
-	params, err := c.convertAndValidate(txCtx, extensionId, extensionVersion, parameterValues)
+	params, masker, err := c.convertAndValidate(txCtx, extensionId, extensionVersion, parameterValues)
 	if err != nil {
 		return nil, err
 	}
 
+	txCtx.MaskSecrets(masker)
 	extensionContext := c.createExtensionContext(txCtx)
 	instance, err := extension.AddInstance(extensionContext, extensionVersion, &params)
 	if err != nil {
-		return nil, err
+		return nil, masker.MaskError(err)
 	}
 	if instance == nil {
-		return nil, fmt.Errorf("extension %q did not return an instance", extensionId)
+		return nil, apiErrors.CTRL_NO_INSTANCE_RETURNED.NewInternalErrorF("extension %q did not return an instance", extensionId)
 	}
 	return instance, nil
 }
 
-func (c *controllerImpl) convertAndValidate(txCtx *transaction.TransactionContext, extensionId string, extensionVersion string, parameterValues []ParameterValue) (extensionAPI.ParameterValues, error) {
+// convertAndValidate converts and validates the parameter values.
+// The returned [secrets.Masker] masks the values of all parameters with a secret definition.
+func (c *controllerImpl) convertAndValidate(txCtx *transaction.TransactionContext, extensionId string, extensionVersion string, parameterValues []ParameterValue) (extensionAPI.ParameterValues, *secrets.Masker, error) {
+	paramDefinitions, err := c.getParameterDefinitions(txCtx, extensionId, extensionVersion)
+	if err != nil {
+		return extensionAPI.ParameterValues{}, nil, err
+	}
+	return validateWithMasker(paramDefinitions, convertParameters(parameterValues))
+}
+
+func (c *controllerImpl) getParameterDefinitions(txCtx *transaction.TransactionContext, extensionId string, extensionVersion string) ([]parameterValidator.ParameterDefinition, error) {
 	paramDefinitions, err := c.GetParameterDefinitions(txCtx, extensionId, extensionVersion)
 	if err != nil {
-		return extensionAPI.ParameterValues{}, fmt.Errorf("failed to get parameter definitions: %w", err)
+		return nil, fmt.Errorf("failed to get parameter definitions: %w", err)
 	}
-	params := convertParameters(parameterValues)
-	err = validateParameters(paramDefinitions, params)
+	return paramDefinitions, nil
+}
+
+// validateWithMasker validates the parameter values and converts them to the native type of their definitions.
+// The returned [secrets.Masker] masks the values of all parameters with a secret definition.
+func validateWithMasker(paramDefinitions []parameterValidator.ParameterDefinition, params extensionAPI.ParameterValues) (extensionAPI.ParameterValues, *secrets.Masker, error) {
+	masker := secrets.NewMasker(parameterValidator.SecretValues(paramDefinitions, params)...)
+	err := validateParameters(paramDefinitions, params)
 	if err != nil {
-		return extensionAPI.ParameterValues{}, err
+		return extensionAPI.ParameterValues{}, nil, masker.MaskError(err)
 	}
-	return params, nil
+	return parameterValidator.CoerceValues(paramDefinitions, params), masker, nil
 }
 
 func convertParameters(parameterValues []ParameterValue) extensionAPI.ParameterValues {
@@ -324,15 +370,136 @@ func convertParameters(parameterValues []ParameterValue) extensionAPI.ParameterV
 }
 
 func (c *controllerImpl) DeleteInstance(txCtx *transaction.TransactionContext, extensionId, extensionVersion, instanceId string) error {
-	extension, err := c.loadExtensionById(extensionId)
+	extension, err := c.loadExtensionById(txCtx.GetContext(), extensionId)
 	if err != nil {
 		return extensionLoadingFailed(extensionId, err)
 	}
 	return extension.DeleteInstance(c.createExtensionContext(txCtx), extensionVersion, instanceId)
 }
 
+func (c *controllerImpl) UpdateInstance(txCtx *transaction.TransactionContext, extensionId, extensionVersion, instanceId string, parameterValues []ParameterValue) (*extensionAPI.JsExtInstance, error) {
+	extension, err := c.loadExtensionById(txCtx.GetContext(), extensionId)
+	if err != nil {
+		return nil, extensionLoadingFailed(extensionId, err)
+	}
+	paramDefinitions, err := c.getParameterDefinitions(txCtx, extensionId, extensionVersion)
+	if err != nil {
+		return nil, err
+	}
+	params := convertParameters(parameterValues)
+	if containsMaskedSecretValue(paramDefinitions, params) {
+		currentInstance, err := c.getInstance(txCtx, extension, extensionVersion, instanceId)
+		if err != nil {
+			return nil, err
+		}
+		params, err = restoreSecretValues(paramDefinitions, params, currentInstance.Parameters)
+		if err != nil {
+			return nil, err
+		}
+	}
+	params, masker, err := validateWithMasker(paramDefinitions, params)
+	if err != nil {
+		return nil, err
+	}
+	txCtx.MaskSecrets(masker)
+	instance, err := extension.UpdateInstance(c.createExtensionContext(txCtx), extensionVersion, instanceId, &params)
+	if err != nil {
+		return nil, masker.MaskError(err)
+	}
+	if instance == nil {
+		return nil, apiErrors.CTRL_NO_INSTANCE_RETURNED.NewInternalErrorF("extension %q did not return an instance", extensionId)
+	}
+	return instance, nil
+}
+
+func (c *controllerImpl) GetInstance(txCtx *transaction.TransactionContext, extensionId, extensionVersion, instanceId string) (*extensionAPI.JsExtInstanceDetails, error) {
+	extension, err := c.loadExtensionById(txCtx.GetContext(), extensionId)
+	if err != nil {
+		return nil, extensionLoadingFailed(extensionId, err)
+	}
+	instance, err := c.getInstance(txCtx, extension, extensionVersion, instanceId)
+	if err != nil {
+		return nil, err
+	}
+	paramDefinitions, err := c.getParameterDefinitions(txCtx, extensionId, extensionVersion)
+	if err != nil {
+		return nil, err
+	}
+	instance.Parameters = maskSecretValues(paramDefinitions, instance.Parameters)
+	return instance, nil
+}
+
+func (c *controllerImpl) getInstance(txCtx *transaction.TransactionContext, extension *extensionAPI.JsExtension, extensionVersion, instanceId string) (*extensionAPI.JsExtInstanceDetails, error) {
+	instance, err := extension.GetInstance(c.createExtensionContext(txCtx), extensionVersion, instanceId)
+	if err != nil {
+		return nil, err
+	}
+	if instance == nil {
+		return nil, apiErrors.CTRL_INSTANCE_NOT_FOUND.NewErrorF("instance %q not found for extension %q", instanceId, extension.Id)
+	}
+	return instance, nil
+}
+
+// containsMaskedSecretValue checks if a client sent back the masked value of a secret parameter, e.g. because it was not changed.
+func containsMaskedSecretValue(paramDefinitions []parameterValidator.ParameterDefinition, params extensionAPI.ParameterValues) bool {
+	for _, def := range paramDefinitions {
+		if param, found := params.Find(def.Id); found && def.Secret && param.Value == secrets.MASKED_VALUE {
+			return true
+		}
+	}
+	return false
+}
+
+// restoreSecretValues replaces masked values of secret parameters with the current values of the instance.
+// If the instance has no current value for a masked parameter, the client must provide the value.
+func restoreSecretValues(paramDefinitions []parameterValidator.ParameterDefinition, params extensionAPI.ParameterValues, currentValues []extensionAPI.ParameterValue) (extensionAPI.ParameterValues, error) {
+	current := extensionAPI.ParameterValues{Values: currentValues}
+	values := make([]extensionAPI.ParameterValue, 0, len(params.Values))
+	messages := make([]string, 0)
+	details := make([]apiErrors.ErrorDetail, 0)
+	for _, param := range params.Values {
+		if param.Value == secrets.MASKED_VALUE && isSecret(paramDefinitions, param.Name) {
+			currentParam, found := current.Find(param.Name)
+			if !found || currentParam.Value == nil || currentParam.Value == "" {
+				message := fmt.Sprintf("secret parameter %s must be provided", param.Name)
+				messages = append(messages, message)
+				details = append(details, apiErrors.ErrorDetail{Field: param.Name, ErrorCode: apiErrors.DETAIL_MISSING_VALUE, Message: message})
+				continue
+			}
+			param.Value = currentParam.Value
+		}
+		values = append(values, param)
+	}
+	if len(details) > 0 {
+		return extensionAPI.ParameterValues{}, apiErrors.CTRL_INVALID_PARAMETERS.NewErrorWithDetails("invalid parameters: "+strings.Join(messages, ", "), details)
+	}
+	return extensionAPI.ParameterValues{Values: values}, nil
+}
+
+// maskSecretValues replaces the values of secret parameters with [secrets.MASKED_VALUE].
+// Absent values (nil or empty) are kept, so clients can distinguish them from values that are set.
+func maskSecretValues(paramDefinitions []parameterValidator.ParameterDefinition, params []extensionAPI.ParameterValue) []extensionAPI.ParameterValue {
+	result := make([]extensionAPI.ParameterValue, 0, len(params))
+	for _, param := range params {
+		if param.Value != nil && param.Value != "" && isSecret(paramDefinitions, param.Name) {
+			param.Value = secrets.MASKED_VALUE
+		}
+		result = append(result, param)
+	}
+	return result
+}
+
+func isSecret(paramDefinitions []parameterValidator.ParameterDefinition, paramId string) bool {
+	for _, def := range paramDefinitions {
+		if def.Id == paramId {
+			return def.Secret
+		}
+	}
+	return false
+}
+
 func (c *controllerImpl) FindInstances(txCtx *transaction.TransactionContext, extensionId string, extensionVersion string) ([]*extensionAPI.JsExtInstance, error) {
-	extension, err := c.loadExtensionById(extensionId)
+	extension, err := c.loadExtensionById(txCtx.GetContext(), extensionId)
 	if err != nil {
 		return nil, extensionLoadingFailed(extensionId, err)
 	}
@@ -346,32 +513,44 @@ func (c *controllerImpl) createExtensionContext(txCtx *transaction.TransactionCo
 func (c *controllerImpl) ensureSchemaExists(txCtx *transaction.TransactionContext) error {
 	_, err := txCtx.GetTransaction().ExecContext(txCtx.GetContext(), fmt.Sprintf(`CREATE SCHEMA IF NOT EXISTS "%s"`, c.config.ExtensionSchema))
 	if err != nil {
-		return fmt.Errorf("failed to create schema: %w", err)
+		return apiErrors.CTRL_CREATING_SCHEMA_FAILED.NewInternalErrorF("failed to create schema: %w", err)
 	}
 	return nil
 }
 
 /* [impl -> dsn~parameter-types~1]. */
 func validateParameters(parameterDefinitions []parameterValidator.ParameterDefinition, params extensionAPI.ParameterValues) error {
-	validator, err := parameterValidator.New()
-	if err != nil {
-		return fmt.Errorf("failed to create parameter validator: %w", err)
-	}
-	result, err := validator.ValidateParameters(parameterDefinitions, params)
+	results, err := parameterValidator.New().ValidateEach(parameterDefinitions, params)
 	if err != nil {
 		return fmt.Errorf("failed to validate parameters: %w", err)
 	}
-	var messageBuilder strings.Builder
-	for _, r := range result {
-		messageBuilder.WriteString(r.Message + ", ")
+	messages := make([]string, 0)
+	details := make([]apiErrors.ErrorDetail, 0)
+	for _, r := range results {
+		if !r.Success {
+			messages = append(messages, r.FormatMessage())
+			details = append(details, convertToErrorDetail(r))
+		}
 	}
-	message := strings.TrimSuffix(messageBuilder.String(), ", ")
-	if message != "" {
-		return apiErrors.NewBadRequestErrorF("invalid parameters: %s", message)
+	if len(messages) > 0 {
+		return apiErrors.CTRL_INVALID_PARAMETERS.NewErrorWithDetails("invalid parameters: "+strings.Join(messages, ", "), details)
 	}
 	return nil
 }
 
+func convertToErrorDetail(result parameterValidator.ParameterValidationResult) apiErrors.ErrorDetail {
+	var errorCode string
+	switch result.Failure {
+	case parameterValidator.FailureUnknownParameter:
+		errorCode = apiErrors.DETAIL_UNKNOWN_FIELD
+	case parameterValidator.FailureMissingValue:
+		errorCode = apiErrors.DETAIL_MISSING_VALUE
+	default:
+		errorCode = apiErrors.DETAIL_INVALID_VALUE
+	}
+	return apiErrors.ErrorDetail{Field: result.ParameterId, ErrorCode: errorCode, Message: result.Message}
+}
+
 func extensionLoadingFailed(extensionId string, err error) error {
 	return fmt.Errorf("failed to load extension %q: %w", extensionId, err)
 }
@@ -2,6 +2,8 @@
 package extensionController
 
 import (
+	"context"
+
 	"github.com/exasol/extension-manager/pkg/extensionAPI"
 	"github.com/exasol/extension-manager/pkg/extensionController/bfs"
 	"github.com/exasol/extension-manager/pkg/extensionController/transaction"
@@ -18,7 +20,15 @@ func createMockControllerImpl() mockControllerImpl {
 	return mockControllerImpl{}
 }
 
-func (mock *mockControllerImpl) GetAllExtensions(bfsFiles []bfs.BfsFile) ([]*Extension, error) {
+func (mock *mockControllerImpl) CheckReadiness(ctx context.Context) []ComponentStatus {
+	args := mock.Called(ctx)
+	if result, ok := args.Get(0).([]ComponentStatus); ok {
+		return result
+	}
+	return nil
+}
+
+func (mock *mockControllerImpl) GetAllExtensions(ctx context.Context, bfsFiles []bfs.BfsFile) ([]*Extension, error) {
 	args := mock.Called(bfsFiles)
 	if ext, ok := args.Get(0).([]*Extension); ok {
 		return ext, args.Error(1)
@@ -47,6 +57,14 @@ func (mock *mockControllerImpl) InstallExtension(txCtx *transaction.TransactionC
 	return args.Error(0)
 }
 
+func (mock *mockControllerImpl) CheckInstall(txCtx *transaction.TransactionContext, extensionId string, extensionVersion string) (*InstallCheckResult, error) {
+	args := mock.Called(txCtx, extensionId, extensionVersion)
+	if result, ok := args.Get(0).(*InstallCheckResult); ok {
+		return result, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
 func (mock *mockControllerImpl) UninstallExtension(txCtx *transaction.TransactionContext, extensionId string, extensionVersion string) error {
 	args := mock.Called(txCtx, extensionId, extensionVersion)
 	return args.Error(0)
@@ -80,3 +98,19 @@ func (mock *mockControllerImpl) DeleteInstance(txCtx *transaction.TransactionCon
 	args := mock.Called(txCtx, extensionId, extensionVersion, instanceId)
 	return args.Error(0)
 }
+
+func (mock *mockControllerImpl) UpdateInstance(txCtx *transaction.TransactionContext, extensionId, extensionVersion, instanceId string, parameterValues []ParameterValue) (*extensionAPI.JsExtInstance, error) {
+	args := mock.Called(txCtx, extensionId, extensionVersion, instanceId, parameterValues)
+	if result, ok := args.Get(0).(*extensionAPI.JsExtInstance); ok {
+		return result, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (mock *mockControllerImpl) GetInstance(txCtx *transaction.TransactionContext, extensionId, extensionVersion, instanceId string) (*extensionAPI.JsExtInstanceDetails, error) {
+	args := mock.Called(txCtx, extensionId, extensionVersion, instanceId)
+	if result, ok := args.Get(0).(*extensionAPI.JsExtInstanceDetails); ok {
+		return result, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
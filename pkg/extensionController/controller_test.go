@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"path"
 	"testing"
@@ -68,6 +69,7 @@ func (suite *ControllerUTestSuite) createController() {
 		registry:       registry.NewRegistry(suite.tempExtensionRepo),
 		config:         config,
 		metaDataReader: suite.metaDataMock,
+		registryCheck:  newCachedCheck(registryCheckInterval),
 	}
 
 	suite.transactionStarterMock = transaction.CreateTransactionStarterMock(suite.db, suite.bucketFsMock)
@@ -101,7 +103,22 @@ func (suite *ControllerUTestSuite) TestGetAllExtensionsAndBucketFSContainsJar()
 	extensions, err := suite.controller.GetAllExtensions(mockContext(), suite.db)
 	suite.Require().NoError(err)
 	suite.Equal([]*Extension{{Name: "MyDemoExtension", Id: "testing-extension.js", Category: "Demo category", Description: "An extension for testing.",
-		InstallableVersions: []extensionAPI.JsExtensionVersion{{Name: "0.1.0", Latest: true, Deprecated: false}}}}, extensions)
+		InstallableVersions: []extensionAPI.JsExtensionVersion{{Name: "0.1.0", Latest: true, Deprecated: false}},
+		BucketFsFiles:       []bfs.BfsFile{{Name: "my-extension.1.2.3.jar", Size: 3, Path: "path"}}}}, extensions)
+}
+
+func (suite *ControllerUTestSuite) TestGetAllExtensionsUsesFileFromFirstBasePath() {
+	suite.registerDefaultExtensionDefinition()
+	suite.dbMock.ExpectBegin()
+	suite.bucketFsMock.SimulateFiles([]bfs.BfsFile{
+		{Name: "my-extension.1.2.3.jar", Size: 3, Path: "/path1/my-extension.1.2.3.jar", BasePath: "/path1/"},
+		{Name: "my-extension.1.2.3.jar", Size: 3, Path: "/path2/my-extension.1.2.3.jar", BasePath: "/path2/"}})
+	suite.bucketFsMock.SimulateCloseSuccess()
+	suite.dbMock.ExpectRollback()
+	extensions, err := suite.controller.GetAllExtensions(mockContext(), suite.db)
+	suite.Require().NoError(err)
+	suite.Require().Len(extensions, 1)
+	suite.Equal([]bfs.BfsFile{{Name: "my-extension.1.2.3.jar", Size: 3, Path: "/path1/my-extension.1.2.3.jar", BasePath: "/path1/"}}, extensions[0].BucketFsFiles)
 }
 
 func (suite *ControllerUTestSuite) TestGetAllExtensionsFailsStartingTransaction() {
@@ -157,7 +174,8 @@ func (suite *ControllerUTestSuite) TestGetAllExtensionsIgnoresFileSizeForNegativ
 	extensions, err := suite.controller.GetAllExtensions(mockContext(), suite.db)
 	suite.Require().NoError(err)
 	suite.Equal([]*Extension{{Name: "MyDemoExtension", Id: "testing-extension.js", Category: "Demo category", Description: "An extension for testing.",
-		InstallableVersions: []extensionAPI.JsExtensionVersion{{Name: "0.1.0", Latest: true, Deprecated: false}}}}, extensions)
+		InstallableVersions: []extensionAPI.JsExtensionVersion{{Name: "0.1.0", Latest: true, Deprecated: false}},
+		BucketFsFiles:       []bfs.BfsFile{{Name: "my-extension.1.2.3.jar", Size: 3, Path: "path"}}}}, extensions)
 }
 
 func (suite *ControllerUTestSuite) TestGetAllExtensionsFailsForInvalidExtension() {
@@ -228,6 +246,17 @@ func (suite *ControllerUTestSuite) TestGetAllInstallationsReturnsEmptyList() {
 	suite.Empty(installations)
 }
 
+func (suite *ControllerUTestSuite) TestGetAllInstallationsFailsReadingMetadata() {
+	suite.registerDefaultExtensionDefinition()
+	suite.metaDataMock.SimulateReadScriptsFails(errMock)
+	suite.dbMock.ExpectBegin()
+	suite.dbMock.ExpectRollback()
+	installations, err := suite.controller.GetInstalledExtensions(mockContext(), suite.db)
+	suite.Require().ErrorContains(err, "failed to read scripts. Cause: "+errMock.Error())
+	suite.Equal("E-EM-CTRL-6", apiErrors.UnwrapAPIError(err).ErrorCode)
+	suite.Nil(installations)
+}
+
 func (suite *ControllerUTestSuite) TestGetAllInstallationsFails() {
 	for _, t := range errorTests {
 		suite.Run(t.testName, func() {
@@ -411,6 +440,124 @@ func (suite *ControllerUTestSuite) TestInstallFails() {
 	}
 }
 
+// CheckInstall
+
+func (suite *ControllerUTestSuite) TestCheckInstallAllChecksSucceed() {
+	suite.registerDefaultExtensionDefinition()
+	suite.dbMock.ExpectBegin()
+	suite.bucketFsMock.SimulateFiles([]bfs.BfsFile{{Name: "my-extension.1.2.3.jar", Size: 3, Path: "path"}})
+	suite.bucketFsMock.SimulateCloseSuccess()
+	suite.metaDataMock.SimulateSessionPrivileges("CREATE ANY SCRIPT", "CREATE VIRTUAL SCHEMA", "CREATE CONNECTION")
+	suite.dbMock.ExpectExec(`CREATE SCHEMA IF NOT EXISTS "test"`).WillReturnResult(sqlmock.NewResult(0, 0))
+	suite.metaDataMock.SimulateExaAllScripts([]exaMetadata.ExaScriptRow{})
+	suite.dbMock.ExpectExec("install extension").WillReturnResult(sqlmock.NewResult(0, 0))
+	suite.dbMock.ExpectRollback()
+	result, err := suite.controller.CheckInstall(mockContext(), suite.db, EXTENSION_ID, "0.1.0")
+	suite.Require().NoError(err)
+	suite.Equal(&InstallCheckResult{Success: true, Checks: []InstallCheck{
+		{Type: CHECK_BUCKETFS_FILE, Name: "my-extension.1.2.3.jar", Success: true, Message: ""},
+		{Type: CHECK_PRIVILEGE, Name: "CREATE SCRIPT", Success: true, Message: ""},
+		{Type: CHECK_PRIVILEGE, Name: "CREATE VIRTUAL SCHEMA", Success: true, Message: ""},
+		{Type: CHECK_PRIVILEGE, Name: "CREATE CONNECTION", Success: true, Message: ""},
+		{Type: CHECK_SCHEMA, Name: "test", Success: true, Message: ""},
+		{Type: CHECK_INSTALLATION, Name: "MyDemoExtension", Success: true, Message: ""},
+		{Type: CHECK_SCRIPTS, Name: "test", Success: true, Message: ""},
+	}}, result)
+}
+
+func (suite *ControllerUTestSuite) TestCheckInstallReportsConflictingScripts() {
+	suite.registerDefaultExtensionDefinition()
+	suite.dbMock.ExpectBegin()
+	suite.bucketFsMock.SimulateFiles([]bfs.BfsFile{{Name: "my-extension.1.2.3.jar", Size: 3, Path: "path"}})
+	suite.bucketFsMock.SimulateCloseSuccess()
+	suite.metaDataMock.SimulateSessionPrivileges("CREATE ANY SCRIPT", "CREATE VIRTUAL SCHEMA", "CREATE CONNECTION")
+	suite.dbMock.ExpectExec(`CREATE SCHEMA IF NOT EXISTS "test"`).WillReturnResult(sqlmock.NewResult(0, 0))
+	suite.metaDataMock.SimulateReadScriptTexts(
+		map[string]string{"OTHER_ADAPTER": "other text", "UNCHANGED": "text", "IMPORT_PATH": "other import"},
+		map[string]string{"OTHER_ADAPTER": "new text", "UNCHANGED": "text", "IMPORT_PATH": "new import", "NEW_SCRIPT": "new"})
+	suite.metaDataMock.SimulateExaAllScripts([]exaMetadata.ExaScriptRow{})
+	suite.dbMock.ExpectExec("install extension").WillReturnResult(sqlmock.NewResult(0, 0))
+	suite.dbMock.ExpectRollback()
+	result, err := suite.controller.CheckInstall(mockContext(), suite.db, EXTENSION_ID, "0.1.0")
+	suite.Require().NoError(err)
+	suite.False(result.Success)
+	suite.Equal(InstallCheck{Type: CHECK_SCRIPTS, Name: "test", Success: false,
+		Message: `installing the extension would replace existing scripts "IMPORT_PATH", "OTHER_ADAPTER" in schema "test"`}, result.Checks[len(result.Checks)-1])
+}
+
+func (suite *ControllerUTestSuite) TestCheckInstallReportsFailingInstallation() {
+	suite.registerDefaultExtensionDefinition()
+	suite.dbMock.ExpectBegin()
+	suite.bucketFsMock.SimulateFiles([]bfs.BfsFile{{Name: "my-extension.1.2.3.jar", Size: 3, Path: "path"}})
+	suite.bucketFsMock.SimulateCloseSuccess()
+	suite.metaDataMock.SimulateSessionPrivileges("CREATE ANY SCRIPT", "CREATE VIRTUAL SCHEMA", "CREATE CONNECTION")
+	suite.dbMock.ExpectExec(`CREATE SCHEMA IF NOT EXISTS "test"`).WillReturnResult(sqlmock.NewResult(0, 0))
+	suite.metaDataMock.SimulateExaAllScripts([]exaMetadata.ExaScriptRow{})
+	suite.dbMock.ExpectExec("install extension").WillReturnError(errMock)
+	suite.dbMock.ExpectRollback()
+	result, err := suite.controller.CheckInstall(mockContext(), suite.db, EXTENSION_ID, "0.1.0")
+	suite.Require().NoError(err)
+	suite.False(result.Success)
+	check := result.Checks[len(result.Checks)-1]
+	suite.Equal(CHECK_SCRIPTS, check.Type)
+	suite.False(check.Success)
+	suite.Contains(check.Message, "installing the extension failed: ")
+}
+
+func (suite *ControllerUTestSuite) TestCheckInstallReportsFailedChecks() {
+	integrationTesting.CreateTestExtensionBuilder(suite.T()).
+		WithBucketFsUpload(integrationTesting.BucketFsUploadParams{Name: "extension jar", BucketFsFilename: "my-extension.1.2.3.jar", FileSize: 3, DownloadUrl: "", LicenseUrl: "", LicenseAgreementRequired: false}).
+		WithBucketFsUpload(integrationTesting.BucketFsUploadParams{Name: "missing jar", BucketFsFilename: "missing.jar", FileSize: 3, DownloadUrl: "", LicenseUrl: "", LicenseAgreementRequired: false}).
+		WithFindInstallationsFunc(`return [{name: "EXA_EXTENSIONS.SCRIPT", version: "0.0.1"}]`).
+		WithPreflightFunc(`return [{name: "custom check for " + version, success: false, message: "custom failure"}]`).
+		Build().
+		WriteToFile(path.Join(suite.tempExtensionRepo, EXTENSION_ID))
+	suite.dbMock.ExpectBegin()
+	suite.bucketFsMock.SimulateFiles([]bfs.BfsFile{{Name: "my-extension.1.2.3.jar", Size: 5, Path: "/path/my-extension.1.2.3.jar"}})
+	suite.bucketFsMock.SimulateCloseSuccess()
+	suite.metaDataMock.SimulatePrivileges(&exaMetadata.ExaPrivileges{
+		SystemPrivileges:  exaMetadata.ExaSystemPrivilegesTable{Rows: []exaMetadata.ExaSystemPrivilegeRow{}},
+		Roles:             exaMetadata.ExaRolesTable{Rows: []exaMetadata.ExaRoleRow{{Role: "PUBLIC", AdminOption: false}}},
+		ObjectPrivileges:  exaMetadata.ExaObjectPrivilegesTable{Rows: []exaMetadata.ExaObjectPrivilegeRow{}},
+		SessionPrivileges: []string{"CREATE VIRTUAL SCHEMA"}})
+	suite.dbMock.ExpectExec(`CREATE SCHEMA IF NOT EXISTS "test"`).WillReturnError(errMock)
+	suite.metaDataMock.SimulateExaAllScripts([]exaMetadata.ExaScriptRow{})
+	suite.dbMock.ExpectRollback()
+	result, err := suite.controller.CheckInstall(mockContext(), suite.db, EXTENSION_ID, "0.1.0")
+	suite.Require().NoError(err)
+	suite.Equal(&InstallCheckResult{Success: false, Checks: []InstallCheck{
+		{Type: CHECK_BUCKETFS_FILE, Name: "my-extension.1.2.3.jar", Success: false, Message: `file "/path/my-extension.1.2.3.jar" has size 5 bytes but expected 3 bytes`},
+		{Type: CHECK_BUCKETFS_FILE, Name: "missing.jar", Success: false, Message: `file "missing.jar" does not exist in BucketFS`},
+		{Type: CHECK_PRIVILEGE, Name: "CREATE SCRIPT", Success: false, Message: `the database user does not have system privilege "CREATE SCRIPT", neither directly nor via granted roles PUBLIC`},
+		{Type: CHECK_PRIVILEGE, Name: "CREATE VIRTUAL SCHEMA", Success: true, Message: ""},
+		{Type: CHECK_PRIVILEGE, Name: "CREATE CONNECTION", Success: false, Message: `the database user does not have system privilege "CREATE CONNECTION", neither directly nor via granted roles PUBLIC`},
+		{Type: CHECK_SCHEMA, Name: "test", Success: false, Message: `schema "test" does not exist and can't be created`},
+		{Type: CHECK_INSTALLATION, Name: "MyDemoExtension", Success: false, Message: `version 0.0.1 is already installed as "EXA_EXTENSIONS.SCRIPT", upgrade the extension instead`},
+		{Type: CHECK_EXTENSION, Name: "custom check for 0.1.0", Success: false, Message: "custom failure"},
+	}}, result)
+}
+
+func (suite *ControllerUTestSuite) TestCheckInstallFailsReadingPrivileges() {
+	suite.registerDefaultExtensionDefinition()
+	suite.dbMock.ExpectBegin()
+	suite.bucketFsMock.SimulateFiles([]bfs.BfsFile{})
+	suite.bucketFsMock.SimulateCloseSuccess()
+	suite.metaDataMock.SimulateReadPrivilegesFails(errMock)
+	suite.dbMock.ExpectRollback()
+	result, err := suite.controller.CheckInstall(mockContext(), suite.db, EXTENSION_ID, "0.1.0")
+	suite.Require().EqualError(err, "failed to read privileges. Cause: "+mockErrorMsg)
+	suite.Nil(result)
+}
+
+func (suite *ControllerUTestSuite) TestCheckInstallFailsForUnknownExtensionId() {
+	suite.dbMock.ExpectBegin()
+	suite.dbMock.ExpectRollback()
+	result, err := suite.controller.CheckInstall(mockContext(), suite.db, "unknown-extension-id", "0.1.0")
+	suite.Require().ErrorContains(err, `failed to load extension "unknown-extension-id"`)
+	suite.Require().ErrorContains(err, `unknown-extension-id" not found`)
+	suite.Nil(result)
+}
+
 // UninstallExtension
 
 func (suite *ControllerUTestSuite) TestUninstallFailsForUnknownExtensionId() {
@@ -587,6 +734,7 @@ func (suite *ControllerUTestSuite) TestCreateInstanceInvalidParameters() {
 	suite.dbMock.ExpectRollback()
 	instance, err := suite.controller.CreateInstance(mockContext(), suite.db, EXTENSION_ID, "0.1.0", []ParameterValue{})
 	suite.Require().EqualError(err, `invalid parameters: Failed to validate parameter 'My param' (param1): This is a required parameter.`)
+	suite.Equal([]apiErrors.ErrorDetail{{Field: "param1", ErrorCode: apiErrors.DETAIL_MISSING_VALUE, Message: "This is a required parameter."}}, apiErrors.UnwrapAPIError(err).Details)
 	suite.Nil(instance)
 }
 
@@ -615,10 +763,27 @@ func (suite *ControllerUTestSuite) TestCreateInstanceFails() {
 	}
 }
 
+func (suite *ControllerUTestSuite) TestCreateInstanceFailsMasksSecretParameterValues() {
+	integrationTesting.CreateTestExtensionBuilder(suite.T()).
+		WithFindInstallationsFunc(integrationTesting.MockFindInstallationsFunction("test", "0.1.0")).
+		WithAddInstanceFunc("throw new Error(`failed to create connection with password ${params.values[1].value} for user ${params.values[0].value}`)").
+		WithGetInstanceParameterDefinitionFunc(`return [{id: "user", name: "User", type: "string"}, {id: "password", name: "Password", type: "string", secret: true}]`).
+		Build().
+		WriteToFile(path.Join(suite.tempExtensionRepo, EXTENSION_ID))
+	suite.dbMock.ExpectBegin()
+	suite.dbMock.ExpectExec(`CREATE SCHEMA IF NOT EXISTS "test"`).WillReturnResult(sqlmock.NewResult(0, 0))
+	suite.dbMock.ExpectRollback()
+	instance, err := suite.controller.CreateInstance(mockContext(), suite.db, EXTENSION_ID, "0.1.0", []ParameterValue{{Name: "user", Value: "admin"}, {Name: "password", Value: "my-password"}})
+	suite.Require().ErrorContains(err, "failed to create connection with password ****** for user admin")
+	suite.NotContains(err.Error(), "my-password")
+	suite.Nil(instance)
+}
+
 func (suite *ControllerUTestSuite) TestCreateInstanceValidParameters() {
 	integrationTesting.CreateTestExtensionBuilder(suite.T()).
 		WithFindInstallationsFunc(integrationTesting.MockFindInstallationsFunction("test", "0.1.0")).
 		WithAddInstanceFunc("return {id: 'instId', name: `ext_${version}_${params.values[0].name}_${params.values[0].value}`};").
+		WithGetInstanceParameterDefinitionFunc(`return [{id: "p1", name: "My param", type: "string"}]`).
 		Build().
 		WriteToFile(path.Join(suite.tempExtensionRepo, EXTENSION_ID))
 	suite.dbMock.ExpectBegin()
@@ -629,6 +794,194 @@ func (suite *ControllerUTestSuite) TestCreateInstanceValidParameters() {
 	suite.Equal(&extensionAPI.JsExtInstance{Id: "instId", Name: "ext_0.1.0_p1_val"}, instance)
 }
 
+func (suite *ControllerUTestSuite) TestCreateInstanceUnknownParameter() {
+	integrationTesting.CreateTestExtensionBuilder(suite.T()).
+		WithFindInstallationsFunc(integrationTesting.MockFindInstallationsFunction("test", "0.1.0")).
+		WithAddInstanceFunc("throw new Error('This should not be called.')").
+		WithGetInstanceParameterDefinitionFunc(`return [{id: "p1", name: "My param", type: "string"}]`).
+		Build().
+		WriteToFile(path.Join(suite.tempExtensionRepo, EXTENSION_ID))
+	suite.dbMock.ExpectBegin()
+	suite.dbMock.ExpectExec(`CREATE SCHEMA IF NOT EXISTS "test"`).WillReturnResult(sqlmock.NewResult(0, 0))
+	suite.dbMock.ExpectRollback()
+	instance, err := suite.controller.CreateInstance(mockContext(), suite.db, EXTENSION_ID, "0.1.0", []ParameterValue{{Name: "p1", Value: "val"}, {Name: "p2", Value: "val"}})
+	suite.Require().EqualError(err, `invalid parameters: Unknown parameter 'p2'.`)
+	suite.Equal([]apiErrors.ErrorDetail{{Field: "p2", ErrorCode: apiErrors.DETAIL_UNKNOWN_FIELD, Message: "Unknown parameter."}}, apiErrors.UnwrapAPIError(err).Details)
+	suite.Nil(instance)
+}
+
+func (suite *ControllerUTestSuite) TestCreateInstanceInvalidParametersReturnsDetails() {
+	integrationTesting.CreateTestExtensionBuilder(suite.T()).
+		WithFindInstallationsFunc(integrationTesting.MockFindInstallationsFunction("test", "0.1.0")).
+		WithAddInstanceFunc("throw new Error('This should not be called.')").
+		WithGetInstanceParameterDefinitionFunc(`return [{id: "p1", name: "My param", type: "string", regex: "^[a-z]+$"}, {id: "p2", name: "Other param", type: "string", required: true}]`).
+		Build().
+		WriteToFile(path.Join(suite.tempExtensionRepo, EXTENSION_ID))
+	suite.dbMock.ExpectBegin()
+	suite.dbMock.ExpectExec(`CREATE SCHEMA IF NOT EXISTS "test"`).WillReturnResult(sqlmock.NewResult(0, 0))
+	suite.dbMock.ExpectRollback()
+	instance, err := suite.controller.CreateInstance(mockContext(), suite.db, EXTENSION_ID, "0.1.0", []ParameterValue{{Name: "p1", Value: "123"}, {Name: "p3", Value: "val"}})
+	suite.Require().EqualError(err, `invalid parameters: Failed to validate parameter 'My param' (p1): The value has an invalid format., `+
+		`Failed to validate parameter 'Other param' (p2): This is a required parameter., Unknown parameter 'p3'.`)
+	suite.Equal([]apiErrors.ErrorDetail{
+		{Field: "p1", ErrorCode: apiErrors.DETAIL_INVALID_VALUE, Message: "The value has an invalid format."},
+		{Field: "p2", ErrorCode: apiErrors.DETAIL_MISSING_VALUE, Message: "This is a required parameter."},
+		{Field: "p3", ErrorCode: apiErrors.DETAIL_UNKNOWN_FIELD, Message: "Unknown parameter."}}, apiErrors.UnwrapAPIError(err).Details)
+	suite.Nil(instance)
+}
+
+func (suite *ControllerUTestSuite) TestCreateInstanceSkipsInactiveParameter() {
+	integrationTesting.CreateTestExtensionBuilder(suite.T()).
+		WithFindInstallationsFunc(integrationTesting.MockFindInstallationsFunction("test", "0.1.0")).
+		WithAddInstanceFunc("return {id: 'instId', name: `ext_${params.values[0].value}`};").
+		WithGetInstanceParameterDefinitionFunc(`return [{id: "type", name: "Type", type: "string"},
+			{id: "driver", name: "Driver", type: "string", required: true, condition: {parameter: "type", operator: "==", value: "jdbc"}}]`).
+		Build().
+		WriteToFile(path.Join(suite.tempExtensionRepo, EXTENSION_ID))
+	suite.dbMock.ExpectBegin()
+	suite.dbMock.ExpectExec(`CREATE SCHEMA IF NOT EXISTS "test"`).WillReturnResult(sqlmock.NewResult(0, 0))
+	suite.dbMock.ExpectCommit()
+	instance, err := suite.controller.CreateInstance(mockContext(), suite.db, EXTENSION_ID, "0.1.0", []ParameterValue{{Name: "type", Value: "odbc"}})
+	suite.Require().NoError(err)
+	suite.Equal(&extensionAPI.JsExtInstance{Id: "instId", Name: "ext_odbc"}, instance)
+}
+
+func (suite *ControllerUTestSuite) TestCreateInstanceCoercesTypedParameters() {
+	integrationTesting.CreateTestExtensionBuilder(suite.T()).
+		WithFindInstallationsFunc(integrationTesting.MockFindInstallationsFunction("test", "0.1.0")).
+		WithAddInstanceFunc("return {id: 'instId', name: params.values.map(p => `${p.name}:${typeof p.value}:${p.value}`).join(';')};").
+		WithGetInstanceParameterDefinitionFunc(`return [{id: "port", name: "Port", type: "string"}, {id: "ssl", name: "SSL", type: "boolean"},
+			{id: "regions", name: "Regions", type: "select", multiple: true, options: [{id: "eu", name: "EU"}, {id: "us", name: "US"}]}]`).
+		Build().
+		WriteToFile(path.Join(suite.tempExtensionRepo, EXTENSION_ID))
+	suite.dbMock.ExpectBegin()
+	suite.dbMock.ExpectExec(`CREATE SCHEMA IF NOT EXISTS "test"`).WillReturnResult(sqlmock.NewResult(0, 0))
+	suite.dbMock.ExpectCommit()
+	instance, err := suite.controller.CreateInstance(mockContext(), suite.db, EXTENSION_ID, "0.1.0",
+		[]ParameterValue{{Name: "port", Value: 8563.0}, {Name: "ssl", Value: "true"}, {Name: "regions", Value: []interface{}{"eu", "us"}}})
+	suite.Require().NoError(err)
+	suite.Equal(&extensionAPI.JsExtInstance{Id: "instId", Name: "port:string:8563;ssl:boolean:true;regions:object:eu,us"}, instance)
+}
+
+// UpdateInstance
+
+func (suite *ControllerUTestSuite) TestUpdateInstanceValidParameters() {
+	integrationTesting.CreateTestExtensionBuilder(suite.T()).
+		WithUpdateInstanceFunc("return {id: instanceId, name: `ext_${extensionVersion}_${params.values[0].name}_${params.values[0].value}`};").
+		WithGetInstanceParameterDefinitionFunc(`return [{id: "p1", name: "My param", type: "string"}]`).
+		Build().
+		WriteToFile(path.Join(suite.tempExtensionRepo, EXTENSION_ID))
+	suite.dbMock.ExpectBegin()
+	suite.dbMock.ExpectCommit()
+	instance, err := suite.controller.UpdateInstance(mockContext(), suite.db, EXTENSION_ID, "0.1.0", "instId", []ParameterValue{{Name: "p1", Value: "val"}})
+	suite.Require().NoError(err)
+	suite.Equal(&extensionAPI.JsExtInstance{Id: "instId", Name: "ext_0.1.0_p1_val"}, instance)
+}
+
+func (suite *ControllerUTestSuite) TestUpdateInstanceInvalidParameters() {
+	integrationTesting.CreateTestExtensionBuilder(suite.T()).
+		WithUpdateInstanceFunc("throw new Error('This should not be called.')").
+		WithGetInstanceParameterDefinitionFunc(`return [{id: "param1", name: "My param", type: "string", required: true}]`).
+		Build().
+		WriteToFile(path.Join(suite.tempExtensionRepo, EXTENSION_ID))
+	suite.dbMock.ExpectBegin()
+	suite.dbMock.ExpectRollback()
+	instance, err := suite.controller.UpdateInstance(mockContext(), suite.db, EXTENSION_ID, "0.1.0", "instId", []ParameterValue{})
+	suite.Require().EqualError(err, `invalid parameters: Failed to validate parameter 'My param' (param1): This is a required parameter.`)
+	suite.Nil(instance)
+}
+
+func (suite *ControllerUTestSuite) TestUpdateInstanceRestoresMaskedSecretValue() {
+	integrationTesting.CreateTestExtensionBuilder(suite.T()).
+		WithUpdateInstanceFunc("return {id: instanceId, name: `${params.values[0].value}_${params.values[1].value}`};").
+		WithGetInstanceFunc(`return {id: instanceId, name: "inst", parameters: [{name: "user", value: "old-user"}, {name: "password", value: "current-password"}]}`).
+		WithGetInstanceParameterDefinitionFunc(`return [{id: "user", name: "User", type: "string"}, {id: "password", name: "Password", type: "string", secret: true}]`).
+		Build().
+		WriteToFile(path.Join(suite.tempExtensionRepo, EXTENSION_ID))
+	suite.dbMock.ExpectBegin()
+	suite.dbMock.ExpectCommit()
+	instance, err := suite.controller.UpdateInstance(mockContext(), suite.db, EXTENSION_ID, "0.1.0", "instId", []ParameterValue{{Name: "user", Value: "new-user"}, {Name: "password", Value: "******"}})
+	suite.Require().NoError(err)
+	suite.Equal(&extensionAPI.JsExtInstance{Id: "instId", Name: "new-user_current-password"}, instance)
+}
+
+func (suite *ControllerUTestSuite) TestUpdateInstanceFailsForMaskedSecretValueWithoutCurrentValue() {
+	integrationTesting.CreateTestExtensionBuilder(suite.T()).
+		WithUpdateInstanceFunc("throw new Error('This should not be called.')").
+		WithGetInstanceFunc(`return {id: instanceId, name: "inst", parameters: [{name: "user", value: "old-user"}]}`).
+		WithGetInstanceParameterDefinitionFunc(`return [{id: "user", name: "User", type: "string"}, {id: "password", name: "Password", type: "string", secret: true}]`).
+		Build().
+		WriteToFile(path.Join(suite.tempExtensionRepo, EXTENSION_ID))
+	suite.dbMock.ExpectBegin()
+	suite.dbMock.ExpectRollback()
+	instance, err := suite.controller.UpdateInstance(mockContext(), suite.db, EXTENSION_ID, "0.1.0", "instId", []ParameterValue{{Name: "user", Value: "new-user"}, {Name: "password", Value: "******"}})
+	suite.Require().EqualError(err, "invalid parameters: secret parameter password must be provided")
+	apiErr := apiErrors.UnwrapAPIError(err)
+	suite.Equal(http.StatusBadRequest, apiErr.Status)
+	suite.Equal([]apiErrors.ErrorDetail{{Field: "password", ErrorCode: apiErrors.DETAIL_MISSING_VALUE, Message: "secret parameter password must be provided"}}, apiErr.Details)
+	suite.Nil(instance)
+}
+
+func (suite *ControllerUTestSuite) TestUpdateInstanceFails() {
+	for _, t := range errorTests {
+		suite.Run(t.testName, func() {
+			integrationTesting.CreateTestExtensionBuilder(suite.T()).
+				WithUpdateInstanceFunc(t.throwCommand).
+				Build().
+				WriteToFile(path.Join(suite.tempExtensionRepo, EXTENSION_ID))
+			suite.dbMock.ExpectBegin()
+			suite.dbMock.ExpectRollback()
+			instance, err := suite.controller.UpdateInstance(mockContext(), suite.db, EXTENSION_ID, "0.1.0", "instId", []ParameterValue{})
+			suite.assertError(t, err)
+			suite.Nil(instance)
+		})
+	}
+}
+
+// GetInstance
+
+func (suite *ControllerUTestSuite) TestGetInstanceMasksSecretValues() {
+	integrationTesting.CreateTestExtensionBuilder(suite.T()).
+		WithGetInstanceFunc(`return {id: instanceId, name: "inst", parameters: [{name: "user", value: "user"}, {name: "password", value: "my-password"}]}`).
+		WithGetInstanceParameterDefinitionFunc(`return [{id: "user", name: "User", type: "string"}, {id: "password", name: "Password", type: "string", secret: true}]`).
+		Build().
+		WriteToFile(path.Join(suite.tempExtensionRepo, EXTENSION_ID))
+	suite.dbMock.ExpectBegin()
+	suite.dbMock.ExpectRollback()
+	instance, err := suite.controller.GetInstance(mockContext(), suite.db, EXTENSION_ID, "0.1.0", "instId")
+	suite.Require().NoError(err)
+	suite.Equal(&extensionAPI.JsExtInstanceDetails{Id: "instId", Name: "inst",
+		Parameters: []extensionAPI.ParameterValue{{Name: "user", Value: "user"}, {Name: "password", Value: "******"}}}, instance)
+}
+
+func (suite *ControllerUTestSuite) TestGetInstanceReturnsMetadataAndDbObjects() {
+	integrationTesting.CreateTestExtensionBuilder(suite.T()).
+		WithGetInstanceFunc(`return {id: instanceId, name: "inst", parameters: [],
+			metadata: {createdAt: "2023-01-02 03:04:05.000000", createdBy: "SYS"},
+			dbObjects: [{type: "VIRTUAL SCHEMA", name: "VS"}]}`).
+		WithGetInstanceParameterDefinitionFunc(`return []`).
+		Build().
+		WriteToFile(path.Join(suite.tempExtensionRepo, EXTENSION_ID))
+	suite.dbMock.ExpectBegin()
+	suite.dbMock.ExpectRollback()
+	instance, err := suite.controller.GetInstance(mockContext(), suite.db, EXTENSION_ID, "0.1.0", "instId")
+	suite.Require().NoError(err)
+	suite.Equal(&extensionAPI.JsExtInstanceMetadata{CreatedAt: "2023-01-02 03:04:05.000000", CreatedBy: "SYS"}, instance.Metadata)
+	suite.Equal([]extensionAPI.JsExtDbObject{{Type: "VIRTUAL SCHEMA", Schema: "", Name: "VS"}}, instance.DbObjects)
+}
+
+func (suite *ControllerUTestSuite) TestGetInstanceNotFound() {
+	integrationTesting.CreateTestExtensionBuilder(suite.T()).
+		WithGetInstanceFunc(`return undefined`).
+		Build().
+		WriteToFile(path.Join(suite.tempExtensionRepo, EXTENSION_ID))
+	suite.dbMock.ExpectBegin()
+	suite.dbMock.ExpectRollback()
+	instance, err := suite.controller.GetInstance(mockContext(), suite.db, EXTENSION_ID, "0.1.0", "instId")
+	suite.assertApiError(err, 404, `instance "instId" not found for extension "testing-extension.js"`)
+	suite.Nil(instance)
+}
+
 // DeleteInstance
 
 func (suite *ControllerUTestSuite) TestDeleteInstancesFails() {
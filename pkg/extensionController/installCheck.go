@@ -0,0 +1,236 @@
+package extensionController
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/exasol/extension-manager/pkg/apiErrors"
+	"github.com/exasol/extension-manager/pkg/extensionAPI"
+	"github.com/exasol/extension-manager/pkg/extensionAPI/exaMetadata"
+	"github.com/exasol/extension-manager/pkg/extensionController/bfs"
+	"github.com/exasol/extension-manager/pkg/extensionController/transaction"
+	log "github.com/sirupsen/logrus"
+)
+
+// Types of checks executed by [TransactionController.CheckInstall].
+const (
+	CHECK_BUCKETFS_FILE = "bucketfs-file" // A file required by the extension exists in BucketFS
+	CHECK_PRIVILEGE     = "privilege"     // The database user has a required system privilege
+	CHECK_SCHEMA        = "schema"        // The schema for extensions exists or can be created
+	CHECK_INSTALLATION  = "installation"  // No other version of the extension is installed
+	CHECK_SCRIPTS       = "scripts"       // Installing the extension does not replace existing scripts of other extensions
+	CHECK_EXTENSION     = "extension"     // Check implemented by the extension's optional "preflight" function
+)
+
+// requiredPrivileges contains the system privileges required for installing an extension and creating instances.
+// Each privilege is also granted by the privileges in the list.
+var requiredPrivileges = []struct {
+	name          string
+	grantedAlsoBy []string
+}{
+	{name: "CREATE SCRIPT", grantedAlsoBy: []string{"CREATE ANY SCRIPT"}},
+	{name: "CREATE VIRTUAL SCHEMA", grantedAlsoBy: []string{}},
+	{name: "CREATE CONNECTION", grantedAlsoBy: []string{}},
+}
+
+// InstallCheckResult is the result of [TransactionController.CheckInstall].
+type InstallCheckResult struct {
+	Success bool           // True if all checks passed
+	Checks  []InstallCheck // Results of the individual checks
+}
+
+// InstallCheck is the result of a single check.
+type InstallCheck struct {
+	Type    string // Type of the check, e.g. [CHECK_PRIVILEGE]
+	Name    string // Description of what was checked, e.g. the name of a file or privilege
+	Success bool   // True if the check passed
+	Message string // Reason why the check failed, empty if the check passed
+}
+
+func (c *controllerImpl) CheckInstall(txCtx *transaction.TransactionContext, extensionId string, extensionVersion string) (*InstallCheckResult, error) {
+	extension, err := c.loadExtensionById(txCtx.GetContext(), extensionId)
+	if err != nil {
+		return nil, extensionLoadingFailed(extensionId, err)
+	}
+	checks := make([]InstallCheck, 0)
+	bfsChecks, err := checkBucketFsFiles(txCtx, extension)
+	if err != nil {
+		return nil, err
+	}
+	checks = append(checks, bfsChecks...)
+	privilegeChecks, err := c.checkPrivileges(txCtx)
+	if err != nil {
+		return nil, err
+	}
+	checks = append(checks, privilegeChecks...)
+	schemaCheck := c.checkSchema(txCtx)
+	checks = append(checks, schemaCheck)
+	installationCheck, err := c.checkNoOtherVersionInstalled(txCtx, extension, extensionVersion)
+	if err != nil {
+		return nil, err
+	}
+	checks = append(checks, installationCheck)
+	checks = append(checks, c.runExtensionPreflight(txCtx, extension, extensionVersion)...)
+	// Installing requires the schema. If another version is installed, the client must upgrade instead.
+	if schemaCheck.Success && installationCheck.Success {
+		scriptsCheck, err := c.checkNoConflictingScripts(txCtx, extension, extensionVersion)
+		if err != nil {
+			return nil, err
+		}
+		checks = append(checks, scriptsCheck)
+	}
+	return &InstallCheckResult{Success: allChecksSucceeded(checks), Checks: checks}, nil
+}
+
+func checkBucketFsFiles(txCtx *transaction.TransactionContext, extension *extensionAPI.JsExtension) ([]InstallCheck, error) {
+	if len(extension.BucketFsUploads) == 0 {
+		return nil, nil
+	}
+	bfsClient, err := txCtx.GetBucketFsClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to search for required files in BucketFS. Cause: %w", err)
+	}
+	bfsFiles, err := bfsClient.ListFiles()
+	if err != nil {
+		return nil, fmt.Errorf("failed to search for required files in BucketFS. Cause: %w", err)
+	}
+	checks := make([]InstallCheck, 0, len(extension.BucketFsUploads))
+	for _, requiredFile := range extension.BucketFsUploads {
+		checks = append(checks, checkBucketFsFile(bfsFiles, requiredFile))
+	}
+	return checks, nil
+}
+
+func checkBucketFsFile(bfsFiles []bfs.BfsFile, requiredFile extensionAPI.BucketFsUpload) InstallCheck {
+	check := InstallCheck{Type: CHECK_BUCKETFS_FILE, Name: requiredFile.BucketFsFilename, Success: true, Message: ""}
+	if _, found := findFileInBfs(bfsFiles, requiredFile); found {
+		return check
+	}
+	check.Success = false
+	check.Message = fmt.Sprintf("file %q does not exist in BucketFS", requiredFile.BucketFsFilename)
+	for _, file := range bfsFiles {
+		if file.Name == requiredFile.BucketFsFilename {
+			check.Message = fmt.Sprintf("file %q has size %d bytes but expected %d bytes", file.Path, file.Size, requiredFile.FileSize)
+			break
+		}
+	}
+	return check
+}
+
+func (c *controllerImpl) checkPrivileges(txCtx *transaction.TransactionContext) ([]InstallCheck, error) {
+	privileges, err := c.metaDataReader.ReadPrivileges(txCtx.GetTransaction())
+	if err != nil {
+		return nil, apiErrors.CTRL_READING_METADATA_FAILED.NewInternalErrorF("failed to read privileges. Cause: %w", err)
+	}
+	checks := make([]InstallCheck, 0, len(requiredPrivileges))
+	for _, required := range requiredPrivileges {
+		check := InstallCheck{Type: CHECK_PRIVILEGE, Name: required.name, Success: true, Message: ""}
+		if !hasAnyPrivilege(privileges, append([]string{required.name}, required.grantedAlsoBy...)) {
+			check.Success = false
+			check.Message = missingPrivilegeMessage(privileges, required.name)
+		}
+		checks = append(checks, check)
+	}
+	return checks, nil
+}
+
+func hasAnyPrivilege(privileges *exaMetadata.ExaPrivileges, candidates []string) bool {
+	for _, candidate := range candidates {
+		if privileges.HasSystemPrivilege(candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+func missingPrivilegeMessage(privileges *exaMetadata.ExaPrivileges, privilege string) string {
+	message := fmt.Sprintf("the database user does not have system privilege %q", privilege)
+	if roles := privileges.RoleNames(); len(roles) > 0 {
+		message += fmt.Sprintf(", neither directly nor via granted roles %s", strings.Join(roles, ", "))
+	}
+	return message
+}
+
+func (c *controllerImpl) checkSchema(txCtx *transaction.TransactionContext) InstallCheck {
+	check := InstallCheck{Type: CHECK_SCHEMA, Name: c.config.ExtensionSchema, Success: true, Message: ""}
+	if err := c.ensureSchemaExists(txCtx); err != nil {
+		log.Debugf("Pre-flight check for schema %q failed: %v", c.config.ExtensionSchema, err)
+		check.Success = false
+		check.Message = fmt.Sprintf("schema %q does not exist and can't be created", c.config.ExtensionSchema)
+	}
+	return check
+}
+
+func (c *controllerImpl) checkNoOtherVersionInstalled(txCtx *transaction.TransactionContext, extension *extensionAPI.JsExtension, extensionVersion string) (InstallCheck, error) {
+	check := InstallCheck{Type: CHECK_INSTALLATION, Name: extension.Name, Success: true, Message: ""}
+	metadata := exaMetadata.NewLazyExaMetadata(c.metaDataReader, txCtx.GetTransaction(), c.config.ExtensionSchema)
+	installations, err := extension.FindInstallations(c.createExtensionContext(txCtx), metadata)
+	if err != nil {
+		return check, apiErrors.NewAPIErrorWithCause(fmt.Sprintf("failed to find installations for extension %q", extension.Name), err)
+	}
+	for _, installation := range installations {
+		if installation.Version != extensionVersion {
+			check.Success = false
+			check.Message = fmt.Sprintf("version %s is already installed as %q, upgrade the extension instead", installation.Version, installation.Name)
+			break
+		}
+	}
+	return check, nil
+}
+
+// checkNoConflictingScripts installs the extension in the transaction and reports existing scripts in the extension schema
+// that the installation replaces with a different script, e.g. a script with the same name belonging to another extension.
+// This relies on the caller rolling back the transaction, so it must run after all other checks.
+func (c *controllerImpl) checkNoConflictingScripts(txCtx *transaction.TransactionContext, extension *extensionAPI.JsExtension, extensionVersion string) (InstallCheck, error) {
+	check := InstallCheck{Type: CHECK_SCRIPTS, Name: c.config.ExtensionSchema, Success: true, Message: ""}
+	existingScripts, err := c.metaDataReader.ReadScriptTexts(txCtx.GetTransaction(), c.config.ExtensionSchema)
+	if err != nil {
+		return check, apiErrors.CTRL_READING_METADATA_FAILED.NewInternalErrorF("failed to read existing scripts. Cause: %w", err)
+	}
+	if err := extension.Install(c.createExtensionContext(txCtx), extensionVersion); err != nil {
+		check.Success = false
+		check.Message = fmt.Sprintf("installing the extension failed: %v", err)
+		return check, nil
+	}
+	installedScripts, err := c.metaDataReader.ReadScriptTexts(txCtx.GetTransaction(), c.config.ExtensionSchema)
+	if err != nil {
+		return check, apiErrors.CTRL_READING_METADATA_FAILED.NewInternalErrorF("failed to read installed scripts. Cause: %w", err)
+	}
+	conflicts := make([]string, 0)
+	for name, text := range existingScripts {
+		if installedText, found := installedScripts[name]; found && installedText != text {
+			conflicts = append(conflicts, fmt.Sprintf("%q", name))
+		}
+	}
+	if len(conflicts) > 0 {
+		slices.Sort(conflicts)
+		check.Success = false
+		check.Message = fmt.Sprintf("installing the extension would replace existing scripts %s in schema %q", strings.Join(conflicts, ", "), c.config.ExtensionSchema)
+	}
+	return check, nil
+}
+
+func (c *controllerImpl) runExtensionPreflight(txCtx *transaction.TransactionContext, extension *extensionAPI.JsExtension, extensionVersion string) []InstallCheck {
+	if !extension.SupportsPreflight() {
+		return nil
+	}
+	jsChecks, err := extension.Preflight(c.createExtensionContext(txCtx), extensionVersion)
+	if err != nil {
+		return []InstallCheck{{Type: CHECK_EXTENSION, Name: "preflight", Success: false, Message: err.Error()}}
+	}
+	checks := make([]InstallCheck, 0, len(jsChecks))
+	for _, jsCheck := range jsChecks {
+		checks = append(checks, InstallCheck{Type: CHECK_EXTENSION, Name: jsCheck.Name, Success: jsCheck.Success, Message: jsCheck.Message})
+	}
+	return checks
+}
+
+func allChecksSucceeded(checks []InstallCheck) bool {
+	for _, check := range checks {
+		if !check.Success {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,34 @@
+package extensionController
+
+import (
+	"testing"
+
+	"github.com/exasol/extension-manager/pkg/apiErrors"
+	"github.com/exasol/extension-manager/pkg/parameterValidator"
+	"github.com/stretchr/testify/suite"
+)
+
+type ParameterErrorsSuite struct {
+	suite.Suite
+}
+
+func TestParameterErrorsSuite(t *testing.T) {
+	suite.Run(t, new(ParameterErrorsSuite))
+}
+
+func (suite *ParameterErrorsSuite) TestConvertToErrorDetail() {
+	var tests = []struct {
+		failure           parameterValidator.ValidationFailure
+		expectedErrorCode string
+	}{
+		{parameterValidator.FailureMissingValue, apiErrors.DETAIL_MISSING_VALUE},
+		{parameterValidator.FailureInvalidValue, apiErrors.DETAIL_INVALID_VALUE},
+		{parameterValidator.FailureUnknownParameter, apiErrors.DETAIL_UNKNOWN_FIELD},
+	}
+	for _, test := range tests {
+		suite.Run(string(test.failure), func() {
+			result := parameterValidator.ParameterValidationResult{ParameterId: "p1", ParameterName: "", Success: false, Failure: test.failure, Message: "message"}
+			suite.Equal(apiErrors.ErrorDetail{Field: "p1", ErrorCode: test.expectedErrorCode, Message: "message"}, convertToErrorDetail(result))
+		})
+	}
+}
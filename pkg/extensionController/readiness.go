@@ -0,0 +1,73 @@
+package extensionController
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Names of the components checked by [TransactionController.CheckReadiness].
+const (
+	ComponentRegistry = "registry"
+)
+
+// registryCheckInterval is the duration for which the result of checking the extension registry is reused.
+// Orchestration tools call the readiness check every few seconds, this avoids fetching the registry index for each call.
+const registryCheckInterval = 30 * time.Second
+
+// ComponentStatus is the result of checking if a component is ready.
+type ComponentStatus struct {
+	Name  string // Name of the component, e.g. [ComponentRegistry]
+	Ready bool   // True if the component is ready
+	Error string // Reason why the component is not ready, empty if it is ready
+}
+
+func newComponentStatus(name string, err error) ComponentStatus {
+	if err != nil {
+		return ComponentStatus{Name: name, Ready: false, Error: err.Error()}
+	}
+	return ComponentStatus{Name: name, Ready: true, Error: ""}
+}
+
+func (c *controllerImpl) CheckReadiness(_ context.Context) []ComponentStatus {
+	return []ComponentStatus{
+		newComponentStatus(ComponentRegistry, c.registryCheck.run(c.checkRegistry)),
+	}
+}
+
+// checkRegistry verifies that the index of the extension registry can be loaded.
+func (c *controllerImpl) checkRegistry() error {
+	_, err := c.registry.FindExtensions()
+	if err != nil {
+		return fmt.Errorf("failed to load extension registry: %w", err)
+	}
+	return nil
+}
+
+// cachedCheck reuses the result of a readiness check for a limited time.
+type cachedCheck struct {
+	mutex     sync.Mutex
+	ttl       time.Duration
+	now       func() time.Time
+	checkedAt *time.Time // Time of the last check, nil if the check did not run yet
+	result    error
+}
+
+func newCachedCheck(ttl time.Duration) *cachedCheck {
+	return &cachedCheck{mutex: sync.Mutex{}, ttl: ttl, now: time.Now, checkedAt: nil, result: nil}
+}
+
+// run returns the result of the last check if it is younger than the TTL, else it runs the check again.
+// Concurrent callers wait for the running check instead of starting their own.
+func (c *cachedCheck) run(check func() error) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	now := c.now()
+	if c.checkedAt != nil && now.Sub(*c.checkedAt) < c.ttl {
+		return c.result
+	}
+	c.result = check()
+	c.checkedAt = &now
+	return c.result
+}
@@ -0,0 +1,90 @@
+package extensionController
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/exasol/extension-manager/pkg/extensionController/registry"
+	"github.com/exasol/extension-manager/pkg/integrationTesting"
+	"github.com/stretchr/testify/suite"
+)
+
+type ReadinessSuite struct {
+	suite.Suite
+	server *integrationTesting.MockRegistryServer
+}
+
+func TestReadinessSuite(t *testing.T) {
+	suite.Run(t, new(ReadinessSuite))
+}
+
+func (suite *ReadinessSuite) SetupSuite() {
+	suite.server = integrationTesting.NewMockRegistryServer(&suite.Suite)
+	suite.server.Start()
+}
+
+func (suite *ReadinessSuite) TearDownSuite() {
+	suite.server.Close()
+}
+
+func (suite *ReadinessSuite) SetupTest() {
+	suite.server.Reset()
+}
+
+func (suite *ReadinessSuite) TestReadyWithLocalRegistry() {
+	suite.Equal([]ComponentStatus{
+		{Name: ComponentRegistry, Ready: true, Error: ""},
+	}, suite.createController(suite.T().TempDir()).CheckReadiness(mockContext()))
+}
+
+func (suite *ReadinessSuite) TestReadyWithHttpRegistry() {
+	suite.server.SetRegistryContent(`{"extensions":[{"id": "ext1"}]}`)
+	statuses := suite.createController(suite.server.IndexUrl()).CheckReadiness(mockContext())
+	suite.Equal(ComponentStatus{Name: ComponentRegistry, Ready: true, Error: ""}, statuses[0])
+}
+
+func (suite *ReadinessSuite) TestNotReadyWhenRegistryIndexIsInvalid() {
+	suite.server.SetRegistryContent(`invalid content`)
+	statuses := suite.createController(suite.server.IndexUrl()).CheckReadiness(mockContext())
+	suite.Require().Len(statuses, 1)
+	suite.Equal(ComponentRegistry, statuses[0].Name)
+	suite.False(statuses[0].Ready)
+	suite.Contains(statuses[0].Error, "failed to load extension registry: ")
+}
+
+func (suite *ReadinessSuite) TestRegistryCheckResultIsReused() {
+	suite.server.SetRegistryContent(`invalid content`)
+	ctrl := suite.createController(suite.server.IndexUrl())
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	ctrl.registryCheck.now = func() time.Time { return now }
+	suite.False(ctrl.CheckReadiness(mockContext())[0].Ready)
+
+	suite.server.SetRegistryContent(`{"extensions":[{"id": "ext1"}]}`)
+	now = now.Add(registryCheckInterval - time.Second)
+	suite.False(ctrl.CheckReadiness(mockContext())[0].Ready)
+
+	now = now.Add(time.Second)
+	suite.True(ctrl.CheckReadiness(mockContext())[0].Ready)
+}
+
+func (suite *ReadinessSuite) TestCachedCheckRunsCheckOncePerInterval() {
+	check := newCachedCheck(time.Minute)
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	check.now = func() time.Time { return now }
+	calls := 0
+	checkFunc := func() error {
+		calls++
+		return fmt.Errorf("error %d", calls)
+	}
+	suite.EqualError(check.run(checkFunc), "error 1")
+	suite.EqualError(check.run(checkFunc), "error 1")
+	now = now.Add(time.Minute)
+	suite.EqualError(check.run(checkFunc), "error 2")
+	suite.Equal(2, calls)
+}
+
+func (suite *ReadinessSuite) createController(registryUrl string) *controllerImpl {
+	return &controllerImpl{registry: registry.NewRegistry(registryUrl), config: ExtensionManagerConfig{}, metaDataReader: nil,
+		registryCheck: newCachedCheck(registryCheckInterval)}
+}
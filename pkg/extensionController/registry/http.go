@@ -26,6 +26,10 @@ type httpRegistry struct {
 /* [impl -> dsn~extension-registry~1] */
 /* [impl -> dsn~extension-definitions-storage~1]. */
 func (h *httpRegistry) FindExtensions() ([]string, error) {
+	return observeFetch(registryTypeHttp, operationFindExtensions, h.findExtensions)
+}
+
+func (h *httpRegistry) findExtensions() ([]string, error) {
 	index, err := h.getIndex()
 	if err != nil {
 		return nil, err
@@ -36,7 +40,7 @@ func (h *httpRegistry) FindExtensions() ([]string, error) {
 /* [impl -> dsn~extension-registry.cache~1]. */
 func (h *httpRegistry) getIndex() (*index.RegistryIndex, error) {
 	if h.index == nil {
-		index, err := loadIndex(h.url)
+		index, err := observeFetch(registryTypeHttp, operationLoadIndex, func() (*index.RegistryIndex, error) { return loadIndex(h.url) })
 		if err != nil {
 			return nil, err
 		}
@@ -49,12 +53,12 @@ func loadIndex(url string) (*index.RegistryIndex, error) {
 	t0 := time.Now()
 	response, err := getResponse(url)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load index from %q: %w", url, err)
+		return nil, apiErrors.REG_LOADING_INDEX_FAILED.NewInternalErrorF("failed to load index from %q: %w", url, err)
 	}
 	defer response.Body.Close()
 	index, err := index.Decode(response.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to decode index from %q: %w", url, err)
+		return nil, apiErrors.REG_LOADING_INDEX_FAILED.NewInternalErrorF("failed to decode index from %q: %w", url, err)
 	}
 	log.Debugf("Loaded registry index with %d extensions from %q in %dms", len(index.Extensions), url, time.Since(t0).Milliseconds())
 	return &index, nil
@@ -77,18 +81,22 @@ func getResponse(url string) (*http.Response, error) {
 }
 
 func (h *httpRegistry) ReadExtension(id string) (string, error) {
+	return observeFetch(registryTypeHttp, operationReadExtension, func() (string, error) { return h.readExtension(id) })
+}
+
+func (h *httpRegistry) readExtension(id string) (string, error) {
 	index, err := h.getIndex()
 	if err != nil {
 		return "", err
 	}
 	ext, ok := index.GetExtension(id)
 	if !ok {
-		return "", apiErrors.NewNotFoundErrorF("extension %q not found", id)
+		return "", apiErrors.REG_EXTENSION_NOT_FOUND.NewErrorF("extension %q not found", id)
 	}
 
 	extContent, err := getUrlContent(ext.URL)
 	if err != nil {
-		return "", fmt.Errorf("failed to load extension %q: %w", id, err)
+		return "", apiErrors.REG_READING_EXTENSION_FAILED.NewInternalErrorF("failed to load extension %q: %w", id, err)
 	}
 	return extContent, nil
 }
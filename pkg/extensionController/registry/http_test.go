@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/exasol/extension-manager/pkg/apiErrors"
 	"github.com/exasol/extension-manager/pkg/integrationTesting"
 	"github.com/stretchr/testify/suite"
 )
@@ -73,6 +74,7 @@ func (suite *HttpRegistrySuite) TestReadExtensionFailsForUnknownExtension() {
 	suite.server.SetRegistryContent(`{"extensions":[{"id": "ext1", "url": "` + url + `"}]}`)
 	content, err := suite.registry.ReadExtension("unknown-ext-id")
 	suite.Require().ErrorContains(err, `extension "unknown-ext-id" not found`)
+	suite.Equal("E-EM-REG-1", apiErrors.UnwrapAPIError(err).ErrorCode)
 	suite.Empty(content)
 }
 
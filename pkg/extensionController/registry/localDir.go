@@ -2,7 +2,6 @@ package registry
 
 import (
 	"errors"
-	"fmt"
 	"os"
 	"path"
 	"path/filepath"
@@ -24,6 +23,10 @@ type localDirRegistry struct {
 // FindExtensions searches for .js files in the local registry directory.
 /* [impl -> dsn~extension-definitions-storage~1]. */
 func (l *localDirRegistry) FindExtensions() ([]string, error) {
+	return observeFetch(registryTypeLocal, operationFindExtensions, l.findExtensions)
+}
+
+func (l *localDirRegistry) findExtensions() ([]string, error) {
 	var files []string
 	err := filepath.Walk(l.dir, func(path string, info os.FileInfo, err error) error {
 		if info != nil && strings.HasSuffix(info.Name(), ".js") {
@@ -32,19 +35,23 @@ func (l *localDirRegistry) FindExtensions() ([]string, error) {
 		return nil
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to find extensions in %q: %w", l.dir, err)
+		return nil, apiErrors.REG_LISTING_EXTENSIONS_FAILED.NewInternalErrorF("failed to find extensions in %q: %w", l.dir, err)
 	}
 	return files, nil
 }
 
 func (l *localDirRegistry) ReadExtension(id string) (string, error) {
+	return observeFetch(registryTypeLocal, operationReadExtension, func() (string, error) { return l.readExtension(id) })
+}
+
+func (l *localDirRegistry) readExtension(id string) (string, error) {
 	fileName := path.Join(l.dir, id)
 	bytes, err := os.ReadFile(fileName)
 	if err != nil {
 		if errors.Is(err, os.ErrNotExist) {
-			return "", apiErrors.NewNotFoundErrorF("extension %q not found", fileName)
+			return "", apiErrors.REG_EXTENSION_NOT_FOUND.NewErrorF("extension %q not found", fileName)
 		}
-		return "", fmt.Errorf("failed to open extension file %q: %w", fileName, err)
+		return "", apiErrors.REG_READING_EXTENSION_FAILED.NewInternalErrorF("failed to open extension file %q: %w", fileName, err)
 	}
 	return string(bytes), nil
 }
@@ -2,6 +2,9 @@ package registry
 
 import (
 	"strings"
+	"time"
+
+	"github.com/exasol/extension-manager/pkg/metrics"
 )
 
 // Registry allows listing and loading extension files.
@@ -28,3 +31,20 @@ func isHttpUrl(urlOrPath string) bool {
 	lowerCaseUrlOrPath := strings.ToLower(urlOrPath)
 	return strings.HasPrefix(lowerCaseUrlOrPath, "http://") || strings.HasPrefix(lowerCaseUrlOrPath, "https://")
 }
+
+// Names of registry types and operations used as metric labels.
+const (
+	registryTypeHttp        = "http"
+	registryTypeLocal       = "local"
+	operationFindExtensions = "find_extensions"
+	operationReadExtension  = "read_extension"
+	operationLoadIndex      = "load_index"
+)
+
+// observeFetch runs the given function and records its duration and errors in the registry metrics.
+func observeFetch[T any](registryType, operation string, fetch func() (T, error)) (T, error) {
+	start := time.Now()
+	result, err := fetch()
+	metrics.ObserveRegistryFetch(registryType, operation, start, err)
+	return result, err
+}
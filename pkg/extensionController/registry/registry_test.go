@@ -3,6 +3,8 @@ package registry
 import (
 	"fmt"
 	"testing"
+
+	"github.com/exasol/extension-manager/pkg/metrics"
 )
 
 /* [utest -> dsn~extension-registry~1]. */
@@ -35,3 +37,34 @@ func TestNewRegistry(t *testing.T) {
 		})
 	}
 }
+
+func TestReadingRegistryIsObservedInMetrics(t *testing.T) {
+	registry := NewRegistry(t.TempDir())
+	errorsBefore := getFetchErrorCount(t, registryTypeLocal, operationReadExtension)
+	if _, err := registry.ReadExtension("missing.js"); err == nil {
+		t.Fatal("expected reading missing extension to fail")
+	}
+	if errorsAfter := getFetchErrorCount(t, registryTypeLocal, operationReadExtension); errorsAfter != errorsBefore+1 {
+		t.Errorf("expected %v fetch errors but got %v", errorsBefore+1, errorsAfter)
+	}
+}
+
+func getFetchErrorCount(t *testing.T, registryType, operation string) float64 {
+	t.Helper()
+	families, err := metrics.Registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, family := range families {
+		if family.GetName() != "extension_manager_registry_fetch_errors_total" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			labels := metric.GetLabel()
+			if labels[0].GetValue() == operation && labels[1].GetValue() == registryType {
+				return metric.GetCounter().GetValue()
+			}
+		}
+	}
+	return 0
+}
@@ -0,0 +1,79 @@
+package extensionController
+
+import (
+	"testing"
+
+	"github.com/exasol/extension-manager/pkg/apiErrors"
+	"github.com/exasol/extension-manager/pkg/extensionAPI"
+	"github.com/exasol/extension-manager/pkg/parameterValidator"
+	"github.com/stretchr/testify/suite"
+)
+
+type SecretValuesSuite struct {
+	suite.Suite
+}
+
+func TestSecretValuesSuite(t *testing.T) {
+	suite.Run(t, new(SecretValuesSuite))
+}
+
+var secretParamDefinitions = []parameterValidator.ParameterDefinition{
+	{Id: "user", Name: "User", Secret: false, RawDefinition: map[string]interface{}{"id": "user", "name": "User", "type": "string"}},
+	{Id: "password", Name: "Password", Secret: true, RawDefinition: map[string]interface{}{"id": "password", "name": "Password", "type": "string", "secret": true}},
+}
+
+func (suite *SecretValuesSuite) TestRestoreSecretValues() {
+	params := extensionAPI.ParameterValues{Values: []extensionAPI.ParameterValue{{Name: "user", Value: "new-user"}, {Name: "password", Value: "******"}}}
+	restored, err := restoreSecretValues(secretParamDefinitions, params, []extensionAPI.ParameterValue{{Name: "user", Value: "old-user"}, {Name: "password", Value: "current-password"}})
+	suite.Require().NoError(err)
+	suite.Equal([]extensionAPI.ParameterValue{{Name: "user", Value: "new-user"}, {Name: "password", Value: "current-password"}}, restored.Values)
+}
+
+func (suite *SecretValuesSuite) TestRestoreSecretValuesKeepsMaskedValueOfNonSecretParameter() {
+	params := extensionAPI.ParameterValues{Values: []extensionAPI.ParameterValue{{Name: "user", Value: "******"}}}
+	restored, err := restoreSecretValues(secretParamDefinitions, params, []extensionAPI.ParameterValue{{Name: "user", Value: "old-user"}})
+	suite.Require().NoError(err)
+	suite.Equal([]extensionAPI.ParameterValue{{Name: "user", Value: "******"}}, restored.Values)
+}
+
+func (suite *SecretValuesSuite) TestRestoreSecretValuesFailsWithoutCurrentValue() {
+	var tests = []struct {
+		name          string
+		currentValues []extensionAPI.ParameterValue
+	}{
+		{name: "missing", currentValues: []extensionAPI.ParameterValue{{Name: "user", Value: "old-user"}}},
+		{name: "nil", currentValues: []extensionAPI.ParameterValue{{Name: "password", Value: nil}}},
+		{name: "empty", currentValues: []extensionAPI.ParameterValue{{Name: "password", Value: ""}}},
+	}
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			params := extensionAPI.ParameterValues{Values: []extensionAPI.ParameterValue{{Name: "password", Value: "******"}}}
+			_, err := restoreSecretValues(secretParamDefinitions, params, test.currentValues)
+			suite.Require().EqualError(err, "invalid parameters: secret parameter password must be provided")
+			apiErr := apiErrors.UnwrapAPIError(err)
+			suite.Equal("E-EM-CTRL-3", apiErr.ErrorCode)
+			suite.Equal([]apiErrors.ErrorDetail{{Field: "password", ErrorCode: apiErrors.DETAIL_MISSING_VALUE, Message: "secret parameter password must be provided"}}, apiErr.Details)
+		})
+	}
+}
+
+func (suite *SecretValuesSuite) TestMaskSecretValues() {
+	masked := maskSecretValues(secretParamDefinitions, []extensionAPI.ParameterValue{{Name: "user", Value: "user"}, {Name: "password", Value: "my-password"}})
+	suite.Equal([]extensionAPI.ParameterValue{{Name: "user", Value: "user"}, {Name: "password", Value: "******"}}, masked)
+}
+
+func (suite *SecretValuesSuite) TestMaskSecretValuesKeepsAbsentValues() {
+	var tests = []struct {
+		name  string
+		value interface{}
+	}{
+		{name: "nil", value: nil},
+		{name: "empty", value: ""},
+	}
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			masked := maskSecretValues(secretParamDefinitions, []extensionAPI.ParameterValue{{Name: "password", Value: test.value}})
+			suite.Equal([]extensionAPI.ParameterValue{{Name: "password", Value: test.value}}, masked)
+		})
+	}
+}
@@ -3,18 +3,19 @@ package transaction
 import (
 	"context"
 	"database/sql"
-	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/exasol/extension-manager/pkg/apiErrors"
 	"github.com/exasol/extension-manager/pkg/extensionController/bfs"
+	"github.com/exasol/extension-manager/pkg/metrics"
+	"github.com/exasol/extension-manager/pkg/secrets"
 )
 
 // TransactionStarter starts a database transaction and returns a new [TransactionContext].
 // It allows injecting a mock transaction in unit tests.
 type (
-	TransactionStarter func(ctx context.Context, db *sql.DB, bucketFsBasePath string) (*TransactionContext, error)
+	TransactionStarter func(ctx context.Context, db *sql.DB, bucketFsBasePaths []string) (*TransactionContext, error)
 )
 
 // BucketFsClientCreator creates a new [bfs.BucketFsAPI].
@@ -24,16 +25,28 @@ type (
 )
 
 // BeginTransaction starts a new database transaction.
-func BeginTransaction(ctx context.Context, db *sql.DB, bucketFsBasePath string) (*TransactionContext, error) {
-	if bucketFsBasePath == "" {
-		return nil, errors.New("bucketFsBasePath is empty")
+// The BucketFS client of the transaction searches the given base paths in the given order.
+func BeginTransaction(ctx context.Context, db *sql.DB, bucketFsBasePaths []string) (*TransactionContext, error) {
+	return beginTransaction(ctx, db, bucketFsBasePaths, nil)
+}
+
+// NewTransactionStarter creates a [TransactionStarter] that shares BucketFS file listings between transactions using the given cache.
+func NewTransactionStarter(listingCache *bfs.ListingCache) TransactionStarter {
+	return func(ctx context.Context, db *sql.DB, bucketFsBasePaths []string) (*TransactionContext, error) {
+		return beginTransaction(ctx, db, bucketFsBasePaths, listingCache)
+	}
+}
+
+func beginTransaction(ctx context.Context, db *sql.DB, bucketFsBasePaths []string, listingCache *bfs.ListingCache) (*TransactionContext, error) {
+	if err := bfs.ValidateBasePaths(bucketFsBasePaths); err != nil {
+		return nil, err
 	}
 	tx, err := db.BeginTx(ctx, nil)
 	if err != nil {
 		if strings.Contains(err.Error(), "Connection exception - authentication failed") {
-			return nil, apiErrors.NewUnauthorizedErrorF("invalid database credentials")
+			return nil, apiErrors.CTRL_INVALID_DATABASE_CREDENTIALS.NewErrorF("invalid database credentials")
 		}
-		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+		return nil, apiErrors.CTRL_BEGIN_TRANSACTION_FAILED.NewInternalErrorF("failed to begin transaction: %w", err)
 	}
 	return &TransactionContext{
 		context:     ctx,
@@ -41,8 +54,9 @@ func BeginTransaction(ctx context.Context, db *sql.DB, bucketFsBasePath string)
 		transaction: tx,
 		bfsClient:   nil,
 		createBfsClient: func() (bfs.BucketFsAPI, error) {
-			return bfs.CreateBucketFsAPI(bucketFsBasePath, ctx, db)
+			return bfs.CreateCachingBucketFsAPI(bucketFsBasePaths, ctx, db, listingCache)
 		},
+		finished: false,
 	}, nil
 }
 
@@ -53,6 +67,7 @@ type TransactionContext struct {
 	transaction     *sql.Tx
 	createBfsClient BucketFsClientCreator
 	bfsClient       bfs.BucketFsAPI
+	finished        bool // true after the transaction was committed or rolled back
 }
 
 // GetTransaction returns the current database transaction.
@@ -70,8 +85,15 @@ func (ctx *TransactionContext) GetContext() context.Context {
 	return ctx.context
 }
 
+// MaskSecrets adds the given [secrets.Masker] to the [context.Context] of this transaction.
+// Components using the context afterwards, e.g. the SQL client of an extension context, mask secret values in their log messages.
+func (ctx *TransactionContext) MaskSecrets(masker *secrets.Masker) {
+	ctx.context = secrets.WithMasker(ctx.context, masker)
+}
+
 // GetBucketFsClient returns a [bfs.BucketFsAPI].
 // This creates a new client if none exists yet or returns the existing one.
+// The client lists the files in BucketFS only once, so all callers within this transaction share the same listing.
 func (ctx *TransactionContext) GetBucketFsClient() (bfs.BucketFsAPI, error) {
 	if ctx.bfsClient == nil {
 		client, err := ctx.createBfsClient()
@@ -84,7 +106,12 @@ func (ctx *TransactionContext) GetBucketFsClient() (bfs.BucketFsAPI, error) {
 }
 
 // Rollback rolls back the transaction and cleans up any resources like the [bfs.BucketFsAPI] if one was created.
+// Callers usually defer Rollback, so it does nothing after the transaction was committed.
 func (ctx *TransactionContext) Rollback() {
+	if !ctx.finished {
+		ctx.finished = true
+		metrics.CountTransaction(metrics.TransactionRolledBack)
+	}
 	_ = ctx.cleanup()
 	// Even if Tx.Rollback fails, the transaction will no longer be valid, nor will it have been committed to the database.
 	// See https://go.dev/doc/database/execute-transactions
@@ -97,7 +124,14 @@ func (ctx *TransactionContext) Commit() error {
 	if err != nil {
 		return err
 	}
-	return ctx.transaction.Commit()
+	ctx.finished = true
+	err = ctx.transaction.Commit()
+	if err != nil {
+		metrics.CountTransaction(metrics.TransactionCommitFailed)
+		return err
+	}
+	metrics.CountTransaction(metrics.TransactionCommitted)
+	return nil
 }
 
 func (ctx *TransactionContext) cleanup() error {
@@ -5,12 +5,17 @@ import (
 	"database/sql"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/exasol/extension-manager/pkg/apiErrors"
+	"github.com/exasol/extension-manager/pkg/extensionController/bfs"
+	"github.com/exasol/extension-manager/pkg/metrics"
+	"github.com/exasol/extension-manager/pkg/secrets"
 	"github.com/stretchr/testify/suite"
 )
 
-const BUCKETFS_BASE_PATH = "bucketfs-base-path"
+var BUCKETFS_BASE_PATHS = []string{"bucketfs-base-path"}
 
 var errMock = errors.New("mock error")
 
@@ -44,11 +49,23 @@ func (suite *TransactionContextSuite) TestBeginTransaction() {
 }
 
 func (suite *TransactionContextSuite) TestBeginTransactionFailsWithEmptyBucketFsBasePath() {
-	txCtx, err := BeginTransaction(context.Background(), suite.db, "")
+	txCtx, err := BeginTransaction(context.Background(), suite.db, []string{""})
 	suite.Require().EqualError(err, "bucketFsBasePath is empty")
 	suite.Nil(txCtx)
 }
 
+func (suite *TransactionContextSuite) TestBeginTransactionFailsWithoutBucketFsBasePaths() {
+	txCtx, err := BeginTransaction(context.Background(), suite.db, []string{})
+	suite.Require().EqualError(err, "bucketFsBasePath is empty")
+	suite.Nil(txCtx)
+}
+
+func (suite *TransactionContextSuite) TestBeginTransactionFailsWithDuplicateBucketFsBasePaths() {
+	txCtx, err := BeginTransaction(context.Background(), suite.db, []string{"path", "path"})
+	suite.Require().EqualError(err, `bucketFsBasePath "path" is configured more than once`)
+	suite.Nil(txCtx)
+}
+
 func (suite *TransactionContextSuite) TestBeginTransactionFails() {
 	suite.dbMock.ExpectBegin().WillReturnError(errMock)
 	txCtx, err := suite.beginTransaction()
@@ -60,6 +77,20 @@ func (suite *TransactionContextSuite) TestBeginTransactionAuthenticationFails()
 	suite.dbMock.ExpectBegin().WillReturnError(errors.New("mock error: 'Connection exception - authentication failed'"))
 	txCtx, err := suite.beginTransaction()
 	suite.Require().EqualError(err, "invalid database credentials")
+	suite.Equal("E-EM-CTRL-4", apiErrors.UnwrapAPIError(err).ErrorCode)
+	suite.Nil(txCtx)
+}
+
+func (suite *TransactionContextSuite) TestNewTransactionStarter() {
+	suite.dbMock.ExpectBegin()
+	txCtx, err := NewTransactionStarter(bfs.NewListingCache(time.Minute))(context.Background(), suite.db, BUCKETFS_BASE_PATHS)
+	suite.Require().NoError(err)
+	suite.NotNil(txCtx)
+}
+
+func (suite *TransactionContextSuite) TestNewTransactionStarterFailsWithEmptyBucketFsBasePath() {
+	txCtx, err := NewTransactionStarter(bfs.NewListingCache(time.Minute))(context.Background(), suite.db, []string{""})
+	suite.Require().EqualError(err, "bucketFsBasePath is empty")
 	suite.Nil(txCtx)
 }
 
@@ -69,6 +100,14 @@ func (suite *TransactionContextSuite) TestGetContext() {
 	suite.NotNil(txCtx.GetContext())
 }
 
+func (suite *TransactionContextSuite) TestMaskSecrets() {
+	suite.dbMock.ExpectBegin()
+	txCtx, _ := suite.beginTransaction()
+	masker := secrets.NewMasker("secret")
+	txCtx.MaskSecrets(masker)
+	suite.Same(masker, secrets.MaskerFromContext(txCtx.GetContext()))
+}
+
 func (suite *TransactionContextSuite) TestGetDBConnection() {
 	suite.dbMock.ExpectBegin()
 	txCtx, _ := suite.beginTransaction()
@@ -86,9 +125,6 @@ func (suite *TransactionContextSuite) TestGetTransaction() {
 func (suite *TransactionContextSuite) TestGetBucketFsClient() {
 	suite.dbMock.ExpectBegin()
 	txCtx, _ := suite.beginTransaction()
-	suite.dbMock.ExpectBegin()
-	suite.dbMock.ExpectExec("CREATE SCHEMA INTERNAL_\\d+").WillReturnResult(sqlmock.NewResult(0, 1))
-	suite.dbMock.ExpectExec("(?m)CREATE OR REPLACE PYTHON3 SCALAR SCRIPT.*").WillReturnResult(sqlmock.NewResult(0, 1))
 	bfsClient, err := txCtx.GetBucketFsClient()
 	suite.Require().NoError(err)
 	suite.NotNil(bfsClient)
@@ -97,9 +133,6 @@ func (suite *TransactionContextSuite) TestGetBucketFsClient() {
 func (suite *TransactionContextSuite) TestGetBucketFsClientTwiceReturnsSameObject() {
 	suite.dbMock.ExpectBegin()
 	txCtx, _ := suite.beginTransaction()
-	suite.dbMock.ExpectBegin()
-	suite.dbMock.ExpectExec("CREATE SCHEMA INTERNAL_\\d+").WillReturnResult(sqlmock.NewResult(0, 1))
-	suite.dbMock.ExpectExec("(?m)CREATE OR REPLACE PYTHON3 SCALAR SCRIPT.*").WillReturnResult(sqlmock.NewResult(0, 1))
 	bfsClient1, err := txCtx.GetBucketFsClient()
 	suite.Require().NoError(err)
 	suite.NotNil(bfsClient1)
@@ -111,18 +144,30 @@ func (suite *TransactionContextSuite) TestGetBucketFsClientTwiceReturnsSameObjec
 	suite.Same(bfsClient1, bfsClient2)
 }
 
-func (suite *TransactionContextSuite) TestGetBucketFsClientFailsCreatingSchema() {
+func (suite *TransactionContextSuite) TestBucketFsClientFailsCreatingSchema() {
 	suite.dbMock.ExpectBegin()
 	txCtx, _ := suite.beginTransaction()
+	bfsClient, err := txCtx.GetBucketFsClient()
+	suite.Require().NoError(err)
 	suite.dbMock.ExpectBegin()
 	suite.dbMock.ExpectExec("CREATE SCHEMA INTERNAL_\\d+").WillReturnError(errMock)
-	bfsClient, err := txCtx.GetBucketFsClient()
+	suite.dbMock.ExpectRollback()
+	files, err := bfsClient.ListFiles()
 	suite.Require().EqualError(err, "failed to create a schema for BucketFS list script. Cause: mock error")
-	suite.Nil(bfsClient)
+	suite.Nil(files)
 }
 
 // Rollback()
 
+func (suite *TransactionContextSuite) TestRollbackWithUnusedBfsClient() {
+	suite.dbMock.ExpectBegin()
+	txCtx, _ := suite.beginTransaction()
+	_, err := txCtx.GetBucketFsClient()
+	suite.Require().NoError(err)
+	suite.dbMock.ExpectRollback()
+	txCtx.Rollback()
+}
+
 func (suite *TransactionContextSuite) TestRollback() {
 	suite.dbMock.ExpectBegin()
 	txCtx, _ := suite.beginTransaction()
@@ -134,11 +179,7 @@ func (suite *TransactionContextSuite) TestRollbackClosesBfsClient() {
 	suite.dbMock.ExpectBegin()
 	txCtx, _ := suite.beginTransaction()
 
-	suite.dbMock.ExpectBegin()
-	suite.dbMock.ExpectExec("CREATE SCHEMA INTERNAL_\\d+").WillReturnResult(sqlmock.NewResult(0, 1))
-	suite.dbMock.ExpectExec("(?m)CREATE OR REPLACE PYTHON3 SCALAR SCRIPT.*").WillReturnResult(sqlmock.NewResult(0, 1))
-	_, err := txCtx.GetBucketFsClient()
-	suite.Require().NoError(err)
+	suite.listFilesWithBucketFsClient(txCtx)
 
 	suite.dbMock.ExpectRollback() // Rollback from BFS client
 	suite.dbMock.ExpectRollback() // Rollback transaction
@@ -149,11 +190,7 @@ func (suite *TransactionContextSuite) TestRollbackClosingBfsClientFails() {
 	suite.dbMock.ExpectBegin()
 	txCtx, _ := suite.beginTransaction()
 
-	suite.dbMock.ExpectBegin()
-	suite.dbMock.ExpectExec("CREATE SCHEMA INTERNAL_\\d+").WillReturnResult(sqlmock.NewResult(0, 1))
-	suite.dbMock.ExpectExec("(?m)CREATE OR REPLACE PYTHON3 SCALAR SCRIPT.*").WillReturnResult(sqlmock.NewResult(0, 1))
-	_, err := txCtx.GetBucketFsClient()
-	suite.Require().NoError(err)
+	suite.listFilesWithBucketFsClient(txCtx)
 
 	suite.dbMock.ExpectRollback().WillReturnError(errMock) // Rollback from BFS client
 	suite.dbMock.ExpectRollback()
@@ -173,11 +210,7 @@ func (suite *TransactionContextSuite) TestCommitClosesBfsClient() {
 	suite.dbMock.ExpectBegin()
 	txCtx, _ := suite.beginTransaction()
 
-	suite.dbMock.ExpectBegin()
-	suite.dbMock.ExpectExec("CREATE SCHEMA INTERNAL_\\d+").WillReturnResult(sqlmock.NewResult(0, 1))
-	suite.dbMock.ExpectExec("(?m)CREATE OR REPLACE PYTHON3 SCALAR SCRIPT.*").WillReturnResult(sqlmock.NewResult(0, 1))
-	_, err := txCtx.GetBucketFsClient()
-	suite.Require().NoError(err)
+	suite.listFilesWithBucketFsClient(txCtx)
 
 	suite.dbMock.ExpectRollback() // Rollback from BFS client
 	suite.dbMock.ExpectCommit()   // Commit transaction
@@ -188,16 +221,73 @@ func (suite *TransactionContextSuite) TestCommitClosingBfsClientFails() {
 	suite.dbMock.ExpectBegin()
 	txCtx, _ := suite.beginTransaction()
 
+	suite.listFilesWithBucketFsClient(txCtx)
+
+	suite.dbMock.ExpectRollback().WillReturnError(errMock) // Rollback from BFS client
+	suite.Require().EqualError(txCtx.Commit(), "failed to close BucketFS client: failed to rollback transaction to cleanup resources. Cause: mock error")
+}
+
+// Metrics
+
+func (suite *TransactionContextSuite) TestRollbackCountsTransaction() {
+	suite.dbMock.ExpectBegin()
+	txCtx, _ := suite.beginTransaction()
+	rolledBack := suite.getTransactionCount(metrics.TransactionRolledBack)
+	suite.dbMock.ExpectRollback()
+	txCtx.Rollback()
+	suite.Equal(rolledBack+1, suite.getTransactionCount(metrics.TransactionRolledBack))
+}
+
+func (suite *TransactionContextSuite) TestRollbackAfterCommitIsNotCounted() {
+	suite.dbMock.ExpectBegin()
+	txCtx, _ := suite.beginTransaction()
+	committed := suite.getTransactionCount(metrics.TransactionCommitted)
+	rolledBack := suite.getTransactionCount(metrics.TransactionRolledBack)
+	suite.dbMock.ExpectCommit()
+	suite.NoError(txCtx.Commit())
+	txCtx.Rollback()
+	suite.Equal(committed+1, suite.getTransactionCount(metrics.TransactionCommitted))
+	suite.Equal(rolledBack, suite.getTransactionCount(metrics.TransactionRolledBack))
+}
+
+func (suite *TransactionContextSuite) TestFailedCommitIsCounted() {
+	suite.dbMock.ExpectBegin()
+	txCtx, _ := suite.beginTransaction()
+	commitFailed := suite.getTransactionCount(metrics.TransactionCommitFailed)
+	suite.dbMock.ExpectCommit().WillReturnError(errMock)
+	suite.EqualError(txCtx.Commit(), "mock error")
+	suite.Equal(commitFailed+1, suite.getTransactionCount(metrics.TransactionCommitFailed))
+}
+
+func (suite *TransactionContextSuite) getTransactionCount(result string) float64 {
+	families, err := metrics.Registry.Gather()
+	suite.Require().NoError(err)
+	for _, family := range families {
+		if family.GetName() != "extension_manager_database_transactions_total" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			if metric.GetLabel()[0].GetValue() == result {
+				return metric.GetCounter().GetValue()
+			}
+		}
+	}
+	return 0
+}
+
+// listFilesWithBucketFsClient lists files so that the BucketFS client creates its UDF in a separate transaction.
+func (suite *TransactionContextSuite) listFilesWithBucketFsClient(txCtx *TransactionContext) {
+	bfsClient, err := txCtx.GetBucketFsClient()
+	suite.Require().NoError(err)
 	suite.dbMock.ExpectBegin()
 	suite.dbMock.ExpectExec("CREATE SCHEMA INTERNAL_\\d+").WillReturnResult(sqlmock.NewResult(0, 1))
 	suite.dbMock.ExpectExec("(?m)CREATE OR REPLACE PYTHON3 SCALAR SCRIPT.*").WillReturnResult(sqlmock.NewResult(0, 1))
-	_, err := txCtx.GetBucketFsClient()
+	suite.dbMock.ExpectPrepare(`SELECT "INTERNAL_.* ORDER BY FULL_PATH`).WillBeClosed().
+		ExpectQuery().WillReturnRows(sqlmock.NewRows([]string{"FILE_NAME", "FULL_PATH", "SIZE"})).RowsWillBeClosed()
+	_, err = bfsClient.ListFiles()
 	suite.Require().NoError(err)
-
-	suite.dbMock.ExpectRollback().WillReturnError(errMock) // Rollback from BFS client
-	suite.Require().EqualError(txCtx.Commit(), "failed to close BucketFS client: failed to rollback transaction to cleanup resources. Cause: mock error")
 }
 
 func (suite *TransactionContextSuite) beginTransaction() (*TransactionContext, error) {
-	return BeginTransaction(context.Background(), suite.db, BUCKETFS_BASE_PATH)
+	return BeginTransaction(context.Background(), suite.db, BUCKETFS_BASE_PATHS)
 }
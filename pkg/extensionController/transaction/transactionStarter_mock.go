@@ -29,13 +29,13 @@ func (m *TransactionStarterMock) GetTransactionStarter() TransactionStarter {
 }
 
 func (m *TransactionStarterMock) SimulateTransactionFailed(err error) {
-	m.transactionStarter = func(ctx context.Context, db *sql.DB, bucketFsBasePath string) (*TransactionContext, error) {
+	m.transactionStarter = func(ctx context.Context, db *sql.DB, bucketFsBasePaths []string) (*TransactionContext, error) {
 		return nil, err
 	}
 }
 
 func (m *TransactionStarterMock) SimulateMockTransaction() {
-	m.transactionStarter = func(ctx context.Context, db *sql.DB, bucketFsBasePath string) (*TransactionContext, error) {
+	m.transactionStarter = func(ctx context.Context, db *sql.DB, bucketFsBasePaths []string) (*TransactionContext, error) {
 		tx, err := m.dbMock.BeginTx(ctx, nil)
 		if err != nil {
 			return nil, fmt.Errorf("failed to start mock transaction: %w", err)
@@ -48,6 +48,7 @@ func (m *TransactionStarterMock) SimulateMockTransaction() {
 				return m.bfsMock, nil
 			},
 			bfsClient: nil,
+			finished:  false,
 		}, nil
 	}
 }
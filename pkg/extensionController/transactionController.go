@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/exasol/extension-manager/pkg/apiErrors"
 	"github.com/exasol/extension-manager/pkg/extensionAPI"
 	"github.com/exasol/extension-manager/pkg/extensionController/bfs"
 	"github.com/exasol/extension-manager/pkg/extensionController/transaction"
@@ -19,6 +20,10 @@ import (
 // The controller will take care of transaction handling,
 // i.e. it will create a new transaction and commit or rollback if necessary.
 type TransactionController interface {
+	// CheckReadiness verifies that the components required for processing requests are available,
+	// e.g. that the index of the extension registry can be loaded.
+	CheckReadiness(ctx context.Context) []ComponentStatus
+
 	// GetAllExtensions reports all extension definitions.
 	// db is a connection to the Exasol DB
 	GetAllExtensions(ctx context.Context, db *sql.DB) ([]*Extension, error)
@@ -30,12 +35,25 @@ type TransactionController interface {
 	// GetParameterDefinitions returns the parameter definitions required for installing a given extension version.
 	GetParameterDefinitions(ctx context.Context, db *sql.DB, extensionId string, extensionVersion string) ([]parameterValidator.ParameterDefinition, error)
 
+	// GetActiveParameterDefinitions returns the effective parameter definitions for the given (possibly incomplete) parameter values,
+	// i.e. it omits conditional parameters whose condition is not fulfilled.
+	GetActiveParameterDefinitions(ctx context.Context, db *sql.DB, extensionId string, extensionVersion string, parameterValues []ParameterValue) ([]parameterValidator.ParameterDefinition, error)
+
+	// ValidateParameters validates the given parameter values against the parameter definitions of an extension version
+	// and returns a result for each parameter. This does not create or modify any database objects.
+	ValidateParameters(ctx context.Context, db *sql.DB, extensionId string, extensionVersion string, parameterValues []ParameterValue) ([]parameterValidator.ParameterValidationResult, error)
+
 	// InstallExtension installs an extension.
 	// db is a connection to the Exasol DB
 	// extensionId is the ID of the extension to install
 	// extensionVersion is the version of the extension to install
 	InstallExtension(ctx context.Context, db *sql.DB, extensionId string, extensionVersion string) error
 
+	// CheckInstall checks if an extension can be installed, e.g. if required BucketFS files exist
+	// and the database user has the required privileges. It also runs the optional pre-flight checks of the extension.
+	// All checks run in a transaction that is rolled back, so this does not modify the database.
+	CheckInstall(ctx context.Context, db *sql.DB, extensionId string, extensionVersion string) (*InstallCheckResult, error)
+
 	// UninstallExtension uninstalls an extension.
 	// db is a connection to the Exasol DB
 	// extensionId is the ID of the extension to uninstall
@@ -56,6 +74,14 @@ type TransactionController interface {
 
 	// DeleteInstance deletes instance with the given ID.
 	DeleteInstance(ctx context.Context, db *sql.DB, extensionId, extensionVersion, instanceId string) error
+
+	// UpdateInstance changes the parameters of an existing instance of an extension, e.g. the credentials of a virtual schema.
+	// Values of secret parameters equal to the masked value returned by [TransactionController.GetInstance] keep their current value.
+	UpdateInstance(ctx context.Context, db *sql.DB, extensionId, extensionVersion, instanceId string, parameterValues []ParameterValue) (*extensionAPI.JsExtInstance, error)
+
+	// GetInstance returns the instance with the given ID including its current parameter values.
+	// Values of secret parameters are masked.
+	GetInstance(ctx context.Context, db *sql.DB, extensionId, extensionVersion, instanceId string) (*extensionAPI.JsExtInstanceDetails, error)
 }
 
 type Extension struct {
@@ -64,11 +90,13 @@ type Extension struct {
 	Category            string
 	Description         string
 	InstallableVersions []extensionAPI.JsExtensionVersion
+	// BucketFsFiles contains the BucketFS files required by the extension, including the base path where each file was found.
+	BucketFsFiles []bfs.BfsFile
 }
 
 type ParameterValue struct {
 	Name  string
-	Value string
+	Value interface{} // String, bool, number or list of values
 }
 
 // ExtInstallation represents the installation of an Extension.
@@ -83,8 +111,16 @@ type ExtensionManagerConfig struct {
 	ExtensionRegistryURL string
 	// BucketFS base path where to search for extension files, e.g. "/buckets/bfsdefault/default/".
 	BucketFSBasePath string
+	// Optional additional BucketFS base paths where to search for extension files, e.g. "/buckets/bfssecondary/jars/".
+	// EM searches these paths in the given order after [ExtensionManagerConfig.BucketFSBasePath].
+	// If a file exists in more than one path, EM uses the file from the first path.
+	BucketFSBasePaths []string
 	// Schema where extensions are searched for and new extensions are created, e.g. "EXA_EXTENSIONS".
 	ExtensionSchema string
+	// Optional duration for which BucketFS file listings are reused across requests, e.g. 30 seconds.
+	// Listings are cached per database host, so the context passed to the controller must contain the host, see [bfs.WithDatabaseHost].
+	// Default value 0 disables the cache, listings are then only reused within a single request.
+	BucketFSListingCacheTTL time.Duration
 }
 
 // Create creates a new instance of [TransactionController].
@@ -109,43 +145,68 @@ func CreateWithConfig(config ExtensionManagerConfig) TransactionController {
 // CreateWithValidatedConfig validates the configuration and creates a new instance of [TransactionController].
 func CreateWithValidatedConfig(config ExtensionManagerConfig) (TransactionController, error) {
 	if err := validateConfig(config); err != nil {
-		return nil, fmt.Errorf("invalid configuration: %w", err)
+		return nil, apiErrors.CTRL_INVALID_CONFIGURATION.NewInternalErrorF("invalid configuration: %w", err)
 	}
 	controller := createImpl(config)
 	transactionController := &transactionControllerImpl{
 		controller:         controller,
-		transactionStarter: transaction.BeginTransaction,
+		transactionStarter: createTransactionStarter(config),
 		config:             config,
 	}
-	return transactionController, nil
+	return &tracingTransactionController{delegate: transactionController}, nil
+}
+
+func createTransactionStarter(config ExtensionManagerConfig) transaction.TransactionStarter {
+	if config.BucketFSListingCacheTTL > 0 {
+		return transaction.NewTransactionStarter(bfs.NewListingCache(config.BucketFSListingCacheTTL))
+	}
+	return transaction.BeginTransaction
 }
 
 func validateConfig(config ExtensionManagerConfig) error {
-	if config.BucketFSBasePath == "" {
+	if config.BucketFSBasePath == "" && len(config.BucketFSBasePaths) == 0 {
 		return errors.New("missing BucketFSBasePath")
 	}
+	if err := bfs.ValidateBasePaths(config.bucketFsBasePaths()); err != nil {
+		return err
+	}
 	if config.ExtensionRegistryURL == "" {
 		return errors.New("missing ExtensionRegistryURL")
 	}
 	if config.ExtensionSchema == "" {
 		return errors.New("missing ExtensionSchema")
 	}
+	if config.BucketFSListingCacheTTL < 0 {
+		return fmt.Errorf("invalid BucketFSListingCacheTTL %v, must not be negative", config.BucketFSListingCacheTTL)
+	}
 	return nil
 }
 
+// bucketFsBasePaths returns all configured BucketFS base paths in the order in which EM searches them.
+func (config ExtensionManagerConfig) bucketFsBasePaths() []string {
+	if config.BucketFSBasePath == "" {
+		return config.BucketFSBasePaths
+	}
+	return append([]string{config.BucketFSBasePath}, config.BucketFSBasePaths...)
+}
+
 type transactionControllerImpl struct {
 	controller         controller
 	transactionStarter transaction.TransactionStarter
 	config             ExtensionManagerConfig
 }
 
+func (c *transactionControllerImpl) CheckReadiness(ctx context.Context) []ComponentStatus {
+	return c.controller.CheckReadiness(ctx)
+}
+
 func (c *transactionControllerImpl) GetAllExtensions(ctx context.Context, db *sql.DB) ([]*Extension, error) {
 	t0 := time.Now()
 	bfsFiles, err := c.listBfsFiles(ctx, db)
 	if err != nil {
 		return nil, err
 	}
-	extensions, err := c.controller.GetAllExtensions(bfsFiles)
+	extensions, err := c.controller.GetAllExtensions(ctx, bfsFiles)
 	log.Debugf("Found %d extensions in %dms (%d files in BucketFS)", len(extensions), time.Since(t0).Milliseconds(), len(bfsFiles))
 	return extensions, err
 }
@@ -183,6 +244,15 @@ func (c *transactionControllerImpl) InstallExtension(ctx context.Context, db *sq
 	return err
 }
 
+func (c *transactionControllerImpl) CheckInstall(ctx context.Context, db *sql.DB, extensionId string, extensionVersion string) (*InstallCheckResult, error) {
+	tx, err := c.beginTransaction(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	return c.controller.CheckInstall(tx, extensionId, extensionVersion)
+}
+
 func (c *transactionControllerImpl) UninstallExtension(ctx context.Context, db *sql.DB, extensionId string, extensionVersion string) (returnErr error) {
 	tx, err := c.beginTransaction(ctx, db)
 	if err != nil {
@@ -237,6 +307,22 @@ func (c *transactionControllerImpl) GetParameterDefinitions(ctx context.Context,
 	return c.controller.GetParameterDefinitions(tx, extensionId, extensionVersion)
 }
 
+func (c *transactionControllerImpl) GetActiveParameterDefinitions(ctx context.Context, db *sql.DB, extensionId string, extensionVersion string, parameterValues []ParameterValue) ([]parameterValidator.ParameterDefinition, error) {
+	definitions, err := c.GetParameterDefinitions(ctx, db, extensionId, extensionVersion)
+	if err != nil {
+		return nil, err
+	}
+	return parameterValidator.ActiveDefinitions(definitions, convertParameters(parameterValues))
+}
+
+func (c *transactionControllerImpl) ValidateParameters(ctx context.Context, db *sql.DB, extensionId string, extensionVersion string, parameterValues []ParameterValue) ([]parameterValidator.ParameterValidationResult, error) {
+	definitions, err := c.GetParameterDefinitions(ctx, db, extensionId, extensionVersion)
+	if err != nil {
+		return nil, err
+	}
+	return parameterValidator.New().ValidateEach(definitions, convertParameters(parameterValues))
+}
+
 func (c *transactionControllerImpl) CreateInstance(ctx context.Context, db *sql.DB, extensionId string, extensionVersion string, parameterValues []ParameterValue) (*extensionAPI.JsExtInstance, error) {
 	tx, err := c.beginTransaction(ctx, db)
 	if err != nil {
@@ -278,8 +364,33 @@ func (c *transactionControllerImpl) DeleteInstance(ctx context.Context, db *sql.
 	return err
 }
 
+func (c *transactionControllerImpl) UpdateInstance(ctx context.Context, db *sql.DB, extensionId, extensionVersion, instanceId string, parameterValues []ParameterValue) (*extensionAPI.JsExtInstance, error) {
+	tx, err := c.beginTransaction(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	instance, err := c.controller.UpdateInstance(tx, extensionId, extensionVersion, instanceId, parameterValues)
+	if err == nil {
+		err = tx.Commit()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return instance, err
+}
+
+func (c *transactionControllerImpl) GetInstance(ctx context.Context, db *sql.DB, extensionId, extensionVersion, instanceId string) (*extensionAPI.JsExtInstanceDetails, error) {
+	tx, err := c.beginTransaction(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	return c.controller.GetInstance(tx, extensionId, extensionVersion, instanceId)
+}
+
 func (c *transactionControllerImpl) beginTransaction(ctx context.Context, db *sql.DB) (*transaction.TransactionContext, error) {
-	tx, err := c.transactionStarter(ctx, db, c.config.BucketFSBasePath)
+	tx, err := c.transactionStarter(ctx, db, c.config.bucketFsBasePaths())
 	if err != nil {
 		return nil, fmt.Errorf("failed to start transaction: %w", err)
 	}
@@ -0,0 +1,119 @@
+package extensionController
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/exasol/extension-manager/pkg/extensionAPI"
+	"github.com/exasol/extension-manager/pkg/parameterValidator"
+	"github.com/exasol/extension-manager/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracingTransactionController creates a span for each call of a [TransactionController] method.
+// Spans don't contain parameter values, as they may contain credentials.
+type tracingTransactionController struct {
+	delegate TransactionController
+}
+
+func startSpan(ctx context.Context, method string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracing.Start(ctx, "TransactionController."+method, attributes...)
+}
+
+func extensionAttributes(extensionId, extensionVersion string) []attribute.KeyValue {
+	return []attribute.KeyValue{attribute.String("em.extension.id", extensionId), attribute.String("em.extension.version", extensionVersion)}
+}
+
+func instanceAttributes(extensionId, extensionVersion, instanceId string) []attribute.KeyValue {
+	return append(extensionAttributes(extensionId, extensionVersion), attribute.String("em.instance.id", instanceId))
+}
+
+// CheckReadiness does not create a span because orchestration tools call it frequently.
+func (c *tracingTransactionController) CheckReadiness(ctx context.Context) []ComponentStatus {
+	return c.delegate.CheckReadiness(ctx)
+}
+
+func (c *tracingTransactionController) GetAllExtensions(ctx context.Context, db *sql.DB) (extensions []*Extension, err error) {
+	ctx, span := startSpan(ctx, "GetAllExtensions")
+	defer func() { tracing.End(span, err) }()
+	return c.delegate.GetAllExtensions(ctx, db)
+}
+
+func (c *tracingTransactionController) GetInstalledExtensions(ctx context.Context, db *sql.DB) (installations []*extensionAPI.JsExtInstallation, err error) {
+	ctx, span := startSpan(ctx, "GetInstalledExtensions")
+	defer func() { tracing.End(span, err) }()
+	return c.delegate.GetInstalledExtensions(ctx, db)
+}
+
+func (c *tracingTransactionController) GetParameterDefinitions(ctx context.Context, db *sql.DB, extensionId string, extensionVersion string) (definitions []parameterValidator.ParameterDefinition, err error) {
+	ctx, span := startSpan(ctx, "GetParameterDefinitions", extensionAttributes(extensionId, extensionVersion)...)
+	defer func() { tracing.End(span, err) }()
+	return c.delegate.GetParameterDefinitions(ctx, db, extensionId, extensionVersion)
+}
+
+func (c *tracingTransactionController) GetActiveParameterDefinitions(ctx context.Context, db *sql.DB, extensionId string, extensionVersion string, parameterValues []ParameterValue) (definitions []parameterValidator.ParameterDefinition, err error) {
+	ctx, span := startSpan(ctx, "GetActiveParameterDefinitions", extensionAttributes(extensionId, extensionVersion)...)
+	defer func() { tracing.End(span, err) }()
+	return c.delegate.GetActiveParameterDefinitions(ctx, db, extensionId, extensionVersion, parameterValues)
+}
+
+func (c *tracingTransactionController) ValidateParameters(ctx context.Context, db *sql.DB, extensionId string, extensionVersion string, parameterValues []ParameterValue) (results []parameterValidator.ParameterValidationResult, err error) {
+	ctx, span := startSpan(ctx, "ValidateParameters", extensionAttributes(extensionId, extensionVersion)...)
+	defer func() { tracing.End(span, err) }()
+	return c.delegate.ValidateParameters(ctx, db, extensionId, extensionVersion, parameterValues)
+}
+
+func (c *tracingTransactionController) InstallExtension(ctx context.Context, db *sql.DB, extensionId string, extensionVersion string) (err error) {
+	ctx, span := startSpan(ctx, "InstallExtension", extensionAttributes(extensionId, extensionVersion)...)
+	defer func() { tracing.End(span, err) }()
+	return c.delegate.InstallExtension(ctx, db, extensionId, extensionVersion)
+}
+
+func (c *tracingTransactionController) CheckInstall(ctx context.Context, db *sql.DB, extensionId string, extensionVersion string) (result *InstallCheckResult, err error) {
+	ctx, span := startSpan(ctx, "CheckInstall", extensionAttributes(extensionId, extensionVersion)...)
+	defer func() { tracing.End(span, err) }()
+	return c.delegate.CheckInstall(ctx, db, extensionId, extensionVersion)
+}
+
+func (c *tracingTransactionController) UninstallExtension(ctx context.Context, db *sql.DB, extensionId string, extensionVersion string) (err error) {
+	ctx, span := startSpan(ctx, "UninstallExtension", extensionAttributes(extensionId, extensionVersion)...)
+	defer func() { tracing.End(span, err) }()
+	return c.delegate.UninstallExtension(ctx, db, extensionId, extensionVersion)
+}
+
+func (c *tracingTransactionController) UpgradeExtension(ctx context.Context, db *sql.DB, extensionId string) (result *extensionAPI.JsUpgradeResult, err error) {
+	ctx, span := startSpan(ctx, "UpgradeExtension", attribute.String("em.extension.id", extensionId))
+	defer func() { tracing.End(span, err) }()
+	return c.delegate.UpgradeExtension(ctx, db, extensionId)
+}
+
+func (c *tracingTransactionController) CreateInstance(ctx context.Context, db *sql.DB, extensionId string, extensionVersion string, parameterValues []ParameterValue) (instance *extensionAPI.JsExtInstance, err error) {
+	ctx, span := startSpan(ctx, "CreateInstance", extensionAttributes(extensionId, extensionVersion)...)
+	defer func() { tracing.End(span, err) }()
+	return c.delegate.CreateInstance(ctx, db, extensionId, extensionVersion, parameterValues)
+}
+
+func (c *tracingTransactionController) FindInstances(ctx context.Context, db *sql.DB, extensionId string, extensionVersion string) (instances []*extensionAPI.JsExtInstance, err error) {
+	ctx, span := startSpan(ctx, "FindInstances", extensionAttributes(extensionId, extensionVersion)...)
+	defer func() { tracing.End(span, err) }()
+	return c.delegate.FindInstances(ctx, db, extensionId, extensionVersion)
+}
+
+func (c *tracingTransactionController) DeleteInstance(ctx context.Context, db *sql.DB, extensionId, extensionVersion, instanceId string) (err error) {
+	ctx, span := startSpan(ctx, "DeleteInstance", instanceAttributes(extensionId, extensionVersion, instanceId)...)
+	defer func() { tracing.End(span, err) }()
+	return c.delegate.DeleteInstance(ctx, db, extensionId, extensionVersion, instanceId)
+}
+
+func (c *tracingTransactionController) UpdateInstance(ctx context.Context, db *sql.DB, extensionId, extensionVersion, instanceId string, parameterValues []ParameterValue) (instance *extensionAPI.JsExtInstance, err error) {
+	ctx, span := startSpan(ctx, "UpdateInstance", instanceAttributes(extensionId, extensionVersion, instanceId)...)
+	defer func() { tracing.End(span, err) }()
+	return c.delegate.UpdateInstance(ctx, db, extensionId, extensionVersion, instanceId, parameterValues)
+}
+
+func (c *tracingTransactionController) GetInstance(ctx context.Context, db *sql.DB, extensionId, extensionVersion, instanceId string) (instance *extensionAPI.JsExtInstanceDetails, err error) {
+	ctx, span := startSpan(ctx, "GetInstance", instanceAttributes(extensionId, extensionVersion, instanceId)...)
+	defer func() { tracing.End(span, err) }()
+	return c.delegate.GetInstance(ctx, db, extensionId, extensionVersion, instanceId)
+}
@@ -0,0 +1,123 @@
+package extensionController
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/exasol/extension-manager/pkg/extensionController/bfs"
+	"github.com/exasol/extension-manager/pkg/extensionController/registry"
+	"github.com/exasol/extension-manager/pkg/extensionController/transaction"
+	"github.com/exasol/extension-manager/pkg/tracing"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type TracingControllerSuite struct {
+	suite.Suite
+	ctrl             TransactionController
+	db               *sql.DB
+	dbMock           sqlmock.Sqlmock
+	mockCtrl         mockControllerImpl
+	recorder         *tracetest.SpanRecorder
+	previousProvider trace.TracerProvider
+}
+
+func TestTracingControllerSuite(t *testing.T) {
+	suite.Run(t, new(TracingControllerSuite))
+}
+
+func (suite *TracingControllerSuite) SetupTest() {
+	db, dbMock, err := sqlmock.New()
+	suite.Require().NoError(err)
+	suite.db = db
+	suite.dbMock = dbMock
+	suite.mockCtrl = createMockControllerImpl()
+	transactionStarterMock := transaction.CreateTransactionStarterMock(suite.db, bfs.CreateBucketFsMock())
+	suite.ctrl = &tracingTransactionController{delegate: &transactionControllerImpl{
+		controller:         &suite.mockCtrl,
+		transactionStarter: transactionStarterMock.GetTransactionStarter(),
+		config:             ExtensionManagerConfig{ExtensionRegistryURL: "registry-url", BucketFSBasePath: "bfs-base-path", ExtensionSchema: "ext-schema"},
+	}}
+	suite.previousProvider = otel.GetTracerProvider()
+	suite.recorder = tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(suite.recorder)))
+}
+
+func (suite *TracingControllerSuite) TearDownTest() {
+	otel.SetTracerProvider(suite.previousProvider)
+	suite.Require().NoError(suite.dbMock.ExpectationsWereMet())
+	suite.mockCtrl.AssertExpectations(suite.T())
+}
+
+func (suite *TracingControllerSuite) TestCreateWithValidatedConfigAddsTracing() {
+	ctrl, err := CreateWithValidatedConfig(ExtensionManagerConfig{ExtensionRegistryURL: "url", BucketFSBasePath: "bfspath", ExtensionSchema: "schema"})
+	suite.Require().NoError(err)
+	suite.IsType(&tracingTransactionController{}, ctrl)
+}
+
+func (suite *TracingControllerSuite) TestInstallExtensionCreatesSpan() {
+	suite.dbMock.ExpectBegin()
+	suite.mockCtrl.On("InstallExtension", mock.Anything, "extId", "extVer").Return(nil)
+	suite.dbMock.ExpectCommit()
+	suite.Require().NoError(suite.ctrl.InstallExtension(mockContext(), suite.db, "extId", "extVer"))
+	span := suite.getSingleSpan()
+	suite.Equal("TransactionController.InstallExtension", span.Name())
+	suite.Equal([]attribute.KeyValue{attribute.String("em.extension.id", "extId"), attribute.String("em.extension.version", "extVer")}, span.Attributes())
+	suite.Equal(codes.Unset, span.Status().Code)
+}
+
+func (suite *TracingControllerSuite) TestInstallExtensionFailureMarksSpanAsFailed() {
+	suite.dbMock.ExpectBegin()
+	suite.mockCtrl.On("InstallExtension", mock.Anything, "extId", "extVer").Return(errMock)
+	suite.dbMock.ExpectRollback()
+	suite.Require().EqualError(suite.ctrl.InstallExtension(mockContext(), suite.db, "extId", "extVer"), mockErrorMsg)
+	span := suite.getSingleSpan()
+	suite.Equal(codes.Error, span.Status().Code)
+	suite.Equal(mockErrorMsg, span.Status().Description)
+}
+
+func (suite *TracingControllerSuite) TestDeleteInstanceAddsInstanceId() {
+	suite.dbMock.ExpectBegin()
+	suite.mockCtrl.On("DeleteInstance", mock.Anything, "extId", "extVer", "instId").Return(nil)
+	suite.dbMock.ExpectCommit()
+	suite.Require().NoError(suite.ctrl.DeleteInstance(mockContext(), suite.db, "extId", "extVer", "instId"))
+	suite.Contains(suite.getSingleSpan().Attributes(), attribute.String("em.instance.id", "instId"))
+}
+
+func (suite *TracingControllerSuite) TestSpanIsChildOfSpanInContext() {
+	ctx, parent := tracing.Start(mockContext(), "parent")
+	suite.dbMock.ExpectBegin()
+	suite.mockCtrl.On("UninstallExtension", mock.Anything, "extId", "extVer").Return(nil)
+	suite.dbMock.ExpectCommit()
+	suite.Require().NoError(suite.ctrl.UninstallExtension(ctx, suite.db, "extId", "extVer"))
+	parent.End()
+	spans := suite.recorder.Ended()
+	suite.Require().Len(spans, 2)
+	suite.Equal("TransactionController.UninstallExtension", spans[0].Name())
+	suite.Equal(parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+}
+
+func (suite *TracingControllerSuite) TestReadExtensionCreatesSpan() {
+	ctrl := &controllerImpl{registry: registry.NewRegistry(suite.T().TempDir()), config: ExtensionManagerConfig{}, metaDataReader: nil,
+		registryCheck: newCachedCheck(registryCheckInterval)}
+	extension, err := ctrl.loadExtensionById(mockContext(), "unknown-extension")
+	suite.Require().Error(err)
+	suite.Nil(extension)
+	span := suite.getSingleSpan()
+	suite.Equal("registry.ReadExtension", span.Name())
+	suite.Equal([]attribute.KeyValue{attribute.String("em.extension.id", "unknown-extension")}, span.Attributes())
+	suite.Equal(codes.Error, span.Status().Code)
+}
+
+func (suite *TracingControllerSuite) getSingleSpan() sdktrace.ReadOnlySpan {
+	spans := suite.recorder.Ended()
+	suite.Require().Len(spans, 1)
+	return spans[0]
+}
@@ -4,11 +4,13 @@ import (
 	"database/sql"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/exasol/extension-manager/pkg/extensionAPI"
 	"github.com/exasol/extension-manager/pkg/extensionController/bfs"
 	"github.com/exasol/extension-manager/pkg/extensionController/transaction"
+	"github.com/exasol/extension-manager/pkg/parameterValidator"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -76,6 +78,35 @@ func (suite *extCtrlUnitTestSuite) TestCreateWithValidatedConfigSuccess() {
 	suite.NotNil(ctrl)
 }
 
+func (suite *extCtrlUnitTestSuite) TestCreateWithValidatedConfigWithListingCache() {
+	ctrl, err := CreateWithValidatedConfig(ExtensionManagerConfig{ExtensionRegistryURL: "url", BucketFSBasePath: "bfspath", ExtensionSchema: "schema", BucketFSListingCacheTTL: time.Minute})
+	suite.Require().NoError(err)
+	suite.NotNil(ctrl)
+}
+
+func (suite *extCtrlUnitTestSuite) TestCreateWithValidatedConfigWithAdditionalBasePaths() {
+	ctrl, err := CreateWithValidatedConfig(ExtensionManagerConfig{ExtensionRegistryURL: "url", BucketFSBasePaths: []string{"bfspath1", "bfspath2"}, ExtensionSchema: "schema"})
+	suite.Require().NoError(err)
+	suite.NotNil(ctrl)
+}
+
+func (suite *extCtrlUnitTestSuite) TestBucketFsBasePaths() {
+	var tests = []struct {
+		name     string
+		config   ExtensionManagerConfig
+		expected []string
+	}{
+		{name: "single base path", config: ExtensionManagerConfig{BucketFSBasePath: "path1"}, expected: []string{"path1"}},
+		{name: "additional base paths only", config: ExtensionManagerConfig{BucketFSBasePaths: []string{"path1", "path2"}}, expected: []string{"path1", "path2"}},
+		{name: "base path first", config: ExtensionManagerConfig{BucketFSBasePath: "path1", BucketFSBasePaths: []string{"path2", "path3"}}, expected: []string{"path1", "path2", "path3"}},
+	}
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			suite.Equal(test.expected, test.config.bucketFsBasePaths())
+		})
+	}
+}
+
 func (suite *extCtrlUnitTestSuite) TestCreateWithValidatedConfigFailure() {
 	var tests = []struct {
 		name          string
@@ -88,6 +119,9 @@ func (suite *extCtrlUnitTestSuite) TestCreateWithValidatedConfigFailure() {
 		{name: "empty bucketfs base path", config: ExtensionManagerConfig{ExtensionRegistryURL: "url", BucketFSBasePath: "", ExtensionSchema: "schema"}, expectedError: "invalid configuration: missing BucketFSBasePath"},
 		{name: "missing schema", config: ExtensionManagerConfig{ExtensionRegistryURL: "url", BucketFSBasePath: "bfspath", ExtensionSchema: ""}, expectedError: "invalid configuration: missing ExtensionSchema"},
 		{name: "empty schema", config: ExtensionManagerConfig{ExtensionRegistryURL: "url", BucketFSBasePath: "bfspath", ExtensionSchema: ""}, expectedError: "invalid configuration: missing ExtensionSchema"},
+		{name: "negative listing cache ttl", config: ExtensionManagerConfig{ExtensionRegistryURL: "url", BucketFSBasePath: "bfspath", ExtensionSchema: "schema", BucketFSListingCacheTTL: -time.Second}, expectedError: "invalid configuration: invalid BucketFSListingCacheTTL -1s, must not be negative"},
+		{name: "empty additional bucketfs base path", config: ExtensionManagerConfig{ExtensionRegistryURL: "url", BucketFSBasePath: "bfspath", BucketFSBasePaths: []string{""}, ExtensionSchema: "schema"}, expectedError: "invalid configuration: bucketFsBasePath is empty"},
+		{name: "duplicate bucketfs base path", config: ExtensionManagerConfig{ExtensionRegistryURL: "url", BucketFSBasePath: "bfspath", BucketFSBasePaths: []string{"bfspath"}, ExtensionSchema: "schema"}, expectedError: `invalid configuration: bucketFsBasePath "bfspath" is configured more than once`},
 		{name: "all missing", config: ExtensionManagerConfig{ExtensionRegistryURL: "", BucketFSBasePath: "", ExtensionSchema: ""}, expectedError: "invalid configuration: missing BucketFSBasePath"},
 	}
 	for _, test := range tests {
@@ -99,6 +133,14 @@ func (suite *extCtrlUnitTestSuite) TestCreateWithValidatedConfigFailure() {
 	}
 }
 
+// CheckReadiness
+
+func (suite *extCtrlUnitTestSuite) TestCheckReadiness() {
+	statuses := []ComponentStatus{{Name: ComponentRegistry, Ready: false, Error: "mock error"}}
+	suite.mockCtrl.On("CheckReadiness", mock.Anything).Return(statuses)
+	suite.Equal(statuses, suite.ctrl.CheckReadiness(mockContext()))
+}
+
 // GetAllExtensions
 
 func (suite *extCtrlUnitTestSuite) TestGetAllExtensionsSuccess() {
@@ -190,6 +232,34 @@ func (suite *extCtrlUnitTestSuite) TestInstallExtensionCommitFailure() {
 	suite.Require().EqualError(err, mockErrorMsg)
 }
 
+// CheckInstall
+
+func (suite *extCtrlUnitTestSuite) TestCheckInstallBeginTransactionFailure() {
+	suite.dbMock.ExpectBegin().WillReturnError(errMock)
+	result, err := suite.ctrl.CheckInstall(mockContext(), suite.db, "extId", "extVer")
+	suite.Require().EqualError(err, beginMockTransactionFailedErrorMsg)
+	suite.Nil(result)
+}
+
+func (suite *extCtrlUnitTestSuite) TestCheckInstallSuccessRollsBack() {
+	suite.dbMock.ExpectBegin()
+	mockResult := &InstallCheckResult{Success: true, Checks: []InstallCheck{{Type: CHECK_SCHEMA, Name: "schema", Success: true, Message: ""}}}
+	suite.mockCtrl.On("CheckInstall", mock.Anything, "extId", "extVer").Return(mockResult, nil)
+	suite.dbMock.ExpectRollback()
+	result, err := suite.ctrl.CheckInstall(mockContext(), suite.db, "extId", "extVer")
+	suite.Require().NoError(err)
+	suite.Equal(mockResult, result)
+}
+
+func (suite *extCtrlUnitTestSuite) TestCheckInstallFailure() {
+	suite.dbMock.ExpectBegin()
+	suite.mockCtrl.On("CheckInstall", mock.Anything, "extId", "extVer").Return(nil, errMock)
+	suite.dbMock.ExpectRollback()
+	result, err := suite.ctrl.CheckInstall(mockContext(), suite.db, "extId", "extVer")
+	suite.Require().EqualError(err, mockErrorMsg)
+	suite.Nil(result)
+}
+
 // UninstallExtension
 
 func (suite *extCtrlUnitTestSuite) TestUninstallExtensionBeginTransactionFailure() {
@@ -353,3 +423,127 @@ func (suite *extCtrlUnitTestSuite) TestDeleteInstanceCommitFailure() {
 	err := suite.ctrl.DeleteInstance(mockContext(), suite.db, "extId", "extVers", "instId")
 	suite.Require().EqualError(err, mockErrorMsg)
 }
+
+// UpdateInstance
+
+func (suite *extCtrlUnitTestSuite) TestUpdateInstanceBeginTransactionFailure() {
+	suite.dbMock.ExpectBegin().WillReturnError(errMock)
+	instance, err := suite.ctrl.UpdateInstance(mockContext(), suite.db, "extId", "extVer", "instId", []ParameterValue{})
+	suite.Require().EqualError(err, beginMockTransactionFailedErrorMsg)
+	suite.Nil(instance)
+}
+
+func (suite *extCtrlUnitTestSuite) TestUpdateInstanceSuccess() {
+	suite.dbMock.ExpectBegin()
+	suite.mockCtrl.On("UpdateInstance", mock.Anything, "extId", "extVer", "instId", mock.Anything).Return(&extensionAPI.JsExtInstance{Id: "instId", Name: "inst"}, nil)
+	suite.dbMock.ExpectCommit()
+	instance, err := suite.ctrl.UpdateInstance(mockContext(), suite.db, "extId", "extVer", "instId", []ParameterValue{})
+	suite.Require().NoError(err)
+	suite.Equal(&extensionAPI.JsExtInstance{Id: "instId", Name: "inst"}, instance)
+}
+
+func (suite *extCtrlUnitTestSuite) TestUpdateInstanceFailure() {
+	suite.dbMock.ExpectBegin()
+	suite.mockCtrl.On("UpdateInstance", mock.Anything, "extId", "extVer", "instId", mock.Anything).Return(nil, errMock)
+	suite.dbMock.ExpectRollback()
+	instance, err := suite.ctrl.UpdateInstance(mockContext(), suite.db, "extId", "extVer", "instId", []ParameterValue{})
+	suite.Require().EqualError(err, mockErrorMsg)
+	suite.Nil(instance)
+}
+
+func (suite *extCtrlUnitTestSuite) TestUpdateInstanceCommitFailure() {
+	suite.dbMock.ExpectBegin()
+	suite.mockCtrl.On("UpdateInstance", mock.Anything, "extId", "extVer", "instId", mock.Anything).Return(&extensionAPI.JsExtInstance{Id: "instId", Name: "inst"}, nil)
+	suite.dbMock.ExpectCommit().WillReturnError(errMock)
+	instance, err := suite.ctrl.UpdateInstance(mockContext(), suite.db, "extId", "extVer", "instId", []ParameterValue{})
+	suite.Require().EqualError(err, mockErrorMsg)
+	suite.Nil(instance)
+}
+
+// GetActiveParameterDefinitions
+
+func (suite *extCtrlUnitTestSuite) TestGetActiveParameterDefinitionsBeginTransactionFailure() {
+	suite.dbMock.ExpectBegin().WillReturnError(errMock)
+	definitions, err := suite.ctrl.GetActiveParameterDefinitions(mockContext(), suite.db, "extId", "extVer", []ParameterValue{})
+	suite.Require().EqualError(err, beginMockTransactionFailedErrorMsg)
+	suite.Nil(definitions)
+}
+
+func (suite *extCtrlUnitTestSuite) TestGetActiveParameterDefinitionsSuccess() {
+	suite.dbMock.ExpectBegin()
+	condition := map[string]interface{}{"parameter": "type", "operator": "==", "value": "jdbc"}
+	typeDef := parameterValidator.ParameterDefinition{Id: "type", Name: "Type", Secret: false, RawDefinition: map[string]interface{}{"id": "type"}}
+	driverDef := parameterValidator.ParameterDefinition{Id: "driver", Name: "Driver", Secret: false, RawDefinition: map[string]interface{}{"id": "driver", "condition": condition}}
+	suite.mockCtrl.On("GetParameterDefinitions", "extId", "extVer").Return([]parameterValidator.ParameterDefinition{typeDef, driverDef}, nil)
+	suite.dbMock.ExpectRollback()
+	definitions, err := suite.ctrl.GetActiveParameterDefinitions(mockContext(), suite.db, "extId", "extVer", []ParameterValue{{Name: "type", Value: "odbc"}})
+	suite.Require().NoError(err)
+	suite.Equal([]parameterValidator.ParameterDefinition{typeDef}, definitions)
+}
+
+func (suite *extCtrlUnitTestSuite) TestGetActiveParameterDefinitionsFailure() {
+	suite.dbMock.ExpectBegin()
+	suite.mockCtrl.On("GetParameterDefinitions", "extId", "extVer").Return(nil, errMock)
+	suite.dbMock.ExpectRollback()
+	definitions, err := suite.ctrl.GetActiveParameterDefinitions(mockContext(), suite.db, "extId", "extVer", []ParameterValue{})
+	suite.Require().EqualError(err, mockErrorMsg)
+	suite.Nil(definitions)
+}
+
+// ValidateParameters
+
+func (suite *extCtrlUnitTestSuite) TestValidateParametersBeginTransactionFailure() {
+	suite.dbMock.ExpectBegin().WillReturnError(errMock)
+	results, err := suite.ctrl.ValidateParameters(mockContext(), suite.db, "extId", "extVer", []ParameterValue{})
+	suite.Require().EqualError(err, beginMockTransactionFailedErrorMsg)
+	suite.Nil(results)
+}
+
+func (suite *extCtrlUnitTestSuite) TestValidateParametersSuccess() {
+	suite.dbMock.ExpectBegin()
+	def := parameterValidator.ParameterDefinition{Id: "p1", Name: "Param 1", Secret: false, RawDefinition: map[string]interface{}{"id": "p1", "type": "string", "required": true}}
+	suite.mockCtrl.On("GetParameterDefinitions", "extId", "extVer").Return([]parameterValidator.ParameterDefinition{def}, nil)
+	suite.dbMock.ExpectRollback()
+	results, err := suite.ctrl.ValidateParameters(mockContext(), suite.db, "extId", "extVer", []ParameterValue{{Name: "p2", Value: "v2"}})
+	suite.Require().NoError(err)
+	suite.Equal([]parameterValidator.ParameterValidationResult{
+		{ParameterId: "p1", ParameterName: "Param 1", Success: false, Failure: parameterValidator.FailureMissingValue, Message: "This is a required parameter."},
+		{ParameterId: "p2", ParameterName: "", Success: false, Failure: parameterValidator.FailureUnknownParameter, Message: "Unknown parameter."}}, results)
+}
+
+func (suite *extCtrlUnitTestSuite) TestValidateParametersFailure() {
+	suite.dbMock.ExpectBegin()
+	suite.mockCtrl.On("GetParameterDefinitions", "extId", "extVer").Return(nil, errMock)
+	suite.dbMock.ExpectRollback()
+	results, err := suite.ctrl.ValidateParameters(mockContext(), suite.db, "extId", "extVer", []ParameterValue{})
+	suite.Require().EqualError(err, mockErrorMsg)
+	suite.Nil(results)
+}
+
+// GetInstance
+
+func (suite *extCtrlUnitTestSuite) TestGetInstanceBeginTransactionFailure() {
+	suite.dbMock.ExpectBegin().WillReturnError(errMock)
+	instance, err := suite.ctrl.GetInstance(mockContext(), suite.db, "extId", "extVer", "instId")
+	suite.Require().EqualError(err, beginMockTransactionFailedErrorMsg)
+	suite.Nil(instance)
+}
+
+func (suite *extCtrlUnitTestSuite) TestGetInstanceSuccess() {
+	suite.dbMock.ExpectBegin()
+	mockResult := &extensionAPI.JsExtInstanceDetails{Id: "instId", Name: "inst", Parameters: []extensionAPI.ParameterValue{{Name: "p1", Value: "v1"}}}
+	suite.mockCtrl.On("GetInstance", mock.Anything, "extId", "extVer", "instId").Return(mockResult, nil)
+	suite.dbMock.ExpectRollback()
+	instance, err := suite.ctrl.GetInstance(mockContext(), suite.db, "extId", "extVer", "instId")
+	suite.Require().NoError(err)
+	suite.Equal(mockResult, instance)
+}
+
+func (suite *extCtrlUnitTestSuite) TestGetInstanceFailure() {
+	suite.dbMock.ExpectBegin()
+	suite.mockCtrl.On("GetInstance", mock.Anything, "extId", "extVer", "instId").Return(nil, errMock)
+	suite.dbMock.ExpectRollback()
+	instance, err := suite.ctrl.GetInstance(mockContext(), suite.db, "extId", "extVer", "instId")
+	suite.Require().EqualError(err, mockErrorMsg)
+	suite.Nil(instance)
+}
@@ -33,9 +33,16 @@ function createExtension() {
         deleteInstance(context, extensionVersion, instanceId) {
             $DELETE_INSTANCE$
         },
+        updateInstance(context, extensionVersion, instanceId, params) {
+            $UPDATE_INSTANCE$
+        },
+        getInstance(context, extensionVersion, instanceId) {
+            $GET_INSTANCE$
+        },
         getInstanceParameters(context, version) {
             $GET_INSTANCE_PARAMETER_DEFINITIONS$
         },
+        $PREFLIGHT$
         readInstanceParameterValues(context, extensionVersion, instanceId) {
             return undefined;
         }
@@ -29,7 +29,10 @@ func CreateTestExtensionBuilder(t *testing.T) *TestExtensionBuilder {
 		addInstanceFunc:                     "return undefined",
 		findInstancesFunc:                   "return []",
 		deleteInstanceFunc:                  "context.sqlClient.execute(`drop instance ${instanceId}`)",
+		updateInstanceFunc:                  "return undefined",
+		getInstanceFunc:                     "return undefined",
 		getInstanceParameterDefinitionsFunc: "return []",
+		preflightFunc:                       "",
 		bucketFsUploads:                     []BucketFsUploadParams{},
 		rawBucketFsUploads:                  "",
 	}
@@ -48,7 +51,10 @@ type TestExtensionBuilder struct {
 	addInstanceFunc                     string
 	findInstancesFunc                   string
 	deleteInstanceFunc                  string
+	updateInstanceFunc                  string
+	getInstanceFunc                     string
 	getInstanceParameterDefinitionsFunc string
+	preflightFunc                       string
 }
 
 type BucketFsUploadParams struct {
@@ -95,11 +101,27 @@ func (builder *TestExtensionBuilder) WithDeleteInstanceFunc(tsFunctionCode strin
 	return builder
 }
 
+func (builder *TestExtensionBuilder) WithUpdateInstanceFunc(tsFunctionCode string) *TestExtensionBuilder {
+	builder.updateInstanceFunc = tsFunctionCode
+	return builder
+}
+
+func (builder *TestExtensionBuilder) WithGetInstanceFunc(tsFunctionCode string) *TestExtensionBuilder {
+	builder.getInstanceFunc = tsFunctionCode
+	return builder
+}
+
 func (builder *TestExtensionBuilder) WithGetInstanceParameterDefinitionFunc(tsFunctionCode string) *TestExtensionBuilder {
 	builder.getInstanceParameterDefinitionsFunc = tsFunctionCode
 	return builder
 }
 
+// WithPreflightFunc adds the optional function "preflight" to the extension. By default the extension does not implement it.
+func (builder *TestExtensionBuilder) WithPreflightFunc(tsFunctionCode string) *TestExtensionBuilder {
+	builder.preflightFunc = tsFunctionCode
+	return builder
+}
+
 // MockFindInstallationsFunction creates a JS findInstallations function with extension name and version.
 func MockFindInstallationsFunction(extensionName, version string) string {
 	template := `return [{name: "$NAME$", version: "$VERSION$"}]`
@@ -162,10 +184,20 @@ func (builder *TestExtensionBuilder) createExtensionTsContent() string {
 	content = strings.Replace(content, "$ADD_INSTANCE$", builder.addInstanceFunc, 1)
 	content = strings.Replace(content, "$FIND_INSTANCES$", builder.findInstancesFunc, 1)
 	content = strings.Replace(content, "$DELETE_INSTANCE$", builder.deleteInstanceFunc, 1)
+	content = strings.Replace(content, "$UPDATE_INSTANCE$", builder.updateInstanceFunc, 1)
+	content = strings.Replace(content, "$GET_INSTANCE$", builder.getInstanceFunc, 1)
 	content = strings.Replace(content, "$GET_INSTANCE_PARAMETER_DEFINITIONS$", builder.getInstanceParameterDefinitionsFunc, 1)
+	content = strings.Replace(content, "$PREFLIGHT$", builder.getPreflightFunction(), 1)
 	return content
 }
 
+func (builder *TestExtensionBuilder) getPreflightFunction() string {
+	if builder.preflightFunc == "" {
+		return ""
+	}
+	return fmt.Sprintf("preflight(context, version) {\n            %s\n        },", builder.preflightFunc)
+}
+
 func (builder *TestExtensionBuilder) getBucketFsUpload() string {
 	if builder.rawBucketFsUploads != "" {
 		return builder.rawBucketFsUploads
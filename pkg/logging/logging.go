@@ -0,0 +1,83 @@
+package logging
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Formats for log messages supported by [NewFormatter].
+const (
+	FormatText = "text" // One line per message with level and message, for reading logs in a terminal
+	FormatJSON = "json" // One JSON object per line with timestamp, level, message and all fields, for log pipelines
+)
+
+// Names of the fields added to log messages. The JSON format writes them as top level keys.
+const (
+	FieldTimestamp        = "timestamp"
+	FieldLevel            = "level"
+	FieldMessage          = "message"
+	FieldRequestID        = "requestId"
+	FieldTraceID          = "traceId"
+	FieldOperation        = "operation"
+	FieldExtensionID      = "extensionId"
+	FieldExtensionVersion = "extensionVersion"
+	FieldInstanceID       = "instanceId"
+	FieldJobID            = "jobId"
+	FieldMethod           = "method"
+	FieldPath             = "path"
+	FieldStatus           = "status"
+	FieldBytes            = "bytes"
+	FieldDurationMs       = "durationMs"
+	FieldRemoteAddress    = "remoteAddress"
+)
+
+// NewFormatter creates a formatter for the given format, one of [FormatText] or [FormatJSON].
+// The text formatter omits fields, the JSON formatter redacts credentials and parameter values in fields, see [RedactFields].
+func NewFormatter(format string) (log.Formatter, error) {
+	switch format {
+	case FormatText:
+		return &textFormatter{}, nil
+	case FormatJSON:
+		return newJSONFormatter(), nil
+	default:
+		return nil, fmt.Errorf("unsupported log format %q, use %q or %q", format, FormatText, FormatJSON)
+	}
+}
+
+// textFormatter writes only level and message, the fields are omitted to keep the output readable.
+type textFormatter struct {
+}
+
+func (f *textFormatter) Format(entry *log.Entry) ([]byte, error) {
+	return []byte(fmt.Sprintf("%-7s %s\n", strings.ToUpper(entry.Level.String()), entry.Message)), nil
+}
+
+// jsonFormatter writes each message as a single JSON object. Fields with credentials are redacted before formatting.
+type jsonFormatter struct {
+	delegate *log.JSONFormatter
+}
+
+func newJSONFormatter() *jsonFormatter {
+	//nolint:exhaustruct // Default values are ok for other fields
+	return &jsonFormatter{delegate: &log.JSONFormatter{
+		TimestampFormat:   time.RFC3339Nano,
+		DisableHTMLEscape: true,
+		FieldMap: log.FieldMap{
+			log.FieldKeyTime:  FieldTimestamp,
+			log.FieldKeyLevel: FieldLevel,
+			log.FieldKeyMsg:   FieldMessage,
+		},
+	}}
+}
+
+func (f *jsonFormatter) Format(entry *log.Entry) ([]byte, error) {
+	redacted := entry.Dup()
+	redacted.Level = entry.Level
+	redacted.Message = entry.Message
+	redacted.Caller = entry.Caller
+	redacted.Data = RedactFields(entry.Data)
+	return f.delegate.Format(redacted)
+}
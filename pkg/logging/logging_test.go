@@ -0,0 +1,91 @@
+package logging
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/exasol/exasol-driver-go"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/suite"
+)
+
+type LoggingSuite struct {
+	suite.Suite
+}
+
+func TestLoggingSuite(t *testing.T) {
+	suite.Run(t, new(LoggingSuite))
+}
+
+func (suite *LoggingSuite) TestNewFormatterUnsupportedFormat() {
+	formatter, err := NewFormatter("xml")
+	suite.EqualError(err, `unsupported log format "xml", use "text" or "json"`)
+	suite.Nil(formatter)
+}
+
+func (suite *LoggingSuite) TestTextFormat() {
+	output := suite.format(FormatText, createEntry(log.WarnLevel, "message", log.Fields{FieldRequestID: "req"}))
+	suite.Equal("WARNING message\n", output)
+}
+
+func (suite *LoggingSuite) TestJSONFormat() {
+	entry := createEntry(log.InfoLevel, "Request finished", log.Fields{FieldRequestID: "req", FieldExtensionID: "ext-id", FieldOperation: "InstallExtension", FieldDurationMs: 42})
+	suite.JSONEq(`{"timestamp":"2024-01-02T03:04:05.123456789Z","level":"info","message":"Request finished",
+		"requestId":"req","extensionId":"ext-id","operation":"InstallExtension","durationMs":42}`, suite.format(FormatJSON, entry))
+}
+
+func (suite *LoggingSuite) TestJSONFormatWritesSingleLine() {
+	output := suite.format(FormatJSON, createEntry(log.InfoLevel, "multi\nline", log.Fields{}))
+	suite.Regexp(`^\{[^\n]*\}\n$`, output)
+}
+
+func (suite *LoggingSuite) TestJSONFormatWithError() {
+	output := suite.format(FormatJSON, createEntry(log.ErrorLevel, "failed", log.Fields{log.ErrorKey: errors.New("cause")}))
+	suite.Equal("cause", suite.decode(output)["error"])
+}
+
+func (suite *LoggingSuite) TestJSONFormatRedactsCredentials() {
+	output := suite.format(FormatJSON, createEntry(log.InfoLevel, "message", log.Fields{"password": "secret", "accessToken": "token"}))
+	fields := suite.decode(output)
+	suite.Equal("******", fields["password"])
+	suite.Equal("******", fields["accessToken"])
+}
+
+func (suite *LoggingSuite) TestJSONFormatRedactsDatabaseConfig() {
+	config := exasol.NewConfig("user", "secret").Host("exasol").Port(8563)
+	output := suite.format(FormatJSON, createEntry(log.InfoLevel, "message", log.Fields{"db": config.Config}))
+	suite.NotContains(output, "secret")
+	db := suite.decode(output)["db"].(map[string]any)
+	suite.Equal("******", db["Password"])
+	suite.Equal("user", db["User"])
+	suite.Equal("exasol", db["Host"])
+}
+
+func (suite *LoggingSuite) TestJSONFormatDoesNotModifyEntry() {
+	fields := log.Fields{"password": "secret"}
+	suite.format(FormatJSON, createEntry(log.InfoLevel, "message", fields))
+	suite.Equal("secret", fields["password"])
+}
+
+func (suite *LoggingSuite) format(format string, entry *log.Entry) string {
+	formatter, err := NewFormatter(format)
+	suite.Require().NoError(err)
+	output, err := formatter.Format(entry)
+	suite.Require().NoError(err)
+	return string(output)
+}
+
+func (suite *LoggingSuite) decode(output string) map[string]any {
+	var fields map[string]any
+	suite.Require().NoError(json.Unmarshal([]byte(output), &fields))
+	return fields
+}
+
+func createEntry(level log.Level, message string, fields log.Fields) *log.Entry {
+	entry := log.NewEntry(log.New()).WithFields(fields).WithTime(time.Date(2024, 1, 2, 3, 4, 5, 123456789, time.UTC))
+	entry.Level = level
+	entry.Message = message
+	return entry
+}
@@ -0,0 +1,125 @@
+package logging
+
+import (
+	"encoding/json"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/exasol/extension-manager/pkg/secrets"
+	log "github.com/sirupsen/logrus"
+)
+
+// sensitiveKeyParts are parts of field names, JSON keys and query parameters that contain credentials.
+// Keys are compared in lower case without "_" and "-", so "access_token" and "AccessToken" both match "token".
+var sensitiveKeyParts = []string{"password", "secret", "token", "authorization", "credential", "apikey"}
+
+// parameterKeys are keys that contain parameter values of extension instances. Parameter values may contain credentials,
+// e.g. the password of a connection, so they are redacted as a whole.
+var parameterKeys = []string{"parameters", "parametervalues"}
+
+// IsSensitiveKey returns true if values with the given field name, JSON key or query parameter must not be logged.
+func IsSensitiveKey(key string) bool {
+	normalized := strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(key))
+	for _, part := range sensitiveKeyParts {
+		if strings.Contains(normalized, part) {
+			return true
+		}
+	}
+	for _, parameterKey := range parameterKeys {
+		if normalized == parameterKey {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactFields returns a copy of the given fields with values of sensitive keys replaced by [secrets.MASKED_VALUE].
+// Values of other keys that are structs, maps or slices are redacted recursively using their JSON representation,
+// e.g. a database configuration with a password.
+func RedactFields(fields log.Fields) log.Fields {
+	redacted := make(log.Fields, len(fields))
+	for key, value := range fields {
+		if IsSensitiveKey(key) {
+			redacted[key] = secrets.MASKED_VALUE
+		} else {
+			redacted[key] = redactValue(value)
+		}
+	}
+	return redacted
+}
+
+func redactValue(value any) any {
+	if value == nil {
+		return nil
+	}
+	if _, isError := value.(error); isError {
+		return value
+	}
+	switch reflect.Indirect(reflect.ValueOf(value)).Kind() {
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array:
+		data, err := json.Marshal(value)
+		if err != nil {
+			return value
+		}
+		var decoded any
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			return value
+		}
+		return redactJSONValue(decoded)
+	default:
+		return value
+	}
+}
+
+// RedactJSON returns the given JSON document with values of sensitive keys replaced by [secrets.MASKED_VALUE].
+// If the data is not valid JSON, RedactJSON returns a placeholder instead of the data.
+func RedactJSON(data []byte) []byte {
+	var decoded any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return []byte(`"<invalid JSON>"`)
+	}
+	redacted, err := json.MarshalIndent(redactJSONValue(decoded), "", "    ")
+	if err != nil {
+		return []byte(`"<invalid JSON>"`)
+	}
+	return redacted
+}
+
+func redactJSONValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, element := range v {
+			if IsSensitiveKey(key) {
+				v[key] = secrets.MASKED_VALUE
+			} else {
+				v[key] = redactJSONValue(element)
+			}
+		}
+		return v
+	case []any:
+		for i, element := range v {
+			v[i] = redactJSONValue(element)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// RedactURI returns the given request URI with values of sensitive query parameters replaced by [secrets.MASKED_VALUE].
+func RedactURI(requestURI string) string {
+	path, rawQuery, found := strings.Cut(requestURI, "?")
+	if !found {
+		return requestURI
+	}
+	parameters := strings.Split(rawQuery, "&")
+	for i, parameter := range parameters {
+		rawKey, _, _ := strings.Cut(parameter, "=")
+		key, err := url.QueryUnescape(rawKey)
+		if err != nil || IsSensitiveKey(key) {
+			parameters[i] = rawKey + "=" + secrets.MASKED_VALUE
+		}
+	}
+	return path + "?" + strings.Join(parameters, "&")
+}
@@ -0,0 +1,85 @@
+package logging
+
+import (
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/suite"
+)
+
+type RedactSuite struct {
+	suite.Suite
+}
+
+func TestRedactSuite(t *testing.T) {
+	suite.Run(t, new(RedactSuite))
+}
+
+func (suite *RedactSuite) TestIsSensitiveKey() {
+	var tests = []struct {
+		key      string
+		expected bool
+	}{
+		{"password", true},
+		{"Password", true},
+		{"dbPassword", true},
+		{"accessToken", true},
+		{"refresh_token", true},
+		{"Authorization", true},
+		{"clientSecret", true},
+		{"credentials", true},
+		{"api-key", true},
+		{"parameterValues", true},
+		{"parameters", true},
+		{"requestId", false},
+		{"extensionId", false},
+		{"dbHost", false},
+		{"parameterName", false},
+		{"value", false},
+	}
+	for _, test := range tests {
+		suite.Run(test.key, func() {
+			suite.Equal(test.expected, IsSensitiveKey(test.key))
+		})
+	}
+}
+
+func (suite *RedactSuite) TestRedactFields() {
+	suite.Equal(log.Fields{"requestId": "req", "password": "******", "durationMs": int64(5), "user": map[string]any{"name": "sys", "password": "******"}},
+		RedactFields(log.Fields{"requestId": "req", "password": "secret", "durationMs": int64(5), "user": map[string]string{"name": "sys", "password": "secret"}}))
+}
+
+func (suite *RedactSuite) TestRedactFieldsWithSlice() {
+	suite.Equal(log.Fields{"values": []any{map[string]any{"token": "******"}}},
+		RedactFields(log.Fields{"values": []map[string]string{{"token": "secret"}}}))
+}
+
+func (suite *RedactSuite) TestRedactJSON() {
+	suite.JSONEq(`{"id":"inst","parameters":"******","nested":{"password":"******","host":"exasol"}}`,
+		string(RedactJSON([]byte(`{"id":"inst","parameters":[{"name":"pwd","value":"secret"}],"nested":{"password":"secret","host":"exasol"}}`))))
+}
+
+func (suite *RedactSuite) TestRedactJSONWithInvalidJSON() {
+	suite.Equal(`"<invalid JSON>"`, string(RedactJSON([]byte(`{"password":"secret"`))))
+}
+
+func (suite *RedactSuite) TestRedactURI() {
+	var tests = []struct {
+		name     string
+		uri      string
+		expected string
+	}{
+		{"no query", "/api/v1/extensions", "/api/v1/extensions"},
+		{"no sensitive parameters", "/api/v1/extensions?dbHost=exasol&dbPort=8563", "/api/v1/extensions?dbHost=exasol&dbPort=8563"},
+		{"parameter values", "/api/v1/extensions/ext/1.0.0?dbHost=exasol&parameterValues=%5B%7B%22name%22%3A%22pwd%22%7D%5D",
+			"/api/v1/extensions/ext/1.0.0?dbHost=exasol&parameterValues=******"},
+		{"token", "/path?access_token=secret&async=true", "/path?access_token=******&async=true"},
+		{"key without value", "/path?password", "/path?password=******"},
+		{"invalid escaping", "/path?%zz=secret", "/path?%zz=******"},
+	}
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			suite.Equal(test.expected, RedactURI(test.uri))
+		})
+	}
+}
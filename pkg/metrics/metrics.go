@@ -0,0 +1,120 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Namespace is the prefix of all metrics of the extension manager.
+const Namespace = "extension_manager"
+
+// Results of database transactions counted by [CountTransaction].
+const (
+	TransactionCommitted    = "committed"
+	TransactionRolledBack   = "rolled_back"
+	TransactionCommitFailed = "commit_failed"
+)
+
+// Registry contains all metrics of the extension manager including Go runtime and process metrics.
+// Applications embedding EM can register it with their own metrics endpoint, e.g. using [prometheus.Gatherers].
+var Registry = prometheus.NewRegistry()
+
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace, Subsystem: "http", Name: "requests_total",
+		Help: "Number of HTTP requests by method, route and response status.",
+	}, []string{"method", "route", "status"})
+
+	httpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace, Subsystem: "http", Name: "request_duration_seconds",
+		Help:    "Duration of HTTP requests by method, route and response status.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	registryFetchDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace, Subsystem: "registry", Name: "fetch_duration_seconds",
+		Help:    "Duration of reading the extension registry by registry type (http or local) and operation.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"registry", "operation"})
+
+	registryFetchErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace, Subsystem: "registry", Name: "fetch_errors_total",
+		Help: "Number of failed reads of the extension registry by registry type (http or local) and operation.",
+	}, []string{"registry", "operation"})
+
+	jsExecutionDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace, Subsystem: "extension", Name: "js_execution_duration_seconds",
+		Help:    "Duration of calls to JavaScript functions of extension definitions by extension ID and function.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"extension", "function"})
+
+	bucketFsListingDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace, Subsystem: "bucketfs", Name: "listing_duration_seconds",
+		Help:    "Duration of listing files in a BucketFS base path by result (success or error).",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"result"})
+
+	transactions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace, Subsystem: "database", Name: "transactions_total",
+		Help: "Number of finished database transactions by result (committed, rolled_back or commit_failed).",
+	}, []string{"result"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}), //nolint:exhaustruct // Default options are OK
+		httpRequests, httpRequestDuration,
+		registryFetchDuration, registryFetchErrors,
+		jsExecutionDuration,
+		bucketFsListingDuration,
+		transactions,
+	)
+}
+
+// Handler returns an HTTP handler serving all metrics of [Registry] and the given additional gatherers in the Prometheus text format.
+func Handler(additionalGatherers ...prometheus.Gatherer) http.Handler {
+	gatherers := append(prometheus.Gatherers{Registry}, additionalGatherers...)
+	//nolint:exhaustruct // Default options are OK
+	return promhttp.HandlerFor(gatherers, promhttp.HandlerOpts{})
+}
+
+// ObserveHTTPRequest records a finished HTTP request. Argument route is the route pattern, e.g. "/api/v1/extensionmanager/extensions/{extensionId}".
+func ObserveHTTPRequest(method, route string, status int, duration time.Duration) {
+	statusLabel := strconv.Itoa(status)
+	httpRequests.WithLabelValues(method, route, statusLabel).Inc()
+	httpRequestDuration.WithLabelValues(method, route, statusLabel).Observe(duration.Seconds())
+}
+
+// ObserveRegistryFetch records reading the extension registry that started at the given time and failed if err is not nil.
+func ObserveRegistryFetch(registry, operation string, start time.Time, err error) {
+	registryFetchDuration.WithLabelValues(registry, operation).Observe(time.Since(start).Seconds())
+	if err != nil {
+		registryFetchErrors.WithLabelValues(registry, operation).Inc()
+	}
+}
+
+// ObserveJsExecution records a call to a JavaScript function of an extension definition that started at the given time.
+func ObserveJsExecution(extensionId, function string, start time.Time) {
+	jsExecutionDuration.WithLabelValues(extensionId, function).Observe(time.Since(start).Seconds())
+}
+
+// ObserveBucketFsListing records listing files in a BucketFS base path that started at the given time and failed if err is not nil.
+func ObserveBucketFsListing(start time.Time, err error) {
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	bucketFsListingDuration.WithLabelValues(result).Observe(time.Since(start).Seconds())
+}
+
+// CountTransaction counts a finished database transaction. Argument result is one of
+// [TransactionCommitted], [TransactionRolledBack] or [TransactionCommitFailed].
+func CountTransaction(result string) {
+	transactions.WithLabelValues(result).Inc()
+}
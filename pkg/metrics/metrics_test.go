@@ -0,0 +1,77 @@
+package metrics
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/suite"
+)
+
+type MetricsSuite struct {
+	suite.Suite
+}
+
+func TestMetricsSuite(t *testing.T) {
+	suite.Run(t, new(MetricsSuite))
+}
+
+func (suite *MetricsSuite) TestObserveHTTPRequest() {
+	before := testutil.ToFloat64(httpRequests.WithLabelValues("GET", "/route", "200"))
+	ObserveHTTPRequest("GET", "/route", 200, time.Second)
+	suite.InDelta(before+1, testutil.ToFloat64(httpRequests.WithLabelValues("GET", "/route", "200")), 0)
+	suite.Positive(testutil.CollectAndCount(httpRequestDuration))
+}
+
+func (suite *MetricsSuite) TestObserveRegistryFetch() {
+	errorsBefore := testutil.ToFloat64(registryFetchErrors.WithLabelValues("http", "read_extension"))
+	ObserveRegistryFetch("http", "read_extension", time.Now(), nil)
+	suite.InDelta(errorsBefore, testutil.ToFloat64(registryFetchErrors.WithLabelValues("http", "read_extension")), 0)
+	ObserveRegistryFetch("http", "read_extension", time.Now(), errors.New("mock error"))
+	suite.InDelta(errorsBefore+1, testutil.ToFloat64(registryFetchErrors.WithLabelValues("http", "read_extension")), 0)
+}
+
+func (suite *MetricsSuite) TestObserveJsExecution() {
+	ObserveJsExecution("ext-id", "install", time.Now())
+	suite.Contains(suite.getMetrics(), `extension_manager_extension_js_execution_duration_seconds_count{extension="ext-id",function="install"}`)
+}
+
+func (suite *MetricsSuite) TestObserveBucketFsListing() {
+	ObserveBucketFsListing(time.Now(), nil)
+	ObserveBucketFsListing(time.Now(), errors.New("mock error"))
+	metrics := suite.getMetrics()
+	suite.Contains(metrics, `extension_manager_bucketfs_listing_duration_seconds_count{result="success"}`)
+	suite.Contains(metrics, `extension_manager_bucketfs_listing_duration_seconds_count{result="error"}`)
+}
+
+func (suite *MetricsSuite) TestCountTransaction() {
+	before := testutil.ToFloat64(transactions.WithLabelValues(TransactionCommitted))
+	CountTransaction(TransactionCommitted)
+	suite.InDelta(before+1, testutil.ToFloat64(transactions.WithLabelValues(TransactionCommitted)), 0)
+}
+
+func (suite *MetricsSuite) TestHandlerContainsRuntimeMetrics() {
+	suite.Contains(suite.getMetrics(), "go_goroutines ")
+}
+
+func (suite *MetricsSuite) TestHandlerContainsAdditionalMetrics() {
+	additionalMetrics := prometheus.NewRegistry()
+	counter := prometheus.NewCounter(prometheus.CounterOpts{Name: "additional_total", Help: "Additional counter."}) //nolint:exhaustruct // Default options are OK
+	additionalMetrics.MustRegister(counter)
+	counter.Add(3)
+	suite.Contains(suite.getMetrics(additionalMetrics), "additional_total 3")
+}
+
+func (suite *MetricsSuite) getMetrics(additionalGatherers ...prometheus.Gatherer) string {
+	recorder := httptest.NewRecorder()
+	Handler(additionalGatherers...).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	suite.Require().Equal(http.StatusOK, recorder.Code)
+	body, err := io.ReadAll(recorder.Body)
+	suite.Require().NoError(err)
+	return string(body)
+}
@@ -0,0 +1,68 @@
+package parameterValidator
+
+import (
+	"github.com/exasol/extension-manager/pkg/extensionAPI"
+)
+
+// CoerceValues converts the parameter values to the native type of their parameter definition:
+//   - boolean: bool
+//   - string and select: string
+//   - select with multiple values: list of strings
+//
+// Empty values and values of parameters with other types or without definition are not modified.
+// Call this only for valid parameter values, see [Validator.ValidateParameters].
+func CoerceValues(definitions []ParameterDefinition, params extensionAPI.ParameterValues) extensionAPI.ParameterValues {
+	values := make([]extensionAPI.ParameterValue, 0, len(params.Values))
+	for _, param := range params.Values {
+		if def, found := findDefinition(definitions, param.Name); found {
+			param = extensionAPI.ParameterValue{Name: param.Name, Value: coerceValue(def, param)}
+		}
+		values = append(values, param)
+	}
+	return extensionAPI.ParameterValues{Values: values}
+}
+
+func coerceValue(def ParameterDefinition, param extensionAPI.ParameterValue) interface{} {
+	if isMultiSelect(def) {
+		return coerceList(param.Value)
+	}
+	if param.StringValue() == "" {
+		return param.Value
+	}
+	switch def.RawDefinition["type"] {
+	case "boolean":
+		if value, ok := param.Value.(bool); ok {
+			return value
+		}
+		return param.StringValue() == "true"
+	case "string", "select":
+		return param.StringValue()
+	default:
+		return param.Value
+	}
+}
+
+func coerceList(value interface{}) []interface{} {
+	list, isList := value.([]interface{})
+	if !isList {
+		if extensionAPI.FormatValue(value) == "" {
+			return []interface{}{}
+		}
+		list = []interface{}{value}
+	}
+	result := make([]interface{}, 0, len(list))
+	for _, entry := range list {
+		result = append(result, extensionAPI.FormatValue(entry))
+	}
+	return result
+}
+
+func findDefinition(definitions []ParameterDefinition, id string) (ParameterDefinition, bool) {
+	for _, def := range definitions {
+		if def.Id == id {
+			return def, true
+		}
+	}
+	//nolint:exhaustruct // Empty definition is not used
+	return ParameterDefinition{}, false
+}
@@ -0,0 +1,181 @@
+package parameterValidator
+
+import (
+	"cmp"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/exasol/extension-manager/pkg/extensionAPI"
+)
+
+// Operators supported in comparisons of conditional parameters.
+const (
+	OPERATOR_EQ            = "=="
+	OPERATOR_LESS          = "<"
+	OPERATOR_GREATER       = ">"
+	OPERATOR_LESS_EQUAL    = "<="
+	OPERATOR_GREATER_EQUAL = ">="
+)
+
+// operators maps each operator to a function checking the result of compareValues.
+var operators = map[string]func(result int) bool{
+	OPERATOR_EQ:            func(result int) bool { return result == 0 },
+	OPERATOR_LESS:          func(result int) bool { return result < 0 },
+	OPERATOR_GREATER:       func(result int) bool { return result > 0 },
+	OPERATOR_LESS_EQUAL:    func(result int) bool { return result <= 0 },
+	OPERATOR_GREATER_EQUAL: func(result int) bool { return result >= 0 },
+}
+
+// ActiveDefinitions returns the definitions of all parameters that are active for the given (possibly incomplete) parameter values,
+// i.e. parameters without condition or with a fulfilled condition.
+// Values of inactive parameters are ignored when evaluating the conditions of other parameters.
+func ActiveDefinitions(definitions []ParameterDefinition, params extensionAPI.ParameterValues) ([]ParameterDefinition, error) {
+	active := definitions
+	// Each iteration removes the values of parameters that became inactive. Limit iterations in case of cyclic conditions.
+	for i := 0; i <= len(definitions); i++ {
+		next, err := filterActive(definitions, valuesOf(active, params))
+		if err != nil {
+			return nil, err
+		}
+		if sameDefinitions(active, next) {
+			break
+		}
+		active = next
+	}
+	return active, nil
+}
+
+func filterActive(definitions []ParameterDefinition, params extensionAPI.ParameterValues) ([]ParameterDefinition, error) {
+	result := make([]ParameterDefinition, 0, len(definitions))
+	for _, def := range definitions {
+		active, err := isActive(def, params)
+		if err != nil {
+			return nil, err
+		}
+		if active {
+			result = append(result, def)
+		}
+	}
+	return result, nil
+}
+
+// valuesOf returns the values of the given parameter definitions.
+func valuesOf(definitions []ParameterDefinition, params extensionAPI.ParameterValues) extensionAPI.ParameterValues {
+	values := make([]extensionAPI.ParameterValue, 0, len(params.Values))
+	for _, param := range params.Values {
+		if isDefined(definitions, param.Name) {
+			values = append(values, param)
+		}
+	}
+	return extensionAPI.ParameterValues{Values: values}
+}
+
+func sameDefinitions(a, b []ParameterDefinition) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Id != b[i].Id {
+			return false
+		}
+	}
+	return true
+}
+
+// isActive evaluates the optional condition of the parameter definition against the given parameter values.
+// Parameters without condition are always active.
+func isActive(def ParameterDefinition, params extensionAPI.ParameterValues) (bool, error) {
+	condition, ok := def.RawDefinition["condition"]
+	if !ok || condition == nil {
+		return true, nil
+	}
+	active, err := evaluateCondition(condition, params)
+	if err != nil {
+		return false, fmt.Errorf("failed to evaluate condition of parameter %q: %w", def.Id, err)
+	}
+	return active, nil
+}
+
+// evaluateCondition evaluates a condition. A condition is either a comparison
+// {parameter, operator, value} or a combination of conditions {and: [...]}, {or: [...]} or {not: condition}.
+// A comparison referring to a parameter without value is not fulfilled.
+/* [impl -> dsn~conditional-parameters~1]. */
+func evaluateCondition(condition interface{}, params extensionAPI.ParameterValues) (bool, error) {
+	c, ok := condition.(map[string]interface{})
+	if !ok {
+		return false, fmt.Errorf("unexpected type %T of condition %v, expected object", condition, condition)
+	}
+	if operands, ok := c["and"]; ok {
+		return evaluateAll(operands, params, true)
+	}
+	if operands, ok := c["or"]; ok {
+		return evaluateAll(operands, params, false)
+	}
+	if operand, ok := c["not"]; ok {
+		result, err := evaluateCondition(operand, params)
+		return !result, err
+	}
+	return evaluateComparison(c, params)
+}
+
+// evaluateAll evaluates a list of conditions combined with "and" (all must be true) or "or" (at least one must be true).
+func evaluateAll(operands interface{}, params extensionAPI.ParameterValues, and bool) (bool, error) {
+	list, ok := operands.([]interface{})
+	if !ok {
+		return false, fmt.Errorf("unexpected type %T of operands %v, expected array", operands, operands)
+	}
+	for _, operand := range list {
+		result, err := evaluateCondition(operand, params)
+		if err != nil {
+			return false, err
+		}
+		if result != and {
+			return result, nil
+		}
+	}
+	return and, nil
+}
+
+func evaluateComparison(comparison map[string]interface{}, params extensionAPI.ParameterValues) (bool, error) {
+	parameterId, ok := comparison["parameter"].(string)
+	if !ok {
+		return false, fmt.Errorf("missing parameter in condition %v", comparison)
+	}
+	operator, ok := comparison["operator"].(string)
+	if !ok {
+		return false, fmt.Errorf("missing operator in condition %v", comparison)
+	}
+	matches, ok := operators[operator]
+	if !ok {
+		return false, fmt.Errorf("unsupported operator %q in condition %v", operator, comparison)
+	}
+	actual, found := params.Find(parameterId)
+	if !found {
+		return false, nil
+	}
+	result, comparable := compareValues(actual.StringValue(), comparison["value"])
+	return comparable && matches(result), nil
+}
+
+// compareValues compares the actual string value of a parameter with the expected value of a condition.
+// Numbers are compared numerically, all other values as strings.
+// If the actual value can't be converted to a number, the values are not comparable.
+func compareValues(actual string, expected interface{}) (result int, comparable bool) {
+	var expectedNumber float64
+	switch e := expected.(type) {
+	case float64:
+		expectedNumber = e
+	case int64:
+		expectedNumber = float64(e)
+	case int:
+		expectedNumber = float64(e)
+	default:
+		return strings.Compare(actual, jsString(expected)), true
+	}
+	actualNumber, err := strconv.ParseFloat(actual, 64)
+	if err != nil {
+		return 0, false
+	}
+	return cmp.Compare(actualNumber, expectedNumber), true
+}
@@ -0,0 +1,161 @@
+package parameterValidator
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/exasol/extension-manager/pkg/extensionAPI"
+	"github.com/stretchr/testify/suite"
+)
+
+// ConditionSuite verifies the grammar of conditions as specified in design requirement dsn~conditional-parameters~1.
+// Conditions are parsed from JSON, so they have the same types as in the parameter definitions of an extension.
+type ConditionSuite struct {
+	suite.Suite
+}
+
+func TestConditionSuite(t *testing.T) {
+	suite.Run(t, new(ConditionSuite))
+}
+
+/* [utest -> dsn~conditional-parameters~1]. */
+func (suite *ConditionSuite) TestComparisonOperators() {
+	var tests = []struct {
+		condition string
+		value     interface{}
+		expected  bool
+	}{
+		{`{"parameter": "p", "operator": "==", "value": "a"}`, "a", true},
+		{`{"parameter": "p", "operator": "==", "value": "a"}`, "b", false},
+		{`{"parameter": "p", "operator": "<", "value": "b"}`, "a", true},
+		{`{"parameter": "p", "operator": "<", "value": "b"}`, "b", false},
+		{`{"parameter": "p", "operator": ">", "value": "a"}`, "b", true},
+		{`{"parameter": "p", "operator": ">", "value": "a"}`, "a", false},
+		{`{"parameter": "p", "operator": "<=", "value": "b"}`, "b", true},
+		{`{"parameter": "p", "operator": "<=", "value": "b"}`, "c", false},
+		{`{"parameter": "p", "operator": ">=", "value": "b"}`, "b", true},
+		{`{"parameter": "p", "operator": ">=", "value": "b"}`, "a", false},
+	}
+	for _, test := range tests {
+		suite.Run(test.condition+" with value "+extensionAPI.FormatValue(test.value), func() {
+			suite.Equal(test.expected, suite.evaluate(test.condition, test.value))
+		})
+	}
+}
+
+/* [utest -> dsn~conditional-parameters~1]. */
+func (suite *ConditionSuite) TestNumericValuesComparedNumerically() {
+	var tests = []struct {
+		name      string
+		condition string
+		value     interface{}
+		expected  bool
+	}{
+		{"string value greater", `{"parameter": "p", "operator": ">", "value": 9}`, "10", true},
+		{"number value greater", `{"parameter": "p", "operator": ">", "value": 9}`, float64(10), true},
+		{"number value less", `{"parameter": "p", "operator": "<", "value": 10}`, float64(9), true},
+		{"equal with different format", `{"parameter": "p", "operator": "==", "value": 1.5}`, "1.50", true},
+		{"equal", `{"parameter": "p", "operator": "==", "value": 8563}`, float64(8563), true},
+		{"not a number", `{"parameter": "p", "operator": "<", "value": 10}`, "abc", false},
+		{"not a number not greater", `{"parameter": "p", "operator": ">=", "value": 10}`, "abc", false},
+	}
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			suite.Equal(test.expected, suite.evaluate(test.condition, test.value))
+		})
+	}
+}
+
+/* [utest -> dsn~conditional-parameters~1]. */
+func (suite *ConditionSuite) TestOtherValuesComparedAsStrings() {
+	var tests = []struct {
+		name      string
+		condition string
+		value     interface{}
+		expected  bool
+	}{
+		{"lexicographic order", `{"parameter": "p", "operator": "<", "value": "9"}`, "10", true},
+		{"case sensitive", `{"parameter": "p", "operator": "==", "value": "JDBC"}`, "jdbc", false},
+		{"boolean", `{"parameter": "p", "operator": "==", "value": true}`, true, true},
+		{"boolean as string", `{"parameter": "p", "operator": "==", "value": true}`, "true", true},
+		{"boolean not equal", `{"parameter": "p", "operator": "==", "value": true}`, false, false},
+		{"empty string", `{"parameter": "p", "operator": "==", "value": ""}`, "", true},
+	}
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			suite.Equal(test.expected, suite.evaluate(test.condition, test.value))
+		})
+	}
+}
+
+/* [utest -> dsn~conditional-parameters~1]. */
+func (suite *ConditionSuite) TestCombinations() {
+	var tests = []struct {
+		name      string
+		condition string
+		expected  bool
+	}{
+		{"and, all fulfilled", `{"and": [{"parameter": "a", "operator": "==", "value": "x"}, {"parameter": "b", "operator": "==", "value": "y"}]}`, true},
+		{"and, one not fulfilled", `{"and": [{"parameter": "a", "operator": "==", "value": "x"}, {"parameter": "b", "operator": "==", "value": "x"}]}`, false},
+		{"and, empty", `{"and": []}`, true},
+		{"or, one fulfilled", `{"or": [{"parameter": "a", "operator": "==", "value": "y"}, {"parameter": "b", "operator": "==", "value": "y"}]}`, true},
+		{"or, none fulfilled", `{"or": [{"parameter": "a", "operator": "==", "value": "y"}, {"parameter": "b", "operator": "==", "value": "x"}]}`, false},
+		{"or, empty", `{"or": []}`, false},
+		{"not, fulfilled", `{"not": {"parameter": "a", "operator": "==", "value": "y"}}`, true},
+		{"not, not fulfilled", `{"not": {"parameter": "a", "operator": "==", "value": "x"}}`, false},
+		{"nested", `{"or": [{"not": {"parameter": "a", "operator": "==", "value": "x"}}, {"and": [{"parameter": "b", "operator": "==", "value": "y"}]}]}`, true},
+	}
+	params := extensionAPI.ParameterValues{Values: []extensionAPI.ParameterValue{{Name: "a", Value: "x"}, {Name: "b", Value: "y"}}}
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			result, err := evaluateCondition(suite.parse(test.condition), params)
+			suite.Require().NoError(err)
+			suite.Equal(test.expected, result)
+		})
+	}
+}
+
+/* [utest -> dsn~conditional-parameters~1]. */
+func (suite *ConditionSuite) TestComparisonWithParameterWithoutValueNotFulfilled() {
+	for _, operator := range []string{"==", "<", ">", "<=", ">="} {
+		suite.Run(operator, func() {
+			condition := suite.parse(`{"parameter": "missing", "operator": "` + operator + `", "value": ""}`)
+			result, err := evaluateCondition(condition, extensionAPI.ParameterValues{Values: []extensionAPI.ParameterValue{{Name: "p", Value: ""}}})
+			suite.Require().NoError(err)
+			suite.False(result)
+		})
+	}
+}
+
+/* [utest -> dsn~conditional-parameters~1]. */
+func (suite *ConditionSuite) TestComparisonWithInactiveParameterNotFulfilled() {
+	definitions, err := ConvertDefinitions(suite.parse(`[
+		{"id": "type", "name": "Type", "type": "string"},
+		{"id": "driver", "name": "Driver", "type": "string", "condition": {"parameter": "type", "operator": "==", "value": "jdbc"}},
+		{"id": "driverPath", "name": "Driver path", "type": "string", "condition": {"parameter": "driver", "operator": "==", "value": "custom"}}
+	]`).([]interface{}))
+	suite.Require().NoError(err)
+	active, err := ActiveDefinitions(definitions, extensionAPI.ParameterValues{Values: []extensionAPI.ParameterValue{{Name: "type", Value: "odbc"}, {Name: "driver", Value: "custom"}}})
+	suite.Require().NoError(err)
+	suite.Equal([]string{"type"}, definitionIds(active))
+}
+
+func (suite *ConditionSuite) evaluate(condition string, value interface{}) bool {
+	result, err := evaluateCondition(suite.parse(condition), extensionAPI.ParameterValues{Values: []extensionAPI.ParameterValue{{Name: "p", Value: value}}})
+	suite.Require().NoError(err)
+	return result
+}
+
+func (suite *ConditionSuite) parse(jsonText string) interface{} {
+	var result interface{}
+	suite.Require().NoError(json.Unmarshal([]byte(jsonText), &result))
+	return result
+}
+
+func definitionIds(definitions []ParameterDefinition) []string {
+	ids := make([]string, 0, len(definitions))
+	for _, def := range definitions {
+		ids = append(ids, def.Id)
+	}
+	return ids
+}
@@ -0,0 +1,132 @@
+package parameterValidator
+
+import (
+	_ "embed" // Required to embed the validator JS code
+	"fmt"
+	"testing"
+
+	"github.com/dop251/goja"
+	"github.com/dop251/goja_nodejs/console"
+	"github.com/dop251/goja_nodejs/require"
+	"github.com/stretchr/testify/suite"
+)
+
+// The bundled JavaScript library "@exasol/extension-parameter-validator" serves as reference for the Go implementation.
+//
+//go:generate npm ci --ignore-scripts
+//go:generate npm run build
+//go:embed parameterValidator.js
+var dependencyValidatorJs string
+
+type jsValidator struct {
+	validate func(definition interface{}, value string) ValidationResult
+}
+
+func newJsValidator() (*jsValidator, error) {
+	vm := goja.New()
+	vm.SetFieldNameMapper(goja.TagFieldNameMapper("json", true))
+	registry := new(require.Registry)
+	registry.Enable(vm)
+	console.Enable(vm)
+	globalJsObj := vm.NewObject()
+	err := vm.Set("global", globalJsObj)
+	if err != nil {
+		return nil, err
+	}
+	_, err = vm.RunString(dependencyValidatorJs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load validateParameter script. Cause: %w", err)
+	}
+	//nolint:exhaustruct // Omitting values by intention for deserialization
+	validator := jsValidator{}
+	err = vm.ExportTo(globalJsObj.Get("validateParameter"), &validator.validate)
+	if err != nil {
+		return nil, err
+	}
+	return &validator, nil
+}
+
+func (v *jsValidator) validateValue(definition map[string]interface{}, value string) (result ValidationResult, errorResult error) {
+	defer func() {
+		if err := recover(); err != nil {
+			errorResult = fmt.Errorf("%v", err)
+		}
+	}()
+	return v.validate(definition, value), nil
+}
+
+type JsConformanceSuite struct {
+	suite.Suite
+	jsValidator *jsValidator
+}
+
+func TestJsConformanceSuite(t *testing.T) {
+	suite.Run(t, new(JsConformanceSuite))
+}
+
+func (suite *JsConformanceSuite) SetupSuite() {
+	v, err := newJsValidator()
+	suite.Require().NoError(err)
+	suite.jsValidator = v
+}
+
+var conformanceDefinitions = []map[string]interface{}{
+	{"type": "string"},
+	{"type": "string", "required": true},
+	{"type": "string", "required": false},
+	{"type": "string", "required": 1},
+	{"type": "string", "required": 0},
+	{"type": "string", "required": "yes"},
+	{"type": "string", "required": ""},
+	{"type": "string", "regex": "^a+$"},
+	{"type": "string", "regex": "a+"},
+	{"type": "string", "regex": ""},
+	{"type": "string", "regex": `^\d+$`},
+	{"type": "string", "regex": "^(true|false)$", "required": true},
+	{"type": "string", "regex": "^[a-z]{2,3}$"},
+	{"type": "string", "regex": `^\w+\s\w+$`},
+	{"type": "string", "regex": "^a.*b?$"},
+	{"type": "string", "regex": "[[:alpha:]"},
+	{"type": "string", "regex": `^(?=.*\d).+$`},
+	{"type": "string", "regex": "^(?!a).*$"},
+	{"type": "string", "regex": `^(a)\1+$`},
+	{"type": "string", "regex": `^(\w)\w*\1$`},
+	{"type": "string", "regex": `(?<!a)b`},
+	{"type": "boolean"},
+	{"type": "boolean", "required": true},
+	{"type": "select", "options": []interface{}{map[string]interface{}{"id": "a", "name": "Value a"}, map[string]interface{}{"id": "b", "name": "Value b"}}},
+	{"type": "select", "required": true, "options": []interface{}{map[string]interface{}{"id": "a", "name": "Value a"}}},
+	{"type": "select", "options": []interface{}{map[string]string{"id": "a", "name": "Value a"}}},
+	{"type": "select", "options": []interface{}{}},
+	{"type": "select", "options": []interface{}{map[string]interface{}{"id": 1.0}, map[string]interface{}{"id": 2.5}}},
+	{"type": "select", "options": []interface{}{map[string]interface{}{"name": "no id"}}},
+	{"type": "select", "options": []interface{}{"a"}},
+	{"type": "select", "options": []interface{}{nil}},
+	{"type": "select", "options": "invalid"},
+	{"type": "select"},
+	{"type": "unknown"},
+	{"type": "unknown", "required": true},
+	{"type": 5},
+	{},
+}
+
+var conformanceValues = []string{"", " ", "a", "aaa", "ab", "b", "c", "1", "123", "true", "false", "TRUE", "hello world", "a\nb", "a\n", "a1", "aba", "foo"}
+
+/* [utest -> dsn~reuse-parameter-validation-rules~1] */
+/* [utest -> dsn~parameter-validation-rules-simple~1] */
+func (suite *JsConformanceSuite) TestGoImplementationReturnsSameResultAsJavaScript() {
+	for _, definition := range conformanceDefinitions {
+		for _, value := range conformanceValues {
+			suite.Run(fmt.Sprintf("definition %v, value %q", definition, value), func() {
+				expected, jsErr := suite.jsValidator.validateValue(definition, value)
+				actual, goErr := validateValue(definition, value)
+				if jsErr != nil {
+					suite.Errorf(goErr, "JavaScript failed with error %q but Go returned %v", jsErr, actual)
+					return
+				}
+				suite.Require().NoError(goErr, "JavaScript returned %v", expected)
+				suite.Equal(expected, actual)
+			})
+		}
+	}
+}
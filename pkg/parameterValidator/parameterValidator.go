@@ -1,23 +1,16 @@
 package parameterValidator
 
 import (
-	_ "embed" // Required to embed the validator JS code
 	"fmt"
 
-	"github.com/dop251/goja"
-	"github.com/dop251/goja_nodejs/console"
-	"github.com/dop251/goja_nodejs/require"
 	"github.com/exasol/extension-manager/pkg/extensionAPI"
+	"github.com/exasol/extension-manager/pkg/secrets"
 )
 
-//go:generate npm ci --ignore-scripts
-//go:generate npm run build
-//go:embed parameterValidator.js
-var dependencyValidatorJs string
-
 type ParameterDefinition struct {
 	Id            string
 	Name          string
+	Secret        bool // Secret values like passwords must not be logged or returned to the client.
 	RawDefinition map[string]interface{}
 }
 
@@ -42,7 +35,11 @@ func convertDefinition(rawDefinition map[string]interface{}) (ParameterDefinitio
 	if err != nil {
 		return ParameterDefinition{}, err
 	}
-	return ParameterDefinition{Id: id, Name: name, RawDefinition: rawDefinition}, nil
+	secret, err := extractOptionalBoolValue(rawDefinition, "secret")
+	if err != nil {
+		return ParameterDefinition{}, err
+	}
+	return ParameterDefinition{Id: id, Name: name, Secret: secret, RawDefinition: rawDefinition}, nil
 }
 
 func extractValues(def map[string]interface{}) (id, name string, err error) {
@@ -67,90 +64,190 @@ func extractStringValue(def map[string]interface{}, key string) (string, error)
 	return "", fmt.Errorf("unexpected type of key %q in parameter definition: %T, expected string", key, def[key])
 }
 
+func extractOptionalBoolValue(def map[string]interface{}, key string) (bool, error) {
+	if _, ok := def[key]; !ok {
+		return false, nil
+	}
+	if value, ok := def[key].(bool); ok {
+		return value, nil
+	}
+	return false, fmt.Errorf("unexpected type of key %q in parameter definition: %T, expected bool", key, def[key])
+}
+
+// SecretValues returns the values of all parameters that have a secret definition.
+func SecretValues(definitions []ParameterDefinition, params extensionAPI.ParameterValues) []string {
+	values := make([]string, 0)
+	for _, def := range definitions {
+		if !def.Secret {
+			continue
+		}
+		if param, found := params.Find(def.Id); found {
+			values = append(values, param.StringValue())
+			if list, isList := param.Value.([]interface{}); isList {
+				for _, entry := range list {
+					values = append(values, extensionAPI.FormatValue(entry))
+				}
+			}
+		}
+	}
+	return values
+}
+
 type ValidationResult struct {
 	Success bool   `json:"success"`
 	Message string `json:"message"`
 }
 
-type Validator struct {
-	validate func(definition interface{}, value string) ValidationResult
-}
+// Validator validates parameter values against parameter definitions.
+// It implements the same rules as the TypeScript library "@exasol/extension-parameter-validator" used by the frontend.
+type Validator struct{}
 
 // New creates a new reusable validator.
 /* [impl -> dsn~reuse-parameter-validation-rules~1] */
 /* [impl -> dsn~parameter-validation-rules-simple~1]. */
-func New() (*Validator, error) {
-	vm := newJavaScriptVm()
-	globalJsObj := vm.NewObject()
-	err := vm.Set("global", globalJsObj)
-	if err != nil {
-		return nil, err
-	}
-	_, err = vm.RunString(dependencyValidatorJs)
-	if err != nil {
-		return nil, fmt.Errorf("failed to load validateParameter script. Cause: %w", err)
-	}
-	function := globalJsObj.Get("validateParameter")
-
-	//nolint:exhaustruct // Omitting values by intention for deserialization
-	validator := Validator{}
-	err = vm.ExportTo(function, &validator.validate)
-	if err != nil {
-		return nil, err
-	}
-	return &validator, nil
+func New() *Validator {
+	return &Validator{}
 }
 
 // ValidateParameters validates parameter values against the parameter definition and returns a list of failed validations.
+// Parameters with an unfulfilled condition are not validated. Values for parameters without definition are rejected.
 // If all parameters are valid, this returns an empty slice.
 /* [impl -> dsn~validate-parameters~1] */
 /* [impl -> dsn~parameter-definitions~1]. */
 func (v *Validator) ValidateParameters(definitions []ParameterDefinition, params extensionAPI.ParameterValues) (failedValidations []ValidationResult, err error) {
+	results, err := v.ValidateEach(definitions, params)
+	if err != nil {
+		return nil, err
+	}
 	result := make([]ValidationResult, 0)
-	for _, def := range definitions {
+	for _, r := range results {
+		if !r.Success {
+			result = append(result, ValidationResult{Success: false, Message: r.FormatMessage()})
+		}
+	}
+	return result, nil
+}
+
+// ValidationFailure is the kind of a failed validation of a parameter.
+type ValidationFailure string
+
+const (
+	FailureNone             ValidationFailure = ""                 // Validation succeeded
+	FailureMissingValue     ValidationFailure = "missingValue"     // The parameter has no value but is required
+	FailureInvalidValue     ValidationFailure = "invalidValue"     // The value violates the rules of the parameter definition
+	FailureUnknownParameter ValidationFailure = "unknownParameter" // There is no definition for the parameter
+)
+
+// ParameterValidationResult is the result of validating the value of a single parameter.
+type ParameterValidationResult struct {
+	ParameterId   string // ID of the parameter
+	ParameterName string // Name of the parameter, empty for unknown parameters
+	Success       bool
+	Failure       ValidationFailure // Kind of the failure, [FailureNone] for valid parameters
+	Message       string            // Reason why validation failed, empty for valid parameters
+}
+
+// FormatMessage returns a message describing the failed validation that also contains name and ID of the parameter.
+func (r ParameterValidationResult) FormatMessage() string {
+	if r.Failure == FailureUnknownParameter {
+		return fmt.Sprintf("Unknown parameter '%s'.", r.ParameterId)
+	}
+	return fmt.Sprintf("Failed to validate parameter '%s' (%s): %s", r.ParameterName, r.ParameterId, r.Message)
+}
+
+// ValidateEach validates parameter values against the parameter definitions like [Validator.ValidateParameters]
+// but returns a result for each active parameter definition and for each value of an unknown parameter.
+func (v *Validator) ValidateEach(definitions []ParameterDefinition, params extensionAPI.ParameterValues) ([]ParameterValidationResult, error) {
+	activeDefinitions, err := ActiveDefinitions(definitions, params)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]ParameterValidationResult, 0, len(activeDefinitions))
+	for _, def := range activeDefinitions {
 		name, id, validationResult, err := v.validateParameter(def, params)
 		if err != nil {
 			return nil, err
 		}
-		if !validationResult.Success {
-			result = append(result, ValidationResult{Success: false, Message: fmt.Sprintf("Failed to validate parameter '%s' (%s): %s", name, id, validationResult.Message)})
+		result = append(result, ParameterValidationResult{ParameterId: id, ParameterName: name, Success: validationResult.Success,
+			Failure: getFailure(validationResult, params, id), Message: validationResult.Message})
+	}
+	for _, param := range params.Values {
+		if !isDefined(definitions, param.Name) {
+			result = append(result, ParameterValidationResult{ParameterId: param.Name, ParameterName: "", Success: false, Failure: FailureUnknownParameter, Message: "Unknown parameter."})
 		}
 	}
 	return result, nil
 }
 
+// getFailure returns the kind of failure for the validation result of a defined parameter.
+func getFailure(result *ValidationResult, params extensionAPI.ParameterValues, id string) ValidationFailure {
+	if result.Success {
+		return FailureNone
+	}
+	if param, found := params.Find(id); !found || param.StringValue() == "" {
+		return FailureMissingValue
+	}
+	return FailureInvalidValue
+}
+
+func isDefined(definitions []ParameterDefinition, id string) bool {
+	_, found := findDefinition(definitions, id)
+	return found
+}
+
 func (v *Validator) validateParameter(def ParameterDefinition, params extensionAPI.ParameterValues) (paramName, paramId string, validationResult *ValidationResult, validationError error) {
-	paramValue := findParamValue(params, def.Id)
-	result, err := v.ValidateParameter(def, paramValue)
+	param, _ := params.Find(def.Id)
+	result, err := v.validateValue(def, param.Value)
 	if err != nil {
-		return "", "", nil, fmt.Errorf("failed to validate parameter value %q with id %q using definition %v", paramValue, def.Id, def.RawDefinition)
+		return "", "", nil, fmt.Errorf("failed to validate parameter value %q with id %q using definition %v", displayValue(def, param.StringValue()), def.Id, def.RawDefinition)
 	}
 	return def.Name, def.Id, result, nil
 }
 
-func findParamValue(params extensionAPI.ParameterValues, id string) string {
-	if param, found := params.Find(id); found {
-		return param.Value
+// validateValue validates a typed value. Lists are only allowed for select parameters with multiple values, each entry is validated separately.
+func (v *Validator) validateValue(def ParameterDefinition, value interface{}) (*ValidationResult, error) {
+	list, isList := value.([]interface{})
+	if !isList {
+		return v.ValidateParameter(def, extensionAPI.FormatValue(value))
+	}
+	if !isMultiSelect(def) {
+		result := validationError("A list of values is only allowed for select parameters with multiple values.")
+		return &result, nil
+	}
+	if len(list) == 0 {
+		return v.ValidateParameter(def, "")
+	}
+	for _, entry := range list {
+		if _, nested := entry.([]interface{}); nested {
+			result := validationError("Nested lists are not allowed.")
+			return &result, nil
+		}
+		result, err := v.ValidateParameter(def, extensionAPI.FormatValue(entry))
+		if err != nil || !result.Success {
+			return result, err
+		}
 	}
-	return ""
+	result := successResult
+	return &result, nil
 }
 
-// ValidateParameters uses the given parameter definition to validate a single value.
-func (v *Validator) ValidateParameter(def ParameterDefinition, value string) (validationResult *ValidationResult, errorResult error) {
-	defer func() {
-		if err := recover(); err != nil {
-			errorResult = fmt.Errorf("failed to validate parameter value %q using definition %v: %v", value, def, err)
-		}
-	}()
-	result := v.validate(def.RawDefinition, value)
+func isMultiSelect(def ParameterDefinition) bool {
+	return def.RawDefinition["type"] == "select" && def.RawDefinition["multiple"] == true
+}
+
+// ValidateParameter uses the given parameter definition to validate a single value.
+func (v *Validator) ValidateParameter(def ParameterDefinition, value string) (*ValidationResult, error) {
+	result, err := validateValue(def.RawDefinition, value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate parameter value %q using definition %v: %w", displayValue(def, value), def, err)
+	}
 	return &result, nil
 }
 
-func newJavaScriptVm() *goja.Runtime {
-	vm := goja.New()
-	vm.SetFieldNameMapper(goja.TagFieldNameMapper("json", true))
-	registry := new(require.Registry)
-	registry.Enable(vm)
-	console.Enable(vm)
-	return vm
+// displayValue returns the value for use in log and error messages, masking secret values.
+func displayValue(def ParameterDefinition, value string) string {
+	if def.Secret && value != "" {
+		return secrets.MASKED_VALUE
+	}
+	return value
 }
@@ -17,11 +17,7 @@ func TestParameterValidatorSuite(t *testing.T) {
 }
 
 func (suite *ParameterValidatorSuite) SetupSuite() {
-	v, err := New()
-	if err != nil {
-		suite.Fail(err.Error())
-	}
-	suite.validator = v
+	suite.validator = New()
 }
 
 func (suite *ParameterValidatorSuite) TestValidateParameter() {
@@ -114,6 +110,316 @@ func (suite *ParameterValidatorSuite) TestValidateParameters() {
 	}
 }
 
+func (suite *ParameterValidatorSuite) TestValidateEach() {
+	definitions := suite.convert([]interface{}{
+		map[string]interface{}{"id": "type", "name": "Type", "type": "select", "options": []interface{}{map[string]interface{}{"id": "jdbc"}, map[string]interface{}{"id": "odbc"}}},
+		map[string]interface{}{"id": "driver", "name": "Driver", "type": "string", "required": true,
+			"condition": map[string]interface{}{"parameter": "type", "operator": "==", "value": "jdbc"}},
+		map[string]interface{}{"id": "dsn", "name": "DSN", "type": "string", "required": true,
+			"condition": map[string]interface{}{"parameter": "type", "operator": "==", "value": "odbc"}},
+		map[string]interface{}{"id": "port", "name": "Port", "type": "string", "regex": `^\d+$`}})
+	params := extensionAPI.ParameterValues{Values: []extensionAPI.ParameterValue{{Name: "type", Value: "jdbc"}, {Name: "port", Value: "invalid"}, {Name: "unknown", Value: "value"}}}
+	result, err := suite.validator.ValidateEach(definitions, params)
+	suite.Require().NoError(err)
+	suite.Equal([]ParameterValidationResult{
+		{ParameterId: "type", ParameterName: "Type", Success: true, Failure: FailureNone, Message: ""},
+		{ParameterId: "driver", ParameterName: "Driver", Success: false, Failure: FailureMissingValue, Message: "This is a required parameter."},
+		{ParameterId: "port", ParameterName: "Port", Success: false, Failure: FailureInvalidValue, Message: "The value has an invalid format."},
+		{ParameterId: "unknown", ParameterName: "", Success: false, Failure: FailureUnknownParameter, Message: "Unknown parameter."}}, result)
+}
+
+func (suite *ParameterValidatorSuite) TestValidateEachReportsFailureOfParameterWithoutName() {
+	definitions := []ParameterDefinition{
+		{Id: "required", Name: "", Secret: false, RawDefinition: map[string]interface{}{"id": "required", "type": "string", "required": true}},
+		{Id: "port", Name: "", Secret: false, RawDefinition: map[string]interface{}{"id": "port", "type": "string", "regex": `^\d+$`}}}
+	params := extensionAPI.ParameterValues{Values: []extensionAPI.ParameterValue{{Name: "port", Value: "invalid"}}}
+	result, err := suite.validator.ValidateEach(definitions, params)
+	suite.Require().NoError(err)
+	suite.Equal([]ParameterValidationResult{
+		{ParameterId: "required", ParameterName: "", Success: false, Failure: FailureMissingValue, Message: "This is a required parameter."},
+		{ParameterId: "port", ParameterName: "", Success: false, Failure: FailureInvalidValue, Message: "The value has an invalid format."}}, result)
+}
+
+func (suite *ParameterValidatorSuite) TestValidateEachFailsForInvalidCondition() {
+	definitions := suite.convert([]interface{}{map[string]interface{}{"id": "param1", "name": "My param", "type": "string", "condition": "invalid"}})
+	result, err := suite.validator.ValidateEach(definitions, extensionAPI.ParameterValues{})
+	suite.Require().ErrorContains(err, `failed to evaluate condition of parameter "param1"`)
+	suite.Nil(result)
+}
+
+func (suite *ParameterValidatorSuite) TestValidateParametersWithCondition() {
+	requiredIfJdbc := func(condition map[string]interface{}) []interface{} {
+		return []interface{}{
+			map[string]interface{}{"id": "connectorType", "name": "Connector type", "type": "string"},
+			map[string]interface{}{"id": "port", "name": "Port", "type": "string", "required": true, "condition": condition}}
+	}
+	jdbc := map[string]interface{}{"parameter": "connectorType", "operator": "==", "value": "jdbc"}
+	odbc := map[string]interface{}{"parameter": "connectorType", "operator": "==", "value": "odbc"}
+	requiredError := []ValidationResult{{Success: false, Message: `Failed to validate parameter 'Port' (port): This is a required parameter.`}}
+	var tests = []struct {
+		name      string
+		condition map[string]interface{}
+		params    []extensionAPI.ParameterValue
+		expected  []ValidationResult
+	}{
+		{"equal, condition fulfilled", jdbc, []extensionAPI.ParameterValue{{Name: "connectorType", Value: "jdbc"}}, requiredError},
+		{"equal, condition not fulfilled", jdbc, []extensionAPI.ParameterValue{{Name: "connectorType", Value: "odbc"}}, []ValidationResult{}},
+		{"equal, parameter missing", jdbc, []extensionAPI.ParameterValue{}, []ValidationResult{}},
+		{"equal number", map[string]interface{}{"parameter": "connectorType", "operator": "==", "value": 1.0}, []extensionAPI.ParameterValue{{Name: "connectorType", Value: "1.0"}}, requiredError},
+		{"equal boolean", map[string]interface{}{"parameter": "connectorType", "operator": "==", "value": true}, []extensionAPI.ParameterValue{{Name: "connectorType", Value: "true"}}, requiredError},
+		{"less, fulfilled", map[string]interface{}{"parameter": "connectorType", "operator": "<", "value": int64(10)}, []extensionAPI.ParameterValue{{Name: "connectorType", Value: "9"}}, requiredError},
+		{"less, not fulfilled", map[string]interface{}{"parameter": "connectorType", "operator": "<", "value": int64(10)}, []extensionAPI.ParameterValue{{Name: "connectorType", Value: "10"}}, []ValidationResult{}},
+		{"less, not a number", map[string]interface{}{"parameter": "connectorType", "operator": "<", "value": int64(10)}, []extensionAPI.ParameterValue{{Name: "connectorType", Value: "abc"}}, []ValidationResult{}},
+		{"less equal", map[string]interface{}{"parameter": "connectorType", "operator": "<=", "value": 10.0}, []extensionAPI.ParameterValue{{Name: "connectorType", Value: "10"}}, requiredError},
+		{"greater", map[string]interface{}{"parameter": "connectorType", "operator": ">", "value": 10.0}, []extensionAPI.ParameterValue{{Name: "connectorType", Value: "10.5"}}, requiredError},
+		{"greater equal", map[string]interface{}{"parameter": "connectorType", "operator": ">=", "value": 10.0}, []extensionAPI.ParameterValue{{Name: "connectorType", Value: "9.9"}}, []ValidationResult{}},
+		{"greater string", map[string]interface{}{"parameter": "connectorType", "operator": ">", "value": "b"}, []extensionAPI.ParameterValue{{Name: "connectorType", Value: "c"}}, requiredError},
+		{"not", map[string]interface{}{"not": jdbc}, []extensionAPI.ParameterValue{{Name: "connectorType", Value: "odbc"}}, requiredError},
+		{"and, fulfilled", map[string]interface{}{"and": []interface{}{jdbc, map[string]interface{}{"not": odbc}}}, []extensionAPI.ParameterValue{{Name: "connectorType", Value: "jdbc"}}, requiredError},
+		{"and, not fulfilled", map[string]interface{}{"and": []interface{}{jdbc, odbc}}, []extensionAPI.ParameterValue{{Name: "connectorType", Value: "jdbc"}}, []ValidationResult{}},
+		{"and, empty", map[string]interface{}{"and": []interface{}{}}, []extensionAPI.ParameterValue{}, requiredError},
+		{"or, fulfilled", map[string]interface{}{"or": []interface{}{odbc, jdbc}}, []extensionAPI.ParameterValue{{Name: "connectorType", Value: "jdbc"}}, requiredError},
+		{"or, not fulfilled", map[string]interface{}{"or": []interface{}{odbc, jdbc}}, []extensionAPI.ParameterValue{{Name: "connectorType", Value: "other"}}, []ValidationResult{}},
+		{"or, empty", map[string]interface{}{"or": []interface{}{}}, []extensionAPI.ParameterValue{}, []ValidationResult{}},
+	}
+	for _, t := range tests {
+		suite.Run(t.name, func() {
+			result, err := suite.validator.ValidateParameters(suite.convert(requiredIfJdbc(t.condition)), extensionAPI.ParameterValues{Values: t.params})
+			suite.Require().NoError(err)
+			suite.Equal(t.expected, result)
+		})
+	}
+}
+
+func (suite *ParameterValidatorSuite) TestValidateParametersRejectsUnknownParameters() {
+	definitions := suite.convert([]interface{}{map[string]interface{}{"id": "param1", "name": "My param", "type": "string", "required": true}})
+	params := extensionAPI.ParameterValues{Values: []extensionAPI.ParameterValue{{Name: "unknown1", Value: "value"}, {Name: "unknown2", Value: ""}}}
+	result, err := suite.validator.ValidateParameters(definitions, params)
+	suite.Require().NoError(err)
+	suite.Equal([]ValidationResult{
+		{Success: false, Message: `Failed to validate parameter 'My param' (param1): This is a required parameter.`},
+		{Success: false, Message: `Unknown parameter 'unknown1'.`},
+		{Success: false, Message: `Unknown parameter 'unknown2'.`}}, result)
+}
+
+func (suite *ParameterValidatorSuite) TestValidateParametersSkipsInactiveParameterWithValue() {
+	definitions := suite.convert([]interface{}{
+		map[string]interface{}{"id": "connectorType", "name": "Connector type", "type": "string"},
+		map[string]interface{}{"id": "port", "name": "Port", "type": "string", "regex": `^\d+$`,
+			"condition": map[string]interface{}{"parameter": "connectorType", "operator": "==", "value": "jdbc"}}})
+	params := extensionAPI.ParameterValues{Values: []extensionAPI.ParameterValue{{Name: "connectorType", Value: "odbc"}, {Name: "port", Value: "invalid"}}}
+	result, err := suite.validator.ValidateParameters(definitions, params)
+	suite.Require().NoError(err)
+	suite.Empty(result)
+}
+
+func (suite *ParameterValidatorSuite) TestActiveDefinitions() {
+	definitions := suite.convert([]interface{}{
+		map[string]interface{}{"id": "connectorType", "name": "Connector type", "type": "string"},
+		map[string]interface{}{"id": "jdbcDriver", "name": "JDBC driver", "type": "string",
+			"condition": map[string]interface{}{"parameter": "connectorType", "operator": "==", "value": "jdbc"}},
+		map[string]interface{}{"id": "driverVersion", "name": "Driver version", "type": "string",
+			"condition": map[string]interface{}{"parameter": "jdbcDriver", "operator": "==", "value": "custom"}},
+		map[string]interface{}{"id": "emptyValue", "name": "Empty", "type": "string",
+			"condition": map[string]interface{}{"parameter": "connectorType", "operator": "==", "value": ""}},
+		map[string]interface{}{"id": "notJdbc", "name": "Not JDBC", "type": "string",
+			"condition": map[string]interface{}{"not": map[string]interface{}{"parameter": "connectorType", "operator": "==", "value": "jdbc"}}}})
+	var tests = []struct {
+		name     string
+		params   []extensionAPI.ParameterValue
+		expected []string
+	}{
+		{"no values", []extensionAPI.ParameterValue{}, []string{"connectorType", "notJdbc"}},
+		{"empty value", []extensionAPI.ParameterValue{{Name: "connectorType", Value: ""}}, []string{"connectorType", "emptyValue", "notJdbc"}},
+		{"first level", []extensionAPI.ParameterValue{{Name: "connectorType", Value: "jdbc"}}, []string{"connectorType", "jdbcDriver"}},
+		{"second level", []extensionAPI.ParameterValue{{Name: "connectorType", Value: "jdbc"}, {Name: "jdbcDriver", Value: "custom"}}, []string{"connectorType", "jdbcDriver", "driverVersion"}},
+		{"value of inactive parameter ignored", []extensionAPI.ParameterValue{{Name: "connectorType", Value: "odbc"}, {Name: "jdbcDriver", Value: "custom"}}, []string{"connectorType", "notJdbc"}},
+	}
+	for _, t := range tests {
+		suite.Run(t.name, func() {
+			result, err := ActiveDefinitions(definitions, extensionAPI.ParameterValues{Values: t.params})
+			suite.Require().NoError(err)
+			ids := make([]string, 0, len(result))
+			for _, def := range result {
+				ids = append(ids, def.Id)
+			}
+			suite.Equal(t.expected, ids)
+		})
+	}
+}
+
+func (suite *ParameterValidatorSuite) TestActiveDefinitionsWithCyclicConditions() {
+	definitions := suite.convert([]interface{}{
+		map[string]interface{}{"id": "a", "name": "A", "type": "string",
+			"condition": map[string]interface{}{"not": map[string]interface{}{"parameter": "b", "operator": "==", "value": "x"}}},
+		map[string]interface{}{"id": "b", "name": "B", "type": "string",
+			"condition": map[string]interface{}{"parameter": "a", "operator": "==", "value": "x"}}})
+	result, err := ActiveDefinitions(definitions, extensionAPI.ParameterValues{Values: []extensionAPI.ParameterValue{{Name: "a", Value: "x"}, {Name: "b", Value: "x"}}})
+	suite.Require().NoError(err)
+	suite.NotNil(result)
+}
+
+func (suite *ParameterValidatorSuite) TestActiveDefinitionsWithInvalidConditionFails() {
+	definitions := suite.convert([]interface{}{map[string]interface{}{"id": "param1", "name": "My param", "type": "string", "condition": "invalid"}})
+	result, err := ActiveDefinitions(definitions, extensionAPI.ParameterValues{})
+	suite.Require().EqualError(err, `failed to evaluate condition of parameter "param1": unexpected type string of condition invalid, expected object`)
+	suite.Nil(result)
+}
+
+func (suite *ParameterValidatorSuite) TestValidateParametersWithInvalidConditionFails() {
+	var tests = []struct {
+		name          string
+		condition     interface{}
+		expectedError string
+	}{
+		{"not an object", "invalid", `failed to evaluate condition of parameter "param1": unexpected type string of condition invalid, expected object`},
+		{"missing parameter", map[string]interface{}{"operator": "==", "value": "a"}, `failed to evaluate condition of parameter "param1": missing parameter in condition map[operator:== value:a]`},
+		{"missing operator", map[string]interface{}{"parameter": "p", "value": "a"}, `failed to evaluate condition of parameter "param1": missing operator in condition map[parameter:p value:a]`},
+		{"unsupported operator", map[string]interface{}{"parameter": "p", "operator": "!=", "value": "a"}, `failed to evaluate condition of parameter "param1": unsupported operator "!=" in condition map[operator:!= parameter:p value:a]`},
+		{"invalid and", map[string]interface{}{"and": "invalid"}, `failed to evaluate condition of parameter "param1": unexpected type string of operands invalid, expected array`},
+		{"invalid nested condition", map[string]interface{}{"or": []interface{}{"invalid"}}, `failed to evaluate condition of parameter "param1": unexpected type string of condition invalid, expected object`},
+		{"invalid not", map[string]interface{}{"not": "invalid"}, `failed to evaluate condition of parameter "param1": unexpected type string of condition invalid, expected object`},
+	}
+	for _, t := range tests {
+		suite.Run(t.name, func() {
+			definitions := suite.convert([]interface{}{map[string]interface{}{"id": "param1", "name": "My param", "type": "string", "condition": t.condition}})
+			result, err := suite.validator.ValidateParameters(definitions, extensionAPI.ParameterValues{})
+			suite.Require().EqualError(err, t.expectedError)
+			suite.Nil(result)
+		})
+	}
+}
+
+func (suite *ParameterValidatorSuite) TestValidateParameterFailsForInvalidRegex() {
+	def := suite.convertParam(map[string]interface{}{"id": "param1", "name": "My param", "type": "string", "regex": "(a"})
+	result, err := suite.validator.ValidateParameter(def, "value")
+	suite.Require().ErrorContains(err, `failed to validate parameter value "value" using definition`)
+	suite.Require().ErrorContains(err, `invalid regular expression "(a"`)
+	suite.Nil(result)
+}
+
+func (suite *ParameterValidatorSuite) TestValidateParameterWithJavaScriptRegex() {
+	var tests = []struct {
+		regex         string
+		value         string
+		expectSuccess bool
+	}{
+		{`^(?!foo).*$`, "bar", true},
+		{`^(?!foo).*$`, "foobar", false},
+		{`^(?=.*\d).+$`, "a1", true},
+		{`^(?=.*\d).+$`, "ab", false},
+		{`^(a)\1$`, "aa", true},
+		{`^(a)\1$`, "ab", false},
+		{`(?<=a)b`, "ab", true},
+		{`(?<=a)b`, "cb", false},
+	}
+	for _, test := range tests {
+		suite.Run(test.regex+" "+test.value, func() {
+			def := suite.convertParam(map[string]interface{}{"id": "param1", "name": "My param", "type": "string", "regex": test.regex})
+			result, err := suite.validator.ValidateParameter(def, test.value)
+			suite.Require().NoError(err)
+			suite.Equal(test.expectSuccess, result.Success)
+		})
+	}
+}
+
+func (suite *ParameterValidatorSuite) TestValidateTypedParameters() {
+	multiSelect := map[string]interface{}{"id": "param1", "name": "My param", "type": "select", "multiple": true,
+		"options": []interface{}{map[string]interface{}{"id": "a"}, map[string]interface{}{"id": "b"}}}
+	var tests = []struct {
+		name       string
+		definition map[string]interface{}
+		value      interface{}
+		expected   []ValidationResult
+	}{
+		{"boolean true", map[string]interface{}{"id": "param1", "name": "My param", "type": "boolean"}, true, []ValidationResult{}},
+		{"boolean false", map[string]interface{}{"id": "param1", "name": "My param", "type": "boolean", "required": true}, false, []ValidationResult{}},
+		{"boolean number", map[string]interface{}{"id": "param1", "name": "My param", "type": "boolean"}, 1.0,
+			[]ValidationResult{{Success: false, Message: `Failed to validate parameter 'My param' (param1): Boolean value must be 'true' or 'false'.`}}},
+		{"number for string with regex", map[string]interface{}{"id": "param1", "name": "My param", "type": "string", "regex": `^\d+$`}, 8563.0, []ValidationResult{}},
+		{"list for string", map[string]interface{}{"id": "param1", "name": "My param", "type": "string"}, []interface{}{"a"},
+			[]ValidationResult{{Success: false, Message: `Failed to validate parameter 'My param' (param1): A list of values is only allowed for select parameters with multiple values.`}}},
+		{"list for single select", map[string]interface{}{"id": "param1", "name": "My param", "type": "select", "options": []interface{}{map[string]interface{}{"id": "a"}}}, []interface{}{"a"},
+			[]ValidationResult{{Success: false, Message: `Failed to validate parameter 'My param' (param1): A list of values is only allowed for select parameters with multiple values.`}}},
+		{"multi select valid", multiSelect, []interface{}{"a", "b"}, []ValidationResult{}},
+		{"multi select single value", multiSelect, "a", []ValidationResult{}},
+		{"multi select invalid entry", multiSelect, []interface{}{"a", "c"},
+			[]ValidationResult{{Success: false, Message: `Failed to validate parameter 'My param' (param1): The value is not allowed. Possible values are 'a', 'b'.`}}},
+		{"multi select nested list", multiSelect, []interface{}{[]interface{}{"a"}},
+			[]ValidationResult{{Success: false, Message: `Failed to validate parameter 'My param' (param1): Nested lists are not allowed.`}}},
+		{"multi select empty list", multiSelect, []interface{}{}, []ValidationResult{}},
+	}
+	for _, t := range tests {
+		suite.Run(t.name, func() {
+			params := extensionAPI.ParameterValues{Values: []extensionAPI.ParameterValue{{Name: "param1", Value: t.value}}}
+			result, err := suite.validator.ValidateParameters(suite.convert([]interface{}{t.definition}), params)
+			suite.Require().NoError(err)
+			suite.Equal(t.expected, result)
+		})
+	}
+}
+
+func (suite *ParameterValidatorSuite) TestValidateRequiredMultiSelectWithEmptyList() {
+	definitions := suite.convert([]interface{}{map[string]interface{}{"id": "param1", "name": "My param", "type": "select", "multiple": true, "required": true,
+		"options": []interface{}{map[string]interface{}{"id": "a"}}}})
+	params := extensionAPI.ParameterValues{Values: []extensionAPI.ParameterValue{{Name: "param1", Value: []interface{}{}}}}
+	result, err := suite.validator.ValidateParameters(definitions, params)
+	suite.Require().NoError(err)
+	suite.Equal([]ValidationResult{{Success: false, Message: `Failed to validate parameter 'My param' (param1): This is a required parameter.`}}, result)
+}
+
+func (suite *ParameterValidatorSuite) TestCoerceValues() {
+	definitions := suite.convert([]interface{}{
+		map[string]interface{}{"id": "string", "name": "String", "type": "string"},
+		map[string]interface{}{"id": "boolean", "name": "Boolean", "type": "boolean"},
+		map[string]interface{}{"id": "booleanString", "name": "Boolean", "type": "boolean"},
+		map[string]interface{}{"id": "emptyBoolean", "name": "Boolean", "type": "boolean"},
+		map[string]interface{}{"id": "select", "name": "Select", "type": "select"},
+		map[string]interface{}{"id": "multiSelect", "name": "Multi select", "type": "select", "multiple": true},
+		map[string]interface{}{"id": "multiSelectSingle", "name": "Multi select", "type": "select", "multiple": true},
+		map[string]interface{}{"id": "multiSelectEmpty", "name": "Multi select", "type": "select", "multiple": true},
+		map[string]interface{}{"id": "other", "name": "Other", "type": "other"}})
+	params := extensionAPI.ParameterValues{Values: []extensionAPI.ParameterValue{
+		{Name: "string", Value: 8563.0}, {Name: "boolean", Value: true}, {Name: "booleanString", Value: "false"}, {Name: "emptyBoolean", Value: ""},
+		{Name: "select", Value: 1.0}, {Name: "multiSelect", Value: []interface{}{"a", 2.0}}, {Name: "multiSelectSingle", Value: "a"},
+		{Name: "multiSelectEmpty", Value: ""}, {Name: "other", Value: 1.5}, {Name: "undefined", Value: true}}}
+	suite.Equal(extensionAPI.ParameterValues{Values: []extensionAPI.ParameterValue{
+		{Name: "string", Value: "8563"}, {Name: "boolean", Value: true}, {Name: "booleanString", Value: false}, {Name: "emptyBoolean", Value: ""},
+		{Name: "select", Value: "1"}, {Name: "multiSelect", Value: []interface{}{"a", "2"}}, {Name: "multiSelectSingle", Value: []interface{}{"a"}},
+		{Name: "multiSelectEmpty", Value: []interface{}{}}, {Name: "other", Value: 1.5}, {Name: "undefined", Value: true}}},
+		CoerceValues(definitions, params))
+}
+
+func (suite *ParameterValidatorSuite) TestValidateParameterFails() {
+	def := suite.convertParam(map[string]interface{}{"id": "param1", "name": "My param", "type": "select", "options": "invalid"})
+	result, err := suite.validator.ValidateParameter(def, "value")
+	suite.Require().ErrorContains(err, `failed to validate parameter value "value" using definition`)
+	suite.Nil(result)
+}
+
+func (suite *ParameterValidatorSuite) TestValidateParameterFailsMasksSecretValue() {
+	def := suite.convertParam(map[string]interface{}{"id": "param1", "name": "My param", "type": "select", "options": "invalid", "secret": true})
+	result, err := suite.validator.ValidateParameter(def, "my-password")
+	suite.Require().ErrorContains(err, `failed to validate parameter value "******" using definition`)
+	suite.NotContains(err.Error(), "my-password")
+	suite.Nil(result)
+}
+
+func (suite *ParameterValidatorSuite) TestSecretValues() {
+	definitions := suite.convert([]interface{}{
+		map[string]interface{}{"id": "param1", "name": "My param", "type": "string"},
+		map[string]interface{}{"id": "param2", "name": "Password", "type": "string", "secret": true},
+		map[string]interface{}{"id": "param3", "name": "Missing password", "type": "string", "secret": true}})
+	params := extensionAPI.ParameterValues{Values: []extensionAPI.ParameterValue{{Name: "param1", Value: "value1"}, {Name: "param2", Value: "value2"}}}
+	suite.Equal([]string{"value2"}, SecretValues(definitions, params))
+}
+
+func (suite *ParameterValidatorSuite) TestSecretValuesOfList() {
+	definitions := suite.convert([]interface{}{map[string]interface{}{"id": "param1", "name": "Secret list", "type": "select", "multiple": true, "secret": true}})
+	params := extensionAPI.ParameterValues{Values: []extensionAPI.ParameterValue{{Name: "param1", Value: []interface{}{"secret1", "secret2"}}}}
+	suite.Equal([]string{"secret1,secret2", "secret1", "secret2"}, SecretValues(definitions, params))
+}
+
 func (suite *ParameterValidatorSuite) TestInvalidDefinitionIgnored() {
 	rawDefinition := []interface{}{map[string]interface{}{"id": "param1", "name": "My param", "type": "invalidType"}}
 	result, err := suite.validator.ValidateParameters(suite.convert(rawDefinition), extensionAPI.ParameterValues{Values: []extensionAPI.ParameterValue{}})
@@ -132,6 +438,8 @@ func (suite *ParameterValidatorSuite) TestConvertDefinitionsSucceeds() {
 			RawDefinition: map[string]interface{}{"id": "param1", "name": "My param", "type": "invalidType"}}}},
 		{"missing type", []interface{}{map[string]interface{}{"id": "param1", "name": "My param"}}, []ParameterDefinition{{Id: "param1", Name: "My param",
 			RawDefinition: map[string]interface{}{"id": "param1", "name": "My param"}}}},
+		{"secret", []interface{}{map[string]interface{}{"id": "param1", "name": "My param", "secret": true}}, []ParameterDefinition{{Id: "param1", Name: "My param", Secret: true,
+			RawDefinition: map[string]interface{}{"id": "param1", "name": "My param", "secret": true}}}},
 		{"two entries", []interface{}{map[string]interface{}{"id": "param1", "name": "My param", "type": "invalidType"}, map[string]interface{}{"id": "param2", "name": "My param2", "type": "string"}},
 			[]ParameterDefinition{{Id: "param1", Name: "My param", RawDefinition: map[string]interface{}{"id": "param1", "name": "My param", "type": "invalidType"}},
 				{Id: "param2", Name: "My param2", RawDefinition: map[string]interface{}{"id": "param2", "name": "My param2", "type": "string"}}}},
@@ -154,6 +462,7 @@ func (suite *ParameterValidatorSuite) TestConvertDefinitionsFails() {
 		{"empty map", []interface{}{map[string]interface{}{}}, "entry \"id\" missing in parameter definition map[]"},
 		{"missing id", []interface{}{map[string]interface{}{"name": "My param", "type": "invalidType"}}, "entry \"id\" missing in parameter definition map[name:My param type:invalidType]"},
 		{"missing name", []interface{}{map[string]interface{}{"id": "param2", "type": "invalidType"}}, "entry \"name\" missing in parameter definition map[id:param2 type:invalidType]"},
+		{"invalid secret", []interface{}{map[string]interface{}{"id": "param2", "name": "My param", "secret": "true"}}, "unexpected type of key \"secret\" in parameter definition: string, expected bool"},
 	}
 	for _, test := range tests {
 		suite.T().Run(test.name, func(t *testing.T) {
@@ -0,0 +1,150 @@
+package parameterValidator
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/dlclark/regexp2/v2"
+	"github.com/exasol/extension-manager/pkg/extensionAPI"
+)
+
+// This file implements the validation rules of the TypeScript library "@exasol/extension-parameter-validator" in Go.
+// The conformance test in jsConformance_test.go verifies that both implementations return the same results.
+
+// regexMatchTimeout limits the time for matching a value against the regular expression of a parameter definition.
+// Regular expressions are evaluated with backtracking like in JavaScript, so a bad expression could otherwise block a request.
+const regexMatchTimeout = time.Second
+
+//nolint:exhaustruct // Message is not needed for a successful result
+var successResult = ValidationResult{Success: true}
+
+func validationError(message string) ValidationResult {
+	return ValidationResult{Success: false, Message: message}
+}
+
+func validateValue(definition map[string]interface{}, value string) (ValidationResult, error) {
+	if value == "" {
+		if isTruthy(definition["required"]) {
+			return validationError("This is a required parameter."), nil
+		}
+		return successResult, nil
+	}
+	switch definition["type"] {
+	case "string":
+		return validateStringValue(definition, value)
+	case "boolean":
+		return validateBooleanValue(value), nil
+	case "select":
+		return validateSelectValue(definition, value)
+	default:
+		return validationError(fmt.Sprintf("unsupported parameter type '%s'", jsString(definition["type"]))), nil
+	}
+}
+
+func validateStringValue(definition map[string]interface{}, value string) (ValidationResult, error) {
+	if !isTruthy(definition["regex"]) {
+		return successResult, nil
+	}
+	pattern := jsString(definition["regex"])
+	// Use ECMAScript syntax and semantics, as extension definitions contain JavaScript regular expressions,
+	// e.g. with lookahead or backreferences that are not supported by package regexp.
+	regex, err := regexp2.Compile(pattern, regexp2.ECMAScript)
+	if err != nil {
+		return ValidationResult{}, fmt.Errorf("invalid regular expression %q: %w", pattern, err)
+	}
+	regex.MatchTimeout = regexMatchTimeout
+	matches, err := regex.MatchString(value)
+	if err != nil {
+		return ValidationResult{}, fmt.Errorf("failed to match value against regular expression %q: %w", pattern, err)
+	}
+	if !matches {
+		return validationError("The value has an invalid format."), nil
+	}
+	return successResult, nil
+}
+
+func validateSelectValue(definition map[string]interface{}, value string) (ValidationResult, error) {
+	possibleValues, err := getOptionIds(definition["options"])
+	if err != nil {
+		return ValidationResult{}, err
+	}
+	if len(possibleValues) == 0 {
+		return validationError("No option available for this parameter."), nil
+	}
+	quotedValues := make([]string, 0, len(possibleValues))
+	for _, possibleValue := range possibleValues {
+		if id, ok := possibleValue.(string); ok && id == value {
+			return successResult, nil
+		}
+		quotedValues = append(quotedValues, "'"+jsString(possibleValue)+"'")
+	}
+	return validationError(fmt.Sprintf("The value is not allowed. Possible values are %s.", strings.Join(quotedValues, ", "))), nil
+}
+
+func getOptionIds(options interface{}) ([]interface{}, error) {
+	list, ok := options.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected type %T of options, expected array", options)
+	}
+	ids := make([]interface{}, 0, len(list))
+	for _, option := range list {
+		switch o := option.(type) {
+		case nil:
+			return nil, fmt.Errorf("option must not be null")
+		case map[string]interface{}:
+			ids = append(ids, o["id"])
+		case map[string]string:
+			if id, ok := o["id"]; ok {
+				ids = append(ids, id)
+			} else {
+				ids = append(ids, nil)
+			}
+		default:
+			ids = append(ids, nil)
+		}
+	}
+	return ids, nil
+}
+
+func validateBooleanValue(value string) ValidationResult {
+	if value == "true" || value == "false" {
+		return successResult
+	}
+	return validationError("Boolean value must be 'true' or 'false'.")
+}
+
+// isTruthy evaluates the value like a condition in JavaScript.
+func isTruthy(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != ""
+	case int:
+		return v != 0
+	case int64:
+		return v != 0
+	case float64:
+		return v != 0 && !math.IsNaN(v)
+	default:
+		return true
+	}
+}
+
+// jsString converts the value to a string like a JavaScript template literal.
+func jsString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "undefined"
+	case string:
+		return v
+	case float64:
+		return extensionAPI.FormatValue(v)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
@@ -8,10 +8,16 @@ func NewApiContext(controller extensionController.TransactionController, addCaus
 	return &ApiContext{
 		Controller:                    controller,
 		addCauseToInternalServerError: addCauseToInternalServerError,
+		database:                      newDefaultDatabaseConnector(),
+		jobs:                          newDefaultJobManager(),
+		drainer:                       newDrainer(),
 	}
 }
 
 type ApiContext struct {
 	Controller                    extensionController.TransactionController
 	addCauseToInternalServerError bool
+	database                      *databaseConnector
+	jobs                          *jobManager
+	drainer                       *drainer
 }
@@ -7,11 +7,15 @@ import (
 var authentication = map[string][]string{BasicAuth: {}, BearerAuth: {}}
 
 // newPathWithDbQueryParams creates a base path starting with "/api/v1/extensionmanager"
-// including query parameters dbHost and dbPort.
+// including query parameters dbHost, dbPort and dbTarget.
+//
+// The parameters are optional because depending on the server configuration clients either specify dbHost and dbPort
+// or select one of the configured database targets with dbTarget.
 func newPathWithDbQueryParams() *openapi.PathBuilder {
 	path := getV1PublicBasePath(openapi.NewPathBuilder())
-	path.WithQueryParameter("dbHost", openapi.STRING, "Exasol database hostname", true)
-	path.WithQueryParameter("dbPort", openapi.INTEGER, "Exasol database port number", true)
+	path.WithQueryParameter("dbHost", openapi.STRING, "Exasol database hostname. Required unless the server is configured with database targets.", false)
+	path.WithQueryParameter("dbPort", openapi.INTEGER, "Exasol database port number. Required unless the server is configured with database targets.", false)
+	path.WithQueryParameter(databaseTargetQueryParam, openapi.STRING, "Name of the configured database target. Only allowed if the server is configured with database targets, optional if it has a default target.", false)
 	return path
 }
 
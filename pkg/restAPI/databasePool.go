@@ -0,0 +1,177 @@
+package restAPI
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// connectionsPerRequest is the number of connections a request uses at the same time:
+	// one for its transaction and one for the transaction listing files in BucketFS.
+	connectionsPerRequest = 2
+	// maxRequestsPerDatabase is the number of concurrent requests that share a pooled database.
+	// Further requests with the same credentials open a connection that is closed after the request.
+	maxRequestsPerDatabase = 4
+)
+
+// databasePool reuses database connections across requests.
+//
+// The pool contains one [sql.DB] per combination of database target and credentials. Entries are identified by
+// a hash of the complete connection string, so requests only get a connection opened with exactly the same credentials.
+type databasePool struct {
+	maxSize     int
+	idleTimeout time.Duration
+	openDB      func(dataSourceName string) (*sql.DB, error)
+	now         func() time.Time
+	mutex       sync.Mutex
+	entries     map[string]*pooledDatabase
+	hits        uint64
+	misses      uint64
+	evictions   uint64
+}
+
+type pooledDatabase struct {
+	db       *sql.DB
+	address  string
+	inUse    int
+	lastUsed time.Time
+}
+
+// databasePoolStats contains the current state of the pool.
+type databasePoolStats struct {
+	Size            int    // Number of pooled databases
+	InUse           int    // Number of pooled databases currently used by requests
+	OpenConnections int    // Number of open connections of all pooled databases
+	Hits            uint64 // Number of requests that reused a pooled database
+	Misses          uint64 // Number of requests that opened a new database
+	Evictions       uint64 // Number of databases closed because they were idle or the pool was full
+}
+
+func newDatabasePool(config DatabasePoolConfig) *databasePool {
+	idleTimeout := config.IdleTimeout
+	if idleTimeout == 0 {
+		idleTimeout = DefaultDatabasePoolIdleTimeout
+	}
+	return &databasePool{
+		maxSize:     config.MaxSize,
+		idleTimeout: idleTimeout,
+		openDB:      func(dataSourceName string) (*sql.DB, error) { return sql.Open("exasol", dataSourceName) },
+		now:         time.Now,
+		mutex:       sync.Mutex{},
+		entries:     make(map[string]*pooledDatabase),
+		hits:        0,
+		misses:      0,
+		evictions:   0,
+	}
+}
+
+// acquire returns the pooled database for the given connection string and a function that must be called after the request.
+// If the pool is full and all entries are in use, or if the pooled database is already used by [maxRequestsPerDatabase] requests,
+// acquire opens a database that is closed after the request.
+func (p *databasePool) acquire(dataSourceName, address string) (*sql.DB, func(), error) {
+	key := hashDataSourceName(dataSourceName)
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.evictIdleEntries()
+	entry, exists := p.entries[key]
+	if exists && entry.inUse < maxRequestsPerDatabase {
+		p.hits++
+		entry.inUse++
+		return entry.db, func() { p.release(entry) }, nil
+	}
+	p.misses++
+	db, err := p.openDB(dataSourceName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open a database connection. Cause: %w", err)
+	}
+	if exists {
+		log.Debugf("Pooled database is used by %d requests, opening unpooled connection to %s", entry.inUse, address)
+		return db, func() { closeDBRequest(db) }, nil
+	}
+	if len(p.entries) >= p.maxSize && !p.evictLeastRecentlyUsed() {
+		log.Debugf("Database pool is full with %d entries in use, opening unpooled connection to %s", len(p.entries), address)
+		return db, func() { closeDBRequest(db) }, nil
+	}
+	db.SetMaxOpenConns(maxRequestsPerDatabase * connectionsPerRequest)
+	db.SetMaxIdleConns(connectionsPerRequest)
+	db.SetConnMaxIdleTime(p.idleTimeout)
+	entry = &pooledDatabase{db: db, address: address, inUse: 1, lastUsed: p.now()}
+	p.entries[key] = entry
+	return db, func() { p.release(entry) }, nil
+}
+
+func (p *databasePool) release(entry *pooledDatabase) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	entry.inUse--
+	entry.lastUsed = p.now()
+}
+
+// evictIdleEntries closes all unused databases that were not used within the idle timeout.
+func (p *databasePool) evictIdleEntries() {
+	for key, entry := range p.entries {
+		if entry.inUse == 0 && p.now().Sub(entry.lastUsed) > p.idleTimeout {
+			p.evict(key)
+		}
+	}
+}
+
+// evictLeastRecentlyUsed closes the unused database that was not used for the longest time.
+// It returns false if all databases are in use.
+func (p *databasePool) evictLeastRecentlyUsed() bool {
+	var oldestKey string
+	var oldest *pooledDatabase
+	for key, entry := range p.entries {
+		if entry.inUse == 0 && (oldest == nil || entry.lastUsed.Before(oldest.lastUsed)) {
+			oldestKey = key
+			oldest = entry
+		}
+	}
+	if oldest == nil {
+		return false
+	}
+	p.evict(oldestKey)
+	return true
+}
+
+func (p *databasePool) evict(key string) {
+	log.Debugf("Closing pooled database connection to %s", p.entries[key].address)
+	closeDBRequest(p.entries[key].db)
+	delete(p.entries, key)
+	p.evictions++
+}
+
+// stats returns the current state of the pool.
+func (p *databasePool) stats() databasePoolStats {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	stats := databasePoolStats{Size: len(p.entries), InUse: 0, OpenConnections: 0, Hits: p.hits, Misses: p.misses, Evictions: p.evictions}
+	for _, entry := range p.entries {
+		if entry.inUse > 0 {
+			stats.InUse++
+		}
+		stats.OpenConnections += entry.db.Stats().OpenConnections
+	}
+	return stats
+}
+
+// close closes all pooled databases.
+func (p *databasePool) close() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	for key := range p.entries {
+		p.evict(key)
+	}
+}
+
+// hashDataSourceName returns a hash of the connection string so that the pool does not need to keep credentials as keys.
+func hashDataSourceName(dataSourceName string) string {
+	hash := sha256.Sum256([]byte(dataSourceName))
+	return hex.EncodeToString(hash[:])
+}
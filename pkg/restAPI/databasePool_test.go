@@ -0,0 +1,207 @@
+package restAPI
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/exasol/exasol-driver-go"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/suite"
+)
+
+type DatabasePoolSuite struct {
+	suite.Suite
+	pool *databasePool
+	now  time.Time
+}
+
+func TestDatabasePoolSuite(t *testing.T) {
+	suite.Run(t, new(DatabasePoolSuite))
+}
+
+func (suite *DatabasePoolSuite) SetupTest() {
+	suite.now = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	suite.pool = newDatabasePool(DatabasePoolConfig{MaxSize: 2, IdleTimeout: time.Minute})
+	suite.pool.now = func() time.Time { return suite.now }
+}
+
+func (suite *DatabasePoolSuite) TearDownTest() {
+	suite.pool.close()
+}
+
+func (suite *DatabasePoolSuite) TestDefaultIdleTimeout() {
+	pool := newDatabasePool(DatabasePoolConfig{MaxSize: 1, IdleTimeout: 0})
+	suite.Equal(DefaultDatabasePoolIdleTimeout, pool.idleTimeout)
+}
+
+func (suite *DatabasePoolSuite) TestReusesDatabaseForSameCredentials() {
+	db1 := suite.acquireAndRelease(suite.dsn("user", "password"))
+	db2 := suite.acquireAndRelease(suite.dsn("user", "password"))
+	suite.Same(db1, db2)
+	suite.assertStats(databasePoolStats{Size: 1, InUse: 0, OpenConnections: 0, Hits: 1, Misses: 1, Evictions: 0})
+}
+
+func (suite *DatabasePoolSuite) TestDoesNotShareDatabaseBetweenCredentials() {
+	var tests = []struct {
+		name  string
+		other string
+	}{
+		{"other password", suite.dsn("user", "other password")},
+		{"other user", suite.dsn("other user", "password")},
+		{"access token", exasol.NewConfigWithAccessToken("token").Host("exasol.example.com").Port(8563).String()},
+		{"other host", exasol.NewConfig("user", "password").Host("other.example.com").Port(8563).String()},
+	}
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			suite.pool.close()
+			suite.SetupTest()
+			db1 := suite.acquireAndRelease(suite.dsn("user", "password"))
+			db2 := suite.acquireAndRelease(test.other)
+			suite.NotSame(db1, db2)
+			suite.Equal(2, suite.pool.stats().Size)
+		})
+	}
+}
+
+func (suite *DatabasePoolSuite) TestConcurrentRequestsShareDatabase() {
+	db1, release1 := suite.acquire(suite.dsn("user", "password"))
+	db2, release2 := suite.acquire(suite.dsn("user", "password"))
+	suite.Same(db1, db2)
+	suite.assertStats(databasePoolStats{Size: 1, InUse: 1, OpenConnections: 0, Hits: 1, Misses: 1, Evictions: 0})
+	release1()
+	suite.Equal(1, suite.pool.stats().InUse)
+	release2()
+	suite.Equal(0, suite.pool.stats().InUse)
+}
+
+func (suite *DatabasePoolSuite) TestEvictsIdleDatabase() {
+	db1 := suite.acquireAndRelease(suite.dsn("user", "password"))
+	suite.now = suite.now.Add(time.Minute + time.Second)
+	db2 := suite.acquireAndRelease(suite.dsn("user", "password"))
+	suite.NotSame(db1, db2)
+	suite.assertStats(databasePoolStats{Size: 1, InUse: 0, OpenConnections: 0, Hits: 0, Misses: 2, Evictions: 1})
+}
+
+func (suite *DatabasePoolSuite) TestKeepsDatabaseUsedWithinIdleTimeout() {
+	db1 := suite.acquireAndRelease(suite.dsn("user", "password"))
+	suite.now = suite.now.Add(time.Minute)
+	db2 := suite.acquireAndRelease(suite.dsn("user", "password"))
+	suite.Same(db1, db2)
+}
+
+func (suite *DatabasePoolSuite) TestDoesNotEvictDatabaseInUse() {
+	db1, release := suite.acquire(suite.dsn("user", "password"))
+	defer release()
+	suite.now = suite.now.Add(time.Hour)
+	db2 := suite.acquireAndRelease(suite.dsn("user", "password"))
+	suite.Same(db1, db2)
+}
+
+func (suite *DatabasePoolSuite) TestEvictsLeastRecentlyUsedDatabaseWhenFull() {
+	suite.acquireAndRelease(suite.dsn("user1", "password"))
+	suite.now = suite.now.Add(time.Second)
+	db2 := suite.acquireAndRelease(suite.dsn("user2", "password"))
+	suite.now = suite.now.Add(time.Second)
+	suite.acquireAndRelease(suite.dsn("user3", "password"))
+	suite.assertStats(databasePoolStats{Size: 2, InUse: 0, OpenConnections: 0, Hits: 0, Misses: 3, Evictions: 1})
+	suite.Same(db2, suite.acquireAndRelease(suite.dsn("user2", "password")))
+}
+
+func (suite *DatabasePoolSuite) TestOpensUnpooledDatabaseWhenAllDatabasesInUse() {
+	_, release1 := suite.acquire(suite.dsn("user1", "password"))
+	defer release1()
+	_, release2 := suite.acquire(suite.dsn("user2", "password"))
+	defer release2()
+	db3 := suite.acquireAndRelease(suite.dsn("user3", "password"))
+	suite.Error(db3.Ping(), "unpooled database is closed after release")
+	suite.assertStats(databasePoolStats{Size: 2, InUse: 2, OpenConnections: 0, Hits: 0, Misses: 3, Evictions: 0})
+}
+
+func (suite *DatabasePoolSuite) TestLimitsConnectionsOfPooledDatabase() {
+	db := suite.acquireAndRelease(suite.dsn("user", "password"))
+	suite.Equal(maxRequestsPerDatabase*connectionsPerRequest, db.Stats().MaxOpenConnections)
+}
+
+func (suite *DatabasePoolSuite) TestOpensUnpooledDatabaseWhenPooledDatabaseUsedByTooManyRequests() {
+	pooled, release := suite.acquire(suite.dsn("user", "password"))
+	defer release()
+	for i := 1; i < maxRequestsPerDatabase; i++ {
+		db, release := suite.acquire(suite.dsn("user", "password"))
+		defer release()
+		suite.Same(pooled, db)
+	}
+	unpooled := suite.acquireAndRelease(suite.dsn("user", "password"))
+	suite.NotSame(pooled, unpooled)
+	suite.Error(unpooled.Ping(), "unpooled database is closed after release")
+	suite.assertStats(databasePoolStats{Size: 1, InUse: 1, OpenConnections: 0, Hits: maxRequestsPerDatabase - 1, Misses: 2, Evictions: 0})
+}
+
+func (suite *DatabasePoolSuite) TestOpenFails() {
+	suite.pool.openDB = func(string) (*sql.DB, error) { return nil, errors.New("mock error") }
+	db, release, err := suite.pool.acquire(suite.dsn("user", "password"), "exasol.example.com:8563")
+	suite.EqualError(err, "failed to open a database connection. Cause: mock error")
+	suite.Nil(db)
+	suite.Nil(release)
+	suite.assertStats(databasePoolStats{Size: 0, InUse: 0, OpenConnections: 0, Hits: 0, Misses: 1, Evictions: 0})
+}
+
+func (suite *DatabasePoolSuite) TestClose() {
+	suite.acquireAndRelease(suite.dsn("user1", "password"))
+	suite.acquireAndRelease(suite.dsn("user2", "password"))
+	suite.pool.close()
+	suite.assertStats(databasePoolStats{Size: 0, InUse: 0, OpenConnections: 0, Hits: 0, Misses: 2, Evictions: 2})
+}
+
+func (suite *DatabasePoolSuite) TestHashDoesNotContainCredentials() {
+	hash := hashDataSourceName(suite.dsn("user", "secret-password"))
+	suite.Len(hash, 64)
+	suite.NotContains(hash, "secret-password")
+	suite.Equal(hash, hashDataSourceName(suite.dsn("user", "secret-password")))
+}
+
+func (suite *DatabasePoolSuite) TestCollectorExportsStats() {
+	suite.acquireAndRelease(suite.dsn("user", "password"))
+	suite.acquireAndRelease(suite.dsn("user", "password"))
+	_, release := suite.acquire(suite.dsn("other user", "password"))
+	defer release()
+	expected := `
+# HELP extension_manager_database_pool_entries Number of pooled databases.
+# TYPE extension_manager_database_pool_entries gauge
+extension_manager_database_pool_entries 2
+# HELP extension_manager_database_pool_entries_in_use Number of pooled databases currently used by requests.
+# TYPE extension_manager_database_pool_entries_in_use gauge
+extension_manager_database_pool_entries_in_use 1
+# HELP extension_manager_database_pool_hits_total Number of requests that reused a pooled database.
+# TYPE extension_manager_database_pool_hits_total counter
+extension_manager_database_pool_hits_total 1
+# HELP extension_manager_database_pool_misses_total Number of requests that opened a new database.
+# TYPE extension_manager_database_pool_misses_total counter
+extension_manager_database_pool_misses_total 2
+`
+	suite.NoError(testutil.CollectAndCompare(newDatabasePoolCollector(suite.pool), strings.NewReader(expected),
+		"extension_manager_database_pool_entries", "extension_manager_database_pool_entries_in_use",
+		"extension_manager_database_pool_hits_total", "extension_manager_database_pool_misses_total"))
+}
+
+func (suite *DatabasePoolSuite) acquire(dataSourceName string) (*sql.DB, func()) {
+	db, release, err := suite.pool.acquire(dataSourceName, "exasol.example.com:8563")
+	suite.Require().NoError(err)
+	return db, release
+}
+
+func (suite *DatabasePoolSuite) acquireAndRelease(dataSourceName string) *sql.DB {
+	db, release := suite.acquire(dataSourceName)
+	release()
+	return db
+}
+
+func (suite *DatabasePoolSuite) dsn(user, password string) string {
+	return exasol.NewConfig(user, password).Host("exasol.example.com").Port(8563).String()
+}
+
+func (suite *DatabasePoolSuite) assertStats(expected databasePoolStats) {
+	suite.Equal(expected, suite.pool.stats())
+}
@@ -0,0 +1,111 @@
+package restAPI
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/exasol/extension-manager/pkg/apiErrors"
+)
+
+const databaseTargetQueryParam = "dbTarget"
+
+// databaseTargets resolves the database a request connects to.
+//
+// Without configured targets clients specify the database with query parameters dbHost and dbPort.
+// With configured targets clients can only select one of the targets by name, so that EM never connects to arbitrary hosts.
+type databaseTargets struct {
+	targets       map[string]DatabaseTarget
+	names         []string
+	defaultTarget string
+}
+
+// newDatabaseTargets validates the targets of the given configuration.
+func newDatabaseTargets(config DatabaseConfig) (*databaseTargets, error) {
+	result := &databaseTargets{targets: make(map[string]DatabaseTarget), names: make([]string, 0, len(config.Targets)), defaultTarget: config.DefaultTarget}
+	for _, target := range config.Targets {
+		if err := validateDatabaseTarget(target); err != nil {
+			return nil, err
+		}
+		if _, exists := result.targets[target.Name]; exists {
+			return nil, fmt.Errorf("duplicate database target %q", target.Name)
+		}
+		result.targets[target.Name] = target
+		result.names = append(result.names, target.Name)
+	}
+	if config.DefaultTarget != "" {
+		if _, exists := result.targets[config.DefaultTarget]; !exists {
+			return nil, fmt.Errorf("default database target %q is not configured", config.DefaultTarget)
+		}
+	} else if len(config.Targets) == 1 {
+		result.defaultTarget = config.Targets[0].Name
+	}
+	return result, nil
+}
+
+func validateDatabaseTarget(target DatabaseTarget) error {
+	if target.Name == "" {
+		return errors.New("database target name must not be empty")
+	}
+	if target.Host == "" {
+		return fmt.Errorf("host of database target %q must not be empty", target.Name)
+	}
+	if target.Port < 1 || target.Port > 65535 {
+		return fmt.Errorf("port %d of database target %q is invalid", target.Port, target.Name)
+	}
+	return nil
+}
+
+// resolve returns host and port of the database selected by the given query parameters.
+func (t *databaseTargets) resolve(query url.Values) (host string, port int, err error) {
+	if len(t.targets) == 0 {
+		if query.Has(databaseTargetQueryParam) {
+			return "", 0, apiErrors.API_INVALID_DATABASE_TARGET.NewErrorF("query parameter %s is not supported because no database targets are configured, use dbHost and dbPort", databaseTargetQueryParam)
+		}
+		return getDbHostAndPort(query)
+	}
+	if query.Has("dbHost") || query.Has("dbPort") {
+		return "", 0, apiErrors.API_DATABASE_HOST_NOT_ALLOWED.NewErrorF("query parameters dbHost and dbPort are not allowed because database targets are configured")
+	}
+	name := query.Get(databaseTargetQueryParam)
+	if name == "" {
+		name = t.defaultTarget
+	}
+	if name == "" {
+		return "", 0, apiErrors.API_INVALID_DATABASE_TARGET.NewErrorF("missing query parameter %s, available targets: %s", databaseTargetQueryParam, strings.Join(t.names, ", "))
+	}
+	target, exists := t.targets[name]
+	if !exists {
+		return "", 0, apiErrors.API_INVALID_DATABASE_TARGET.NewErrorF("unknown database target %q, available targets: %s", name, strings.Join(t.names, ", "))
+	}
+	return target.Host, target.Port, nil
+}
+
+// ParseDatabaseTargets parses a comma separated list of database targets in the format "name=host:port",
+// e.g. "prod=exasol.example.com:8563,test=localhost:8563".
+func ParseDatabaseTargets(targets string) ([]DatabaseTarget, error) {
+	result := make([]DatabaseTarget, 0)
+	for _, entry := range strings.Split(targets, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, address, found := strings.Cut(entry, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid database target %q, expected format 'name=host:port'", entry)
+		}
+		host, portString, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address of database target %q: %w", entry, err)
+		}
+		port, err := strconv.Atoi(portString)
+		if err != nil {
+			return nil, fmt.Errorf("invalid port of database target %q: %w", entry, err)
+		}
+		result = append(result, DatabaseTarget{Name: strings.TrimSpace(name), Host: host, Port: port})
+	}
+	return result, nil
+}
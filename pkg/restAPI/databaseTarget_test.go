@@ -0,0 +1,164 @@
+package restAPI
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/exasol/extension-manager/pkg/apiErrors"
+	"github.com/stretchr/testify/suite"
+)
+
+type DatabaseTargetsSuite struct {
+	suite.Suite
+}
+
+func TestDatabaseTargetsSuite(t *testing.T) {
+	suite.Run(t, new(DatabaseTargetsSuite))
+}
+
+var (
+	prodTarget = DatabaseTarget{Name: "prod", Host: "exasol.example.com", Port: 8563}
+	testTarget = DatabaseTarget{Name: "test", Host: "localhost", Port: 8564}
+)
+
+func (suite *DatabaseTargetsSuite) TestResolveWithoutTargetsUsesQueryParameters() {
+	targets := suite.createTargets("")
+	host, port, err := targets.resolve(url.Values{"dbHost": {"host"}, "dbPort": {"1234"}})
+	suite.Require().NoError(err)
+	suite.Equal("host", host)
+	suite.Equal(1234, port)
+}
+
+func (suite *DatabaseTargetsSuite) TestResolveWithoutTargetsRejectsTargetParameter() {
+	targets := suite.createTargets("")
+	host, port, err := targets.resolve(url.Values{"dbHost": {"host"}, "dbPort": {"1234"}, "dbTarget": {"prod"}})
+	suite.EqualError(err, "query parameter dbTarget is not supported because no database targets are configured, use dbHost and dbPort")
+	suite.Equal("E-EM-API-12", apiErrors.UnwrapAPIError(err).ErrorCode)
+	suite.Empty(host)
+	suite.Zero(port)
+}
+
+func (suite *DatabaseTargetsSuite) TestResolve() {
+	var tests = []struct {
+		name          string
+		defaultTarget string
+		targets       []DatabaseTarget
+		query         url.Values
+		expectedHost  string
+		expectedPort  int
+	}{
+		{"selected target", "", []DatabaseTarget{prodTarget, testTarget}, url.Values{"dbTarget": {"test"}}, "localhost", 8564},
+		{"default target", "prod", []DatabaseTarget{prodTarget, testTarget}, url.Values{}, "exasol.example.com", 8563},
+		{"selected target overrides default", "prod", []DatabaseTarget{prodTarget, testTarget}, url.Values{"dbTarget": {"test"}}, "localhost", 8564},
+		{"single target is default", "", []DatabaseTarget{testTarget}, url.Values{}, "localhost", 8564},
+		{"empty target uses default", "prod", []DatabaseTarget{prodTarget, testTarget}, url.Values{"dbTarget": {""}}, "exasol.example.com", 8563},
+	}
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			targets := suite.createTargets(test.defaultTarget, test.targets...)
+			host, port, err := targets.resolve(test.query)
+			suite.Require().NoError(err)
+			suite.Equal(test.expectedHost, host)
+			suite.Equal(test.expectedPort, port)
+		})
+	}
+}
+
+func (suite *DatabaseTargetsSuite) TestResolveFails() {
+	var tests = []struct {
+		name              string
+		defaultTarget     string
+		query             url.Values
+		expectedError     string
+		expectedErrorCode string
+	}{
+		{"missing target", "", url.Values{}, "missing query parameter dbTarget, available targets: prod, test", "E-EM-API-12"},
+		{"unknown target", "prod", url.Values{"dbTarget": {"other"}}, `unknown database target "other", available targets: prod, test`, "E-EM-API-12"},
+		{"dbHost and dbPort", "prod", url.Values{"dbHost": {"attacker.example.com"}, "dbPort": {"8563"}}, "query parameters dbHost and dbPort are not allowed because database targets are configured", "E-EM-API-11"},
+		{"dbHost only", "prod", url.Values{"dbHost": {"attacker.example.com"}}, "query parameters dbHost and dbPort are not allowed because database targets are configured", "E-EM-API-11"},
+		{"dbPort with target", "prod", url.Values{"dbTarget": {"prod"}, "dbPort": {"1234"}}, "query parameters dbHost and dbPort are not allowed because database targets are configured", "E-EM-API-11"},
+	}
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			targets := suite.createTargets(test.defaultTarget, prodTarget, testTarget)
+			host, port, err := targets.resolve(test.query)
+			suite.EqualError(err, test.expectedError)
+			suite.Equal(test.expectedErrorCode, apiErrors.UnwrapAPIError(err).ErrorCode)
+			suite.Equal(400, apiErrors.UnwrapAPIError(err).Status)
+			suite.Empty(host)
+			suite.Zero(port)
+		})
+	}
+}
+
+func (suite *DatabaseTargetsSuite) TestInvalidConfigurations() {
+	var tests = []struct {
+		name          string
+		defaultTarget string
+		targets       []DatabaseTarget
+		expectedError string
+	}{
+		{"empty name", "", []DatabaseTarget{{Name: "", Host: "host", Port: 8563}}, "database target name must not be empty"},
+		{"empty host", "", []DatabaseTarget{{Name: "prod", Host: "", Port: 8563}}, `host of database target "prod" must not be empty`},
+		{"port zero", "", []DatabaseTarget{{Name: "prod", Host: "host", Port: 0}}, `port 0 of database target "prod" is invalid`},
+		{"port too large", "", []DatabaseTarget{{Name: "prod", Host: "host", Port: 65536}}, `port 65536 of database target "prod" is invalid`},
+		{"duplicate name", "", []DatabaseTarget{prodTarget, prodTarget}, `duplicate database target "prod"`},
+		{"unknown default target", "other", []DatabaseTarget{prodTarget}, `default database target "other" is not configured`},
+		{"default target without targets", "prod", nil, `default database target "prod" is not configured`},
+	}
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			targets, err := newDatabaseTargets(suite.createConfig(test.defaultTarget, test.targets...))
+			suite.EqualError(err, test.expectedError)
+			suite.Nil(targets)
+		})
+	}
+}
+
+func (suite *DatabaseTargetsSuite) TestParseDatabaseTargets() {
+	var tests = []struct {
+		value    string
+		expected []DatabaseTarget
+	}{
+		{"", []DatabaseTarget{}},
+		{" , ", []DatabaseTarget{}},
+		{"prod=exasol.example.com:8563", []DatabaseTarget{prodTarget}},
+		{"prod=exasol.example.com:8563, test=localhost:8564", []DatabaseTarget{prodTarget, testTarget}},
+		{"ipv6=[::1]:8563", []DatabaseTarget{{Name: "ipv6", Host: "::1", Port: 8563}}},
+	}
+	for _, test := range tests {
+		suite.Run(test.value, func() {
+			targets, err := ParseDatabaseTargets(test.value)
+			suite.Require().NoError(err)
+			suite.Equal(test.expected, targets)
+		})
+	}
+}
+
+func (suite *DatabaseTargetsSuite) TestParseDatabaseTargetsFails() {
+	var tests = []struct {
+		value         string
+		expectedError string
+	}{
+		{"exasol.example.com:8563", `invalid database target "exasol.example.com:8563", expected format 'name=host:port'`},
+		{"prod=exasol.example.com", `invalid address of database target "prod=exasol.example.com": address exasol.example.com: missing port in address`},
+		{"prod=exasol.example.com:port", `invalid port of database target "prod=exasol.example.com:port": strconv.Atoi: parsing "port": invalid syntax`},
+	}
+	for _, test := range tests {
+		suite.Run(test.value, func() {
+			targets, err := ParseDatabaseTargets(test.value)
+			suite.EqualError(err, test.expectedError)
+			suite.Nil(targets)
+		})
+	}
+}
+
+func (suite *DatabaseTargetsSuite) createTargets(defaultTarget string, targets ...DatabaseTarget) *databaseTargets {
+	result, err := newDatabaseTargets(suite.createConfig(defaultTarget, targets...))
+	suite.Require().NoError(err)
+	return result
+}
+
+func (suite *DatabaseTargetsSuite) createConfig(defaultTarget string, targets ...DatabaseTarget) DatabaseConfig {
+	return DatabaseConfig{ValidateServerCertificate: false, CABundleFile: "", CertificateFingerprint: "", Targets: targets, DefaultTarget: defaultTarget, Pool: DatabasePoolConfig{MaxSize: 0, IdleTimeout: 0}}
+}
@@ -0,0 +1,148 @@
+package restAPI
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/exasol/exasol-driver-go/pkg/dsn"
+)
+
+const databaseTLSHandshakeTimeout = 10 * time.Second
+
+// validatedFingerprintTTL is the time for reusing the fingerprint of a certificate validated with the CA bundle.
+// This avoids an additional TLS handshake for each database connection but still notices renewed certificates.
+const validatedFingerprintTTL = 5 * time.Minute
+
+var fingerprintPattern = regexp.MustCompile(`^[0-9a-fA-F]{64}$`)
+
+// databaseTLS applies the TLS options from [DatabaseConfig] to the configuration of database connections.
+type databaseTLS struct {
+	validateServerCertificate bool
+	certificateFingerprint    string
+	caPool                    *x509.CertPool
+	fingerprintsMutex         sync.Mutex
+	fingerprints              map[string]validatedFingerprint // Fingerprints of validated certificates by database address
+}
+
+// validatedFingerprint is the fingerprint of a certificate validated with the CA bundle.
+type validatedFingerprint struct {
+	fingerprint string
+	expires     time.Time
+}
+
+// newDatabaseTLS validates the given configuration and loads the CA bundle if configured.
+func newDatabaseTLS(config DatabaseConfig) (*databaseTLS, error) {
+	if config.CertificateFingerprint != "" && !fingerprintPattern.MatchString(config.CertificateFingerprint) {
+		return nil, fmt.Errorf("invalid database certificate fingerprint %q, expected SHA256 checksum with 64 hex characters", config.CertificateFingerprint)
+	}
+	result := &databaseTLS{
+		validateServerCertificate: config.ValidateServerCertificate,
+		certificateFingerprint:    strings.ToLower(config.CertificateFingerprint),
+		caPool:                    nil,
+		fingerprintsMutex:         sync.Mutex{},
+		fingerprints:              make(map[string]validatedFingerprint),
+	}
+	if config.CABundleFile == "" {
+		return result, nil
+	}
+	if !config.ValidateServerCertificate {
+		return nil, errors.New("database CA bundle requires validating the database certificate")
+	}
+	caPool, err := loadCertPool(config.CABundleFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load database CA bundle: %w", err)
+	}
+	result.caPool = caPool
+	return result, nil
+}
+
+func loadCertPool(file string) (*x509.CertPool, error) {
+	content, err := os.ReadFile(file) //nolint:gosec // File is configured by the administrator
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %q: %w", file, err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(content) {
+		return nil, fmt.Errorf("file %q does not contain any PEM encoded certificates", file)
+	}
+	return pool, nil
+}
+
+// configure sets the TLS options for a connection to the given database host and port.
+//
+// The Exasol driver only validates certificates using the system's CA certificates.
+// When a CA bundle is configured, EM validates the certificate itself and tells the driver to accept only
+// the certificate with the fingerprint of the validated certificate.
+// EM reuses the validated fingerprint of a database for [validatedFingerprintTTL] or until the certificate expires.
+func (t *databaseTLS) configure(config *dsn.DSNConfigBuilder, host string, port int) error {
+	if t.caPool == nil {
+		config.ValidateServerCertificate(t.validateServerCertificate)
+		if t.certificateFingerprint != "" {
+			config.CertificateFingerprint(t.certificateFingerprint)
+		}
+		return nil
+	}
+	address := net.JoinHostPort(host, strconv.Itoa(port))
+	fingerprint, err := t.getValidatedFingerprint(address, host)
+	if err != nil {
+		return err
+	}
+	if t.certificateFingerprint != "" && t.certificateFingerprint != fingerprint {
+		return fmt.Errorf("certificate of database %s has fingerprint %q but expected %q", address, fingerprint, t.certificateFingerprint)
+	}
+	config.ValidateServerCertificate(false)
+	config.CertificateFingerprint(fingerprint)
+	return nil
+}
+
+// getValidatedFingerprint returns the fingerprint of the database's certificate validated with the CA bundle,
+// either from the cache or by connecting to the database.
+func (t *databaseTLS) getValidatedFingerprint(address, host string) (string, error) {
+	t.fingerprintsMutex.Lock()
+	cached, found := t.fingerprints[address]
+	t.fingerprintsMutex.Unlock()
+	if found && time.Now().Before(cached.expires) {
+		return cached.fingerprint, nil
+	}
+	validated, err := t.validateCertificate(address, host)
+	if err != nil {
+		return "", err
+	}
+	t.fingerprintsMutex.Lock()
+	t.fingerprints[address] = validated
+	t.fingerprintsMutex.Unlock()
+	return validated.fingerprint, nil
+}
+
+// validateCertificate connects to the database, validates its certificate using the CA bundle and returns the certificate's SHA256 fingerprint.
+func (t *databaseTLS) validateCertificate(address, host string) (validatedFingerprint, error) {
+	//nolint:exhaustruct // Default values are ok for other fields
+	dialer := &net.Dialer{Timeout: databaseTLSHandshakeTimeout}
+	//nolint:exhaustruct // Default values are ok for other fields
+	conn, err := tls.DialWithDialer(dialer, "tcp", address, &tls.Config{RootCAs: t.caPool, ServerName: host, MinVersion: tls.VersionTLS12})
+	if err != nil {
+		return validatedFingerprint{}, fmt.Errorf("failed to validate certificate of database %s: %w", address, err)
+	}
+	defer conn.Close()
+	certificates := conn.ConnectionState().PeerCertificates
+	if len(certificates) == 0 {
+		return validatedFingerprint{}, fmt.Errorf("database %s did not send a certificate", address)
+	}
+	checksum := sha256.Sum256(certificates[0].Raw)
+	expires := time.Now().Add(validatedFingerprintTTL)
+	if certificates[0].NotAfter.Before(expires) {
+		expires = certificates[0].NotAfter
+	}
+	return validatedFingerprint{fingerprint: hex.EncodeToString(checksum[:]), expires: expires}, nil
+}
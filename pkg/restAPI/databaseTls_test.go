@@ -0,0 +1,174 @@
+package restAPI
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/pem"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/exasol/exasol-driver-go"
+	"github.com/stretchr/testify/suite"
+)
+
+type DatabaseTLSSuite struct {
+	suite.Suite
+	tlsServer *httptest.Server
+	host      string
+	port      int
+}
+
+func TestDatabaseTLSSuite(t *testing.T) {
+	suite.Run(t, new(DatabaseTLSSuite))
+}
+
+func (suite *DatabaseTLSSuite) SetupSuite() {
+	suite.tlsServer = httptest.NewTLSServer(http.NotFoundHandler())
+	host, port, err := net.SplitHostPort(suite.tlsServer.Listener.Addr().String())
+	suite.Require().NoError(err)
+	suite.host = host
+	suite.port, err = strconv.Atoi(port)
+	suite.Require().NoError(err)
+}
+
+func (suite *DatabaseTLSSuite) TearDownSuite() {
+	suite.tlsServer.Close()
+}
+
+func (suite *DatabaseTLSSuite) TestDefaultConfigDisablesValidation() {
+	dsnString := suite.configure(DatabaseConfig{ValidateServerCertificate: false, CABundleFile: "", CertificateFingerprint: "", Targets: nil, DefaultTarget: "", Pool: DatabasePoolConfig{MaxSize: 0, IdleTimeout: 0}})
+	suite.Contains(dsnString, "validateservercertificate=0")
+	suite.NotContains(dsnString, "certificatefingerprint")
+}
+
+func (suite *DatabaseTLSSuite) TestValidateWithSystemCertificates() {
+	dsnString := suite.configure(DatabaseConfig{ValidateServerCertificate: true, CABundleFile: "", CertificateFingerprint: "", Targets: nil, DefaultTarget: "", Pool: DatabasePoolConfig{MaxSize: 0, IdleTimeout: 0}})
+	suite.Contains(dsnString, "validateservercertificate=1")
+}
+
+func (suite *DatabaseTLSSuite) TestFingerprintIsPassedToDriver() {
+	fingerprint := suite.serverFingerprint()
+	dsnString := suite.configure(DatabaseConfig{ValidateServerCertificate: false, CABundleFile: "", CertificateFingerprint: fingerprint, Targets: nil, DefaultTarget: "", Pool: DatabasePoolConfig{MaxSize: 0, IdleTimeout: 0}})
+	suite.Contains(dsnString, "certificatefingerprint="+fingerprint)
+}
+
+func (suite *DatabaseTLSSuite) TestCABundlePinsValidatedCertificate() {
+	dsnString := suite.configure(DatabaseConfig{ValidateServerCertificate: true, CABundleFile: suite.writeServerCertificate(), CertificateFingerprint: "", Targets: nil, DefaultTarget: "", Pool: DatabasePoolConfig{MaxSize: 0, IdleTimeout: 0}})
+	suite.Contains(dsnString, "validateservercertificate=0")
+	suite.Contains(dsnString, "certificatefingerprint="+suite.serverFingerprint())
+}
+
+func (suite *DatabaseTLSSuite) TestCABundleReusesValidatedFingerprint() {
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	host, port := suite.splitAddress(server)
+	dbTLS, err := newDatabaseTLS(DatabaseConfig{ValidateServerCertificate: true, CABundleFile: suite.writeCertificate(server), CertificateFingerprint: "", Targets: nil, DefaultTarget: "", Pool: DatabasePoolConfig{MaxSize: 0, IdleTimeout: 0}})
+	suite.Require().NoError(err)
+	suite.Require().NoError(dbTLS.configure(exasol.NewConfig("user", "password"), host, port))
+	server.Close()
+	dsnConfig := exasol.NewConfig("user", "password")
+	suite.Require().NoError(dbTLS.configure(dsnConfig, host, port))
+	suite.Contains(dsnConfig.String(), "certificatefingerprint="+fingerprint(server))
+}
+
+func (suite *DatabaseTLSSuite) TestCABundleValidatesCertificateAgainAfterExpiry() {
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	host, port := suite.splitAddress(server)
+	dbTLS, err := newDatabaseTLS(DatabaseConfig{ValidateServerCertificate: true, CABundleFile: suite.writeCertificate(server), CertificateFingerprint: "", Targets: nil, DefaultTarget: "", Pool: DatabasePoolConfig{MaxSize: 0, IdleTimeout: 0}})
+	suite.Require().NoError(err)
+	suite.Require().NoError(dbTLS.configure(exasol.NewConfig("user", "password"), host, port))
+	address := server.Listener.Addr().String()
+	dbTLS.fingerprints[address] = validatedFingerprint{fingerprint: dbTLS.fingerprints[address].fingerprint, expires: time.Now().Add(-time.Second)}
+	server.Close()
+	err = dbTLS.configure(exasol.NewConfig("user", "password"), host, port)
+	suite.ErrorContains(err, "failed to validate certificate of database "+address)
+}
+
+func (suite *DatabaseTLSSuite) TestCABundleWithMatchingFingerprint() {
+	dsnString := suite.configure(DatabaseConfig{ValidateServerCertificate: true, CABundleFile: suite.writeServerCertificate(), CertificateFingerprint: suite.serverFingerprint(), Targets: nil, DefaultTarget: "", Pool: DatabasePoolConfig{MaxSize: 0, IdleTimeout: 0}})
+	suite.Contains(dsnString, "certificatefingerprint="+suite.serverFingerprint())
+}
+
+func (suite *DatabaseTLSSuite) TestCABundleWithWrongFingerprintFails() {
+	wrongFingerprint := "0000000000000000000000000000000000000000000000000000000000000000"
+	dbTLS, err := newDatabaseTLS(DatabaseConfig{ValidateServerCertificate: true, CABundleFile: suite.writeServerCertificate(), CertificateFingerprint: wrongFingerprint, Targets: nil, DefaultTarget: "", Pool: DatabasePoolConfig{MaxSize: 0, IdleTimeout: 0}})
+	suite.Require().NoError(err)
+	err = dbTLS.configure(exasol.NewConfig("user", "password"), suite.host, suite.port)
+	suite.EqualError(err, "certificate of database "+suite.tlsServer.Listener.Addr().String()+" has fingerprint \""+suite.serverFingerprint()+"\" but expected \""+wrongFingerprint+"\"")
+}
+
+func (suite *DatabaseTLSSuite) TestCABundleWithUntrustedCertificateFails() {
+	caFile, _ := writeSelfSignedCertificate(&suite.Suite, suite.T().TempDir(), "other")
+	dbTLS, err := newDatabaseTLS(DatabaseConfig{ValidateServerCertificate: true, CABundleFile: caFile, CertificateFingerprint: "", Targets: nil, DefaultTarget: "", Pool: DatabasePoolConfig{MaxSize: 0, IdleTimeout: 0}})
+	suite.Require().NoError(err)
+	err = dbTLS.configure(exasol.NewConfig("user", "password"), suite.host, suite.port)
+	suite.ErrorContains(err, "failed to validate certificate of database "+suite.tlsServer.Listener.Addr().String())
+	suite.ErrorContains(err, "certificate signed by unknown authority")
+}
+
+func (suite *DatabaseTLSSuite) TestInvalidConfigurations() {
+	invalidPemFile := path.Join(suite.T().TempDir(), "invalid.pem")
+	suite.Require().NoError(os.WriteFile(invalidPemFile, []byte("no certificate"), 0600))
+	var tests = []struct {
+		name          string
+		config        DatabaseConfig
+		expectedError string
+	}{
+		{"invalid fingerprint", DatabaseConfig{ValidateServerCertificate: false, CABundleFile: "", CertificateFingerprint: "abc", Targets: nil, DefaultTarget: "", Pool: DatabasePoolConfig{MaxSize: 0, IdleTimeout: 0}},
+			`invalid database certificate fingerprint "abc", expected SHA256 checksum with 64 hex characters`},
+		{"CA bundle without validation", DatabaseConfig{ValidateServerCertificate: false, CABundleFile: invalidPemFile, CertificateFingerprint: "", Targets: nil, DefaultTarget: "", Pool: DatabasePoolConfig{MaxSize: 0, IdleTimeout: 0}},
+			"database CA bundle requires validating the database certificate"},
+		{"missing CA bundle", DatabaseConfig{ValidateServerCertificate: true, CABundleFile: "missing.pem", CertificateFingerprint: "", Targets: nil, DefaultTarget: "", Pool: DatabasePoolConfig{MaxSize: 0, IdleTimeout: 0}},
+			`failed to load database CA bundle: failed to read file "missing.pem": open missing.pem: no such file or directory`},
+		{"CA bundle without certificates", DatabaseConfig{ValidateServerCertificate: true, CABundleFile: invalidPemFile, CertificateFingerprint: "", Targets: nil, DefaultTarget: "", Pool: DatabasePoolConfig{MaxSize: 0, IdleTimeout: 0}},
+			`failed to load database CA bundle: file "` + invalidPemFile + `" does not contain any PEM encoded certificates`},
+	}
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			dbTLS, err := newDatabaseTLS(test.config)
+			suite.EqualError(err, test.expectedError)
+			suite.Nil(dbTLS)
+		})
+	}
+}
+
+func (suite *DatabaseTLSSuite) configure(config DatabaseConfig) string {
+	dbTLS, err := newDatabaseTLS(config)
+	suite.Require().NoError(err)
+	dsnConfig := exasol.NewConfig("user", "password")
+	suite.Require().NoError(dbTLS.configure(dsnConfig, suite.host, suite.port))
+	return dsnConfig.String()
+}
+
+func (suite *DatabaseTLSSuite) writeServerCertificate() string {
+	return suite.writeCertificate(suite.tlsServer)
+}
+
+func (suite *DatabaseTLSSuite) writeCertificate(server *httptest.Server) string {
+	file := path.Join(suite.T().TempDir(), "ca.pem")
+	content := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	suite.Require().NoError(os.WriteFile(file, content, 0600))
+	return file
+}
+
+func (suite *DatabaseTLSSuite) splitAddress(server *httptest.Server) (string, int) {
+	host, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	suite.Require().NoError(err)
+	portNumber, err := strconv.Atoi(port)
+	suite.Require().NoError(err)
+	return host, portNumber
+}
+
+func (suite *DatabaseTLSSuite) serverFingerprint() string {
+	return fingerprint(suite.tlsServer)
+}
+
+func fingerprint(server *httptest.Server) string {
+	checksum := sha256.Sum256(server.Certificate().Raw)
+	return hex.EncodeToString(checksum[:])
+}
@@ -5,26 +5,87 @@ import (
 	"encoding/base64"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/exasol/exasol-driver-go"
 	"github.com/exasol/exasol-driver-go/pkg/dsn"
 	"github.com/exasol/extension-manager/pkg/apiErrors"
+	"github.com/exasol/extension-manager/pkg/extensionController/bfs"
 )
 
 type generalHandlerFunc = func(writer http.ResponseWriter, request *http.Request)
 type dbHandler = func(db *sql.DB, writer http.ResponseWriter, request *http.Request) error
 
-func adaptDbHandler(apiContext *ApiContext, handler dbHandler) generalHandlerFunc {
+// databaseConnector opens database connections using the options from [DatabaseConfig].
+type databaseConnector struct {
+	tls     *databaseTLS
+	targets *databaseTargets
+	pool    *databasePool // nil if pooling is disabled
+}
+
+// newDatabaseConnector validates the given configuration and creates a new connector.
+func newDatabaseConnector(config DatabaseConfig) (*databaseConnector, error) {
+	dbTLS, err := newDatabaseTLS(config)
+	if err != nil {
+		return nil, err
+	}
+	targets, err := newDatabaseTargets(config)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateDatabasePoolConfig(config.Pool); err != nil {
+		return nil, err
+	}
+	connector := &databaseConnector{tls: dbTLS, targets: targets, pool: nil}
+	if config.Pool.MaxSize > 0 {
+		connector.pool = newDatabasePool(config.Pool)
+	}
+	return connector, nil
+}
+
+func validateDatabasePoolConfig(config DatabasePoolConfig) error {
+	if config.MaxSize < 0 {
+		return fmt.Errorf("invalid database pool size %d, expected 0 (disabled) or a positive number", config.MaxSize)
+	}
+	if config.IdleTimeout < 0 {
+		return fmt.Errorf("invalid database pool idle timeout %v, expected a positive duration", config.IdleTimeout)
+	}
+	return nil
+}
+
+// newDefaultDatabaseConnector creates a connector that does not validate the database certificate,
+// connects to the database specified by query parameters dbHost and dbPort and does not pool connections.
+func newDefaultDatabaseConnector() *databaseConnector {
+	return &databaseConnector{
+		tls:     &databaseTLS{validateServerCertificate: false, certificateFingerprint: "", caPool: nil, fingerprintsMutex: sync.Mutex{}, fingerprints: nil},
+		targets: &databaseTargets{targets: make(map[string]DatabaseTarget), names: nil, defaultTarget: ""},
+		pool:    nil,
+	}
+}
+
+// close closes all pooled database connections.
+func (c *databaseConnector) close() {
+	if c.pool != nil {
+		c.pool.close()
+	}
+}
+
+// adaptDbHandler executes a read-only operation with a connection to the database selected by the request.
+func adaptDbHandler(apiContext *ApiContext, operationName string, handler dbHandler) generalHandlerFunc {
 	return func(writer http.ResponseWriter, request *http.Request) {
-		db, err := openDBRequest(request)
+		addOperationLogFields(request, operationName)
+		db, databaseAddress, release, err := openDBRequest(apiContext, request)
 		if err != nil {
 			handleError(request.Context(), apiContext, writer, err)
 			return
 		}
-		defer closeDBRequest(db)
+		defer release()
+		request = request.WithContext(bfs.WithDatabaseHost(request.Context(), databaseAddress))
 		err = handler(db, writer, request)
 		if err != nil {
 			handleError(request.Context(), apiContext, writer, err)
@@ -32,53 +93,82 @@ func adaptDbHandler(apiContext *ApiContext, handler dbHandler) generalHandlerFun
 	}
 }
 
-func openDBRequest(request *http.Request) (*sql.DB, error) {
-	config, err := createDbConfig(request)
+// openDBRequest opens a connection to the database selected by the request and returns it together with the database's address "host:port"
+// and a function that releases the connection after the request.
+func openDBRequest(apiContext *ApiContext, request *http.Request) (db *sql.DB, databaseAddress string, release func(), err error) {
+	config, host, port, err := createDbConfig(apiContext.database.targets, request)
 	if err != nil {
-		return nil, err
+		return nil, "", nil, err
+	}
+	if err := apiContext.database.tls.configure(config, host, port); err != nil {
+		return nil, "", nil, apiErrors.API_DATABASE_CERTIFICATE_INVALID.NewInternalErrorF("%w", err)
 	}
-	config.ValidateServerCertificate(false)
 	config.Autocommit(false)
-	database, err := sql.Open("exasol", config.String())
+	databaseAddress = getDatabaseAddress(host, port)
+	if apiContext.database.pool != nil {
+		db, release, err = apiContext.database.pool.acquire(config.String(), databaseAddress)
+		if err != nil {
+			return nil, "", nil, err
+		}
+		return db, databaseAddress, release, nil
+	}
+	db, err = sql.Open("exasol", config.String())
 	if err != nil {
-		return nil, fmt.Errorf("failed to open a database connection. Cause: %w", err)
+		return nil, "", nil, fmt.Errorf("failed to open a database connection. Cause: %w", err)
 	}
-	return database, nil
+	return db, databaseAddress, func() { closeDBRequest(db) }, nil
 }
 
-func createDbConfig(request *http.Request) (*dsn.DSNConfigBuilder, error) {
-	query := request.URL.Query()
-	config, err := createDbConfigWithAuthentication(request)
+func createDbConfig(targets *databaseTargets, request *http.Request) (config *dsn.DSNConfigBuilder, host string, port int, err error) {
+	config, err = createDbConfigWithAuthentication(request)
 	if err != nil {
-		return nil, err
+		return nil, "", 0, err
 	}
-
-	if host := query.Get("dbHost"); host == "" {
-		return nil, apiErrors.NewBadRequestErrorF("missing parameter dbHost")
-	} else {
-		config.Host(host)
+	host, port, err = targets.resolve(request.URL.Query())
+	if err != nil {
+		return nil, "", 0, err
 	}
+	config.Host(host)
+	config.Port(port)
+	return config, host, port, nil
+}
 
+// getDbHostAndPort validates and returns the dbHost and dbPort query parameters.
+// The returned error contains details for each missing or invalid parameter.
+func getDbHostAndPort(query url.Values) (host string, port int, err error) {
+	details := make([]apiErrors.ErrorDetail, 0)
+	host = query.Get("dbHost")
+	if host == "" {
+		details = append(details, apiErrors.ErrorDetail{Field: "dbHost", ErrorCode: apiErrors.DETAIL_MISSING_VALUE, Message: "missing parameter dbHost"})
+	}
 	if portString := query.Get("dbPort"); portString == "" {
-		return nil, apiErrors.NewBadRequestErrorF("missing parameter dbPort")
-	} else {
-		if port, err := strconv.Atoi(portString); err != nil {
-			return nil, apiErrors.NewBadRequestErrorF("invalid value '%s' for parameter dbPort", portString)
-		} else {
-			config.Port(port)
+		details = append(details, apiErrors.ErrorDetail{Field: "dbPort", ErrorCode: apiErrors.DETAIL_MISSING_VALUE, Message: "missing parameter dbPort"})
+	} else if port, err = strconv.Atoi(portString); err != nil {
+		details = append(details, apiErrors.ErrorDetail{Field: "dbPort", ErrorCode: apiErrors.DETAIL_INVALID_VALUE, Message: fmt.Sprintf("invalid value '%s' for parameter dbPort", portString)})
+	}
+	if len(details) > 0 {
+		messages := make([]string, 0, len(details))
+		for _, detail := range details {
+			messages = append(messages, detail.Message)
 		}
+		return "", 0, apiErrors.API_INVALID_DATABASE_PARAMETERS.NewErrorWithDetails(strings.Join(messages, ", "), details)
 	}
-	return config, nil
+	return host, port, nil
+}
+
+// getDatabaseAddress returns the address of the database in format "host:port" or "[host]:port" for IPv6 addresses.
+func getDatabaseAddress(host string, port int) string {
+	return net.JoinHostPort(host, strconv.Itoa(port))
 }
 
 func createDbConfigWithAuthentication(request *http.Request) (*dsn.DSNConfigBuilder, error) {
 	auth := request.Header.Get("Authorization")
 	if auth == "" {
-		return nil, apiErrors.NewUnauthorizedErrorF("missing Authorization header")
+		return nil, apiErrors.API_MISSING_AUTHORIZATION.NewErrorF("missing Authorization header")
 	}
 	parts := strings.Split(auth, " ")
 	if len(parts) < 2 {
-		return nil, apiErrors.NewUnauthorizedErrorF("invalid Authorization header %q", auth)
+		return nil, apiErrors.API_INVALID_AUTHORIZATION.NewErrorF("invalid Authorization header, expected scheme and credentials separated by a space")
 	}
 	scheme := parts[0]
 	switch scheme {
@@ -87,7 +177,7 @@ func createDbConfigWithAuthentication(request *http.Request) (*dsn.DSNConfigBuil
 	case "Bearer":
 		return exasol.NewConfigWithAccessToken(parts[1]), nil
 	default:
-		return nil, apiErrors.NewUnauthorizedErrorF("invalid Authorization scheme %q", parts[0])
+		return nil, apiErrors.API_INVALID_AUTHORIZATION.NewErrorF("invalid Authorization scheme %q", parts[0])
 	}
 }
 
@@ -102,12 +192,12 @@ func newUserPasswordConfig(basicAuthCredentials string) (*dsn.DSNConfigBuilder,
 func extractUserPassword(basicAuthCredentials string) (string, string, error) {
 	data, err := base64.StdEncoding.DecodeString(basicAuthCredentials)
 	if err != nil {
-		return "", "", apiErrors.NewUnauthorizedErrorF("invalid basic auth header %q: %v", basicAuthCredentials, err)
+		return "", "", apiErrors.API_INVALID_AUTHORIZATION.NewErrorF("invalid basic auth header: %v", err)
 	}
 	userPassword := string(data)
 	colon := strings.Index(userPassword, ":")
 	if colon < 0 {
-		return "", "", apiErrors.NewUnauthorizedErrorF("colon missing in basic auth header")
+		return "", "", apiErrors.API_INVALID_AUTHORIZATION.NewErrorF("colon missing in basic auth header")
 	}
 	user := userPassword[:colon]
 	password := userPassword[colon+1:]
@@ -2,8 +2,12 @@ package restAPI
 
 import (
 	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/exasol/extension-manager/pkg/apiErrors"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -25,7 +29,7 @@ func (suite *ApiContextSuite) TestExtractUserPasswordExample() {
 
 func (suite *ApiContextSuite) TestExtractUserPasswordInvalidBase64() {
 	user, password, err := extractUserPassword("invalid base64")
-	suite.Require().EqualError(err, "invalid basic auth header \"invalid base64\": illegal base64 data at input byte 7")
+	suite.Require().EqualError(err, "invalid basic auth header: illegal base64 data at input byte 7")
 	suite.Empty(user)
 	suite.Empty(password)
 }
@@ -58,3 +62,95 @@ func (suite *ApiContextSuite) TestExtractUserPassword() {
 		})
 	}
 }
+
+func (suite *ApiContextSuite) TestGetDatabaseAddress() {
+	suite.Equal("exasol.example.com:8563", getDatabaseAddress("exasol.example.com", 8563))
+	suite.Equal("192.168.0.1:8563", getDatabaseAddress("192.168.0.1", 8563))
+	suite.Equal("[::1]:8563", getDatabaseAddress("::1", 8563))
+}
+
+func (suite *ApiContextSuite) TestOpenDBRequestReturnsDatabaseAddress() {
+	request := httptest.NewRequest(http.MethodGet, "/path?dbHost=exasol.example.com&dbPort=8563", nil)
+	request.Header.Add("Authorization", createBasicAuthHeader("user", "password"))
+	db, address, release, err := openDBRequest(NewApiContext(createMockExtensionController(), false), request)
+	suite.Require().NoError(err)
+	defer release()
+	suite.NotNil(db)
+	suite.Equal("exasol.example.com:8563", address)
+}
+
+func (suite *ApiContextSuite) TestOpenDBRequestReturnsAddressOfDatabaseTarget() {
+	database, err := newDatabaseConnector(DatabaseConfig{ValidateServerCertificate: false, CABundleFile: "", CertificateFingerprint: "",
+		Targets: []DatabaseTarget{{Name: "prod", Host: "exasol.example.com", Port: 8563}}, DefaultTarget: "", Pool: DatabasePoolConfig{MaxSize: 0, IdleTimeout: 0}})
+	suite.Require().NoError(err)
+	apiContext := NewApiContext(createMockExtensionController(), false)
+	apiContext.database = database
+	request := httptest.NewRequest(http.MethodGet, "/path", nil)
+	request.Header.Add("Authorization", createBasicAuthHeader("user", "password"))
+	db, address, release, err := openDBRequest(apiContext, request)
+	suite.Require().NoError(err)
+	defer release()
+	suite.NotNil(db)
+	suite.Equal("exasol.example.com:8563", address)
+}
+
+func (suite *ApiContextSuite) TestOpenDBRequestFailsValidatingCertificate() {
+	caFile, _ := writeSelfSignedCertificate(&suite.Suite, suite.T().TempDir(), "ca")
+	database, err := newDatabaseConnector(DatabaseConfig{ValidateServerCertificate: true, CABundleFile: caFile, CertificateFingerprint: "", Targets: nil, DefaultTarget: "", Pool: DatabasePoolConfig{MaxSize: 0, IdleTimeout: 0}})
+	suite.Require().NoError(err)
+	apiContext := NewApiContext(createMockExtensionController(), false)
+	apiContext.database = database
+	request := httptest.NewRequest(http.MethodGet, "/path?dbHost=localhost&dbPort=1", nil)
+	request.Header.Add("Authorization", createBasicAuthHeader("user", "password"))
+	db, address, release, err := openDBRequest(apiContext, request)
+	suite.ErrorContains(err, "failed to validate certificate of database localhost:1")
+	suite.Equal("E-EM-API-10", apiErrors.UnwrapAPIError(err).ErrorCode)
+	suite.Nil(db)
+	suite.Empty(address)
+	suite.Nil(release)
+}
+
+func (suite *ApiContextSuite) TestOpenDBRequestReusesPooledDatabase() {
+	database, err := newDatabaseConnector(DatabaseConfig{ValidateServerCertificate: false, CABundleFile: "", CertificateFingerprint: "", Targets: nil, DefaultTarget: "",
+		Pool: DatabasePoolConfig{MaxSize: 2, IdleTimeout: time.Minute}})
+	suite.Require().NoError(err)
+	apiContext := NewApiContext(createMockExtensionController(), false)
+	apiContext.database = database
+	defer database.close()
+	db1, _, release1, err := openDBRequest(apiContext, suite.createRequest("user", "password"))
+	suite.Require().NoError(err)
+	release1()
+	db2, _, release2, err := openDBRequest(apiContext, suite.createRequest("user", "password"))
+	suite.Require().NoError(err)
+	release2()
+	db3, _, release3, err := openDBRequest(apiContext, suite.createRequest("user", "other password"))
+	suite.Require().NoError(err)
+	release3()
+	suite.Same(db1, db2)
+	suite.NotSame(db1, db3)
+	suite.Equal(databasePoolStats{Size: 2, InUse: 0, OpenConnections: 0, Hits: 1, Misses: 2, Evictions: 0}, database.pool.stats())
+}
+
+func (suite *ApiContextSuite) TestInvalidPoolConfiguration() {
+	var tests = []struct {
+		name          string
+		pool          DatabasePoolConfig
+		expectedError string
+	}{
+		{"negative size", DatabasePoolConfig{MaxSize: -1, IdleTimeout: 0}, "invalid database pool size -1, expected 0 (disabled) or a positive number"},
+		{"negative idle timeout", DatabasePoolConfig{MaxSize: 1, IdleTimeout: -time.Second}, "invalid database pool idle timeout -1s, expected a positive duration"},
+	}
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			database, err := newDatabaseConnector(DatabaseConfig{ValidateServerCertificate: false, CABundleFile: "", CertificateFingerprint: "", Targets: nil, DefaultTarget: "", Pool: test.pool})
+			suite.EqualError(err, test.expectedError)
+			suite.Nil(database)
+		})
+	}
+}
+
+func (suite *ApiContextSuite) createRequest(user, password string) *http.Request {
+	request := httptest.NewRequest(http.MethodGet, "/path?dbHost=exasol.example.com&dbPort=8563", nil)
+	request.Header.Add("Authorization", createBasicAuthHeader(user, password))
+	return request
+}
@@ -0,0 +1,189 @@
+package restAPI
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Nightapes/go-rest/pkg/openapi"
+	"github.com/exasol/extension-manager/pkg/apiErrors"
+	"github.com/exasol/extension-manager/pkg/extensionController/bfs"
+	"github.com/exasol/extension-manager/pkg/logging"
+	"github.com/go-chi/chi/v5/middleware"
+	log "github.com/sirupsen/logrus"
+)
+
+// dbOperation executes a modifying operation and returns the result sent to the client or nil if the operation has no result.
+type dbOperation = func(ctx context.Context, db *sql.DB) (any, error)
+
+// operationHandler reads the request and returns the operation to execute.
+type operationHandler = func(writer http.ResponseWriter, request *http.Request) (dbOperation, error)
+
+const asyncQueryParam = "async"
+
+// newPathWithAsyncQueryParam creates a base path like [newPathWithDbQueryParams] that additionally accepts query parameter async.
+func newPathWithAsyncQueryParam() *openapi.PathBuilder {
+	path := newPathWithDbQueryParams()
+	path.WithQueryParameter(asyncQueryParam, openapi.BOOLEAN, "Run the operation asynchronously. If this is true, the response has status 202 and contains a job. "+
+		"Get the status and result of the job from endpoint /jobs/{jobId}.", false)
+	return path
+}
+
+// adaptDbOperation executes a modifying operation. If the request contains query parameter async=true,
+// it starts a job that executes the operation in the background and returns the job to the client.
+func adaptDbOperation(apiContext *ApiContext, operationName string, handler operationHandler) generalHandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		addOperationLogFields(request, operationName)
+		if apiContext.drainer.isDraining() {
+			handleError(request.Context(), apiContext, writer, apiErrors.API_SHUTTING_DOWN.NewErrorF("server is shutting down, operation %s was not started", operationName))
+			return
+		}
+		async, err := isAsyncRequest(request)
+		if err != nil {
+			handleError(request.Context(), apiContext, writer, err)
+			return
+		}
+		db, databaseAddress, release, err := openDBRequest(apiContext, request)
+		if err != nil {
+			handleError(request.Context(), apiContext, writer, err)
+			return
+		}
+		request = request.WithContext(bfs.WithDatabaseHost(request.Context(), databaseAddress))
+		operation, err := handler(writer, request)
+		if err != nil {
+			release()
+			handleError(request.Context(), apiContext, writer, err)
+			return
+		}
+		if async {
+			startJob(apiContext, writer, request, operationName, operation, db, release)
+			return
+		}
+		defer release()
+		result, err := operation(request.Context(), db)
+		if err == nil {
+			err = sendOperationResult(request.Context(), writer, result)
+		}
+		if err != nil {
+			handleError(request.Context(), apiContext, writer, err)
+		}
+	}
+}
+
+func isAsyncRequest(request *http.Request) (bool, error) {
+	value := request.URL.Query().Get(asyncQueryParam)
+	if value == "" {
+		return false, nil
+	}
+	async, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, apiErrors.API_INVALID_ASYNC_PARAMETER.NewErrorF("invalid value %q for query parameter async, expected true or false", value)
+	}
+	return async, nil
+}
+
+func sendOperationResult(ctx context.Context, writer http.ResponseWriter, result any) error {
+	if result == nil {
+		return SendNoContent(ctx, writer)
+	}
+	return SendJSON(ctx, writer, result)
+}
+
+// startJob creates a job, runs the operation in the background and sends the job to the client.
+// The job releases the database connection when the operation is finished.
+func startJob(apiContext *ApiContext, writer http.ResponseWriter, request *http.Request, operationName string, operation dbOperation, db *sql.DB, release func()) {
+	owner, err := getJobOwner(request, apiContext.jobs.ownerKey)
+	if err != nil {
+		release()
+		handleError(request.Context(), apiContext, writer, err)
+		return
+	}
+	newJob, err := apiContext.jobs.create(operationName, owner, middleware.GetReqID(request.Context()))
+	if err != nil {
+		release()
+		handleError(request.Context(), apiContext, writer, err)
+		return
+	}
+	addRequestLogFields(request.Context(), log.Fields{logging.FieldJobID: newJob.ID})
+	// Convert the job before starting it, as the operation modifies the job when it finishes.
+	response := convertJob(newJob)
+	// The job must not be cancelled when the request is finished, only when the server aborts it during shutdown.
+	jobContext, cancel := apiContext.drainer.detach(request.Context())
+	apiContext.jobs.running.add()
+	go func() {
+		defer apiContext.jobs.running.done()
+		defer cancel()
+		runJob(jobContext, apiContext, newJob, operation, db, release)
+	}()
+	GetLogger(request.Context()).Infof("Started job %s for operation %s", response.Id, operationName)
+	writer.Header().Set("Location", getJobPath(response.Id))
+	if err := SendJSONWithStatus(request.Context(), http.StatusAccepted, writer, response); err != nil {
+		handleError(request.Context(), apiContext, writer, err)
+	}
+}
+
+func runJob(ctx context.Context, apiContext *ApiContext, runningJob *job, operation dbOperation, db *sql.DB, release func()) {
+	defer release()
+	logger := GetLogger(ctx)
+	result, err := runOperationRecoveringPanics(ctx, operation, db)
+	if err != nil && ctx.Err() != nil {
+		logger.Errorf("Job %s was aborted during shutdown: %v", runningJob.ID, err)
+		err = apiContext.jobs.interrupt(runningJob)
+	} else if err != nil {
+		logger.Errorf("Job %s failed: %v", runningJob.ID, err)
+		err = apiContext.jobs.fail(runningJob, toClientError(ctx, apiContext, err))
+	} else {
+		logger.Infof("Job %s succeeded", runningJob.ID)
+		err = apiContext.jobs.succeed(runningJob, result)
+	}
+	if err != nil {
+		logger.Errorf("Failed to store status of job %s: %v", runningJob.ID, err)
+	}
+}
+
+// runOperationRecoveringPanics runs the operation and converts a panic into an error.
+// Jobs are not protected by the recoverer middleware, so a panic would otherwise stop EM.
+func runOperationRecoveringPanics(ctx context.Context, operation dbOperation, db *sql.DB) (result any, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("operation failed: %v", recovered)
+		}
+	}()
+	return operation(ctx, db)
+}
+
+// getJobOwner returns an identifier of the client that is used for checking that only the client that started a job can read it.
+// The identifier is an HMAC of the complete credentials, i.e. user name and password or the access token, with the given secret key.
+// So reading a job requires the credentials used for starting it, and the stored identifier can't be used for guessing passwords without the key.
+func getJobOwner(request *http.Request, key []byte) (string, error) {
+	auth := request.Header.Get("Authorization")
+	scheme, credentials, found := strings.Cut(auth, " ")
+	if auth == "" {
+		return "", apiErrors.API_MISSING_AUTHORIZATION.NewErrorF("missing Authorization header")
+	}
+	if !found {
+		return "", apiErrors.API_INVALID_AUTHORIZATION.NewErrorF("invalid Authorization header, expected scheme and credentials separated by a space")
+	}
+	var identity string
+	switch scheme {
+	case "Basic":
+		user, password, err := extractUserPassword(credentials)
+		if err != nil {
+			return "", err
+		}
+		identity = "user:" + user + ":" + password
+	case "Bearer":
+		identity = "token:" + credentials
+	default:
+		return "", apiErrors.API_INVALID_AUTHORIZATION.NewErrorF("invalid Authorization scheme %q", scheme)
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(identity))
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
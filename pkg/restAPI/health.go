@@ -0,0 +1,80 @@
+package restAPI
+
+import (
+	"net/http"
+
+	"github.com/exasol/extension-manager/pkg/extensionController"
+)
+
+const (
+	healthLivePath  = "/health/live"
+	healthReadyPath = "/health/ready"
+)
+
+// Status values of [HealthResponse] and [ComponentHealth].
+const (
+	HealthStatusUp   = "UP"
+	HealthStatusDown = "DOWN"
+)
+
+// componentServer is the component reported by the liveness endpoint and by the readiness endpoint during shutdown.
+const componentServer = "server"
+
+// HealthResponse is the response of the liveness and readiness endpoints.
+type HealthResponse struct {
+	Status     string            `json:"status"`     // [HealthStatusUp] if all components are up, else [HealthStatusDown]
+	Components []ComponentHealth `json:"components"` // Status of each checked component
+}
+
+// ComponentHealth is the status of a single component.
+type ComponentHealth struct {
+	Name   string `json:"name"`            // Name of the component, e.g. "registry"
+	Status string `json:"status"`          // [HealthStatusUp] or [HealthStatusDown]
+	Error  string `json:"error,omitempty"` // Reason why the component is down
+}
+
+// handleLiveness reports that the server is able to process requests. It does not check any dependencies,
+// so that orchestration tools don't restart EM when e.g. the extension registry is unavailable.
+func handleLiveness() http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		sendHealth(writer, request, []ComponentHealth{{Name: componentServer, Status: HealthStatusUp, Error: ""}})
+	}
+}
+
+// handleReadiness reports if the components required for processing requests are available.
+// While the server is shutting down it reports that it is not ready, so that load balancers stop sending requests.
+func handleReadiness(controller extensionController.TransactionController, drainer *drainer) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		if drainer.isDraining() {
+			sendHealth(writer, request, []ComponentHealth{{Name: componentServer, Status: HealthStatusDown, Error: "server is shutting down"}})
+			return
+		}
+		statuses := controller.CheckReadiness(request.Context())
+		components := make([]ComponentHealth, 0, len(statuses))
+		for _, status := range statuses {
+			components = append(components, convertComponentStatus(status))
+		}
+		sendHealth(writer, request, components)
+	}
+}
+
+func convertComponentStatus(status extensionController.ComponentStatus) ComponentHealth {
+	if status.Ready {
+		return ComponentHealth{Name: status.Name, Status: HealthStatusUp, Error: ""}
+	}
+	return ComponentHealth{Name: status.Name, Status: HealthStatusDown, Error: status.Error}
+}
+
+// sendHealth sends the status of the given components with status 200 if all components are up, else with status 503.
+func sendHealth(writer http.ResponseWriter, request *http.Request, components []ComponentHealth) {
+	response := HealthResponse{Status: HealthStatusUp, Components: components}
+	httpStatus := http.StatusOK
+	for _, component := range components {
+		if component.Status != HealthStatusUp {
+			response.Status = HealthStatusDown
+			httpStatus = http.StatusServiceUnavailable
+		}
+	}
+	// The headers are already sent, so there is nothing left to do if sending the body fails. SendJSONWithStatus logs the error.
+	_ = SendJSONWithStatus(request.Context(), httpStatus, writer, response)
+}
@@ -0,0 +1,74 @@
+package restAPI
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/exasol/extension-manager/pkg/extensionController"
+	"github.com/kinbiko/jsonassert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type HealthSuite struct {
+	suite.Suite
+	controller *mockExtensionController
+	assertJSON *jsonassert.Asserter
+}
+
+func TestHealthSuite(t *testing.T) {
+	suite.Run(t, new(HealthSuite))
+}
+
+func (suite *HealthSuite) SetupTest() {
+	suite.controller = createMockExtensionController()
+	suite.assertJSON = jsonassert.New(suite.T())
+}
+
+func (suite *HealthSuite) TearDownTest() {
+	suite.controller.AssertExpectations(suite.T())
+}
+
+func (suite *HealthSuite) TestLiveness() {
+	recorder := suite.serve(handleLiveness())
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal(ContentTypeJson, recorder.Header().Get(HeaderContentType))
+	suite.assertJSON.Assertf(recorder.Body.String(), `{"status":"UP","components":[{"name":"server","status":"UP"}]}`)
+}
+
+func (suite *HealthSuite) TestReadinessAllComponentsReady() {
+	suite.controller.On("CheckReadiness", mock.Anything).Return([]extensionController.ComponentStatus{
+		{Name: extensionController.ComponentRegistry, Ready: true, Error: ""},
+		{Name: "other", Ready: true, Error: ""},
+	})
+	recorder := suite.serve(handleReadiness(suite.controller, newDrainer()))
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.assertJSON.Assertf(recorder.Body.String(), `{"status":"UP","components":[{"name":"registry","status":"UP"},{"name":"other","status":"UP"}]}`)
+}
+
+func (suite *HealthSuite) TestReadinessComponentNotReady() {
+	suite.controller.On("CheckReadiness", mock.Anything).Return([]extensionController.ComponentStatus{
+		{Name: extensionController.ComponentRegistry, Ready: false, Error: "failed to load extension registry: mock error"},
+		{Name: "other", Ready: true, Error: ""},
+	})
+	recorder := suite.serve(handleReadiness(suite.controller, newDrainer()))
+	suite.Equal(http.StatusServiceUnavailable, recorder.Code)
+	suite.assertJSON.Assertf(recorder.Body.String(), `{"status":"DOWN","components":[
+		{"name":"registry","status":"DOWN","error":"failed to load extension registry: mock error"},
+		{"name":"other","status":"UP"}]}`)
+}
+
+func (suite *HealthSuite) TestReadinessWhileShuttingDown() {
+	drainer := newDrainer()
+	drainer.startDraining()
+	recorder := suite.serve(handleReadiness(suite.controller, drainer))
+	suite.Equal(http.StatusServiceUnavailable, recorder.Code)
+	suite.assertJSON.Assertf(recorder.Body.String(), `{"status":"DOWN","components":[{"name":"server","status":"DOWN","error":"server is shutting down"}]}`)
+}
+
+func (suite *HealthSuite) serve(handler http.HandlerFunc) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/health", nil))
+	return recorder
+}
@@ -0,0 +1,180 @@
+package restAPI
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// errJobNotFound is returned by [jobStore.load] when the job does not exist.
+var errJobNotFound = errors.New("job not found")
+
+// jobStore persists serialized jobs.
+type jobStore interface {
+	save(id string, data []byte) error
+	load(id string) ([]byte, error)
+	list() ([]string, error)
+	delete(id string) error
+	// ownerKey returns the secret key for identifying the owners of jobs, see [getJobOwner].
+	// The key stays the same as long as the jobs are stored.
+	ownerKey() ([]byte, error)
+}
+
+// maxMemoryJobs is the maximum number of jobs kept by the [memoryJobStore].
+const maxMemoryJobs = 1000
+
+// memoryJobStore keeps jobs in memory. Jobs are lost when EM stops.
+// When the store contains maxJobs jobs, saving a new job evicts the oldest one.
+type memoryJobStore struct {
+	mutex   sync.Mutex
+	jobs    map[string][]byte
+	order   []string // IDs of the stored jobs, oldest first
+	maxJobs int
+	key     []byte
+}
+
+func newMemoryJobStore() *memoryJobStore {
+	return &memoryJobStore{mutex: sync.Mutex{}, jobs: make(map[string][]byte), order: make([]string, 0), maxJobs: maxMemoryJobs, key: []byte(rand.Text())}
+}
+
+func (s *memoryJobStore) save(id string, data []byte) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, exists := s.jobs[id]; !exists {
+		for len(s.order) >= s.maxJobs {
+			delete(s.jobs, s.order[0])
+			s.order = s.order[1:]
+		}
+		s.order = append(s.order, id)
+	}
+	s.jobs[id] = data
+	return nil
+}
+
+func (s *memoryJobStore) load(id string) ([]byte, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if data, exists := s.jobs[id]; exists {
+		return data, nil
+	}
+	return nil, errJobNotFound
+}
+
+func (s *memoryJobStore) list() ([]string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	ids := make([]string, 0, len(s.jobs))
+	for id := range s.jobs {
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func (s *memoryJobStore) delete(id string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, exists := s.jobs[id]; exists {
+		delete(s.jobs, id)
+		s.order = slices.DeleteFunc(s.order, func(storedId string) bool { return storedId == id })
+	}
+	return nil
+}
+
+func (s *memoryJobStore) ownerKey() ([]byte, error) {
+	return s.key, nil
+}
+
+const (
+	jobFileSuffix = ".json"
+	ownerKeyFile  = "owner.key" // File in the job directory containing the key for identifying the owners of jobs
+)
+
+// fileJobStore stores each job as a JSON file in a directory, so that jobs are available after a restart.
+type fileJobStore struct {
+	directory string
+}
+
+func newFileJobStore(directory string) (*fileJobStore, error) {
+	if err := os.MkdirAll(directory, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create job directory %q: %w", directory, err)
+	}
+	return &fileJobStore{directory: directory}, nil
+}
+
+// save writes the job to a temporary file and renames it, so that readers never see a partially written file.
+func (s *fileJobStore) save(id string, data []byte) error {
+	tempFile, err := os.CreateTemp(s.directory, id+"-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create file for job %q: %w", id, err)
+	}
+	defer os.Remove(tempFile.Name()) //nolint:errcheck // File does not exist any more after renaming
+	if _, err := tempFile.Write(data); err != nil {
+		tempFile.Close()
+		return fmt.Errorf("failed to write job %q: %w", id, err)
+	}
+	if err := tempFile.Close(); err != nil {
+		return fmt.Errorf("failed to write job %q: %w", id, err)
+	}
+	if err := os.Rename(tempFile.Name(), s.path(id)); err != nil {
+		return fmt.Errorf("failed to write job %q: %w", id, err)
+	}
+	return nil
+}
+
+func (s *fileJobStore) load(id string) ([]byte, error) {
+	data, err := os.ReadFile(s.path(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, errJobNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read job %q: %w", id, err)
+	}
+	return data, nil
+}
+
+func (s *fileJobStore) list() ([]string, error) {
+	entries, err := os.ReadDir(s.directory)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs in directory %q: %w", s.directory, err)
+	}
+	ids := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), jobFileSuffix) {
+			ids = append(ids, strings.TrimSuffix(entry.Name(), jobFileSuffix))
+		}
+	}
+	return ids, nil
+}
+
+func (s *fileJobStore) delete(id string) error {
+	if err := os.Remove(s.path(id)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete job %q: %w", id, err)
+	}
+	return nil
+}
+
+func (s *fileJobStore) path(id string) string {
+	return filepath.Join(s.directory, id+jobFileSuffix)
+}
+
+// ownerKey reads the key from the job directory or creates it, so that clients can still read their jobs after a restart.
+func (s *fileJobStore) ownerKey() ([]byte, error) {
+	path := filepath.Join(s.directory, ownerKeyFile)
+	key, err := os.ReadFile(path)
+	if err == nil {
+		return key, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read job owner key %q: %w", path, err)
+	}
+	key = []byte(rand.Text())
+	if err := os.WriteFile(path, key, 0600); err != nil {
+		return nil, fmt.Errorf("failed to write job owner key %q: %w", path, err)
+	}
+	return key, nil
+}
@@ -0,0 +1,338 @@
+package restAPI
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/exasol/extension-manager/pkg/apiErrors"
+	log "github.com/sirupsen/logrus"
+)
+
+// JobStatus is the status of an asynchronous job.
+type JobStatus string
+
+const (
+	JobRunning     JobStatus = "running"     // The operation is still running
+	JobSucceeded   JobStatus = "succeeded"   // The operation finished successfully, field result contains its result
+	JobFailed      JobStatus = "failed"      // The operation failed, field error contains the reason
+	JobInterrupted JobStatus = "interrupted" // EM stopped while the operation was running
+)
+
+var jobIdPattern = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+// job is an operation running asynchronously. This is the format stored in the [jobStore].
+type job struct {
+	ID         string              `json:"id"`
+	Operation  string              `json:"operation"`
+	Status     JobStatus           `json:"status"`
+	Owner      string              `json:"owner"`
+	RequestID  string              `json:"requestId"`
+	CreatedAt  time.Time           `json:"createdAt"`
+	FinishedAt *time.Time          `json:"finishedAt,omitempty"`
+	Result     json.RawMessage     `json:"result,omitempty"`
+	Error      *apiErrors.APIError `json:"error,omitempty"`
+	Logs       []JobLogEntry       `json:"logs"`
+}
+
+// JobLogEntry is a log message of an asynchronous job.
+type JobLogEntry struct {
+	Time    time.Time `json:"time"`    // Time when the message was logged
+	Level   string    `json:"level"`   // Log level: "info" or "error"
+	Message string    `json:"message"` // The log message
+}
+
+// jobCleanupInterval is the maximum interval in which EM deletes expired jobs in the background.
+const jobCleanupInterval = 10 * time.Minute
+
+// jobManager creates asynchronous jobs and stores their status.
+type jobManager struct {
+	store        jobStore
+	ownerKey     []byte // Secret key for identifying the owners of jobs, see [getJobOwner]
+	retention    time.Duration
+	now          func() time.Time
+	running      *runningJobs
+	cleanupMutex sync.Mutex
+	stopCleanup  func() // Stops the background cleanup, nil if it is not running
+}
+
+// newJobManager creates a new job manager. If jobs are persisted, it marks jobs that were running when EM stopped as interrupted.
+func newJobManager(config JobsConfig) (*jobManager, error) {
+	if config.Retention < 0 {
+		return nil, fmt.Errorf("invalid job retention %v, expected a positive duration", config.Retention)
+	}
+	retention := config.Retention
+	if retention == 0 {
+		retention = DefaultJobRetention
+	}
+	var store jobStore = newMemoryJobStore()
+	if config.Directory != "" {
+		fileStore, err := newFileJobStore(config.Directory)
+		if err != nil {
+			return nil, err
+		}
+		store = fileStore
+	}
+	ownerKey, err := store.ownerKey()
+	if err != nil {
+		return nil, err
+	}
+	manager := &jobManager{store: store, ownerKey: ownerKey, retention: retention, now: time.Now, running: newRunningJobs(),
+		cleanupMutex: sync.Mutex{}, stopCleanup: nil}
+	if err := manager.markRunningJobsInterrupted(); err != nil {
+		return nil, err
+	}
+	return manager, nil
+}
+
+// newDefaultJobManager creates a job manager that keeps jobs in memory.
+func newDefaultJobManager() *jobManager {
+	store := newMemoryJobStore()
+	return &jobManager{store: store, ownerKey: store.key, retention: DefaultJobRetention, now: time.Now, running: newRunningJobs(),
+		cleanupMutex: sync.Mutex{}, stopCleanup: nil}
+}
+
+// create creates and stores a new running job.
+func (m *jobManager) create(operation, owner, requestID string) (*job, error) {
+	id, err := generateJobId()
+	if err != nil {
+		return nil, err
+	}
+	newJob := &job{ID: id, Operation: operation, Status: JobRunning, Owner: owner, RequestID: requestID, CreatedAt: m.now(),
+		FinishedAt: nil, Result: nil, Error: nil, Logs: make([]JobLogEntry, 0)}
+	m.addLog(newJob, log.InfoLevel, fmt.Sprintf("Started operation %s", operation))
+	if err := m.save(newJob); err != nil {
+		return nil, err
+	}
+	return newJob, nil
+}
+
+// succeed marks the job as succeeded with the given result. The result is nil for operations without result.
+func (m *jobManager) succeed(runningJob *job, result any) error {
+	if result != nil {
+		data, err := json.Marshal(result)
+		if err != nil {
+			return fmt.Errorf("failed to serialize result of job %q: %w", runningJob.ID, err)
+		}
+		runningJob.Result = data
+	}
+	m.finish(runningJob, JobSucceeded)
+	m.addLog(runningJob, log.InfoLevel, fmt.Sprintf("Operation succeeded after %v", runningJob.FinishedAt.Sub(runningJob.CreatedAt)))
+	return m.save(runningJob)
+}
+
+// fail marks the job as failed with the given error as returned to the client.
+func (m *jobManager) fail(runningJob *job, apiError *apiErrors.APIError) error {
+	runningJob.Error = apiError
+	m.finish(runningJob, JobFailed)
+	m.addLog(runningJob, log.ErrorLevel, fmt.Sprintf("Operation failed after %v: %s", runningJob.FinishedAt.Sub(runningJob.CreatedAt), apiError.Message))
+	return m.save(runningJob)
+}
+
+// interrupt marks the job as interrupted because EM aborted the operation while shutting down.
+func (m *jobManager) interrupt(runningJob *job) error {
+	m.finish(runningJob, JobInterrupted)
+	m.addLog(runningJob, log.ErrorLevel, fmt.Sprintf("Extension manager aborted the operation after %v while shutting down. "+
+		"The database rolls back uncommitted transactions, retry the operation.", runningJob.FinishedAt.Sub(runningJob.CreatedAt)))
+	return m.save(runningJob)
+}
+
+// waitForRunningJobs waits until all running jobs are finished or the context is done.
+func (m *jobManager) waitForRunningJobs(ctx context.Context) error {
+	return m.running.wait(ctx)
+}
+
+func (m *jobManager) finish(runningJob *job, status JobStatus) {
+	finishedAt := m.now()
+	runningJob.Status = status
+	runningJob.FinishedAt = &finishedAt
+}
+
+func (m *jobManager) addLog(j *job, level log.Level, message string) {
+	j.Logs = append(j.Logs, JobLogEntry{Time: m.now(), Level: level.String(), Message: message})
+}
+
+// get returns the job with the given ID if it belongs to the given owner.
+// It returns an error with code [apiErrors.API_JOB_NOT_FOUND] if the job does not exist or belongs to another owner.
+func (m *jobManager) get(id, owner string) (*job, error) {
+	if !jobIdPattern.MatchString(id) {
+		return nil, apiErrors.API_JOB_NOT_FOUND.NewErrorF("job %q not found", id)
+	}
+	storedJob, err := m.load(id)
+	if errors.Is(err, errJobNotFound) {
+		return nil, apiErrors.API_JOB_NOT_FOUND.NewErrorF("job %q not found", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if storedJob.Owner != owner {
+		// Don't tell clients that the job exists.
+		return nil, apiErrors.API_JOB_NOT_FOUND.NewErrorF("job %q not found", id)
+	}
+	return storedJob, nil
+}
+
+func (m *jobManager) save(j *job) error {
+	data, err := json.Marshal(j)
+	if err != nil {
+		return fmt.Errorf("failed to serialize job %q: %w", j.ID, err)
+	}
+	return m.store.save(j.ID, data)
+}
+
+func (m *jobManager) load(id string) (*job, error) {
+	data, err := m.store.load(id)
+	if err != nil {
+		return nil, err
+	}
+	//nolint:exhaustruct // Omitting values by intention for deserialization
+	loadedJob := &job{}
+	if err := json.Unmarshal(data, loadedJob); err != nil {
+		return nil, fmt.Errorf("failed to read job %q: %w", id, err)
+	}
+	return loadedJob, nil
+}
+
+// markRunningJobsInterrupted marks all running jobs as interrupted. This is called during startup
+// because jobs of a previous EM process can't be running any more.
+func (m *jobManager) markRunningJobsInterrupted() error {
+	ids, err := m.store.list()
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		storedJob, err := m.load(id)
+		if err != nil {
+			log.Warnf("Ignoring invalid job %q: %v", id, err)
+			continue
+		}
+		if storedJob.Status != JobRunning {
+			continue
+		}
+		m.finish(storedJob, JobInterrupted)
+		m.addLog(storedJob, log.ErrorLevel, "Extension manager stopped while the operation was running. The database rolls back uncommitted transactions, "+
+			"but the operation may have completed just before. Check the installed extensions and instances.")
+		if err := m.save(storedJob); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// startCleanup periodically deletes expired jobs in a background goroutine until [jobManager.endCleanup] is called.
+// This keeps listing and loading all jobs off the request path.
+func (m *jobManager) startCleanup() {
+	m.cleanupMutex.Lock()
+	defer m.cleanupMutex.Unlock()
+	if m.stopCleanup != nil {
+		return
+	}
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(min(m.retention, jobCleanupInterval))
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				m.deleteExpiredJobs()
+			case <-stop:
+				return
+			}
+		}
+	}()
+	m.stopCleanup = func() {
+		close(stop)
+		<-stopped
+	}
+}
+
+// endCleanup stops the background cleanup started by [jobManager.startCleanup] and waits until it has finished.
+func (m *jobManager) endCleanup() {
+	m.cleanupMutex.Lock()
+	defer m.cleanupMutex.Unlock()
+	if m.stopCleanup != nil {
+		m.stopCleanup()
+		m.stopCleanup = nil
+	}
+}
+
+// deleteExpiredJobs deletes all finished jobs older than the retention period.
+func (m *jobManager) deleteExpiredJobs() {
+	ids, err := m.store.list()
+	if err != nil {
+		log.Warnf("Failed to list jobs for deleting expired jobs: %v", err)
+		return
+	}
+	for _, id := range ids {
+		storedJob, err := m.load(id)
+		if err != nil || storedJob.FinishedAt == nil || m.now().Sub(*storedJob.FinishedAt) <= m.retention {
+			continue
+		}
+		if err := m.store.delete(id); err != nil {
+			log.Warnf("Failed to delete expired job %q: %v", id, err)
+		}
+	}
+}
+
+func generateJobId() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", fmt.Errorf("failed to generate job ID: %w", err)
+	}
+	return hex.EncodeToString(id), nil
+}
+
+// runningJobs counts the jobs whose operation is still running.
+// Unlike a [sync.WaitGroup] it allows waiting with a context without starting a goroutine that blocks until the jobs are finished.
+type runningJobs struct {
+	mutex    sync.Mutex
+	count    int
+	finished chan struct{} // Closed when the last running job is finished
+}
+
+func newRunningJobs() *runningJobs {
+	return &runningJobs{mutex: sync.Mutex{}, count: 0, finished: nil}
+}
+
+func (r *runningJobs) add() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.count == 0 {
+		r.finished = make(chan struct{})
+	}
+	r.count++
+}
+
+func (r *runningJobs) done() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.count--
+	if r.count == 0 {
+		close(r.finished)
+	}
+}
+
+// wait waits until all running jobs are finished or the context is done.
+func (r *runningJobs) wait(ctx context.Context) error {
+	r.mutex.Lock()
+	if r.count == 0 {
+		r.mutex.Unlock()
+		return nil
+	}
+	finished := r.finished
+	r.mutex.Unlock()
+	select {
+	case <-finished:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,392 @@
+package restAPI
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/exasol/extension-manager/pkg/apiErrors"
+	"github.com/stretchr/testify/suite"
+)
+
+type JobManagerSuite struct {
+	suite.Suite
+	directory string
+	now       time.Time
+}
+
+func TestJobManagerSuite(t *testing.T) {
+	suite.Run(t, new(JobManagerSuite))
+}
+
+func (suite *JobManagerSuite) SetupTest() {
+	suite.directory = suite.T().TempDir()
+	suite.now = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+}
+
+func (suite *JobManagerSuite) TestCreate() {
+	manager := suite.createManager(JobsConfig{Directory: "", Retention: 0})
+	newJob := suite.createJob(manager, "owner")
+	suite.Regexp("^[0-9a-f]{32}$", newJob.ID)
+	suite.Equal(JobRunning, newJob.Status)
+	suite.Equal("InstallExtension", newJob.Operation)
+	suite.Equal("request-id", newJob.RequestID)
+	suite.Equal(suite.now, newJob.CreatedAt)
+	suite.Nil(newJob.FinishedAt)
+	suite.Equal([]JobLogEntry{{Time: suite.now, Level: "info", Message: "Started operation InstallExtension"}}, newJob.Logs)
+	suite.Equal(newJob, suite.getJob(manager, newJob.ID, "owner"))
+}
+
+func (suite *JobManagerSuite) TestCreateGeneratesUniqueIds() {
+	manager := suite.createManager(JobsConfig{Directory: "", Retention: 0})
+	suite.NotEqual(suite.createJob(manager, "owner").ID, suite.createJob(manager, "owner").ID)
+}
+
+func (suite *JobManagerSuite) TestSucceed() {
+	manager := suite.createManager(JobsConfig{Directory: "", Retention: 0})
+	newJob := suite.createJob(manager, "owner")
+	suite.now = suite.now.Add(3 * time.Second)
+	suite.Require().NoError(manager.succeed(newJob, CreateInstanceResponse{InstanceId: "id", InstanceName: "name"}))
+	storedJob := suite.getJob(manager, newJob.ID, "owner")
+	suite.Equal(JobSucceeded, storedJob.Status)
+	suite.Equal(suite.now, *storedJob.FinishedAt)
+	suite.JSONEq(`{"instanceId":"id","instanceName":"name"}`, string(storedJob.Result))
+	suite.Nil(storedJob.Error)
+	suite.Equal(JobLogEntry{Time: suite.now, Level: "info", Message: "Operation succeeded after 3s"}, storedJob.Logs[1])
+}
+
+func (suite *JobManagerSuite) TestSucceedWithoutResult() {
+	manager := suite.createManager(JobsConfig{Directory: "", Retention: 0})
+	newJob := suite.createJob(manager, "owner")
+	suite.Require().NoError(manager.succeed(newJob, nil))
+	storedJob := suite.getJob(manager, newJob.ID, "owner")
+	suite.Equal(JobSucceeded, storedJob.Status)
+	suite.Nil(storedJob.Result)
+}
+
+func (suite *JobManagerSuite) TestFail() {
+	manager := suite.createManager(JobsConfig{Directory: "", Retention: 0})
+	newJob := suite.createJob(manager, "owner")
+	suite.now = suite.now.Add(time.Second)
+	suite.Require().NoError(manager.fail(newJob, apiErrors.UnwrapAPIError(apiErrors.CTRL_INSTANCES_EXIST.NewErrorF("instances exist"))))
+	storedJob := suite.getJob(manager, newJob.ID, "owner")
+	suite.Equal(JobFailed, storedJob.Status)
+	suite.Equal("E-EM-CTRL-1", storedJob.Error.ErrorCode)
+	suite.Equal("instances exist", storedJob.Error.Message)
+	suite.Equal(http.StatusBadRequest, storedJob.Error.Status)
+	suite.Nil(storedJob.Result)
+	suite.Equal(JobLogEntry{Time: suite.now, Level: "error", Message: "Operation failed after 1s: instances exist"}, storedJob.Logs[1])
+}
+
+func (suite *JobManagerSuite) TestGetFails() {
+	manager := suite.createManager(JobsConfig{Directory: suite.directory, Retention: 0})
+	existingJob := suite.createJob(manager, "owner")
+	var tests = []struct {
+		name  string
+		id    string
+		owner string
+	}{
+		{"unknown job", "00000000000000000000000000000000", "owner"},
+		{"other owner", existingJob.ID, "other owner"},
+		{"invalid id", "invalid", "owner"},
+		{"path traversal", "../" + existingJob.ID, "owner"},
+	}
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			storedJob, err := manager.get(test.id, test.owner)
+			suite.EqualError(err, `job "`+test.id+`" not found`)
+			suite.Equal("E-EM-API-13", apiErrors.UnwrapAPIError(err).ErrorCode)
+			suite.Nil(storedJob)
+		})
+	}
+}
+
+func (suite *JobManagerSuite) TestInterrupt() {
+	manager := suite.createManager(JobsConfig{Directory: "", Retention: 0})
+	newJob := suite.createJob(manager, "owner")
+	suite.now = suite.now.Add(time.Second)
+	suite.Require().NoError(manager.interrupt(newJob))
+	storedJob := suite.getJob(manager, newJob.ID, "owner")
+	suite.Equal(JobInterrupted, storedJob.Status)
+	suite.Equal(JobLogEntry{Time: suite.now, Level: "error", Message: "Extension manager aborted the operation after 1s while shutting down. " +
+		"The database rolls back uncommitted transactions, retry the operation."}, storedJob.Logs[1])
+}
+
+func (suite *JobManagerSuite) TestWaitForRunningJobs() {
+	manager := suite.createManager(JobsConfig{Directory: "", Retention: 0})
+	manager.running.add()
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		manager.running.done()
+	}()
+	suite.NoError(manager.waitForRunningJobs(context.Background()))
+}
+
+func (suite *JobManagerSuite) TestWaitForRunningJobsWithoutJobs() {
+	manager := suite.createManager(JobsConfig{Directory: "", Retention: 0})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	suite.NoError(manager.waitForRunningJobs(ctx))
+}
+
+func (suite *JobManagerSuite) TestWaitForRunningJobsStartedAfterOthersFinished() {
+	manager := suite.createManager(JobsConfig{Directory: "", Retention: 0})
+	manager.running.add()
+	manager.running.done()
+	manager.running.add()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	suite.ErrorIs(manager.waitForRunningJobs(ctx), context.DeadlineExceeded)
+	manager.running.done()
+	suite.NoError(manager.waitForRunningJobs(context.Background()))
+}
+
+func (suite *JobManagerSuite) TestWaitForRunningJobsTimesOut() {
+	manager := suite.createManager(JobsConfig{Directory: "", Retention: 0})
+	manager.running.add()
+	defer manager.running.done()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	suite.ErrorIs(manager.waitForRunningJobs(ctx), context.DeadlineExceeded)
+}
+
+func (suite *JobManagerSuite) TestJobsAreAvailableAfterRestart() {
+	manager := suite.createManager(JobsConfig{Directory: suite.directory, Retention: 0})
+	finishedJob := suite.createJob(manager, "owner")
+	suite.Require().NoError(manager.succeed(finishedJob, nil))
+
+	restartedManager := suite.createManager(JobsConfig{Directory: suite.directory, Retention: 0})
+	suite.Equal(finishedJob, suite.getJob(restartedManager, finishedJob.ID, "owner"))
+}
+
+func (suite *JobManagerSuite) TestRestartMarksRunningJobsInterrupted() {
+	manager := suite.createManager(JobsConfig{Directory: suite.directory, Retention: 0})
+	runningJob := suite.createJob(manager, "owner")
+
+	restartedManager := suite.createManager(JobsConfig{Directory: suite.directory, Retention: 0})
+	storedJob := suite.getJob(restartedManager, runningJob.ID, "owner")
+	suite.Equal(JobInterrupted, storedJob.Status)
+	suite.NotNil(storedJob.FinishedAt)
+	suite.Len(storedJob.Logs, 2)
+	suite.Equal("error", storedJob.Logs[1].Level)
+	suite.Contains(storedJob.Logs[1].Message, "Extension manager stopped while the operation was running.")
+}
+
+func (suite *JobManagerSuite) TestRestartIgnoresInvalidJobFiles() {
+	suite.Require().NoError(os.WriteFile(filepath.Join(suite.directory, "invalid.json"), []byte("invalid"), 0600))
+	suite.Require().NoError(os.WriteFile(filepath.Join(suite.directory, "other-file.txt"), []byte("other"), 0600))
+	suite.createManager(JobsConfig{Directory: suite.directory, Retention: 0})
+}
+
+func (suite *JobManagerSuite) TestDeletesExpiredJobs() {
+	manager := suite.createManager(JobsConfig{Directory: suite.directory, Retention: time.Hour})
+	expiredJob := suite.createJob(manager, "owner")
+	suite.Require().NoError(manager.succeed(expiredJob, nil))
+	runningJob := suite.createJob(manager, "owner")
+	suite.now = suite.now.Add(time.Hour + time.Second)
+	recentJob := suite.createJob(manager, "owner")
+	manager.deleteExpiredJobs()
+
+	_, err := manager.get(expiredJob.ID, "owner")
+	suite.Equal("E-EM-API-13", apiErrors.UnwrapAPIError(err).ErrorCode)
+	suite.NoFileExists(filepath.Join(suite.directory, expiredJob.ID+".json"))
+	suite.Equal(JobRunning, suite.getJob(manager, runningJob.ID, "owner").Status)
+	suite.Equal(JobRunning, suite.getJob(manager, recentJob.ID, "owner").Status)
+}
+
+func (suite *JobManagerSuite) TestCreateDoesNotDeleteExpiredJobs() {
+	manager := suite.createManager(JobsConfig{Directory: suite.directory, Retention: time.Hour})
+	expiredJob := suite.createJob(manager, "owner")
+	suite.Require().NoError(manager.succeed(expiredJob, nil))
+	suite.now = suite.now.Add(time.Hour + time.Second)
+	suite.createJob(manager, "owner")
+	suite.Equal(JobSucceeded, suite.getJob(manager, expiredJob.ID, "owner").Status)
+}
+
+func (suite *JobManagerSuite) TestCleanupDeletesExpiredJobsInBackground() {
+	manager := suite.createManager(JobsConfig{Directory: "", Retention: 10 * time.Millisecond})
+	manager.now = time.Now
+	expiredJob := suite.createJob(manager, "owner")
+	suite.Require().NoError(manager.succeed(expiredJob, nil))
+	manager.startCleanup()
+	defer manager.endCleanup()
+	suite.Eventually(func() bool {
+		_, err := manager.get(expiredJob.ID, "owner")
+		return err != nil
+	}, time.Second, 10*time.Millisecond)
+}
+
+func (suite *JobManagerSuite) TestEndCleanupStopsBackgroundCleanup() {
+	manager := suite.createManager(JobsConfig{Directory: "", Retention: 10 * time.Millisecond})
+	manager.now = time.Now
+	manager.startCleanup()
+	manager.endCleanup()
+	expiredJob := suite.createJob(manager, "owner")
+	suite.Require().NoError(manager.succeed(expiredJob, nil))
+	time.Sleep(50 * time.Millisecond)
+	suite.Equal(JobSucceeded, suite.getJob(manager, expiredJob.ID, "owner").Status)
+	manager.endCleanup()
+}
+
+func (suite *JobManagerSuite) TestMemoryStoreEvictsOldestJobs() {
+	store := newMemoryJobStore()
+	store.maxJobs = 2
+	suite.Require().NoError(store.save("job1", []byte("1")))
+	suite.Require().NoError(store.save("job2", []byte("2")))
+	suite.Require().NoError(store.save("job1", []byte("updated")))
+	suite.Require().NoError(store.save("job3", []byte("3")))
+	_, err := store.load("job1")
+	suite.ErrorIs(err, errJobNotFound)
+	suite.ElementsMatch([]string{"job2", "job3"}, suite.listJobs(store))
+}
+
+func (suite *JobManagerSuite) TestMemoryStoreDeleteFreesCapacity() {
+	store := newMemoryJobStore()
+	store.maxJobs = 2
+	suite.Require().NoError(store.save("job1", []byte("1")))
+	suite.Require().NoError(store.save("job2", []byte("2")))
+	suite.Require().NoError(store.delete("job2"))
+	suite.Require().NoError(store.save("job3", []byte("3")))
+	suite.ElementsMatch([]string{"job1", "job3"}, suite.listJobs(store))
+}
+
+func (suite *JobManagerSuite) TestDefaultRetention() {
+	manager := suite.createManager(JobsConfig{Directory: "", Retention: 0})
+	suite.Equal(DefaultJobRetention, manager.retention)
+}
+
+func (suite *JobManagerSuite) TestInvalidConfigurations() {
+	file := filepath.Join(suite.directory, "file")
+	suite.Require().NoError(os.WriteFile(file, []byte{}, 0600))
+	var tests = []struct {
+		name          string
+		config        JobsConfig
+		expectedError string
+	}{
+		{"negative retention", JobsConfig{Directory: "", Retention: -time.Second}, "invalid job retention -1s, expected a positive duration"},
+		{"directory is a file", JobsConfig{Directory: file, Retention: 0}, `failed to create job directory "` + file + `": mkdir ` + file + `: not a directory`},
+	}
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			manager, err := newJobManager(test.config)
+			suite.EqualError(err, test.expectedError)
+			suite.Nil(manager)
+		})
+	}
+}
+
+func (suite *JobManagerSuite) TestStoredJobFormat() {
+	manager := suite.createManager(JobsConfig{Directory: suite.directory, Retention: 0})
+	newJob := suite.createJob(manager, "owner")
+	data, err := os.ReadFile(filepath.Join(suite.directory, newJob.ID+".json"))
+	suite.Require().NoError(err)
+	var content map[string]any
+	suite.Require().NoError(json.Unmarshal(data, &content))
+	suite.Equal("owner", content["owner"])
+	suite.Equal("running", content["status"])
+}
+
+func (suite *JobManagerSuite) TestGetJobOwner() {
+	var tests = []struct {
+		name       string
+		authHeader string
+	}{
+		{"basic auth", createBasicAuthHeader("user", "password")},
+		{"bearer token", createBearerAuthHeader("token")},
+	}
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			owner, err := getJobOwner(suite.createRequest(test.authHeader), []byte("key"))
+			suite.Require().NoError(err)
+			suite.Regexp("^[0-9a-f]{64}$", owner)
+			suite.NotContains(owner, "password")
+		})
+	}
+}
+
+func (suite *JobManagerSuite) TestGetJobOwnerDependsOnCredentialsAndKey() {
+	owner := suite.getJobOwner(createBasicAuthHeader("user", "password1"), "key")
+	suite.Equal(owner, suite.getJobOwner(createBasicAuthHeader("user", "password1"), "key"))
+	suite.NotEqual(owner, suite.getJobOwner(createBasicAuthHeader("user", "password2"), "key"))
+	suite.NotEqual(owner, suite.getJobOwner(createBasicAuthHeader("other", "password1"), "key"))
+	suite.NotEqual(owner, suite.getJobOwner(createBasicAuthHeader("user", "password1"), "other key"))
+	suite.NotEqual(suite.getJobOwner(createBearerAuthHeader("token1"), "key"), suite.getJobOwner(createBearerAuthHeader("token2"), "key"))
+}
+
+func (suite *JobManagerSuite) TestOwnerKeyOfMemoryStoreIsRandom() {
+	suite.NotEqual(newDefaultJobManager().ownerKey, newDefaultJobManager().ownerKey)
+	suite.NotEmpty(newDefaultJobManager().ownerKey)
+}
+
+func (suite *JobManagerSuite) TestOwnerKeyPersistedInJobDirectory() {
+	manager := suite.createManager(JobsConfig{Directory: suite.directory, Retention: 0})
+	restartedManager := suite.createManager(JobsConfig{Directory: suite.directory, Retention: 0})
+	suite.NotEmpty(manager.ownerKey)
+	suite.Equal(manager.ownerKey, restartedManager.ownerKey)
+	fileInfo, err := os.Stat(filepath.Join(suite.directory, "owner.key"))
+	suite.Require().NoError(err)
+	suite.Equal(os.FileMode(0600), fileInfo.Mode().Perm())
+}
+
+func (suite *JobManagerSuite) TestGetJobOwnerFails() {
+	var tests = []struct {
+		authHeader    string
+		expectedError string
+	}{
+		{"", "missing Authorization header"},
+		{"Basic", "invalid Authorization header, expected scheme and credentials separated by a space"},
+		{"Digest abc", `invalid Authorization scheme "Digest"`},
+		{"Basic invalid", `invalid basic auth header: illegal base64 data at input byte 4`},
+	}
+	for _, test := range tests {
+		suite.Run(test.authHeader, func() {
+			owner, err := getJobOwner(suite.createRequest(test.authHeader), []byte("key"))
+			suite.EqualError(err, test.expectedError)
+			suite.Empty(owner)
+		})
+	}
+}
+
+func (suite *JobManagerSuite) createManager(config JobsConfig) *jobManager {
+	manager, err := newJobManager(config)
+	suite.Require().NoError(err)
+	manager.now = func() time.Time { return suite.now }
+	return manager
+}
+
+func (suite *JobManagerSuite) listJobs(store jobStore) []string {
+	ids, err := store.list()
+	suite.Require().NoError(err)
+	return ids
+}
+
+func (suite *JobManagerSuite) createJob(manager *jobManager, owner string) *job {
+	newJob, err := manager.create("InstallExtension", owner, "request-id")
+	suite.Require().NoError(err)
+	return newJob
+}
+
+func (suite *JobManagerSuite) getJob(manager *jobManager, id, owner string) *job {
+	storedJob, err := manager.get(id, owner)
+	suite.Require().NoError(err)
+	return storedJob
+}
+
+func (suite *JobManagerSuite) getJobOwner(authHeader, key string) string {
+	owner, err := getJobOwner(suite.createRequest(authHeader), []byte(key))
+	suite.Require().NoError(err)
+	return owner
+}
+
+func (suite *JobManagerSuite) createRequest(authHeader string) *http.Request {
+	request := httptest.NewRequest(http.MethodGet, "/jobs/id", nil)
+	if authHeader != "" {
+		request.Header.Add("Authorization", authHeader)
+	}
+	return request
+}
@@ -0,0 +1,101 @@
+package restAPI
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/exasol/extension-manager/pkg/logging"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	log "github.com/sirupsen/logrus"
+)
+
+// requestLogFields contains fields that handlers add to all log messages of a request, e.g. the operation and extension ID.
+// The logger middleware creates it before calling the handler, so that the request log message also contains the fields.
+// Jobs keep the fields of the request that started them.
+type requestLogFields struct {
+	mutex  sync.Mutex
+	fields log.Fields
+}
+
+type requestLogFieldsKey struct{}
+
+func withRequestLogFields(ctx context.Context) context.Context {
+	//nolint:exhaustruct // Zero value of mutex is ok
+	return context.WithValue(ctx, requestLogFieldsKey{}, &requestLogFields{fields: log.Fields{}})
+}
+
+// addRequestLogFields adds the given fields to all following log messages of the request.
+// This does nothing if the context was not created by the logger middleware.
+func addRequestLogFields(ctx context.Context, fields log.Fields) {
+	if holder, ok := ctx.Value(requestLogFieldsKey{}).(*requestLogFields); ok {
+		holder.mutex.Lock()
+		defer holder.mutex.Unlock()
+		for key, value := range fields {
+			holder.fields[key] = value
+		}
+	}
+}
+
+// getRequestLogFields returns a copy of the fields added to the request.
+func getRequestLogFields(ctx context.Context) log.Fields {
+	fields := log.Fields{}
+	if holder, ok := ctx.Value(requestLogFieldsKey{}).(*requestLogFields); ok {
+		holder.mutex.Lock()
+		defer holder.mutex.Unlock()
+		for key, value := range holder.fields {
+			fields[key] = value
+		}
+	}
+	return fields
+}
+
+// addOperationLogFields adds the name of the operation and the IDs from the request path to all following log messages of the request.
+func addOperationLogFields(request *http.Request, operationName string) {
+	fields := log.Fields{logging.FieldOperation: operationName}
+	for field, urlParam := range map[string]string{
+		logging.FieldExtensionID:      "extensionId",
+		logging.FieldExtensionVersion: "extensionVersion",
+		logging.FieldInstanceID:       "instanceId",
+		logging.FieldJobID:            "jobId",
+	} {
+		if value := chi.URLParam(request, urlParam); value != "" {
+			fields[field] = value
+		}
+	}
+	addRequestLogFields(request.Context(), fields)
+}
+
+// loggerMiddleware logs a message for each finished request with method, path, status and duration.
+// Values of query parameters that may contain credentials or parameter values are redacted.
+func loggerMiddleware() func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			r = r.WithContext(withRequestLogFields(r.Context()))
+			start := time.Now()
+			defer func() {
+				duration := time.Since(start)
+				scheme := "http"
+				if r.TLS != nil {
+					scheme = "https"
+				}
+				GetLogger(r.Context()).WithFields(log.Fields{
+					logging.FieldMethod:        r.Method,
+					logging.FieldPath:          r.URL.Path,
+					logging.FieldStatus:        ww.Status(),
+					logging.FieldBytes:         ww.BytesWritten(),
+					logging.FieldDurationMs:    duration.Milliseconds(),
+					logging.FieldRemoteAddress: r.RemoteAddr,
+				}).Info(fmt.Sprintf("\"%s %s://%s%s %s\" from %s - %d %dB in %s", r.Method, scheme, r.Host, logging.RedactURI(r.RequestURI), r.Proto,
+					r.RemoteAddr, ww.Status(), ww.BytesWritten(), duration))
+			}()
+
+			next.ServeHTTP(ww, r)
+		}
+		return http.HandlerFunc(fn)
+	}
+}
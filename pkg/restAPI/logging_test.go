@@ -0,0 +1,91 @@
+package restAPI
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/exasol/extension-manager/pkg/logging"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	log "github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/suite"
+)
+
+type LoggingMiddlewareSuite struct {
+	suite.Suite
+	hook *test.Hook
+}
+
+func TestLoggingMiddlewareSuite(t *testing.T) {
+	suite.Run(t, new(LoggingMiddlewareSuite))
+}
+
+func (suite *LoggingMiddlewareSuite) SetupTest() {
+	suite.hook = test.NewGlobal()
+}
+
+func (suite *LoggingMiddlewareSuite) TearDownTest() {
+	log.StandardLogger().ReplaceHooks(make(log.LevelHooks))
+}
+
+func (suite *LoggingMiddlewareSuite) TestRequestLogContainsFields() {
+	suite.serve(httptest.NewRequest(http.MethodGet, "/extensions/ext-id/1.0.0?dbHost=exasol", nil))
+	entry := suite.hook.LastEntry()
+	suite.Require().NotNil(entry)
+	suite.Equal(log.InfoLevel, entry.Level)
+	suite.Regexp(`^"GET http://example.com/extensions/ext-id/1.0.0\?dbHost=exasol HTTP/1.1" from 192.0.2.1:1234 - 200 2B in \S+$`, entry.Message)
+	suite.Subset(entry.Data, log.Fields{
+		logging.FieldOperation:        "GetExtensionDetails",
+		logging.FieldExtensionID:      "ext-id",
+		logging.FieldExtensionVersion: "1.0.0",
+		logging.FieldMethod:           "GET",
+		logging.FieldPath:             "/extensions/ext-id/1.0.0",
+		logging.FieldStatus:           200,
+		logging.FieldBytes:            2,
+		logging.FieldRemoteAddress:    "192.0.2.1:1234",
+	})
+	suite.NotEmpty(entry.Data[logging.FieldRequestID])
+	suite.Contains(entry.Data, logging.FieldDurationMs)
+}
+
+func (suite *LoggingMiddlewareSuite) TestHandlerLogContainsFields() {
+	suite.serve(httptest.NewRequest(http.MethodGet, "/extensions/ext-id/1.0.0", nil))
+	entries := suite.hook.AllEntries()
+	suite.Require().Len(entries, 2)
+	suite.Equal("handler message", entries[0].Message)
+	suite.Equal(log.Fields{
+		logging.FieldOperation:        "GetExtensionDetails",
+		logging.FieldExtensionID:      "ext-id",
+		logging.FieldExtensionVersion: "1.0.0",
+		logging.FieldRequestID:        entries[1].Data[logging.FieldRequestID],
+	}, entries[0].Data)
+}
+
+func (suite *LoggingMiddlewareSuite) TestRequestLogRedactsParameterValues() {
+	suite.serve(httptest.NewRequest(http.MethodGet, `/extensions/ext-id/1.0.0?parameterValues=%5B%7B%22name%22%3A%22pwd%22%2C%22value%22%3A%22secret%22%7D%5D`, nil))
+	entry := suite.hook.LastEntry()
+	suite.Require().NotNil(entry)
+	suite.Contains(entry.Message, "/extensions/ext-id/1.0.0?parameterValues=****** HTTP/1.1")
+	suite.NotContains(entry.Message, "secret")
+}
+
+func (suite *LoggingMiddlewareSuite) TestGetLoggerWithoutMiddleware() {
+	ctx := context.Background()
+	addRequestLogFields(ctx, log.Fields{logging.FieldOperation: "ignored"})
+	suite.Empty(GetLogger(ctx).Data)
+}
+
+func (suite *LoggingMiddlewareSuite) serve(request *http.Request) {
+	router := chi.NewRouter()
+	router.Use(middleware.RequestID, loggerMiddleware())
+	router.Get("/extensions/{extensionId}/{extensionVersion}", func(w http.ResponseWriter, r *http.Request) {
+		addOperationLogFields(r, "GetExtensionDetails")
+		GetLogger(r.Context()).Info("handler message")
+		_, err := w.Write([]byte("ok"))
+		suite.NoError(err)
+	})
+	router.ServeHTTP(httptest.NewRecorder(), request)
+}
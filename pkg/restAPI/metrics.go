@@ -0,0 +1,100 @@
+package restAPI
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/exasol/extension-manager/pkg/metrics"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const metricsPath = "/metrics"
+
+// unmatchedRoute is used as route label for requests that did not match any route.
+// Using the request path instead would allow clients to create an unlimited number of time series.
+const unmatchedRoute = "unmatched"
+
+// metricsMiddleware records count and duration of each request by method, route pattern and response status.
+func metricsMiddleware() func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			start := time.Now()
+			defer func() {
+				metrics.ObserveHTTPRequest(r.Method, getRoutePattern(r), getResponseStatus(ww), time.Since(start))
+			}()
+			next.ServeHTTP(ww, r)
+		}
+		return http.HandlerFunc(fn)
+	}
+}
+
+func getRoutePattern(r *http.Request) string {
+	routeContext := chi.RouteContext(r.Context())
+	if routeContext == nil || routeContext.RoutePattern() == "" {
+		return unmatchedRoute
+	}
+	return routeContext.RoutePattern()
+}
+
+// getResponseStatus returns the status sent to the client. Handlers that don't set a status explicitly send 200.
+func getResponseStatus(ww middleware.WrapResponseWriter) int {
+	if ww.Status() == 0 {
+		return http.StatusOK
+	}
+	return ww.Status()
+}
+
+// createMetricsHandler creates a handler for the metrics endpoint including the metrics of the given database connector.
+func createMetricsHandler(database *databaseConnector) http.Handler {
+	serverMetrics := prometheus.NewRegistry()
+	if database.pool != nil {
+		serverMetrics.MustRegister(newDatabasePoolCollector(database.pool))
+	}
+	return metrics.Handler(serverMetrics)
+}
+
+// databasePoolCollector exports the statistics of a [databasePool] as Prometheus metrics.
+type databasePoolCollector struct {
+	pool            *databasePool
+	size            *prometheus.Desc
+	inUse           *prometheus.Desc
+	openConnections *prometheus.Desc
+	hits            *prometheus.Desc
+	misses          *prometheus.Desc
+	evictions       *prometheus.Desc
+}
+
+func newDatabasePoolCollector(pool *databasePool) *databasePoolCollector {
+	name := func(name string) string { return prometheus.BuildFQName(metrics.Namespace, "database_pool", name) }
+	return &databasePoolCollector{
+		pool:            pool,
+		size:            prometheus.NewDesc(name("entries"), "Number of pooled databases.", nil, nil),
+		inUse:           prometheus.NewDesc(name("entries_in_use"), "Number of pooled databases currently used by requests.", nil, nil),
+		openConnections: prometheus.NewDesc(name("open_connections"), "Number of open connections of all pooled databases.", nil, nil),
+		hits:            prometheus.NewDesc(name("hits_total"), "Number of requests that reused a pooled database.", nil, nil),
+		misses:          prometheus.NewDesc(name("misses_total"), "Number of requests that opened a new database.", nil, nil),
+		evictions:       prometheus.NewDesc(name("evictions_total"), "Number of databases closed because they were idle or the pool was full.", nil, nil),
+	}
+}
+
+func (c *databasePoolCollector) Describe(descriptions chan<- *prometheus.Desc) {
+	descriptions <- c.size
+	descriptions <- c.inUse
+	descriptions <- c.openConnections
+	descriptions <- c.hits
+	descriptions <- c.misses
+	descriptions <- c.evictions
+}
+
+func (c *databasePoolCollector) Collect(collectedMetrics chan<- prometheus.Metric) {
+	stats := c.pool.stats()
+	collectedMetrics <- prometheus.MustNewConstMetric(c.size, prometheus.GaugeValue, float64(stats.Size))
+	collectedMetrics <- prometheus.MustNewConstMetric(c.inUse, prometheus.GaugeValue, float64(stats.InUse))
+	collectedMetrics <- prometheus.MustNewConstMetric(c.openConnections, prometheus.GaugeValue, float64(stats.OpenConnections))
+	collectedMetrics <- prometheus.MustNewConstMetric(c.hits, prometheus.CounterValue, float64(stats.Hits))
+	collectedMetrics <- prometheus.MustNewConstMetric(c.misses, prometheus.CounterValue, float64(stats.Misses))
+	collectedMetrics <- prometheus.MustNewConstMetric(c.evictions, prometheus.CounterValue, float64(stats.Evictions))
+}
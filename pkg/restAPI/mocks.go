@@ -20,11 +20,27 @@ type mockExtensionController struct {
 	mock.Mock
 }
 
+func (m *mockExtensionController) CheckReadiness(ctx context.Context) []extensionController.ComponentStatus {
+	args := m.Called(ctx)
+	if result, ok := args.Get(0).([]extensionController.ComponentStatus); ok {
+		return result
+	}
+	return nil
+}
+
 func (m *mockExtensionController) InstallExtension(ctx context.Context, db *sql.DB, extensionId string, extensionVersion string) error {
 	args := m.Called(ctx, db, extensionId, extensionVersion)
 	return args.Error(0)
 }
 
+func (m *mockExtensionController) CheckInstall(ctx context.Context, db *sql.DB, extensionId string, extensionVersion string) (*extensionController.InstallCheckResult, error) {
+	args := m.Called(ctx, db, extensionId, extensionVersion)
+	if result, ok := args.Get(0).(*extensionController.InstallCheckResult); ok {
+		return result, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
 func (m *mockExtensionController) UninstallExtension(ctx context.Context, db *sql.DB, extensionId string, extensionVersion string) error {
 	args := m.Called(ctx, db, extensionId, extensionVersion)
 	return args.Error(0)
@@ -54,6 +70,22 @@ func (m *mockExtensionController) GetParameterDefinitions(ctx context.Context, d
 	return nil, args.Error(1)
 }
 
+func (m *mockExtensionController) GetActiveParameterDefinitions(ctx context.Context, db *sql.DB, extensionId string, extensionVersion string, parameterValues []extensionController.ParameterValue) ([]parameterValidator.ParameterDefinition, error) {
+	args := m.Called(ctx, db, extensionId, extensionVersion, parameterValues)
+	if paramDefinitions, ok := args.Get(0).([]parameterValidator.ParameterDefinition); ok {
+		return paramDefinitions, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *mockExtensionController) ValidateParameters(ctx context.Context, db *sql.DB, extensionId string, extensionVersion string, parameterValues []extensionController.ParameterValue) ([]parameterValidator.ParameterValidationResult, error) {
+	args := m.Called(ctx, db, extensionId, extensionVersion, parameterValues)
+	if results, ok := args.Get(0).([]parameterValidator.ParameterValidationResult); ok {
+		return results, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
 func (m *mockExtensionController) GetAllExtensions(ctx context.Context, db *sql.DB) ([]*extensionController.Extension, error) {
 	args := m.Called(ctx, db)
 	if extensions, ok := args.Get(0).([]*extensionController.Extension); ok {
@@ -82,3 +114,19 @@ func (m *mockExtensionController) DeleteInstance(ctx context.Context, db *sql.DB
 	args := m.Called(ctx, db, extensionId, extensionVersion, instanceId)
 	return args.Error(0)
 }
+
+func (m *mockExtensionController) UpdateInstance(ctx context.Context, db *sql.DB, extensionId, extensionVersion, instanceId string, parameterValues []extensionController.ParameterValue) (*extensionAPI.JsExtInstance, error) {
+	args := m.Called(ctx, db, extensionId, extensionVersion, instanceId, parameterValues)
+	if instance, ok := args.Get(0).(*extensionAPI.JsExtInstance); ok {
+		return instance, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *mockExtensionController) GetInstance(ctx context.Context, db *sql.DB, extensionId, extensionVersion, instanceId string) (*extensionAPI.JsExtInstanceDetails, error) {
+	args := m.Called(ctx, db, extensionId, extensionVersion, instanceId)
+	if instance, ok := args.Get(0).(*extensionAPI.JsExtInstanceDetails); ok {
+		return instance, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
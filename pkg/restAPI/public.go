@@ -1,6 +1,8 @@
 package restAPI
 
 import (
+	"fmt"
+
 	"github.com/Nightapes/go-rest/pkg/openapi"
 	"github.com/exasol/extension-manager/pkg/extensionController"
 )
@@ -12,6 +14,7 @@ const (
 	TagExtension    = "Extension"
 	TagInstallation = "Installation"
 	TagInstance     = "Instance"
+	TagJob          = "Job"
 
 	BearerAuth = "DbAccessToken"
 	BasicAuth  = "DbUsernamePassword"
@@ -21,21 +24,37 @@ const (
 // The config struct contains configuration options for the extension manager.
 /* [impl -> dsn~go-library~1]. */
 func AddPublicEndpoints(api *openapi.API, config extensionController.ExtensionManagerConfig) error {
+	return AddPublicEndpointsWithDatabaseConfig(api, config, DatabaseConfig{ValidateServerCertificate: false, CABundleFile: "", CertificateFingerprint: "", Targets: nil, DefaultTarget: "", Pool: DatabasePoolConfig{MaxSize: 0, IdleTimeout: 0}})
+}
+
+// AddPublicEndpointsWithDatabaseConfig adds the extension manager endpoints to the API.
+// In addition to [AddPublicEndpoints] this allows configuring how EM connects to the database, e.g. validating its TLS certificate.
+/* [impl -> dsn~go-library~1]. */
+func AddPublicEndpointsWithDatabaseConfig(api *openapi.API, config extensionController.ExtensionManagerConfig, dbConfig DatabaseConfig) error {
 	controller, err := extensionController.CreateWithValidatedConfig(config)
 	if err != nil {
 		return err
 	}
-	return addPublicEndpointsWithController(api, false, controller)
+	database, err := newDatabaseConnector(dbConfig)
+	if err != nil {
+		return fmt.Errorf("invalid database configuration: %w", err)
+	}
+	return addPublicEndpointsWithController(api, false, controller, database, newDefaultJobManager(), newDrainer())
 }
 
 /* [impl -> dsn~rest-interface~1] */
 /* [impl -> dsn~openapi-spec~1]. */
-func addPublicEndpointsWithController(api *openapi.API, addCauseToInternalServerError bool, controller extensionController.TransactionController) error {
+func addPublicEndpointsWithController(api *openapi.API, addCauseToInternalServerError bool, controller extensionController.TransactionController,
+	database *databaseConnector, jobs *jobManager, drainer *drainer) error {
 	api.AddTag(TagExtension, "List and install extensions")
 	api.AddTag(TagInstallation, "List and uninstall installed extensions")
-	api.AddTag(TagInstance, "Calls to list, create and remove instances of an extension")
+	api.AddTag(TagInstance, "Calls to list, create, update and remove instances of an extension")
+	api.AddTag(TagJob, "Get the status of asynchronous jobs")
 
 	apiContext := NewApiContext(controller, addCauseToInternalServerError)
+	apiContext.database = database
+	apiContext.jobs = jobs
+	apiContext.drainer = drainer
 
 	if err := api.Get(ListAvailableExtensions(apiContext)); err != nil {
 		return err
@@ -46,9 +65,15 @@ func addPublicEndpointsWithController(api *openapi.API, addCauseToInternalServer
 	if err := api.Get(GetExtensionDetails(apiContext)); err != nil {
 		return err
 	}
+	if err := api.Post(ValidateParameters(apiContext)); err != nil {
+		return err
+	}
 	if err := api.Put(InstallExtension(apiContext)); err != nil {
 		return err
 	}
+	if err := api.Get(CheckInstall(apiContext)); err != nil {
+		return err
+	}
 	if err := api.Delete(UninstallExtension(apiContext)); err != nil {
 		return err
 	}
@@ -64,5 +89,14 @@ func addPublicEndpointsWithController(api *openapi.API, addCauseToInternalServer
 	if err := api.Delete(DeleteInstance(apiContext)); err != nil {
 		return err
 	}
+	if err := api.Put(UpdateInstance(apiContext)); err != nil {
+		return err
+	}
+	if err := api.Get(GetInstance(apiContext)); err != nil {
+		return err
+	}
+	if err := api.Get(GetJob(apiContext)); err != nil {
+		return err
+	}
 	return nil
 }
@@ -0,0 +1,76 @@
+package restAPI
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/Nightapes/go-rest/pkg/openapi"
+	"github.com/go-chi/chi/v5"
+
+	"github.com/exasol/extension-manager/pkg/apiErrors"
+	"github.com/exasol/extension-manager/pkg/extensionController"
+)
+
+func CheckInstall(apiContext *ApiContext) *openapi.Get {
+	//nolint:exhaustruct // Default values for request are OK
+	return &openapi.Get{
+		Summary: "Check if an extension can be installed.",
+		Description: "This checks if an extension can be installed in a given version without modifying the database. " +
+			"It verifies that required files exist in BucketFS, that the database user has the required privileges, " +
+			"that the extension schema can be created, that no other version of the extension is installed " +
+			"and that installing the extension does not replace existing scripts. " +
+			"Extensions can provide additional checks. The response contains the result of each check.",
+		OperationID:    "CheckInstall",
+		Tags:           []string{TagExtension},
+		Authentication: authentication,
+		Response: map[string]openapi.MethodResponse{
+			"200": {Description: "OK", Value: InstallCheckResponse{Success: false, Checks: []InstallCheck{
+				{Type: extensionController.CHECK_BUCKETFS_FILE, Name: "document-files-virtual-schema-dist-7.3.3-s3-2.6.2.jar", Success: true, Message: ""},
+				{Type: extensionController.CHECK_PRIVILEGE, Name: "CREATE CONNECTION", Success: false, Message: `the database user does not have system privilege "CREATE CONNECTION"`}}}},
+			"404": {
+				Description: "Extension not found",
+				Value:       apiErrors.REG_EXTENSION_NOT_FOUND.NewErrorF("Extension not found")},
+		},
+		Path: newPathWithDbQueryParams().
+			Add("extensions").
+			AddParameter("extensionId", openapi.STRING, "ID of the extension to check").
+			AddParameter("extensionVersion", openapi.STRING, "Version of the extension to check").
+			Add("install").
+			Add("check"),
+		HandlerFunc: adaptDbHandler(apiContext, "CheckInstall", handleCheckInstall(apiContext)),
+	}
+}
+
+func handleCheckInstall(apiContext *ApiContext) dbHandler {
+	return func(db *sql.DB, writer http.ResponseWriter, request *http.Request) error {
+		extensionId := chi.URLParam(request, "extensionId")
+		extensionVersion := chi.URLParam(request, "extensionVersion")
+		result, err := apiContext.Controller.CheckInstall(request.Context(), db, extensionId, extensionVersion)
+		if err != nil {
+			return err
+		}
+		return SendJSON(request.Context(), writer, convertInstallCheckResult(result))
+	}
+}
+
+func convertInstallCheckResult(result *extensionController.InstallCheckResult) InstallCheckResponse {
+	checks := make([]InstallCheck, 0, len(result.Checks))
+	for _, c := range result.Checks {
+		checks = append(checks, InstallCheck{Type: c.Type, Name: c.Name, Success: c.Success, Message: c.Message})
+	}
+	return InstallCheckResponse{Success: result.Success, Checks: checks}
+}
+
+// InstallCheckResponse contains the result of each pre-flight check for installing an extension.
+type InstallCheckResponse struct {
+	Success bool           `json:"success"` // True if all checks passed
+	Checks  []InstallCheck `json:"checks"`  // The results of the individual checks
+}
+
+// InstallCheck is the result of a single pre-flight check.
+type InstallCheck struct {
+	Type    string `json:"type"`    // Type of the check, one of "bucketfs-file", "privilege", "schema", "installation", "scripts" or "extension"
+	Name    string `json:"name"`    // Description of what was checked, e.g. the name of a file or privilege
+	Success bool   `json:"success"` // True if the check passed
+	Message string `json:"message"` // The reason why the check failed, empty for successful checks
+}
@@ -1,6 +1,7 @@
 package restAPI
 
 import (
+	"context"
 	"database/sql"
 	"net/http"
 
@@ -10,6 +11,7 @@ import (
 
 	"github.com/exasol/extension-manager/pkg/apiErrors"
 	"github.com/exasol/extension-manager/pkg/extensionController"
+	"github.com/exasol/extension-manager/pkg/logging"
 )
 
 func CreateInstance(apiContext *ApiContext) *openapi.Post {
@@ -23,28 +25,36 @@ func CreateInstance(apiContext *ApiContext) *openapi.Post {
 		RequestBody:    CreateInstanceRequest{ParameterValues: []ParameterValue{{Name: "param1", Value: "value1"}}},
 		Response: map[string]openapi.MethodResponse{
 			"200": {Description: "OK", Value: CreateInstanceResponse{InstanceId: "id", InstanceName: "new-instance-name"}},
+			"202": {Description: "Job started", Value: jobStartedExample("CreateInstance")},
 			"400": {
 				Description: "Invalid parameters specified",
-				Value:       apiErrors.NewBadRequestErrorF("Validation failed: parameter 'Virtual Schema' is missing")},
+				Value:       invalidParametersErrorExample()},
 			"404": {
 				Description: "Extension not found",
-				Value:       apiErrors.NewNotFoundErrorF("Extension not found")},
+				Value:       apiErrors.REG_EXTENSION_NOT_FOUND.NewErrorF("Extension not found")},
 		},
-		Path: newPathWithDbQueryParams().Add("installations").
+		Path: newPathWithAsyncQueryParam().Add("installations").
 			AddParameter("extensionId", openapi.STRING, "ID of the installed extension for which to create an instance").
 			AddParameter("extensionVersion", openapi.STRING, "Version of the installed extension for which to create an instance").
 			Add("instances"),
-		HandlerFunc: adaptDbHandler(apiContext, handleCreateInstance(apiContext)),
+		HandlerFunc: adaptDbOperation(apiContext, "CreateInstance", handleCreateInstance(apiContext)),
 	}
 }
 
-func handleCreateInstance(apiContext *ApiContext) dbHandler {
-	return func(db *sql.DB, writer http.ResponseWriter, request *http.Request) error {
+// invalidParametersErrorExample returns an example error for invalid parameter values.
+// Clients can use the details to highlight the invalid parameters.
+func invalidParametersErrorExample() error {
+	return apiErrors.CTRL_INVALID_PARAMETERS.NewErrorWithDetails("invalid parameters: Failed to validate parameter 'Virtual Schema' (vsName): This is a required parameter.",
+		[]apiErrors.ErrorDetail{{Field: "vsName", ErrorCode: apiErrors.DETAIL_MISSING_VALUE, Message: "This is a required parameter."}})
+}
+
+func handleCreateInstance(apiContext *ApiContext) operationHandler {
+	return func(writer http.ResponseWriter, request *http.Request) (dbOperation, error) {
 		//nolint:exhaustruct // Omitting values by intention for deserialization
 		requestBody := CreateInstanceRequest{}
 		err := DecodeJSONBody(writer, request, &requestBody)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		var parameters []extensionController.ParameterValue
 		for _, p := range requestBody.ParameterValues {
@@ -52,12 +62,15 @@ func handleCreateInstance(apiContext *ApiContext) dbHandler {
 		}
 		extensionId := chi.URLParam(request, "extensionId")
 		extensionVersion := chi.URLParam(request, "extensionVersion")
-		instance, err := apiContext.Controller.CreateInstance(request.Context(), db, extensionId, extensionVersion, parameters)
-		if err != nil {
-			return err
-		}
-		logrus.Debugf("Created instance %q", instance)
-		return SendJSON(request.Context(), writer, CreateInstanceResponse{InstanceId: instance.Id, InstanceName: instance.Name})
+		return func(ctx context.Context, db *sql.DB) (any, error) {
+			instance, err := apiContext.Controller.CreateInstance(ctx, db, extensionId, extensionVersion, parameters)
+			if err != nil {
+				return nil, err
+			}
+			addRequestLogFields(ctx, logrus.Fields{logging.FieldInstanceID: instance.Id})
+			GetLogger(ctx).Debugf("Created instance %q", instance.Name)
+			return CreateInstanceResponse{InstanceId: instance.Id, InstanceName: instance.Name}, nil
+		}, nil
 	}
 }
 
@@ -68,8 +81,8 @@ type CreateInstanceRequest struct {
 
 // Parameter values for creating a new instance.
 type ParameterValue struct {
-	Name  string `json:"name"`  // The name of the parameter
-	Value string `json:"value"` // The value of the parameter
+	Name  string      `json:"name"`  // The name of the parameter
+	Value interface{} `json:"value"` // The value of the parameter: string, number, boolean or array
 }
 
 // Response data for creating a new instance of an extension.
@@ -1,6 +1,7 @@
 package restAPI
 
 import (
+	"context"
 	"database/sql"
 	"net/http"
 
@@ -19,28 +20,27 @@ func DeleteInstance(apiContext *ApiContext) *openapi.Delete {
 		Authentication: authentication,
 		Response: map[string]openapi.MethodResponse{
 			"204": {Description: "OK"},
+			"202": {Description: "Job started", Value: jobStartedExample("DeleteInstance")},
 			"404": {
 				Description: "Extension or instance not found",
-				Value:       apiErrors.NewNotFoundErrorF("Extension not found")},
+				Value:       apiErrors.REG_EXTENSION_NOT_FOUND.NewErrorF("Extension not found")},
 		},
-		Path: newPathWithDbQueryParams().Add("installations").
+		Path: newPathWithAsyncQueryParam().Add("installations").
 			AddParameter("extensionId", openapi.STRING, "The ID of the extension for which to delete an instance").
 			AddParameter("extensionVersion", openapi.STRING, "The version of the installed extension for which to delete an instance").
 			Add("instances").
 			AddParameter("instanceId", openapi.STRING, "The ID of the instance to delete"),
-		HandlerFunc: adaptDbHandler(apiContext, handleDeleteInstance(apiContext)),
+		HandlerFunc: adaptDbOperation(apiContext, "DeleteInstance", handleDeleteInstance(apiContext)),
 	}
 }
 
-func handleDeleteInstance(apiContext *ApiContext) dbHandler {
-	return func(db *sql.DB, writer http.ResponseWriter, request *http.Request) error {
+func handleDeleteInstance(apiContext *ApiContext) operationHandler {
+	return func(writer http.ResponseWriter, request *http.Request) (dbOperation, error) {
 		extensionId := chi.URLParam(request, "extensionId")
 		extensionVersion := chi.URLParam(request, "extensionVersion")
 		instanceId := chi.URLParam(request, "instanceId")
-		err := apiContext.Controller.DeleteInstance(request.Context(), db, extensionId, extensionVersion, instanceId)
-		if err != nil {
-			return err
-		}
-		return SendNoContent(request.Context(), writer)
+		return func(ctx context.Context, db *sql.DB) (any, error) {
+			return nil, apiContext.Controller.DeleteInstance(ctx, db, extensionId, extensionVersion, instanceId)
+		}, nil
 	}
 }
@@ -2,10 +2,12 @@ package restAPI
 
 import (
 	"database/sql"
+	"encoding/json"
 	"net/http"
 
 	"github.com/Nightapes/go-rest/pkg/openapi"
 	"github.com/exasol/extension-manager/pkg/apiErrors"
+	"github.com/exasol/extension-manager/pkg/extensionController"
 	"github.com/exasol/extension-manager/pkg/parameterValidator"
 	"github.com/go-chi/chi/v5"
 )
@@ -15,7 +17,7 @@ func GetExtensionDetails(apiContext *ApiContext) *openapi.Get {
 	//nolint:exhaustruct // Default values for request are OK
 	return &openapi.Get{
 		Summary:        "Get details about an extension version.",
-		Description:    "This returns details about an extension version, e.g. the parameter definitions required for creating an instance.",
+		Description:    "This returns details about an extension version, e.g. the parameter definitions required for creating an instance. If query parameter parameterValues is specified, the response contains only the parameter definitions that are active for the given values, i.e. conditional parameters with unfulfilled condition are omitted.",
 		OperationID:    "GetExtensionDetails",
 		Tags:           []string{TagExtension},
 		Authentication: authentication,
@@ -25,6 +27,9 @@ func GetExtensionDetails(apiContext *ApiContext) *openapi.Get {
 				Value: ExtensionDetailsResponse{Id: "s3-vs", Version: "1.2.3", ParamDefinitions: []ParamDefinition{
 					{Id: "s3Bucket", Name: "S3 Bucket Name",
 						RawDefinition: map[string]interface{}{"id": "s3Bucket", "name": "S3 Bucket Name", "type": "string", "required": true}}}}},
+			"400": {
+				Description: "Invalid parameter values specified",
+				Value:       apiErrors.API_INVALID_PARAMETER_VALUES_QUERY.NewErrorF("invalid value for parameter parameterValues")},
 			"404": {
 				Description: "Extension not found or creating instances not supported for this extension",
 				Value:       apiErrors.NewNotFoundErrorF("Creating instances not supported")},
@@ -32,8 +37,9 @@ func GetExtensionDetails(apiContext *ApiContext) *openapi.Get {
 		Path: newPathWithDbQueryParams().
 			Add("extensions").
 			AddParameter("extensionId", openapi.STRING, "ID of the extension").
-			AddParameter("extensionVersion", openapi.STRING, "Version of the extension"),
-		HandlerFunc: adaptDbHandler(apiContext, handleGetParameterDefinitions(apiContext)),
+			AddParameter("extensionVersion", openapi.STRING, "Version of the extension").
+			WithQueryParameter("parameterValues", openapi.STRING, `Optional, possibly incomplete parameter values as JSON, e.g. [{"name":"connectorType","value":"jdbc"}]`, false),
+		HandlerFunc: adaptDbHandler(apiContext, "GetExtensionDetails", handleGetParameterDefinitions(apiContext)),
 	}
 }
 
@@ -41,7 +47,7 @@ func handleGetParameterDefinitions(apiContext *ApiContext) dbHandler {
 	return func(db *sql.DB, writer http.ResponseWriter, request *http.Request) error {
 		extensionId := chi.URLParam(request, "extensionId")
 		extensionVersion := chi.URLParam(request, "extensionVersion")
-		definitions, err := apiContext.Controller.GetParameterDefinitions(request.Context(), db, extensionId, extensionVersion)
+		definitions, err := getParameterDefinitions(apiContext, db, request, extensionId, extensionVersion)
 		if err != nil {
 			return err
 		}
@@ -50,6 +56,21 @@ func handleGetParameterDefinitions(apiContext *ApiContext) dbHandler {
 	}
 }
 
+func getParameterDefinitions(apiContext *ApiContext, db *sql.DB, request *http.Request, extensionId, extensionVersion string) ([]parameterValidator.ParameterDefinition, error) {
+	if !request.URL.Query().Has("parameterValues") {
+		return apiContext.Controller.GetParameterDefinitions(request.Context(), db, extensionId, extensionVersion)
+	}
+	var values []ParameterValue
+	if err := json.Unmarshal([]byte(request.URL.Query().Get("parameterValues")), &values); err != nil {
+		return nil, apiErrors.API_INVALID_PARAMETER_VALUES_QUERY.NewErrorF("invalid value for parameter parameterValues: %v", err)
+	}
+	parameters := make([]extensionController.ParameterValue, 0, len(values))
+	for _, p := range values {
+		parameters = append(parameters, extensionController.ParameterValue{Name: p.Name, Value: p.Value})
+	}
+	return apiContext.Controller.GetActiveParameterDefinitions(request.Context(), db, extensionId, extensionVersion, parameters)
+}
+
 func convertParamDefinitions(definitions []parameterValidator.ParameterDefinition) []ParamDefinition {
 	result := make([]ParamDefinition, 0, len(definitions))
 	for _, d := range definitions {
@@ -0,0 +1,89 @@
+package restAPI
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/Nightapes/go-rest/pkg/openapi"
+	"github.com/exasol/extension-manager/pkg/apiErrors"
+	"github.com/exasol/extension-manager/pkg/extensionAPI"
+	"github.com/go-chi/chi/v5"
+)
+
+func GetInstance(apiContext *ApiContext) *openapi.Get {
+	//nolint:exhaustruct // Default values for request are OK
+	return &openapi.Get{
+		Summary:        "Get an instance of an extension.",
+		Description:    "This returns the details of a single instance of an extension: its current parameter values, information about its creation and the database objects belonging to it. Values of secret parameters are masked.",
+		OperationID:    "GetInstance",
+		Tags:           []string{TagInstance},
+		Authentication: authentication,
+		Response: map[string]openapi.MethodResponse{
+			"200": {Description: "OK", Value: InstanceDetails{Id: "s3-vs-1", Name: "SALES_S3_VS",
+				ParameterValues: []ParameterValue{{Name: "bucket", Value: "sales"}, {Name: "secretKey", Value: "******"}},
+				Metadata:        InstanceMetadata{CreatedAt: "2023-01-02 03:04:05.000000", CreatedBy: "SYS"},
+				DbObjects:       []DbObject{{Type: "VIRTUAL SCHEMA", Schema: "", Name: "SALES_S3_VS"}, {Type: "CONNECTION", Schema: "", Name: "SALES_S3_VS_CONNECTION"}}}},
+			"404": {
+				Description: "Extension or instance not found",
+				Value:       apiErrors.CTRL_INSTANCE_NOT_FOUND.NewErrorF("Instance not found")},
+		},
+		Path: newPathWithDbQueryParams().Add("installations").
+			AddParameter("extensionId", openapi.STRING, "The ID of the installed extension for which to get an instance").
+			AddParameter("extensionVersion", openapi.STRING, "The version of the installed extension for which to get an instance").
+			Add("instances").
+			AddParameter("instanceId", openapi.STRING, "The ID of the instance"),
+		HandlerFunc: adaptDbHandler(apiContext, "GetInstance", handleGetInstance(apiContext)),
+	}
+}
+
+func handleGetInstance(apiContext *ApiContext) dbHandler {
+	return func(db *sql.DB, writer http.ResponseWriter, request *http.Request) error {
+		extensionId := chi.URLParam(request, "extensionId")
+		extensionVersion := chi.URLParam(request, "extensionVersion")
+		instanceId := chi.URLParam(request, "instanceId")
+		instance, err := apiContext.Controller.GetInstance(request.Context(), db, extensionId, extensionVersion, instanceId)
+		if err != nil {
+			return err
+		}
+		return SendJSON(request.Context(), writer, convertInstanceDetails(instance))
+	}
+}
+
+func convertInstanceDetails(instance *extensionAPI.JsExtInstanceDetails) InstanceDetails {
+	parameters := make([]ParameterValue, 0, len(instance.Parameters))
+	for _, p := range instance.Parameters {
+		parameters = append(parameters, ParameterValue{Name: p.Name, Value: p.Value})
+	}
+	//nolint:exhaustruct // Metadata is optional
+	metadata := InstanceMetadata{}
+	if instance.Metadata != nil {
+		metadata = InstanceMetadata{CreatedAt: instance.Metadata.CreatedAt, CreatedBy: instance.Metadata.CreatedBy}
+	}
+	dbObjects := make([]DbObject, 0, len(instance.DbObjects))
+	for _, o := range instance.DbObjects {
+		dbObjects = append(dbObjects, DbObject{Type: o.Type, Schema: o.Schema, Name: o.Name})
+	}
+	return InstanceDetails{Id: instance.Id, Name: instance.Name, ParameterValues: parameters, Metadata: metadata, DbObjects: dbObjects}
+}
+
+// InstanceDetails contains an instance of an extension, its current parameter values and the database objects belonging to it.
+type InstanceDetails struct {
+	Id              string           `json:"id"`              // The ID of the instance
+	Name            string           `json:"name"`            // The name of the instance
+	ParameterValues []ParameterValue `json:"parameterValues"` // The current parameter values. Values of secret parameters are masked.
+	Metadata        InstanceMetadata `json:"metadata"`        // Information about the creation of the instance. Fields are empty if the extension does not provide them.
+	DbObjects       []DbObject       `json:"dbObjects"`       // The database objects belonging to the instance, e.g. a virtual schema and its connection.
+}
+
+// InstanceMetadata contains information about the creation of an instance.
+type InstanceMetadata struct {
+	CreatedAt string `json:"createdAt"` // The creation timestamp as returned by the database
+	CreatedBy string `json:"createdBy"` // The name of the database user who created the instance
+}
+
+// DbObject is a database object belonging to an instance.
+type DbObject struct {
+	Type   string `json:"type"`   // The object type, e.g. "VIRTUAL SCHEMA" or "CONNECTION"
+	Schema string `json:"schema"` // The schema containing the object. Empty for objects without schema.
+	Name   string `json:"name"`   // The name of the object
+}
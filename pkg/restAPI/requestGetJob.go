@@ -0,0 +1,87 @@
+package restAPI
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/Nightapes/go-rest/pkg/openapi"
+	"github.com/exasol/extension-manager/pkg/apiErrors"
+	"github.com/go-chi/chi/v5"
+)
+
+func GetJob(apiContext *ApiContext) *openapi.Get {
+	finishedAt := time.Date(2024, 1, 2, 3, 4, 10, 0, time.UTC)
+	//nolint:exhaustruct // Default values for request are OK
+	return &openapi.Get{
+		Summary:        "Get the status of an asynchronous job.",
+		Description:    "This returns status, result and log messages of a job started by calling a modifying endpoint with query parameter async=true. Only a client with the same credentials that started the job can read it.",
+		OperationID:    "GetJob",
+		Tags:           []string{TagJob},
+		Authentication: authentication,
+		Response: map[string]openapi.MethodResponse{
+			"200": {Description: "OK", Value: JobResponse{
+				Id: "3f2a9c0d7e1b4a6f8c5d2e9b0a1f7c3e", Operation: "CreateInstance", Status: JobSucceeded,
+				CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), FinishedAt: &finishedAt,
+				Result: json.RawMessage(`{"instanceId":"id","instanceName":"new-instance-name"}`), Error: nil,
+				Logs: []JobLogEntry{
+					{Time: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), Level: "info", Message: "Started operation CreateInstance"},
+					{Time: finishedAt, Level: "info", Message: "Operation succeeded after 5s"}}}},
+			"404": {
+				Description: "Job not found",
+				Value:       apiErrors.API_JOB_NOT_FOUND.NewErrorF("Job not found")},
+		},
+		Path: getV1PublicBasePath(openapi.NewPathBuilder()).
+			Add("jobs").
+			AddParameter("jobId", openapi.STRING, "ID of the job"),
+		HandlerFunc: handleGetJob(apiContext),
+	}
+}
+
+// jobStartedExample returns an example response of a modifying endpoint called with query parameter async=true.
+func jobStartedExample(operation string) JobResponse {
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	return JobResponse{Id: "3f2a9c0d7e1b4a6f8c5d2e9b0a1f7c3e", Operation: operation, Status: JobRunning, CreatedAt: createdAt, FinishedAt: nil, Result: nil, Error: nil,
+		Logs: []JobLogEntry{{Time: createdAt, Level: "info", Message: "Started operation " + operation}}}
+}
+
+func handleGetJob(apiContext *ApiContext) generalHandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		addOperationLogFields(request, "GetJob")
+		owner, err := getJobOwner(request, apiContext.jobs.ownerKey)
+		if err != nil {
+			handleError(request.Context(), apiContext, writer, err)
+			return
+		}
+		storedJob, err := apiContext.jobs.get(chi.URLParam(request, "jobId"), owner)
+		if err == nil {
+			err = SendJSON(request.Context(), writer, convertJob(storedJob))
+		}
+		if err != nil {
+			handleError(request.Context(), apiContext, writer, err)
+		}
+	}
+}
+
+func getJobPath(jobId string) string {
+	return "/api/v1/extensionmanager/jobs/" + jobId
+}
+
+// convertJob converts the job to the response. The response contains a copy of the logs, so it is not affected by messages added later.
+func convertJob(j *job) JobResponse {
+	return JobResponse{Id: j.ID, Operation: j.Operation, Status: j.Status, CreatedAt: j.CreatedAt, FinishedAt: j.FinishedAt,
+		Result: j.Result, Error: j.Error, Logs: slices.Clone(j.Logs)}
+}
+
+// Status of an asynchronous job.
+type JobResponse struct {
+	Id         string              `json:"id"`                   // ID of the job
+	Operation  string              `json:"operation"`            // Operation executed by the job, e.g. "InstallExtension"
+	Status     JobStatus           `json:"status"`               // Status of the job: "running", "succeeded", "failed" or "interrupted"
+	CreatedAt  time.Time           `json:"createdAt"`            // Time when the job was started
+	FinishedAt *time.Time          `json:"finishedAt,omitempty"` // Time when the job finished
+	Result     json.RawMessage     `json:"result,omitempty"`     // Result of the operation if it succeeded and returns a result. This is the same as the response of the synchronous operation.
+	Error      *apiErrors.APIError `json:"error,omitempty"`      // Error if the operation failed. This is the same as the error response of the synchronous operation.
+	Logs       []JobLogEntry       `json:"logs"`                 // Log messages of the job
+}
@@ -1,6 +1,7 @@
 package restAPI
 
 import (
+	"context"
 	"database/sql"
 	"net/http"
 
@@ -20,28 +21,27 @@ func InstallExtension(apiContext *ApiContext) *openapi.Put {
 		RequestBody:    InstallExtensionRequest{},
 		Response: map[string]openapi.MethodResponse{
 			"204": {Description: "OK"},
+			"202": {Description: "Job started", Value: jobStartedExample("InstallExtension")},
 			"404": {
 				Description: "Extension not found",
-				Value:       apiErrors.NewNotFoundErrorF("Extension not found")},
+				Value:       apiErrors.REG_EXTENSION_NOT_FOUND.NewErrorF("Extension not found")},
 		},
-		Path: newPathWithDbQueryParams().
+		Path: newPathWithAsyncQueryParam().
 			Add("extensions").
 			AddParameter("extensionId", openapi.STRING, "ID of the extension to install").
 			AddParameter("extensionVersion", openapi.STRING, "Version of the extension to install").
 			Add("install"),
-		HandlerFunc: adaptDbHandler(apiContext, handleInstallExtension(apiContext)),
+		HandlerFunc: adaptDbOperation(apiContext, "InstallExtension", handleInstallExtension(apiContext)),
 	}
 }
 
-func handleInstallExtension(apiContext *ApiContext) dbHandler {
-	return func(db *sql.DB, writer http.ResponseWriter, request *http.Request) error {
+func handleInstallExtension(apiContext *ApiContext) operationHandler {
+	return func(writer http.ResponseWriter, request *http.Request) (dbOperation, error) {
 		extensionId := chi.URLParam(request, "extensionId")
 		extensionVersion := chi.URLParam(request, "extensionVersion")
-		err := apiContext.Controller.InstallExtension(request.Context(), db, extensionId, extensionVersion)
-		if err != nil {
-			return err
-		}
-		return SendNoContent(request.Context(), writer)
+		return func(ctx context.Context, db *sql.DB) (any, error) {
+			return nil, apiContext.Controller.InstallExtension(ctx, db, extensionId, extensionVersion)
+		}, nil
 	}
 }
 
@@ -10,6 +10,7 @@ import (
 
 	"github.com/exasol/extension-manager/pkg/extensionAPI"
 	"github.com/exasol/extension-manager/pkg/extensionController"
+	"github.com/exasol/extension-manager/pkg/extensionController/bfs"
 )
 
 func ListAvailableExtensions(apiContext *ApiContext) *openapi.Get {
@@ -28,11 +29,16 @@ func ListAvailableExtensions(apiContext *ApiContext) *openapi.Get {
 					Category:            "virtual-schema",
 					Description:         "...",
 					InstallableVersions: []ExtensionVersion{{Name: "1.2.3", Deprecated: true, Latest: false}, {Name: "1.3.0", Latest: true, Deprecated: false}},
+					BucketFsFiles: []BucketFsFile{{
+						Name:     "document-files-virtual-schema-dist-7.3.3-s3-2.6.2.jar",
+						Path:     "/buckets/bfsdefault/default/document-files-virtual-schema-dist-7.3.3-s3-2.6.2.jar",
+						BasePath: "/buckets/bfsdefault/default/",
+					}},
 				}},
 			}},
 		},
 		Path:        newPathWithDbQueryParams().Add("extensions"),
-		HandlerFunc: adaptDbHandler(apiContext, handleListAvailableExtensions(apiContext)),
+		HandlerFunc: adaptDbHandler(apiContext, "ListAvailableExtensions", handleListAvailableExtensions(apiContext)),
 	}
 }
 
@@ -62,7 +68,16 @@ func convertExtension(extension *extensionController.Extension) ExtensionsRespon
 		Name:                extension.Name,
 		Category:            extension.Category,
 		Description:         extension.Description,
-		InstallableVersions: convertVersions(extension.InstallableVersions)}
+		InstallableVersions: convertVersions(extension.InstallableVersions),
+		BucketFsFiles:       convertBucketFsFiles(extension.BucketFsFiles)}
+}
+
+func convertBucketFsFiles(files []bfs.BfsFile) []BucketFsFile {
+	result := make([]BucketFsFile, 0, len(files))
+	for _, f := range files {
+		result = append(result, BucketFsFile{Name: f.Name, Path: f.Path, BasePath: f.BasePath})
+	}
+	return result
 }
 
 func convertVersions(versions []extensionAPI.JsExtensionVersion) []ExtensionVersion {
@@ -85,6 +100,14 @@ type ExtensionsResponseExtension struct {
 	Category            string             `json:"category"`            // The category of the extension, e.g. "driver" or "virtual-schema".
 	Description         string             `json:"description"`         // The description of the extension to be displayed to the user.
 	InstallableVersions []ExtensionVersion `json:"installableVersions"` // A list of versions of this extension available for installation.
+	BucketFsFiles       []BucketFsFile     `json:"bucketFsFiles"`       // The files required by this extension found in BucketFS.
+}
+
+// BucketFsFile contains information about a file found in BucketFS.
+type BucketFsFile struct {
+	Name     string `json:"name"`     // The file name.
+	Path     string `json:"path"`     // The absolute path of the file in BucketFS.
+	BasePath string `json:"basePath"` // The configured BucketFS base path in which the file was found.
 }
 
 type ExtensionVersion struct {
@@ -24,7 +24,7 @@ func ListInstalledExtensions(apiContext *ApiContext) *openapi.Get {
 			}},
 		},
 		Path:        newPathWithDbQueryParams().Add("installations"),
-		HandlerFunc: adaptDbHandler(apiContext, handleListInstalledExtensions(apiContext)),
+		HandlerFunc: adaptDbHandler(apiContext, "ListInstalledExtensions", handleListInstalledExtensions(apiContext)),
 	}
 }
 
@@ -21,13 +21,13 @@ func ListInstances(apiContext *ApiContext) *openapi.Get {
 			"200": {Description: "OK", Value: ListInstancesResponse{Instances: []Instance{{Id: "s3-vs-1", Name: "SALES_S3_VS"}}}},
 			"404": {
 				Description: "Extension not found",
-				Value:       apiErrors.NewNotFoundErrorF("Extension not found")},
+				Value:       apiErrors.REG_EXTENSION_NOT_FOUND.NewErrorF("Extension not found")},
 		},
 		Path: newPathWithDbQueryParams().Add("installations").
 			AddParameter("extensionId", openapi.STRING, "The ID of the installed extension for which to get the instances").
 			AddParameter("extensionVersion", openapi.STRING, "The version of the installed extension for which to get the instances").
 			Add("instances"),
-		HandlerFunc: adaptDbHandler(apiContext, handleListInstances(apiContext)),
+		HandlerFunc: adaptDbHandler(apiContext, "ListInstances", handleListInstances(apiContext)),
 	}
 }
 
@@ -7,9 +7,12 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/exasol/extension-manager/pkg/apiErrors"
+	"github.com/exasol/extension-manager/pkg/logging"
+	"github.com/exasol/extension-manager/pkg/tracing"
 	"github.com/go-chi/chi/v5/middleware"
 	log "github.com/sirupsen/logrus"
 )
@@ -38,7 +41,7 @@ func SendJSONWithStatus(ctx context.Context, status int, writer http.ResponseWri
 		if err != nil {
 			logger.Warnf("Failed to format json data for logging: %q", data)
 		} else {
-			logger.Debugf("Send json %s", jsonData)
+			logger.Debugf("Send json %s", logging.RedactJSON(jsonData))
 		}
 	}
 	if data != nil {
@@ -58,20 +61,25 @@ func SendJSONWithStatus(ctx context.Context, status int, writer http.ResponseWri
 }
 
 func handleError(context context.Context, apiContext *ApiContext, writer http.ResponseWriter, err error) {
-	log.Errorf("Error processing request: %v", err)
-	errorToSend := apiErrors.UnwrapAPIError(err)
-	sendError(errorToSend, context, apiContext, writer)
+	GetLogger(context).Errorf("Error processing request: %v", err)
+	sendError(toClientError(context, apiContext, err), context, writer)
 }
 
-func sendError(a *apiErrors.APIError, context context.Context, apiContext *ApiContext, writer http.ResponseWriter) {
-	writer.Header().Set(HeaderContentType, ContentTypeJson)
-	writer.WriteHeader(a.Status)
+// toClientError converts the given error to the error returned to the client.
+func toClientError(context context.Context, apiContext *ApiContext, err error) *apiErrors.APIError {
+	a := apiErrors.UnwrapAPIError(err)
 	if context != nil && a.Status != http.StatusUnauthorized {
 		a.RequestID = middleware.GetReqID(context)
 	}
 	if apiContext.addCauseToInternalServerError && a.Status == http.StatusInternalServerError && a.OriginalError != nil {
 		a.Message = a.Message + ": " + a.OriginalError.Error()
 	}
+	return a
+}
+
+func sendError(a *apiErrors.APIError, context context.Context, writer http.ResponseWriter) {
+	writer.Header().Set(HeaderContentType, ContentTypeJson)
+	writer.WriteHeader(a.Status)
 	err := json.NewEncoder(writer).Encode(a)
 	if err != nil {
 		logger := GetLogger(context)
@@ -79,17 +87,21 @@ func sendError(a *apiErrors.APIError, context context.Context, apiContext *ApiCo
 	}
 }
 
+// GetLogger returns a logger that adds request ID, trace ID and the fields added by the handlers to all messages.
 func GetLogger(context context.Context) *log.Entry {
-	fields := log.Fields{}
+	fields := getRequestLogFields(context)
 	if id := middleware.GetReqID(context); id != "" {
-		fields["request"] = id
+		fields[logging.FieldRequestID] = id
+	}
+	if traceId := tracing.TraceID(context); traceId != "" {
+		fields[logging.FieldTraceID] = traceId
 	}
 	return log.WithFields(fields)
 }
 
 func DecodeJSONBody(writer http.ResponseWriter, request *http.Request, dst interface{}) error {
 	if value := request.Header.Get(HeaderContentType); value != ContentTypeJson {
-		return apiErrors.NewAPIError(http.StatusBadRequest, fmt.Sprintf("Content-Type header '%s' is not application/json", value))
+		return apiErrors.API_INVALID_CONTENT_TYPE.NewErrorF("Content-Type header '%s' is not application/json", value)
 	}
 
 	request.Body = http.MaxBytesReader(writer, request.Body, 1048576)
@@ -106,7 +118,7 @@ func DecodeJSONBody(writer http.ResponseWriter, request *http.Request, dst inter
 func verifyNoMoreJsonContent(dec *json.Decoder) error {
 	err := dec.Decode(&struct{}{})
 	if !errors.Is(err, io.EOF) {
-		return apiErrors.NewBadRequestErrorF("Request body must only contain a single JSON object")
+		return apiErrors.API_MALFORMED_REQUEST_BODY.NewErrorF("Request body must only contain a single JSON object")
 	}
 	return nil
 }
@@ -116,25 +128,34 @@ func convertError(err error) error {
 	var unmarshalTypeError *json.UnmarshalTypeError
 	switch {
 	case errors.As(err, &syntaxError):
-		return apiErrors.NewBadRequestErrorF("Request body contains badly-formed JSON (at position %d)", syntaxError.Offset)
+		return apiErrors.API_MALFORMED_REQUEST_BODY.NewErrorF("Request body contains badly-formed JSON (at position %d)", syntaxError.Offset)
 
 	case errors.Is(err, io.ErrUnexpectedEOF):
-		return apiErrors.NewBadRequestErrorF("Request body contains badly-formed JSON")
+		return apiErrors.API_MALFORMED_REQUEST_BODY.NewErrorF("Request body contains badly-formed JSON")
 
 	case errors.As(err, &unmarshalTypeError):
-		return apiErrors.NewBadRequestErrorF("Request body contains an invalid value for the %q field (at position %d)", unmarshalTypeError.Field, unmarshalTypeError.Offset)
+		message := fmt.Sprintf("Request body contains an invalid value for the %q field (at position %d)", unmarshalTypeError.Field, unmarshalTypeError.Offset)
+		return apiErrors.API_INVALID_REQUEST_FIELD.NewErrorWithDetails(message, []apiErrors.ErrorDetail{{Field: unmarshalTypeError.Field, ErrorCode: apiErrors.DETAIL_INVALID_VALUE, Message: message}})
 
 	case strings.HasPrefix(err.Error(), "json: unknown field "):
-		fieldName := strings.TrimPrefix(err.Error(), "json: unknown field ")
-		return apiErrors.NewBadRequestErrorF("Request body contains unknown field %q", fieldName)
+		fieldName := unquote(strings.TrimPrefix(err.Error(), "json: unknown field "))
+		message := fmt.Sprintf("Request body contains unknown field %q", fieldName)
+		return apiErrors.API_INVALID_REQUEST_FIELD.NewErrorWithDetails(message, []apiErrors.ErrorDetail{{Field: fieldName, ErrorCode: apiErrors.DETAIL_UNKNOWN_FIELD, Message: message}})
 
 	case errors.Is(err, io.EOF):
-		return apiErrors.NewBadRequestErrorF("Request body must not be empty")
+		return apiErrors.API_MALFORMED_REQUEST_BODY.NewErrorF("Request body must not be empty")
 
 	case err.Error() == "http: request body too large":
-		return apiErrors.NewBadRequestErrorF("Request body must not be larger than 1MB")
+		return apiErrors.API_REQUEST_BODY_TOO_LARGE.NewErrorF("Request body must not be larger than 1MB")
 
 	default:
 		return err
 	}
 }
+
+func unquote(value string) string {
+	if unquoted, err := strconv.Unquote(value); err == nil {
+		return unquoted
+	}
+	return value
+}
@@ -22,6 +22,17 @@ func startRestApi(suite *suite.Suite, addCauseToInternalServerError bool, contro
 	return &api
 }
 
+func startRestApiWithConfig(suite *suite.Suite, controller extensionController.TransactionController, config ServerConfig) *baseRestAPITest {
+	restAPI, err := CreateWithConfig(controller, config)
+	suite.Require().NoError(err)
+	api := baseRestAPITest{
+		suite:   suite,
+		restAPI: restAPI,
+		baseUrl: "http://" + config.ServerAddress}
+	api.restAPI.StartInBackground()
+	return &api
+}
+
 type baseRestAPITest struct {
 	suite   *suite.Suite
 	baseUrl string
@@ -1,6 +1,7 @@
 package restAPI
 
 import (
+	"context"
 	"database/sql"
 	"net/http"
 
@@ -18,23 +19,22 @@ func UninstallExtension(apiContext *ApiContext) *openapi.Delete {
 		Authentication: authentication,
 		Response: map[string]openapi.MethodResponse{
 			"204": {Description: "OK"},
+			"202": {Description: "Job started", Value: jobStartedExample("UninstallExtension")},
 		},
-		Path: newPathWithDbQueryParams().
+		Path: newPathWithAsyncQueryParam().
 			Add("installations").
 			AddParameter("extensionId", openapi.STRING, "The ID of the installed extension to uninstall").
 			AddParameter("extensionVersion", openapi.STRING, "The version of the installed extension to uninstall"),
-		HandlerFunc: adaptDbHandler(apiContext, handleUninstallExtension(apiContext)),
+		HandlerFunc: adaptDbOperation(apiContext, "UninstallExtension", handleUninstallExtension(apiContext)),
 	}
 }
 
-func handleUninstallExtension(apiContext *ApiContext) dbHandler {
-	return func(db *sql.DB, writer http.ResponseWriter, request *http.Request) error {
+func handleUninstallExtension(apiContext *ApiContext) operationHandler {
+	return func(writer http.ResponseWriter, request *http.Request) (dbOperation, error) {
 		extensionId := chi.URLParam(request, "extensionId")
 		version := chi.URLParam(request, "extensionVersion")
-		err := apiContext.Controller.UninstallExtension(request.Context(), db, extensionId, version)
-		if err != nil {
-			return err
-		}
-		return SendNoContent(request.Context(), writer)
+		return func(ctx context.Context, db *sql.DB) (any, error) {
+			return nil, apiContext.Controller.UninstallExtension(ctx, db, extensionId, version)
+		}, nil
 	}
 }
@@ -0,0 +1,72 @@
+package restAPI
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/Nightapes/go-rest/pkg/openapi"
+	"github.com/go-chi/chi/v5"
+
+	"github.com/exasol/extension-manager/pkg/apiErrors"
+	"github.com/exasol/extension-manager/pkg/extensionController"
+)
+
+func UpdateInstance(apiContext *ApiContext) *openapi.Put {
+	//nolint:exhaustruct // Default values for request are OK
+	return &openapi.Put{
+		Summary:        "Update an instance of an extension.",
+		Description:    "This changes the parameters of an existing instance of an extension, e.g. the credentials of a virtual schema. Secret parameters with the masked value '******' keep their current value.",
+		OperationID:    "UpdateInstance",
+		Tags:           []string{TagInstance},
+		Authentication: authentication,
+		RequestBody:    UpdateInstanceRequest{ParameterValues: []ParameterValue{{Name: "param1", Value: "value1"}}},
+		Response: map[string]openapi.MethodResponse{
+			"200": {Description: "OK", Value: Instance{Id: "s3-vs-1", Name: "SALES_S3_VS"}},
+			"202": {Description: "Job started", Value: jobStartedExample("UpdateInstance")},
+			"400": {
+				Description: "Invalid parameters specified",
+				Value:       invalidParametersErrorExample()},
+			"404": {
+				Description: "Extension or instance not found",
+				Value:       apiErrors.CTRL_INSTANCE_NOT_FOUND.NewErrorF("Instance not found")},
+		},
+		Path: newPathWithAsyncQueryParam().Add("installations").
+			AddParameter("extensionId", openapi.STRING, "The ID of the installed extension for which to update an instance").
+			AddParameter("extensionVersion", openapi.STRING, "The version of the installed extension for which to update an instance").
+			Add("instances").
+			AddParameter("instanceId", openapi.STRING, "The ID of the instance to update"),
+		HandlerFunc: adaptDbOperation(apiContext, "UpdateInstance", handleUpdateInstance(apiContext)),
+	}
+}
+
+func handleUpdateInstance(apiContext *ApiContext) operationHandler {
+	return func(writer http.ResponseWriter, request *http.Request) (dbOperation, error) {
+		//nolint:exhaustruct // Omitting values by intention for deserialization
+		requestBody := UpdateInstanceRequest{}
+		err := DecodeJSONBody(writer, request, &requestBody)
+		if err != nil {
+			return nil, err
+		}
+		var parameters []extensionController.ParameterValue
+		for _, p := range requestBody.ParameterValues {
+			parameters = append(parameters, extensionController.ParameterValue{Name: p.Name, Value: p.Value})
+		}
+		extensionId := chi.URLParam(request, "extensionId")
+		extensionVersion := chi.URLParam(request, "extensionVersion")
+		instanceId := chi.URLParam(request, "instanceId")
+		return func(ctx context.Context, db *sql.DB) (any, error) {
+			instance, err := apiContext.Controller.UpdateInstance(ctx, db, extensionId, extensionVersion, instanceId, parameters)
+			if err != nil {
+				return nil, err
+			}
+			GetLogger(ctx).Debugf("Updated instance %q", instance.Name)
+			return Instance{Id: instance.Id, Name: instance.Name}, nil
+		}, nil
+	}
+}
+
+// Request data for updating an instance of an extension.
+type UpdateInstanceRequest struct {
+	ParameterValues []ParameterValue `json:"parameterValues"` // The new parameters of the instance
+}
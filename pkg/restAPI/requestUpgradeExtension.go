@@ -1,13 +1,13 @@
 package restAPI
 
 import (
+	"context"
 	"database/sql"
 	"net/http"
 
 	"github.com/Nightapes/go-rest/pkg/openapi"
 	"github.com/exasol/extension-manager/pkg/apiErrors"
 	"github.com/go-chi/chi/v5"
-	"github.com/sirupsen/logrus"
 )
 
 /* [impl -> dsn~upgrade-extension~1]. */
@@ -23,33 +23,36 @@ func UpgradeExtension(apiContext *ApiContext) *openapi.Post {
 			"200": {
 				Description: "Extension upgraded successfully",
 				Value:       UpgradeExtensionResponse{PreviousVersion: "1.2.3", NewVersion: "1.3.0"}},
+			"202": {Description: "Job started", Value: jobStartedExample("UpgradeExtension")},
 			"412": {
 				Description: "Extension already installed in the latest version",
 				Value:       apiErrors.NewNotFoundErrorF("Latest version 1.3.0 is already installed")},
 			"404": {
 				Description: "Extension not found or not installed",
-				Value:       apiErrors.NewNotFoundErrorF("Extension not found")},
+				Value:       apiErrors.REG_EXTENSION_NOT_FOUND.NewErrorF("Extension not found")},
 		},
-		Path: newPathWithDbQueryParams().
+		Path: newPathWithAsyncQueryParam().
 			Add("installations").
 			AddParameter("extensionId", openapi.STRING, "The ID of the installed extension to upgrade").
 			Add("upgrade"),
-		HandlerFunc: adaptDbHandler(apiContext, handleUpgradeExtension(apiContext)),
+		HandlerFunc: adaptDbOperation(apiContext, "UpgradeExtension", handleUpgradeExtension(apiContext)),
 	}
 }
 
-func handleUpgradeExtension(apiContext *ApiContext) dbHandler {
-	return func(db *sql.DB, writer http.ResponseWriter, request *http.Request) error {
+func handleUpgradeExtension(apiContext *ApiContext) operationHandler {
+	return func(writer http.ResponseWriter, request *http.Request) (dbOperation, error) {
 		extensionId := chi.URLParam(request, "extensionId")
-		result, err := apiContext.Controller.UpgradeExtension(request.Context(), db, extensionId)
-		if err != nil {
-			logrus.Warnf("Upgrading of extension %q failed: %v", extensionId, err)
-			return err
-		}
-		logrus.Infof("Successfully upgraded extension %q from version %s to %s", extensionId, result.PreviousVersion, result.NewVersion)
-		return SendJSON(request.Context(), writer, UpgradeExtensionResponse{
-			PreviousVersion: result.PreviousVersion,
-			NewVersion:      result.NewVersion})
+		return func(ctx context.Context, db *sql.DB) (any, error) {
+			result, err := apiContext.Controller.UpgradeExtension(ctx, db, extensionId)
+			if err != nil {
+				GetLogger(ctx).Warnf("Upgrading of extension %q failed: %v", extensionId, err)
+				return nil, err
+			}
+			GetLogger(ctx).Infof("Successfully upgraded extension %q from version %s to %s", extensionId, result.PreviousVersion, result.NewVersion)
+			return UpgradeExtensionResponse{
+				PreviousVersion: result.PreviousVersion,
+				NewVersion:      result.NewVersion}, nil
+		}, nil
 	}
 }
 
@@ -0,0 +1,90 @@
+package restAPI
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/Nightapes/go-rest/pkg/openapi"
+	"github.com/go-chi/chi/v5"
+
+	"github.com/exasol/extension-manager/pkg/apiErrors"
+	"github.com/exasol/extension-manager/pkg/extensionController"
+	"github.com/exasol/extension-manager/pkg/parameterValidator"
+)
+
+func ValidateParameters(apiContext *ApiContext) *openapi.Post {
+	//nolint:exhaustruct // Default values for request are OK
+	return &openapi.Post{
+		Summary:        "Validate parameter values for an extension version.",
+		Description:    "This validates the given parameter values against the parameter definitions of an extension version without creating an instance. The response contains a result for each active parameter and for each unknown parameter.",
+		OperationID:    "ValidateParameters",
+		Tags:           []string{TagExtension},
+		Authentication: authentication,
+		RequestBody:    ValidateParametersRequest{ParameterValues: []ParameterValue{{Name: "s3Bucket", Value: ""}}},
+		Response: map[string]openapi.MethodResponse{
+			"200": {Description: "OK", Value: ValidateParametersResponse{Valid: false, Results: []ParameterValidationResult{
+				{ParameterId: "s3Bucket", Success: false, Message: "This is a required parameter."},
+				{ParameterId: "s3Region", Success: true, Message: ""}}}},
+			"404": {
+				Description: "Extension not found",
+				Value:       apiErrors.REG_EXTENSION_NOT_FOUND.NewErrorF("Extension not found")},
+		},
+		Path: newPathWithDbQueryParams().
+			Add("extensions").
+			AddParameter("extensionId", openapi.STRING, "ID of the extension").
+			AddParameter("extensionVersion", openapi.STRING, "Version of the extension").
+			Add("parameters").
+			Add("validate"),
+		HandlerFunc: adaptDbHandler(apiContext, "ValidateParameters", handleValidateParameters(apiContext)),
+	}
+}
+
+func handleValidateParameters(apiContext *ApiContext) dbHandler {
+	return func(db *sql.DB, writer http.ResponseWriter, request *http.Request) error {
+		//nolint:exhaustruct // Omitting values by intention for deserialization
+		requestBody := ValidateParametersRequest{}
+		err := DecodeJSONBody(writer, request, &requestBody)
+		if err != nil {
+			return err
+		}
+		parameters := make([]extensionController.ParameterValue, 0, len(requestBody.ParameterValues))
+		for _, p := range requestBody.ParameterValues {
+			parameters = append(parameters, extensionController.ParameterValue{Name: p.Name, Value: p.Value})
+		}
+		extensionId := chi.URLParam(request, "extensionId")
+		extensionVersion := chi.URLParam(request, "extensionVersion")
+		results, err := apiContext.Controller.ValidateParameters(request.Context(), db, extensionId, extensionVersion, parameters)
+		if err != nil {
+			return err
+		}
+		return SendJSON(request.Context(), writer, convertValidationResults(results))
+	}
+}
+
+func convertValidationResults(results []parameterValidator.ParameterValidationResult) ValidateParametersResponse {
+	valid := true
+	converted := make([]ParameterValidationResult, 0, len(results))
+	for _, r := range results {
+		valid = valid && r.Success
+		converted = append(converted, ParameterValidationResult{ParameterId: r.ParameterId, Success: r.Success, Message: r.Message})
+	}
+	return ValidateParametersResponse{Valid: valid, Results: converted}
+}
+
+// Request data for validating parameter values.
+type ValidateParametersRequest struct {
+	ParameterValues []ParameterValue `json:"parameterValues"` // The parameter values to validate
+}
+
+// ValidateParametersResponse contains the validation result for each parameter.
+type ValidateParametersResponse struct {
+	Valid   bool                        `json:"valid"`   // True if all parameters are valid
+	Results []ParameterValidationResult `json:"results"` // The results for each active parameter and each unknown parameter
+}
+
+// ParameterValidationResult is the validation result of a single parameter.
+type ParameterValidationResult struct {
+	ParameterId string `json:"id"`      // The ID of the parameter
+	Success     bool   `json:"success"` // True if the value is valid
+	Message     string `json:"message"` // The reason why validation failed, empty for valid parameters
+}
@@ -2,9 +2,10 @@ package restAPI
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
+	"net"
 	"net/http"
-	"strings"
 	"sync"
 	"time"
 
@@ -18,26 +19,85 @@ type RestAPI interface {
 	Serve()
 	// StartInBackground starts the server in the background and blocks until it is ready, i.e. reacts to HTTP requests.
 	StartInBackground()
-	// Stop stops the server.
+	// Stop stops the server gracefully. It refuses new modifying requests, stops accepting connections after [ServerConfig.ShutdownDelay]
+	// and waits until running requests and jobs are finished.
+	// If they don't finish within [ServerConfig.ShutdownTimeout], it aborts them, so that the database rolls back their transactions.
 	Stop()
 }
 
 // Create creates a new RestAPI.
+//
+// Deprecated: Use function [CreateWithConfig] which allows specifying additional configuration options.
 func Create(controller extensionController.TransactionController, serverAddress string, addCauseToInternalServerError bool) RestAPI {
+	api, err := CreateWithConfig(controller, ServerConfig{
+		ServerAddress:                 serverAddress,
+		AddCauseToInternalServerError: addCauseToInternalServerError,
+		TLS:                           nil,
+		Database:                      DatabaseConfig{ValidateServerCertificate: false, CABundleFile: "", CertificateFingerprint: "", Targets: nil, DefaultTarget: "", Pool: DatabasePoolConfig{MaxSize: 0, IdleTimeout: 0}},
+		Jobs:                          JobsConfig{Directory: "", Retention: 0},
+		RequestTimeout:                0,
+		ReadHeaderTimeout:             0,
+		ShutdownTimeout:               0,
+		ShutdownDelay:                 0,
+	})
+	if err != nil {
+		panic(fmt.Sprintf("failed to create rest API with default configuration: %v", err))
+	}
+	return api
+}
+
+// CreateWithConfig validates the given configuration and creates a new RestAPI.
+func CreateWithConfig(controller extensionController.TransactionController, config ServerConfig) (RestAPI, error) {
+	var tlsConfig *tls.Config
+	if config.TLS != nil {
+		var err error
+		tlsConfig, err = createServerTLSConfig(*config.TLS)
+		if err != nil {
+			return nil, fmt.Errorf("invalid server TLS configuration: %w", err)
+		}
+	}
+	database, err := newDatabaseConnector(config.Database)
+	if err != nil {
+		return nil, fmt.Errorf("invalid database configuration: %w", err)
+	}
+	if config.RequestTimeout < 0 || config.ReadHeaderTimeout < 0 || config.ShutdownTimeout < 0 || config.ShutdownDelay < 0 {
+		return nil, fmt.Errorf("invalid timeout configuration: request timeout %v, read header timeout %v, shutdown timeout %v and shutdown delay %v must not be negative",
+			config.RequestTimeout, config.ReadHeaderTimeout, config.ShutdownTimeout, config.ShutdownDelay)
+	}
+	jobs, err := newJobManager(config.Jobs)
+	if err != nil {
+		return nil, fmt.Errorf("invalid jobs configuration: %w", err)
+	}
 	return &restAPIImpl{
 		controller:                    controller,
-		serverAddress:                 serverAddress,
-		addCauseToInternalServerError: addCauseToInternalServerError,
+		serverAddress:                 config.ServerAddress,
+		addCauseToInternalServerError: config.AddCauseToInternalServerError,
+		tlsConfig:                     tlsConfig,
+		database:                      database,
+		jobs:                          jobs,
+		requestTimeout:                config.requestTimeout(),
+		readHeaderTimeout:             config.readHeaderTimeout(),
+		shutdownTimeout:               config.shutdownTimeout(),
+		shutdownDelay:                 config.ShutdownDelay,
+		drainer:                       nil,
 		server:                        nil,
 		stopped:                       nil,
 		stoppedMutex:                  nil,
-	}
+	}, nil
 }
 
 type restAPIImpl struct {
 	controller                    extensionController.TransactionController
 	addCauseToInternalServerError bool
 	serverAddress                 string
+	tlsConfig                     *tls.Config
+	database                      *databaseConnector
+	jobs                          *jobManager
+	requestTimeout                time.Duration
+	readHeaderTimeout             time.Duration
+	shutdownTimeout               time.Duration
+	shutdownDelay                 time.Duration
+	drainer                       *drainer
 	server                        *http.Server
 	stopped                       *bool
 	stoppedMutex                  *sync.Mutex
@@ -53,8 +113,9 @@ func (api *restAPIImpl) Serve() {
 		panic("server already running")
 	}
 	api.setStopped(false)
+	api.drainer = newDrainer()
 
-	handler, _, err := setupStandaloneAPI(api.controller, api.addCauseToInternalServerError)
+	handler, _, err := setupStandaloneAPI(api.controller, api.addCauseToInternalServerError, api.database, api.jobs, api.drainer, api.requestTimeout)
 	if err != nil {
 		log.Fatalf("failed to setup api: %v", err)
 	}
@@ -62,22 +123,41 @@ func (api *restAPIImpl) Serve() {
 	api.server = &http.Server{
 		Addr:              api.serverAddress,
 		Handler:           handler,
-		ReadHeaderTimeout: 3 * time.Second,
+		ReadHeaderTimeout: api.readHeaderTimeout,
+		TLSConfig:         api.tlsConfig,
+		// Requests are cancelled when the drain period expires during shutdown.
+		BaseContext: func(net.Listener) context.Context { return api.drainer.ctx },
 	}
+	api.jobs.startCleanup()
 	api.startServer()
 }
 
 // startServer starts the server on the given serverAddress. This method blocks until the server is stopped or fails.
 func (api *restAPIImpl) startServer() {
-	log.Printf("Starting server on %s...\n", api.serverAddress)
-	err := api.server.ListenAndServe() // blocking
+	var err error
+	if api.tlsConfig != nil {
+		log.Printf("Starting server with TLS on %s...\n", api.serverAddress)
+		err = api.server.ListenAndServeTLS("", "") // blocking, certificate is provided by the TLS config
+	} else {
+		log.Printf("Starting server on %s...\n", api.serverAddress)
+		err = api.server.ListenAndServe() // blocking
+	}
 	if err != nil && !api.isStopped() {
 		log.Fatalf("failed to start server: %v", err)
 	}
 }
 
+// serverStartPollInterval is the time to wait between attempts to reach the starting server.
+const serverStartPollInterval = 10 * time.Millisecond
+
+// waitUntilServerReplies waits until the liveness endpoint reports that the server is up.
 func (api *restAPIImpl) waitUntilServerReplies() {
-	request, err := http.NewRequestWithContext(context.Background(), "GET", "http://"+api.serverAddress, strings.NewReader(""))
+	if api.tlsConfig != nil {
+		// Clients may need a certificate, so only wait until the server accepts connections.
+		api.waitUntilServerAcceptsConnections()
+		return
+	}
+	request, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "http://"+api.localAddress()+healthLivePath, nil)
 	if err != nil {
 		log.Fatalf("failed to create request: %v", err)
 	}
@@ -86,16 +166,43 @@ func (api *restAPIImpl) waitUntilServerReplies() {
 		response, err := http.DefaultClient.Do(request)
 		if err == nil {
 			response.Body.Close()
-			if response.StatusCode == 404 {
+			if response.StatusCode == http.StatusOK {
 				return
 			}
+			err = fmt.Errorf("liveness endpoint returned status %q", response.Status)
 		}
 		if time.Now().After(timeout) {
-			log.Fatalf("Server did not reply within 1s, error: %v, response: %d %s", err, response.StatusCode, response.Status)
+			log.Fatalf("Server did not reply within 1s, error: %v", err)
 		}
+		time.Sleep(serverStartPollInterval)
 	}
 }
 
+func (api *restAPIImpl) waitUntilServerAcceptsConnections() {
+	timeout := time.Now().Add(1 * time.Second)
+	for {
+		conn, err := net.DialTimeout("tcp", api.localAddress(), 100*time.Millisecond)
+		if err == nil {
+			conn.Close()
+			return
+		}
+		if time.Now().After(timeout) {
+			log.Fatalf("Server did not accept connections within 1s, error: %v", err)
+		}
+		time.Sleep(serverStartPollInterval)
+	}
+}
+
+// localAddress returns the address for connecting to the server from the same host.
+// Addresses like ":8080" listen on all interfaces, so the server is reachable via localhost.
+func (api *restAPIImpl) localAddress() string {
+	host, port, err := net.SplitHostPort(api.serverAddress)
+	if err != nil || (host != "" && host != "0.0.0.0" && host != "::") {
+		return api.serverAddress
+	}
+	return net.JoinHostPort("localhost", port)
+}
+
 func (api *restAPIImpl) setStopped(stopped bool) {
 	if api.stopped == nil {
 		stopped := false
@@ -118,9 +225,52 @@ func (api *restAPIImpl) Stop() {
 		panic("cant stop server since it's not running")
 	}
 	api.setStopped(true)
-	err := api.server.Shutdown(context.Background())
+	api.drainer.startDraining()
+	log.Infof("Stopping server, waiting up to %v for running requests and jobs", api.shutdownTimeout)
+	ctx, cancel := context.WithTimeout(context.Background(), api.shutdownTimeout)
+	defer cancel()
+	api.waitForShutdownDelay(ctx)
+	err := api.server.Shutdown(ctx)
+	if err == nil {
+		err = api.jobs.waitForRunningJobs(ctx)
+	}
 	if err != nil {
-		panic(fmt.Sprintf("failed to shutdown rest API server. Cause: %v", err))
+		log.Warnf("Running requests and jobs did not finish within %v, aborting them: %v", api.shutdownTimeout, err)
+		api.abort()
 	}
+	api.jobs.endCleanup()
+	api.database.close()
 	api.server = nil
+	log.Info("Server stopped")
+}
+
+// waitForShutdownDelay keeps the server accepting connections while it reports that it is not ready,
+// so that load balancers stop sending requests before the server refuses connections.
+func (api *restAPIImpl) waitForShutdownDelay(ctx context.Context) {
+	if api.shutdownDelay == 0 {
+		return
+	}
+	log.Infof("Waiting %v before closing the listener", api.shutdownDelay)
+	timer := time.NewTimer(api.shutdownDelay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+}
+
+// abortTimeout is the time that aborted jobs have for rolling back and storing their status.
+const abortTimeout = 5 * time.Second
+
+// abort cancels all running requests and jobs and closes all connections.
+func (api *restAPIImpl) abort() {
+	api.drainer.abort()
+	if err := api.server.Close(); err != nil {
+		log.Warnf("Failed to close server connections: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), abortTimeout)
+	defer cancel()
+	if err := api.jobs.waitForRunningJobs(ctx); err != nil {
+		log.Warnf("Aborted jobs did not finish within %v: %v", abortTimeout, err)
+	}
 }
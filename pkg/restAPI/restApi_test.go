@@ -1,13 +1,18 @@
 package restAPI
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
 	"testing"
+	"time"
 
 	"github.com/exasol/extension-manager/pkg/apiErrors"
 	"github.com/exasol/extension-manager/pkg/extensionAPI"
 	"github.com/exasol/extension-manager/pkg/extensionController"
+	"github.com/exasol/extension-manager/pkg/extensionController/bfs"
 	"github.com/exasol/extension-manager/pkg/parameterValidator"
 	"github.com/kinbiko/jsonassert"
 	"github.com/stretchr/testify/mock"
@@ -26,10 +31,14 @@ const (
 	LIST_AVAILABLE_EXTENSIONS = BASE_URL + "/extensions"
 	LIST_INSTALLED_EXTENSIONS = BASE_URL + "/installations"
 	INSTALL_EXT_URL           = BASE_URL + "/extensions/ext-id/ext-version/install"
+	CHECK_INSTALL_URL         = BASE_URL + "/extensions/ext-id/ext-version/install/check"
 	GET_EXTENSION_DETAILS     = BASE_URL + "/extensions/ext-id/ext-version"
+	VALIDATE_PARAMETERS_URL   = BASE_URL + "/extensions/ext-id/ext-version/parameters/validate"
 	UNINSTALL_EXT_URL         = BASE_URL + "/installations/ext-id/ext-version"
 	UPGRADE_EXT_URL           = BASE_URL + "/installations/ext-id/upgrade"
 	DELETE_INSTANCE_URL       = BASE_URL + "/installations/ext-id/ext-version/instances/inst-id"
+	UPDATE_INSTANCE_URL       = BASE_URL + "/installations/ext-id/ext-version/instances/inst-id"
+	GET_INSTANCE_URL          = BASE_URL + "/installations/ext-id/ext-version/instances/inst-id"
 	LIST_INSTANCES_URL        = BASE_URL + "/installations/ext-id/ext-version/instances"
 	CREATE_INSTANCE_URL       = BASE_URL + "/installations/ext-id/ext-version/instances"
 	VALID_DB_ARGS             = "?dbHost=host&dbPort=8563"
@@ -87,11 +96,13 @@ func (suite *RestAPISuite) TestGetInstallationsFailed() {
 func (suite *RestAPISuite) TestGetAllExtensionsSuccessfully() {
 	suite.controller.On("GetAllExtensions", mock.Anything, mock.Anything).Return([]*extensionController.Extension{{
 		Id: "ext-id", Name: "my-extension", Category: "my-category", Description: "a cool extension",
-		InstallableVersions: []extensionAPI.JsExtensionVersion{{Name: "0.1.0", Latest: true, Deprecated: false}}}}, nil)
+		InstallableVersions: []extensionAPI.JsExtensionVersion{{Name: "0.1.0", Latest: true, Deprecated: false}},
+		BucketFsFiles:       []bfs.BfsFile{{Name: "ext.jar", Path: "/buckets/bfsdefault/jars/ext.jar", BasePath: "/buckets/bfsdefault/jars/", Size: 3}}}}, nil)
 	for _, test := range authSuccessTests {
 		suite.Run(test.authHeader, func() {
 			responseString := suite.restApi.makeRequestWithAuthHeader("GET", LIST_AVAILABLE_EXTENSIONS+VALID_DB_ARGS, test.authHeader, "", 200)
-			suite.assertJSON.Assertf(responseString, `{"extensions":[{"id": "ext-id","name":"my-extension","category":"my-category","description":"a cool extension","installableVersions":[{"name":"0.1.0", "latest":true, "deprecated":false}]}]}`)
+			suite.assertJSON.Assertf(responseString, `{"extensions":[{"id": "ext-id","name":"my-extension","category":"my-category","description":"a cool extension","installableVersions":[{"name":"0.1.0", "latest":true, "deprecated":false}],
+				"bucketFsFiles":[{"name":"ext.jar","path":"/buckets/bfsdefault/jars/ext.jar","basePath":"/buckets/bfsdefault/jars/"}]}]}`)
 		})
 	}
 }
@@ -102,6 +113,20 @@ func (suite *RestAPISuite) TestGetAllExtensionsFails() {
 	suite.isInternalServerError(responseString, errMock)
 }
 
+func (suite *RestAPISuite) TestGetAllExtensionsFailsWithErrorCode() {
+	suite.controller.On("GetAllExtensions", mock.Anything, mock.Anything).Return(nil, apiErrors.BFS_LISTING_FAILED.NewInternalErrorF("listing failed"))
+	responseString := suite.makeRequest("GET", LIST_AVAILABLE_EXTENSIONS+VALID_DB_ARGS, "", 500)
+	suite.assertJSON.Assertf(responseString, `{"code":500,"requestID":"<<PRESENCE>>","message":"Internal server error: listing failed",
+		"errorCode":"E-EM-BFS-3","mitigations":["Check that the database can access the configured BucketFS base paths."]}`)
+}
+
+func (suite *RestAPISuite) TestGetAllExtensionsFailsWithoutErrorCode() {
+	suite.controller.On("GetAllExtensions", mock.Anything, mock.Anything).Return(nil, errMock)
+	responseString := suite.makeRequest("GET", LIST_AVAILABLE_EXTENSIONS+VALID_DB_ARGS, "", 500)
+	suite.assertJSON.Assertf(responseString, `{"code":500,"requestID":"<<PRESENCE>>","message":"Internal server error: mock error",
+		"errorCode":"E-EM-API-1","mitigations":["Check the log of the extension manager for details."]}`)
+}
+
 // GetExtensionDetails
 
 func (suite *RestAPISuite) TestGetExtensionDetailsSuccessfully() {
@@ -123,6 +148,76 @@ func (suite *RestAPISuite) TestGetExtensionDetailsFails() {
 	suite.isInternalServerError(responseString, errMock)
 }
 
+func (suite *RestAPISuite) TestGetExtensionDetailsWithParameterValues() {
+	suite.controller.On("GetActiveParameterDefinitions", mock.Anything, mock.Anything, "ext-id", "ext-version", []extensionController.ParameterValue{{Name: "type", Value: "jdbc"}}).
+		Return([]parameterValidator.ParameterDefinition{{Id: "param1", Name: "My param", RawDefinition: map[string]interface{}{"id": "param1"}}}, nil)
+	parameterValues := url.QueryEscape(`[{"name":"type","value":"jdbc"}]`)
+	responseString := suite.makeRequest("GET", GET_EXTENSION_DETAILS+VALID_DB_ARGS+"&parameterValues="+parameterValues, "", 200)
+	suite.assertJSON.Assertf(responseString, `{"id": "ext-id", "version":"ext-version", "parameterDefinitions": [
+		{"id":"param1","name":"My param","definition":{"id": "param1"}}
+	]}`)
+}
+
+func (suite *RestAPISuite) TestGetExtensionDetailsWithEmptyParameterValues() {
+	suite.controller.On("GetActiveParameterDefinitions", mock.Anything, mock.Anything, "ext-id", "ext-version", []extensionController.ParameterValue{}).
+		Return([]parameterValidator.ParameterDefinition{}, nil)
+	responseString := suite.makeRequest("GET", GET_EXTENSION_DETAILS+VALID_DB_ARGS+"&parameterValues=[]", "", 200)
+	suite.assertJSON.Assertf(responseString, `{"id": "ext-id", "version":"ext-version", "parameterDefinitions": []}`)
+}
+
+func (suite *RestAPISuite) TestGetExtensionDetailsWithInvalidParameterValues() {
+	responseString := suite.makeRequest("GET", GET_EXTENSION_DETAILS+VALID_DB_ARGS+"&parameterValues=invalid", "", 400)
+	suite.Regexp(`{"code":400,"message":"invalid value for parameter parameterValues: invalid character 'i' looking for beginning of value"`, responseString)
+}
+
+func (suite *RestAPISuite) TestGetExtensionDetailsWithParameterValuesFails() {
+	suite.controller.On("GetActiveParameterDefinitions", mock.Anything, mock.Anything, "ext-id", "ext-version", mock.Anything).Return(nil, errMock)
+	responseString := suite.makeRequest("GET", GET_EXTENSION_DETAILS+VALID_DB_ARGS+"&parameterValues=[]", "", 500)
+	suite.isInternalServerError(responseString, errMock)
+}
+
+// Validate parameters
+
+func (suite *RestAPISuite) TestValidateParametersSuccessfully() {
+	suite.controller.On("ValidateParameters", mock.Anything, mock.Anything, "ext-id", "ext-version", []extensionController.ParameterValue{{Name: "p1", Value: "v1"}}).
+		Return([]parameterValidator.ParameterValidationResult{{ParameterId: "p1", ParameterName: "Param 1", Success: true, Failure: parameterValidator.FailureNone, Message: ""}}, nil)
+	for _, test := range authSuccessTests {
+		suite.Run(test.authHeader, func() {
+			responseString := suite.restApi.makeRequestWithAuthHeader("POST", VALIDATE_PARAMETERS_URL+VALID_DB_ARGS, test.authHeader,
+				`{"parameterValues": [{"name":"p1", "value":"v1"}]}`, 200)
+			suite.JSONEq(`{"valid":true,"results":[{"id":"p1","success":true,"message":""}]}`+"\n", responseString)
+		})
+	}
+}
+
+func (suite *RestAPISuite) TestValidateParametersWithInvalidValues() {
+	suite.controller.On("ValidateParameters", mock.Anything, mock.Anything, "ext-id", "ext-version", []extensionController.ParameterValue{{Name: "p2", Value: "v2"}}).
+		Return([]parameterValidator.ParameterValidationResult{
+			{ParameterId: "p1", ParameterName: "Param 1", Success: false, Failure: parameterValidator.FailureMissingValue, Message: "This is a required parameter."},
+			{ParameterId: "p2", ParameterName: "", Success: false, Failure: parameterValidator.FailureUnknownParameter, Message: "Unknown parameter."}}, nil)
+	responseString := suite.makeRequest("POST", VALIDATE_PARAMETERS_URL+VALID_DB_ARGS, `{"parameterValues": [{"name":"p2", "value":"v2"}]}`, 200)
+	suite.JSONEq(`{"valid":false,"results":[{"id":"p1","success":false,"message":"This is a required parameter."},
+		{"id":"p2","success":false,"message":"Unknown parameter."}]}`+"\n", responseString)
+}
+
+func (suite *RestAPISuite) TestValidateParametersWithoutResults() {
+	suite.controller.On("ValidateParameters", mock.Anything, mock.Anything, "ext-id", "ext-version", []extensionController.ParameterValue{}).
+		Return([]parameterValidator.ParameterValidationResult{}, nil)
+	responseString := suite.makeRequest("POST", VALIDATE_PARAMETERS_URL+VALID_DB_ARGS, `{"parameterValues": []}`, 200)
+	suite.JSONEq(`{"valid":true,"results":[]}`+"\n", responseString)
+}
+
+func (suite *RestAPISuite) TestValidateParametersFailedInvalidPayload() {
+	responseString := suite.makeRequest("POST", VALIDATE_PARAMETERS_URL+VALID_DB_ARGS, `invalid payload`, 400)
+	suite.Regexp("{\"code\":400,\"message\":\"Request body contains badly-formed JSON \\(at position 1\\)\".*", responseString)
+}
+
+func (suite *RestAPISuite) TestValidateParametersFailed() {
+	suite.controller.On("ValidateParameters", mock.Anything, mock.Anything, "ext-id", "ext-version", mock.Anything).Return(nil, errMock)
+	responseString := suite.makeRequest("POST", VALIDATE_PARAMETERS_URL+VALID_DB_ARGS, `{"parameterValues": []}`, 500)
+	suite.isInternalServerError(responseString, errMock)
+}
+
 // Install extension
 
 func (suite *RestAPISuite) TestInstallExtensionsSuccessfully() {
@@ -141,6 +236,39 @@ func (suite *RestAPISuite) TestInstallExtensionsFailed() {
 	suite.isInternalServerError(responseString, errMock)
 }
 
+// Check install
+
+func (suite *RestAPISuite) TestCheckInstallSuccessfully() {
+	suite.controller.On("CheckInstall", mock.Anything, mock.Anything, "ext-id", "ext-version").Return(&extensionController.InstallCheckResult{Success: false, Checks: []extensionController.InstallCheck{
+		{Type: extensionController.CHECK_BUCKETFS_FILE, Name: "file.jar", Success: true, Message: ""},
+		{Type: extensionController.CHECK_PRIVILEGE, Name: "CREATE SCRIPT", Success: false, Message: "missing privilege"}}}, nil)
+	for _, test := range authSuccessTests {
+		suite.Run(test.authHeader, func() {
+			responseString := suite.restApi.makeRequestWithAuthHeader("GET", CHECK_INSTALL_URL+VALID_DB_ARGS, test.authHeader, "", 200)
+			suite.JSONEq(`{"success":false,"checks":[{"type":"bucketfs-file","name":"file.jar","success":true,"message":""},
+				{"type":"privilege","name":"CREATE SCRIPT","success":false,"message":"missing privilege"}]}`+"\n", responseString)
+		})
+	}
+}
+
+func (suite *RestAPISuite) TestCheckInstallWithoutChecks() {
+	suite.controller.On("CheckInstall", mock.Anything, mock.Anything, "ext-id", "ext-version").Return(&extensionController.InstallCheckResult{Success: true, Checks: nil}, nil)
+	responseString := suite.makeRequest("GET", CHECK_INSTALL_URL+VALID_DB_ARGS, "", 200)
+	suite.JSONEq(`{"success":true,"checks":[]}`+"\n", responseString)
+}
+
+func (suite *RestAPISuite) TestCheckInstallFailed() {
+	suite.controller.On("CheckInstall", mock.Anything, mock.Anything, "ext-id", "ext-version").Return(nil, errMock)
+	responseString := suite.makeRequest("GET", CHECK_INSTALL_URL+VALID_DB_ARGS, "", 500)
+	suite.isInternalServerError(responseString, errMock)
+}
+
+func (suite *RestAPISuite) TestCheckInstallFailedNotFound() {
+	suite.controller.On("CheckInstall", mock.Anything, mock.Anything, "ext-id", "ext-version").Return(nil, apiErrors.REG_EXTENSION_NOT_FOUND.NewErrorF("mock"))
+	responseString := suite.makeRequest("GET", CHECK_INSTALL_URL+VALID_DB_ARGS, "", 404)
+	suite.Contains(responseString, `{"code":404,"message":"mock",`)
+}
+
 // Uninstall extension
 
 func (suite *RestAPISuite) TestUninstallExtensionsSuccessfully() {
@@ -198,6 +326,15 @@ func (suite *RestAPISuite) TestCreateInstanceSuccessfully() {
 	}
 }
 
+func (suite *RestAPISuite) TestCreateInstanceWithTypedValues() {
+	suite.controller.On("CreateInstance", mock.Anything, mock.Anything, "ext-id", "ext-version", []extensionController.ParameterValue{
+		{Name: "string", Value: "v1"}, {Name: "bool", Value: true}, {Name: "number", Value: 1.5}, {Name: "list", Value: []interface{}{"a", "b"}}}).
+		Return(&extensionAPI.JsExtInstance{Id: "instId", Name: "instName"}, nil)
+	responseString := suite.makeRequest("POST", CREATE_INSTANCE_URL+VALID_DB_ARGS,
+		`{"parameterValues": [{"name":"string", "value":"v1"}, {"name":"bool", "value":true}, {"name":"number", "value":1.5}, {"name":"list", "value":["a","b"]}]}`, 200)
+	suite.JSONEq(`{"instanceId":"instId","instanceName":"instName"}`+"\n", responseString)
+}
+
 func (suite *RestAPISuite) TestCreateInstanceFailedInvalidPayload() {
 	suite.controller.On("CreateInstance", mock.Anything, mock.Anything, "ext-id", "ext-version", []extensionController.ParameterValue{{Name: "p1", Value: "v1"}}).Return(&extensionAPI.JsExtInstance{Id: "instId", Name: "instName"}, nil)
 	responseString := suite.makeRequest("POST", CREATE_INSTANCE_URL+VALID_DB_ARGS,
@@ -205,6 +342,20 @@ func (suite *RestAPISuite) TestCreateInstanceFailedInvalidPayload() {
 	suite.Regexp("{\"code\":400,\"message\":\"Request body contains badly-formed JSON \\(at position 1\\)\".*", responseString)
 }
 
+func (suite *RestAPISuite) TestCreateInstanceFailedUnknownField() {
+	responseString := suite.makeRequest("POST", CREATE_INSTANCE_URL+VALID_DB_ARGS, `{"unknownField": "value"}`, 400)
+	suite.assertJSON.Assertf(responseString, `{"code":400,"requestID":"<<PRESENCE>>","message":"Request body contains unknown field \"unknownField\"",
+		"errorCode":"E-EM-API-8","mitigations":["Check the details of the error for the affected field."],
+		"details":[{"field":"unknownField","errorCode":"unknown-field","message":"Request body contains unknown field \"unknownField\""}]}`)
+}
+
+func (suite *RestAPISuite) TestCreateInstanceFailedInvalidParameters() {
+	suite.controller.On("CreateInstance", mock.Anything, mock.Anything, "ext-id", "ext-version", []extensionController.ParameterValue{{Name: "p1", Value: "v1"}}).
+		Return(nil, apiErrors.NewBadRequestErrorWithDetails("invalid parameters", []apiErrors.ErrorDetail{{Field: "p1", ErrorCode: apiErrors.DETAIL_INVALID_VALUE, Message: "invalid"}}))
+	responseString := suite.makeRequest("POST", CREATE_INSTANCE_URL+VALID_DB_ARGS, `{"parameterValues": [{"name":"p1", "value":"v1"}]}`, 400)
+	suite.assertJSON.Assertf(responseString, `{"code":400,"requestID":"<<PRESENCE>>","message":"invalid parameters","details":[{"field":"p1","errorCode":"invalid-value","message":"invalid"}]}`)
+}
+
 func (suite *RestAPISuite) TestCreateInstanceFailed() {
 	suite.controller.On("CreateInstance", mock.Anything, mock.Anything, "ext-id", "ext-version", []extensionController.ParameterValue{{Name: "p1", Value: "v1"}}).Return(nil, errMock)
 	responseString := suite.makeRequest("POST", CREATE_INSTANCE_URL+VALID_DB_ARGS,
@@ -260,6 +411,91 @@ func (suite *RestAPISuite) TestDeleteInstanceFailedApiError() {
 	suite.Contains(responseString, "{\"code\":432,\"message\":\"mock\",")
 }
 
+// Update instance
+
+func (suite *RestAPISuite) TestUpdateInstanceSuccessfully() {
+	suite.controller.On("UpdateInstance", mock.Anything, mock.Anything, "ext-id", "ext-version", "inst-id", []extensionController.ParameterValue{{Name: "p1", Value: "v1"}}).
+		Return(&extensionAPI.JsExtInstance{Id: "inst-id", Name: "instName"}, nil)
+	for _, test := range authSuccessTests {
+		suite.Run(test.authHeader, func() {
+			responseString := suite.restApi.makeRequestWithAuthHeader("PUT", UPDATE_INSTANCE_URL+VALID_DB_ARGS, test.authHeader,
+				`{"parameterValues": [{"name":"p1", "value":"v1"}]}`, 200)
+			suite.JSONEq(`{"id":"inst-id","name":"instName"}`+"\n", responseString)
+		})
+	}
+}
+
+func (suite *RestAPISuite) TestUpdateInstanceFailedInvalidPayload() {
+	responseString := suite.makeRequest("PUT", UPDATE_INSTANCE_URL+VALID_DB_ARGS, `invalid payload`, 400)
+	suite.Regexp("{\"code\":400,\"message\":\"Request body contains badly-formed JSON \\(at position 1\\)\".*", responseString)
+}
+
+func (suite *RestAPISuite) TestUpdateInstanceFailedGenericError() {
+	suite.controller.On("UpdateInstance", mock.Anything, mock.Anything, "ext-id", "ext-version", "inst-id", []extensionController.ParameterValue{{Name: "p1", Value: "v1"}}).Return(nil, errMock)
+	responseString := suite.makeRequest("PUT", UPDATE_INSTANCE_URL+VALID_DB_ARGS, `{"parameterValues": [{"name":"p1", "value":"v1"}]}`, 500)
+	suite.isInternalServerError(responseString, errMock)
+}
+
+func (suite *RestAPISuite) TestUpdateInstanceFailedApiError() {
+	suite.controller.On("UpdateInstance", mock.Anything, mock.Anything, "ext-id", "ext-version", "inst-id", mock.Anything).Return(nil, apiErrors.NewNotFoundErrorF("mock"))
+	responseString := suite.makeRequest("PUT", UPDATE_INSTANCE_URL+VALID_DB_ARGS, `{"parameterValues": []}`, 404)
+	suite.Contains(responseString, "{\"code\":404,\"message\":\"mock\",")
+}
+
+// Get instance
+
+func (suite *RestAPISuite) TestGetInstanceSuccessfully() {
+	suite.controller.On("GetInstance", mock.Anything, mock.Anything, "ext-id", "ext-version", "inst-id").
+		Return(&extensionAPI.JsExtInstanceDetails{Id: "inst-id", Name: "instName",
+			Parameters: []extensionAPI.ParameterValue{{Name: "p1", Value: "v1"}, {Name: "secret", Value: "******"}}}, nil)
+	for _, test := range authSuccessTests {
+		suite.Run(test.authHeader, func() {
+			responseString := suite.restApi.makeRequestWithAuthHeader("GET", GET_INSTANCE_URL+VALID_DB_ARGS, test.authHeader, "", 200)
+			suite.JSONEq(`{"id":"inst-id","name":"instName","parameterValues":[{"name":"p1","value":"v1"},{"name":"secret","value":"******"}],
+				"metadata":{"createdAt":"","createdBy":""},"dbObjects":[]}`+"\n", responseString)
+		})
+	}
+}
+
+func (suite *RestAPISuite) TestGetInstanceWithTypedValues() {
+	suite.controller.On("GetInstance", mock.Anything, mock.Anything, "ext-id", "ext-version", "inst-id").
+		Return(&extensionAPI.JsExtInstanceDetails{Id: "inst-id", Name: "instName",
+			Parameters: []extensionAPI.ParameterValue{{Name: "bool", Value: true}, {Name: "number", Value: int64(42)}, {Name: "list", Value: []interface{}{"a"}}}}, nil)
+	responseString := suite.makeRequest("GET", GET_INSTANCE_URL+VALID_DB_ARGS, "", 200)
+	suite.JSONEq(`{"id":"inst-id","name":"instName","parameterValues":[{"name":"bool","value":true},{"name":"number","value":42},{"name":"list","value":["a"]}],
+		"metadata":{"createdAt":"","createdBy":""},"dbObjects":[]}`+"\n", responseString)
+}
+
+func (suite *RestAPISuite) TestGetInstanceWithoutParameters() {
+	suite.controller.On("GetInstance", mock.Anything, mock.Anything, "ext-id", "ext-version", "inst-id").
+		Return(&extensionAPI.JsExtInstanceDetails{Id: "inst-id", Name: "instName", Parameters: nil}, nil)
+	responseString := suite.makeRequest("GET", GET_INSTANCE_URL+VALID_DB_ARGS, "", 200)
+	suite.JSONEq(`{"id":"inst-id","name":"instName","parameterValues":[],"metadata":{"createdAt":"","createdBy":""},"dbObjects":[]}`+"\n", responseString)
+}
+
+func (suite *RestAPISuite) TestGetInstanceWithMetadataAndDbObjects() {
+	suite.controller.On("GetInstance", mock.Anything, mock.Anything, "ext-id", "ext-version", "inst-id").
+		Return(&extensionAPI.JsExtInstanceDetails{Id: "inst-id", Name: "instName", Parameters: nil,
+			Metadata:  &extensionAPI.JsExtInstanceMetadata{CreatedAt: "2023-01-02 03:04:05.000000", CreatedBy: "SYS"},
+			DbObjects: []extensionAPI.JsExtDbObject{{Type: "VIRTUAL SCHEMA", Schema: "", Name: "VS"}, {Type: "SCRIPT", Schema: "EXA_EXTENSIONS", Name: "ADAPTER"}}}, nil)
+	responseString := suite.makeRequest("GET", GET_INSTANCE_URL+VALID_DB_ARGS, "", 200)
+	suite.JSONEq(`{"id":"inst-id","name":"instName","parameterValues":[],
+		"metadata":{"createdAt":"2023-01-02 03:04:05.000000","createdBy":"SYS"},
+		"dbObjects":[{"type":"VIRTUAL SCHEMA","schema":"","name":"VS"},{"type":"SCRIPT","schema":"EXA_EXTENSIONS","name":"ADAPTER"}]}`+"\n", responseString)
+}
+
+func (suite *RestAPISuite) TestGetInstanceFailedGenericError() {
+	suite.controller.On("GetInstance", mock.Anything, mock.Anything, "ext-id", "ext-version", "inst-id").Return(nil, errMock)
+	responseString := suite.makeRequest("GET", GET_INSTANCE_URL+VALID_DB_ARGS, "", 500)
+	suite.isInternalServerError(responseString, errMock)
+}
+
+func (suite *RestAPISuite) TestGetInstanceFailedNotFound() {
+	suite.controller.On("GetInstance", mock.Anything, mock.Anything, "ext-id", "ext-version", "inst-id").Return(nil, apiErrors.NewNotFoundErrorF("mock"))
+	responseString := suite.makeRequest("GET", GET_INSTANCE_URL+VALID_DB_ARGS, "", 404)
+	suite.Contains(responseString, "{\"code\":404,\"message\":\"mock\",")
+}
+
 func (suite *RestAPISuite) TestRequestsFailForMissingParameters() {
 	var tests = []struct {
 		method        string
@@ -279,6 +515,10 @@ func (suite *RestAPISuite) TestRequestsFailForMissingParameters() {
 		{"PUT", INSTALL_EXT_URL, "extensionId=ext-id&extensionVersion=ext-version&dbHost=host", "missing parameter dbPort"},
 		{"PUT", INSTALL_EXT_URL, "extensionId=ext-id&extensionVersion=ext-version&dbHost=host&dbPort=invalidPort", "invalid value 'invalidPort' for parameter dbPort"},
 
+		{"GET", CHECK_INSTALL_URL, "dbPort=8563", "missing parameter dbHost"},
+		{"GET", CHECK_INSTALL_URL, "dbHost=host", "missing parameter dbPort"},
+		{"GET", CHECK_INSTALL_URL, "dbHost=host&dbPort=invalidPort", "invalid value 'invalidPort' for parameter dbPort"},
+
 		{"POST", CREATE_INSTANCE_URL, "dbPort=8563", "missing parameter dbHost"},
 		{"POST", CREATE_INSTANCE_URL, "dbHost=host", "missing parameter dbPort"},
 		{"POST", CREATE_INSTANCE_URL, "dbHost=host&dbPort=invalidPort", "invalid value 'invalidPort' for parameter dbPort"},
@@ -291,6 +531,18 @@ func (suite *RestAPISuite) TestRequestsFailForMissingParameters() {
 		{"DELETE", DELETE_INSTANCE_URL, "dbHost=host", "missing parameter dbPort"},
 		{"DELETE", DELETE_INSTANCE_URL, "dbHost=host&dbPort=invalidPort", "invalid value 'invalidPort' for parameter dbPort"},
 
+		{"POST", VALIDATE_PARAMETERS_URL, "dbPort=8563", "missing parameter dbHost"},
+		{"POST", VALIDATE_PARAMETERS_URL, "dbHost=host", "missing parameter dbPort"},
+		{"POST", VALIDATE_PARAMETERS_URL, "dbHost=host&dbPort=invalidPort", "invalid value 'invalidPort' for parameter dbPort"},
+
+		{"PUT", UPDATE_INSTANCE_URL, "dbPort=8563", "missing parameter dbHost"},
+		{"PUT", UPDATE_INSTANCE_URL, "dbHost=host", "missing parameter dbPort"},
+		{"PUT", UPDATE_INSTANCE_URL, "dbHost=host&dbPort=invalidPort", "invalid value 'invalidPort' for parameter dbPort"},
+
+		{"GET", GET_INSTANCE_URL, "dbPort=8563", "missing parameter dbHost"},
+		{"GET", GET_INSTANCE_URL, "dbHost=host", "missing parameter dbPort"},
+		{"GET", GET_INSTANCE_URL, "dbHost=host&dbPort=invalidPort", "invalid value 'invalidPort' for parameter dbPort"},
+
 		{"DELETE", UNINSTALL_EXT_URL, "dbPort=8563", "missing parameter dbHost"},
 		{"DELETE", UNINSTALL_EXT_URL, "dbHost=host", "missing parameter dbPort"},
 		{"DELETE", UNINSTALL_EXT_URL, "dbHost=host&dbPort=invalidPort", "invalid value 'invalidPort' for parameter dbPort"},
@@ -309,6 +561,293 @@ func (suite *RestAPISuite) TestRequestsFailForMissingParameters() {
 	}
 }
 
+func (suite *RestAPISuite) TestRequestFailsForMultipleMissingParameters() {
+	responseString := suite.makeRequest("GET", LIST_AVAILABLE_EXTENSIONS+"?dbPort=invalidPort", "", 400)
+	suite.assertJSON.Assertf(responseString, `{"code":400,"requestID":"<<PRESENCE>>","message":"missing parameter dbHost, invalid value 'invalidPort' for parameter dbPort",
+		"errorCode":"E-EM-API-4","mitigations":["Specify host name and port of the database with query parameters dbHost and dbPort."],"details":[
+		{"field":"dbHost","errorCode":"missing-value","message":"missing parameter dbHost"},
+		{"field":"dbPort","errorCode":"invalid-value","message":"invalid value 'invalidPort' for parameter dbPort"}]}`)
+}
+
+func (suite *RestAPISuite) TestRequestsWithDatabaseTargets() {
+	suite.controller.On("GetInstalledExtensions", mock.Anything, mock.Anything).Return([]*extensionAPI.JsExtInstallation{{ID: EXTENSION_ID, Name: "test", Version: "0.1.0"}}, nil)
+	api := startRestApiWithConfig(&suite.Suite, suite.controller, ServerConfig{ServerAddress: "localhost:8084", AddCauseToInternalServerError: false, TLS: nil,
+		Database: DatabaseConfig{ValidateServerCertificate: false, CABundleFile: "", CertificateFingerprint: "", DefaultTarget: "prod", Targets: []DatabaseTarget{
+			{Name: "prod", Host: "exasol.example.com", Port: 8563},
+			{Name: "test", Host: "localhost", Port: 8563}}, Pool: DatabasePoolConfig{MaxSize: 0, IdleTimeout: 0}},
+		Jobs: JobsConfig{Directory: "", Retention: 0}, RequestTimeout: 0, ReadHeaderTimeout: 0, ShutdownTimeout: 0, ShutdownDelay: 0})
+	defer api.restAPI.Stop()
+	var tests = []struct {
+		parameters        string
+		expectedStatus    int
+		expectedMessage   string
+		expectedErrorCode string
+	}{
+		{"", 200, "", ""},
+		{"?dbTarget=prod", 200, "", ""},
+		{"?dbTarget=test", 200, "", ""},
+		{"?dbTarget=unknown", 400, `unknown database target \"unknown\", available targets: prod, test`, "E-EM-API-12"},
+		{"?dbHost=host&dbPort=8563", 400, "query parameters dbHost and dbPort are not allowed because database targets are configured", "E-EM-API-11"},
+		{"?dbHost=host", 400, "query parameters dbHost and dbPort are not allowed because database targets are configured", "E-EM-API-11"},
+		{"?dbTarget=prod&dbPort=8563", 400, "query parameters dbHost and dbPort are not allowed because database targets are configured", "E-EM-API-11"},
+	}
+	for _, test := range tests {
+		suite.Run(test.parameters, func() {
+			responseString := api.makeRequestWithAuthHeader("GET", LIST_INSTALLED_EXTENSIONS+test.parameters, createBasicAuthHeader("user", "password"), "", test.expectedStatus)
+			if test.expectedErrorCode != "" {
+				suite.Contains(responseString, `"message":"`+test.expectedMessage+`"`)
+				suite.Contains(responseString, `"errorCode":"`+test.expectedErrorCode+`"`)
+			}
+		})
+	}
+}
+
+func (suite *RestAPISuite) TestRequestWithDatabaseTargetFailsWithoutTargets() {
+	responseString := suite.makeRequest("GET", LIST_INSTALLED_EXTENSIONS+VALID_DB_ARGS+"&dbTarget=prod", "", 400)
+	suite.assertJSON.Assertf(responseString, `{"code":400,"requestID":"<<PRESENCE>>",
+		"message":"query parameter dbTarget is not supported because no database targets are configured, use dbHost and dbPort",
+		"errorCode":"E-EM-API-12","mitigations":["Select one of the configured database targets with query parameter dbTarget. The error message lists the available targets."]}`)
+}
+
+// Metrics
+
+func (suite *RestAPISuite) TestMetricsContainRequests() {
+	suite.controller.On("GetAllExtensions", mock.Anything, mock.Anything).Return([]*extensionController.Extension{}, nil)
+	suite.makeRequest("GET", LIST_AVAILABLE_EXTENSIONS+VALID_DB_ARGS, "", 200)
+	suite.makeRequest("GET", BASE_URL+"/unknown", "", 404)
+	responseString := suite.restApi.makeRequestWithAuthHeader("GET", "/metrics", "", "", 200)
+	suite.Contains(responseString, `extension_manager_http_requests_total{method="GET",route="/api/v1/extensionmanager/extensions",status="200"}`)
+	suite.Contains(responseString, `extension_manager_http_request_duration_seconds_count{method="GET",route="/api/v1/extensionmanager/extensions",status="200"}`)
+	suite.Contains(responseString, `extension_manager_http_requests_total{method="GET",route="unmatched",status="404"}`)
+	suite.NotContains(responseString, "extension_manager_database_pool_entries")
+}
+
+func (suite *RestAPISuite) TestMetricsContainDatabasePool() {
+	suite.controller.On("GetInstalledExtensions", mock.Anything, mock.Anything).Return([]*extensionAPI.JsExtInstallation{}, nil)
+	api := startRestApiWithConfig(&suite.Suite, suite.controller, ServerConfig{ServerAddress: "localhost:8085", AddCauseToInternalServerError: false, TLS: nil,
+		Database: DatabaseConfig{ValidateServerCertificate: false, CABundleFile: "", CertificateFingerprint: "", DefaultTarget: "", Targets: nil,
+			Pool: DatabasePoolConfig{MaxSize: 1, IdleTimeout: 0}},
+		Jobs: JobsConfig{Directory: "", Retention: 0}, RequestTimeout: 0, ReadHeaderTimeout: 0, ShutdownTimeout: 0, ShutdownDelay: 0})
+	defer api.restAPI.Stop()
+	api.makeRequestWithAuthHeader("GET", LIST_INSTALLED_EXTENSIONS+VALID_DB_ARGS, createBasicAuthHeader("user", "password"), "", 200)
+	responseString := api.makeRequestWithAuthHeader("GET", "/metrics", "", "", 200)
+	suite.Contains(responseString, "extension_manager_database_pool_entries 1\n")
+	suite.Contains(responseString, "extension_manager_database_pool_misses_total 1\n")
+}
+
+// Health
+
+func (suite *RestAPISuite) TestHealthLive() {
+	responseString := suite.restApi.makeRequestWithAuthHeader("GET", "/health/live", "", "", 200)
+	suite.assertJSON.Assertf(responseString, `{"status":"UP","components":[{"name":"server","status":"UP"}]}`)
+}
+
+func (suite *RestAPISuite) TestHealthReady() {
+	suite.controller.On("CheckReadiness", mock.Anything).Return([]extensionController.ComponentStatus{{Name: "registry", Ready: true, Error: ""}})
+	responseString := suite.restApi.makeRequestWithAuthHeader("GET", "/health/ready", "", "", 200)
+	suite.assertJSON.Assertf(responseString, `{"status":"UP","components":[{"name":"registry","status":"UP"}]}`)
+}
+
+func (suite *RestAPISuite) TestHealthNotReady() {
+	suite.controller.On("CheckReadiness", mock.Anything).Return([]extensionController.ComponentStatus{{Name: "registry", Ready: false, Error: "mock error"}})
+	responseString := suite.restApi.makeRequestWithAuthHeader("GET", "/health/ready", "", "", 503)
+	suite.assertJSON.Assertf(responseString, `{"status":"DOWN","components":[{"name":"registry","status":"DOWN","error":"mock error"}]}`)
+}
+
+// Asynchronous jobs
+
+func (suite *RestAPISuite) TestInstallExtensionAsynchronously() {
+	suite.controller.On("InstallExtension", mock.Anything, mock.Anything, "ext-id", "ext-version").Return(nil)
+	responseString := suite.makeRequest("PUT", INSTALL_EXT_URL+VALID_DB_ARGS+"&async=true", `{}`, 202)
+	suite.assertJSON.Assertf(responseString, `{"id":"<<PRESENCE>>","operation":"InstallExtension","status":"running","createdAt":"<<PRESENCE>>",
+		"logs":[{"time":"<<PRESENCE>>","level":"info","message":"Started operation InstallExtension"}]}`)
+	finishedJob := suite.waitForJob(suite.getJobId(responseString))
+	suite.Equal(JobSucceeded, finishedJob.Status)
+	suite.NotNil(finishedJob.FinishedAt)
+	suite.Nil(finishedJob.Result)
+	suite.Nil(finishedJob.Error)
+	suite.Len(finishedJob.Logs, 2)
+}
+
+func (suite *RestAPISuite) TestCreateInstanceAsynchronously() {
+	suite.controller.On("CreateInstance", mock.Anything, mock.Anything, "ext-id", "ext-version", []extensionController.ParameterValue{{Name: "p1", Value: "v1"}}).
+		Return(&extensionAPI.JsExtInstance{Id: "instId", Name: "instName"}, nil)
+	responseString := suite.makeRequest("POST", CREATE_INSTANCE_URL+VALID_DB_ARGS+"&async=true", `{"parameterValues": [{"name":"p1", "value":"v1"}]}`, 202)
+	finishedJob := suite.waitForJob(suite.getJobId(responseString))
+	suite.Equal(JobSucceeded, finishedJob.Status)
+	suite.JSONEq(`{"instanceId":"instId","instanceName":"instName"}`, string(finishedJob.Result))
+}
+
+func (suite *RestAPISuite) TestAsynchronousOperationFails() {
+	suite.controller.On("UninstallExtension", mock.Anything, mock.Anything, "ext-id", "ext-version").
+		Return(apiErrors.CTRL_INSTANCES_EXIST.NewErrorF("instances exist"))
+	responseString := suite.makeRequest("DELETE", UNINSTALL_EXT_URL+VALID_DB_ARGS+"&async=true", "", 202)
+	finishedJob := suite.waitForJob(suite.getJobId(responseString))
+	suite.Equal(JobFailed, finishedJob.Status)
+	suite.Equal("E-EM-CTRL-1", finishedJob.Error.ErrorCode)
+	suite.Equal("instances exist", finishedJob.Error.Message)
+	suite.Contains(finishedJob.Logs[1].Message, "instances exist")
+}
+
+func (suite *RestAPISuite) TestAsynchronousOperationWithInvalidRequestFailsImmediately() {
+	responseString := suite.makeRequest("POST", CREATE_INSTANCE_URL+VALID_DB_ARGS+"&async=true", `invalid payload`, 400)
+	suite.Contains(responseString, "Request body contains badly-formed JSON")
+}
+
+func (suite *RestAPISuite) TestSynchronousOperationWithAsyncFalse() {
+	suite.controller.On("InstallExtension", mock.Anything, mock.Anything, "ext-id", "ext-version").Return(nil)
+	responseString := suite.makeRequest("PUT", INSTALL_EXT_URL+VALID_DB_ARGS+"&async=false", `{}`, 204)
+	suite.Empty(responseString)
+}
+
+func (suite *RestAPISuite) TestInvalidAsyncParameter() {
+	responseString := suite.makeRequest("PUT", INSTALL_EXT_URL+VALID_DB_ARGS+"&async=maybe", `{}`, 400)
+	suite.assertJSON.Assertf(responseString, `{"code":400,"requestID":"<<PRESENCE>>",
+		"message":"invalid value \"maybe\" for query parameter async, expected true or false",
+		"errorCode":"E-EM-API-14","mitigations":["<<PRESENCE>>"]}`)
+}
+
+func (suite *RestAPISuite) TestGetJobOfOtherUserFails() {
+	suite.controller.On("InstallExtension", mock.Anything, mock.Anything, "ext-id", "ext-version").Return(nil)
+	responseString := suite.makeRequest("PUT", INSTALL_EXT_URL+VALID_DB_ARGS+"&async=true", `{}`, 202)
+	jobId := suite.getJobId(responseString)
+	responseString = suite.restApi.makeRequestWithAuthHeader("GET", getJobPath(jobId), createBasicAuthHeader("other", "password"), "", 404)
+	suite.Contains(responseString, `"errorCode":"E-EM-API-13"`)
+	suite.waitForJob(jobId)
+}
+
+func (suite *RestAPISuite) TestGetJobWithWrongPasswordFails() {
+	suite.controller.On("InstallExtension", mock.Anything, mock.Anything, "ext-id", "ext-version").Return(nil)
+	responseString := suite.makeRequest("PUT", INSTALL_EXT_URL+VALID_DB_ARGS+"&async=true", `{}`, 202)
+	jobId := suite.getJobId(responseString)
+	responseString = suite.restApi.makeRequestWithAuthHeader("GET", getJobPath(jobId), createBasicAuthHeader("user", "wrong password"), "", 404)
+	suite.Contains(responseString, `"errorCode":"E-EM-API-13"`)
+	suite.waitForJob(jobId)
+}
+
+func (suite *RestAPISuite) TestGetUnknownJobFails() {
+	responseString := suite.makeRequest("GET", getJobPath("00000000000000000000000000000000"), "", 404)
+	suite.Contains(responseString, `"message":"job \"00000000000000000000000000000000\" not found"`)
+	suite.Contains(responseString, `"errorCode":"E-EM-API-13"`)
+}
+
+func (suite *RestAPISuite) TestGetJobWithoutAuthenticationFails() {
+	responseString := suite.restApi.makeRequestWithAuthHeader("GET", getJobPath("00000000000000000000000000000000"), "", "", 401)
+	suite.Contains(responseString, "missing Authorization header")
+}
+
+// Graceful shutdown
+
+func (suite *RestAPISuite) TestModifyingRequestRefusedWhileShuttingDown() {
+	suite.restApi.restAPI.(*restAPIImpl).drainer.startDraining()
+	responseString := suite.makeRequest("PUT", INSTALL_EXT_URL+VALID_DB_ARGS, `{}`, 503)
+	suite.assertJSON.Assertf(responseString, `{"code":503,"requestID":"<<PRESENCE>>",
+		"message":"server is shutting down, operation InstallExtension was not started",
+		"errorCode":"E-EM-API-15","mitigations":["<<PRESENCE>>"]}`)
+}
+
+func (suite *RestAPISuite) TestReadingRequestAllowedWhileShuttingDown() {
+	suite.controller.On("GetInstalledExtensions", mock.Anything, mock.Anything).Return([]*extensionAPI.JsExtInstallation{}, nil)
+	suite.restApi.restAPI.(*restAPIImpl).drainer.startDraining()
+	suite.makeRequest("GET", LIST_INSTALLED_EXTENSIONS+VALID_DB_ARGS, "", 200)
+}
+
+func (suite *RestAPISuite) TestStopWaitsForRunningJob() {
+	suite.controller.On("InstallExtension", mock.Anything, mock.Anything, "ext-id", "ext-version").
+		Run(func(mock.Arguments) { time.Sleep(200 * time.Millisecond) }).Return(nil)
+	api := suite.startRestApiWithShutdownTimeout(5*time.Second, 0)
+	responseString := api.makeRequestWithAuthHeader("PUT", INSTALL_EXT_URL+VALID_DB_ARGS+"&async=true", createBasicAuthHeader("user", "password"), `{}`, 202)
+	api.restAPI.Stop()
+	suite.Equal(JobSucceeded, suite.loadJob(api, suite.getJobId(responseString)).Status)
+}
+
+func (suite *RestAPISuite) TestStopAbortsJobAfterShutdownTimeout() {
+	suite.controller.On("InstallExtension", mock.Anything, mock.Anything, "ext-id", "ext-version").
+		Run(func(args mock.Arguments) { <-args.Get(0).(context.Context).Done() }).Return(context.Canceled)
+	api := suite.startRestApiWithShutdownTimeout(50*time.Millisecond, 0)
+	responseString := api.makeRequestWithAuthHeader("PUT", INSTALL_EXT_URL+VALID_DB_ARGS+"&async=true", createBasicAuthHeader("user", "password"), `{}`, 202)
+	api.restAPI.Stop()
+	abortedJob := suite.loadJob(api, suite.getJobId(responseString))
+	suite.Equal(JobInterrupted, abortedJob.Status)
+	suite.Contains(abortedJob.Logs[1].Message, "Extension manager aborted the operation after")
+}
+
+func (suite *RestAPISuite) TestStopAcceptsRequestsDuringShutdownDelay() {
+	api := suite.startRestApiWithShutdownTimeout(5*time.Second, 500*time.Millisecond)
+	stopped := make(chan struct{})
+	go func() {
+		api.restAPI.Stop()
+		close(stopped)
+	}()
+	suite.Eventually(func() bool { return api.restAPI.(*restAPIImpl).drainer.isDraining() }, time.Second, 10*time.Millisecond)
+	responseString := api.makeRequestWithAuthHeader("GET", "/health/ready", "", "", 503)
+	suite.assertJSON.Assertf(responseString, `{"status":"DOWN","components":[{"name":"server","status":"DOWN","error":"server is shutting down"}]}`)
+	responseString = api.makeRequestWithAuthHeader("PUT", INSTALL_EXT_URL+VALID_DB_ARGS, createBasicAuthHeader("user", "password"), `{}`, 503)
+	suite.Contains(responseString, `"errorCode":"E-EM-API-15"`)
+	<-stopped
+}
+
+func (suite *RestAPISuite) TestLocalAddress() {
+	var tests = []struct {
+		serverAddress string
+		expected      string
+	}{
+		{":8080", "localhost:8080"},
+		{"0.0.0.0:8080", "localhost:8080"},
+		{"[::]:8080", "localhost:8080"},
+		{"127.0.0.1:8080", "127.0.0.1:8080"},
+		{"example.com:8080", "example.com:8080"},
+		{"invalid", "invalid"},
+	}
+	for _, test := range tests {
+		suite.Run(test.serverAddress, func() {
+			//nolint:exhaustruct // Only the address is required
+			api := &restAPIImpl{serverAddress: test.serverAddress}
+			suite.Equal(test.expected, api.localAddress())
+		})
+	}
+}
+
+func (suite *RestAPISuite) startRestApiWithShutdownTimeout(shutdownTimeout, shutdownDelay time.Duration) *baseRestAPITest {
+	return startRestApiWithConfig(&suite.Suite, suite.controller, ServerConfig{ServerAddress: "localhost:8086", AddCauseToInternalServerError: false, TLS: nil,
+		Database: DatabaseConfig{ValidateServerCertificate: false, CABundleFile: "", CertificateFingerprint: "", DefaultTarget: "", Targets: nil, Pool: DatabasePoolConfig{MaxSize: 0, IdleTimeout: 0}},
+		Jobs:     JobsConfig{Directory: "", Retention: 0}, RequestTimeout: 0, ReadHeaderTimeout: 0, ShutdownTimeout: shutdownTimeout, ShutdownDelay: shutdownDelay})
+}
+
+// loadJob reads a job directly from the job manager, e.g. after the server was stopped.
+func (suite *RestAPISuite) loadJob(api *baseRestAPITest, jobId string) *job {
+	storedJob, err := api.restAPI.(*restAPIImpl).jobs.load(jobId)
+	suite.Require().NoError(err)
+	return storedJob
+}
+
+func (suite *RestAPISuite) getJobId(jobResponse string) string {
+	suite.T().Helper()
+	//nolint:exhaustruct // Omitting values by intention for deserialization
+	response := JobResponse{}
+	suite.Require().NoError(json.Unmarshal([]byte(jobResponse), &response))
+	suite.Require().NotEmpty(response.Id)
+	return response.Id
+}
+
+// waitForJob polls the job until it is not running any more.
+func (suite *RestAPISuite) waitForJob(jobId string) JobResponse {
+	suite.T().Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		//nolint:exhaustruct // Omitting values by intention for deserialization
+		response := JobResponse{}
+		suite.Require().NoError(json.Unmarshal([]byte(suite.makeRequest("GET", getJobPath(jobId), "", 200)), &response))
+		if response.Status != JobRunning {
+			return response
+		}
+		if time.Now().After(deadline) {
+			suite.FailNowf("Job did not finish", "job %s is still running", jobId)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
 func (suite *RestAPISuite) makeRequest(method, path, body string, expectedStatus int) string {
 	suite.T().Helper()
 	authHeader := createBasicAuthHeader("user", "password")
@@ -0,0 +1,132 @@
+package restAPI
+
+import "time"
+
+// ServerConfig contains the configuration of the standalone REST server.
+type ServerConfig struct {
+	// Address of the server, e.g. ":8080" (all network interfaces) or "localhost:8080" (only local interface).
+	ServerAddress string
+	// Add the cause of internal server errors (status 500) to the error message. Don't use this in production!
+	AddCauseToInternalServerError bool
+	// Optional TLS configuration of the server. If this is nil, the server uses plain HTTP.
+	TLS *ServerTLSConfig
+	// Configuration for connecting to the Exasol database.
+	Database DatabaseConfig
+	// Configuration of asynchronous jobs started with query parameter async=true.
+	Jobs JobsConfig
+	// Maximum duration for processing a synchronous request. The default value 0 uses [DefaultRequestTimeout].
+	RequestTimeout time.Duration
+	// Maximum duration for reading the request headers. The default value 0 uses [DefaultReadHeaderTimeout].
+	ReadHeaderTimeout time.Duration
+	// Drain period when stopping the server. During this period EM refuses new modifying requests and waits for running requests and jobs.
+	// Afterwards it cancels them, so that the database rolls back their transactions. The default value 0 uses [DefaultShutdownTimeout].
+	ShutdownTimeout time.Duration
+	// Delay at the beginning of the drain period before the server stops accepting connections. During the delay EM already reports that it
+	// is not ready and refuses new modifying requests, so that load balancers stop sending requests before connections are refused.
+	// The delay is part of [ServerConfig.ShutdownTimeout]. The default value 0 stops accepting connections immediately.
+	ShutdownDelay time.Duration
+}
+
+// Default timeouts used when [ServerConfig.RequestTimeout], [ServerConfig.ReadHeaderTimeout] or [ServerConfig.ShutdownTimeout] are not set.
+const (
+	DefaultRequestTimeout    = 60 * time.Second
+	DefaultReadHeaderTimeout = 3 * time.Second
+	// DefaultShutdownTimeout is shorter than the default termination grace period of Kubernetes (30s), so that EM can abort operations before it is killed.
+	DefaultShutdownTimeout = 25 * time.Second
+)
+
+func (c ServerConfig) requestTimeout() time.Duration {
+	if c.RequestTimeout == 0 {
+		return DefaultRequestTimeout
+	}
+	return c.RequestTimeout
+}
+
+func (c ServerConfig) readHeaderTimeout() time.Duration {
+	if c.ReadHeaderTimeout == 0 {
+		return DefaultReadHeaderTimeout
+	}
+	return c.ReadHeaderTimeout
+}
+
+func (c ServerConfig) shutdownTimeout() time.Duration {
+	if c.ShutdownTimeout == 0 {
+		return DefaultShutdownTimeout
+	}
+	return c.ShutdownTimeout
+}
+
+// ServerTLSConfig configures TLS for the REST server.
+type ServerTLSConfig struct {
+	// Path of the PEM file containing the server certificate. EM reloads the certificate when the file changes.
+	CertFile string
+	// Path of the PEM file containing the private key of the server certificate.
+	KeyFile string
+	// Optional path of a PEM file containing CA certificates. If this is set, clients must present a certificate signed by one of these CAs.
+	ClientCAFile string
+}
+
+// DatabaseConfig contains the configuration for connecting to the Exasol database.
+//
+// The TLS options apply to all databases, EM does not support configuring them per [DatabaseTarget].
+// To validate the certificates of multiple targets, add the CA certificates of all targets to [DatabaseConfig.CABundleFile].
+// [DatabaseConfig.CertificateFingerprint] pins a single certificate, so only use it with multiple targets if they share the same certificate.
+type DatabaseConfig struct {
+	// Validate the TLS certificate of the database. If this is false, EM accepts any certificate unless [DatabaseConfig.CertificateFingerprint] is set.
+	// The default value false keeps the behavior of previous versions, set this to true for production.
+	ValidateServerCertificate bool
+	// Optional path of a PEM file containing the CA certificates used for validating the database certificate instead of the system's CA certificates.
+	// This requires [DatabaseConfig.ValidateServerCertificate].
+	CABundleFile string
+	// Optional SHA256 fingerprint of the database certificate in hex format. If this is set, EM only accepts a certificate with this fingerprint.
+	CertificateFingerprint string
+	// Optional list of databases clients can connect to. If this is empty, clients specify the database with query parameters dbHost and dbPort.
+	// If targets are configured, EM rejects query parameters dbHost and dbPort and clients select a target with query parameter dbTarget.
+	// This prevents clients from making EM connect to arbitrary hosts.
+	Targets []DatabaseTarget
+	// Name of the target used for requests without query parameter dbTarget. This is optional if exactly one target is configured.
+	DefaultTarget string
+	// Configuration for reusing database connections across requests. By default EM opens a new connection for each request.
+	Pool DatabasePoolConfig
+}
+
+// DatabasePoolConfig configures reusing database connections across requests.
+//
+// EM pools connections per database target and credentials, so a request only reuses connections opened with the same credentials.
+// Up to four concurrent requests share the connections of an entry, further requests open a connection that is closed after the request.
+//
+// Pooled connections keep their session state, e.g. the schema opened with OPEN SCHEMA or parameters set with ALTER SESSION,
+// because the Exasol driver does not support resetting a session. Extensions must therefore use qualified names and not change the session.
+type DatabasePoolConfig struct {
+	// Maximum number of pooled combinations of database target and credentials. The default value 0 disables the pool.
+	// If the pool is full, EM closes the least recently used entry. If all entries are in use, EM opens a connection that is closed after the request.
+	MaxSize int
+	// Duration after which EM closes unused connections, e.g. 5 * time.Minute. The default value 0 uses [DefaultDatabasePoolIdleTimeout].
+	// Note that connections stay logged in during this time, so changed passwords or revoked tokens only affect new connections.
+	IdleTimeout time.Duration
+}
+
+// DefaultDatabasePoolIdleTimeout is the idle timeout used when [DatabasePoolConfig.IdleTimeout] is not set.
+const DefaultDatabasePoolIdleTimeout = 5 * time.Minute
+
+// DatabaseTarget is a named Exasol database that clients can connect to.
+type DatabaseTarget struct {
+	// Name that clients use for selecting this database with query parameter dbTarget.
+	Name string
+	// Host name or IP address of the database.
+	Host string
+	// Port of the database.
+	Port int
+}
+
+// JobsConfig configures asynchronous jobs. Clients start a job by calling a modifying endpoint with query parameter async=true
+// and get its status from endpoint /jobs/{jobId}.
+type JobsConfig struct {
+	// Optional directory where EM stores jobs, so that their status is available after a restart. If this is empty, EM keeps jobs only in memory.
+	Directory string
+	// Duration after which EM deletes finished jobs. The default value 0 uses [DefaultJobRetention].
+	Retention time.Duration
+}
+
+// DefaultJobRetention is the retention period used when [JobsConfig.Retention] is not set.
+const DefaultJobRetention = 24 * time.Hour
@@ -0,0 +1,103 @@
+package restAPI
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// createServerTLSConfig creates the TLS configuration for the REST server.
+func createServerTLSConfig(config ServerTLSConfig) (*tls.Config, error) {
+	if config.CertFile == "" || config.KeyFile == "" {
+		return nil, errors.New("TLS requires both a certificate file and a key file")
+	}
+	reloader, err := newCertificateReloader(config.CertFile, config.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	//nolint:exhaustruct // Default values are ok for other fields
+	tlsConfig := &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: reloader.getCertificate,
+	}
+	if config.ClientCAFile != "" {
+		clientCAs, err := loadCertPool(config.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client CA file: %w", err)
+		}
+		tlsConfig.ClientCAs = clientCAs
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return tlsConfig, nil
+}
+
+// certificateReloader provides the server certificate and reloads it when the certificate or key file was modified.
+type certificateReloader struct {
+	certFile    string
+	keyFile     string
+	mutex       sync.Mutex
+	certificate *tls.Certificate
+	certModTime time.Time
+	keyModTime  time.Time
+}
+
+func newCertificateReloader(certFile, keyFile string) (*certificateReloader, error) {
+	//nolint:exhaustruct // Certificate is loaded below
+	reloader := &certificateReloader{certFile: certFile, keyFile: keyFile}
+	certModTime, keyModTime, err := reloader.getModificationTimes()
+	if err != nil {
+		return nil, err
+	}
+	if err := reloader.load(certModTime, keyModTime); err != nil {
+		return nil, err
+	}
+	return reloader, nil
+}
+
+func (r *certificateReloader) getCertificate(_ *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	certModTime, keyModTime, err := r.getModificationTimes()
+	if err != nil {
+		log.Warnf("Failed to check server certificate for changes, using previous certificate: %v", err)
+		return r.certificate, nil
+	}
+	if certModTime.Equal(r.certModTime) && keyModTime.Equal(r.keyModTime) {
+		return r.certificate, nil
+	}
+	if err := r.load(certModTime, keyModTime); err != nil {
+		// Certificate and key may be written one after the other, so try again with the next handshake.
+		log.Warnf("Failed to reload server certificate, using previous certificate: %v", err)
+		return r.certificate, nil
+	}
+	log.Infof("Reloaded server certificate from %q", r.certFile)
+	return r.certificate, nil
+}
+
+func (r *certificateReloader) load(certModTime, keyModTime time.Time) error {
+	certificate, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load server certificate %q with key %q: %w", r.certFile, r.keyFile, err)
+	}
+	r.certificate = &certificate
+	r.certModTime = certModTime
+	r.keyModTime = keyModTime
+	return nil
+}
+
+func (r *certificateReloader) getModificationTimes() (certModTime time.Time, keyModTime time.Time, err error) {
+	certInfo, err := os.Stat(r.certFile)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("failed to read server certificate file: %w", err)
+	}
+	keyInfo, err := os.Stat(r.keyFile)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("failed to read server key file: %w", err)
+	}
+	return certInfo.ModTime(), keyInfo.ModTime(), nil
+}
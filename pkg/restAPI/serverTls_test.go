@@ -0,0 +1,194 @@
+package restAPI
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ServerTLSSuite struct {
+	suite.Suite
+	tempDir string
+}
+
+func TestServerTLSSuite(t *testing.T) {
+	suite.Run(t, new(ServerTLSSuite))
+}
+
+func (suite *ServerTLSSuite) SetupTest() {
+	suite.tempDir = suite.T().TempDir()
+}
+
+func (suite *ServerTLSSuite) TestCreateServerTLSConfig() {
+	certFile, keyFile := writeSelfSignedCertificate(&suite.Suite, suite.tempDir, "server")
+	config, err := createServerTLSConfig(ServerTLSConfig{CertFile: certFile, KeyFile: keyFile, ClientCAFile: ""})
+	suite.Require().NoError(err)
+	suite.Equal(tls.NoClientCert, config.ClientAuth)
+	suite.Equal("server", suite.getCertificateCommonName(config))
+}
+
+func (suite *ServerTLSSuite) TestCreateServerTLSConfigWithClientCA() {
+	certFile, keyFile := writeSelfSignedCertificate(&suite.Suite, suite.tempDir, "server")
+	clientCAFile, _ := writeSelfSignedCertificate(&suite.Suite, suite.tempDir, "client-ca")
+	config, err := createServerTLSConfig(ServerTLSConfig{CertFile: certFile, KeyFile: keyFile, ClientCAFile: clientCAFile})
+	suite.Require().NoError(err)
+	suite.Equal(tls.RequireAndVerifyClientCert, config.ClientAuth)
+	suite.NotNil(config.ClientCAs)
+}
+
+func (suite *ServerTLSSuite) TestCreateServerTLSConfigFails() {
+	certFile, keyFile := writeSelfSignedCertificate(&suite.Suite, suite.tempDir, "server")
+	var tests = []struct {
+		name          string
+		config        ServerTLSConfig
+		expectedError string
+	}{
+		{"missing key file", ServerTLSConfig{CertFile: certFile, KeyFile: "", ClientCAFile: ""}, "TLS requires both a certificate file and a key file"},
+		{"non-existing key file", ServerTLSConfig{CertFile: certFile, KeyFile: "missing.pem", ClientCAFile: ""}, "failed to read server key file: stat missing.pem: no such file or directory"},
+		{"non-existing client CA file", ServerTLSConfig{CertFile: certFile, KeyFile: keyFile, ClientCAFile: "missing.pem"}, `failed to load client CA file: failed to read file "missing.pem": open missing.pem: no such file or directory`},
+	}
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			config, err := createServerTLSConfig(test.config)
+			suite.EqualError(err, test.expectedError)
+			suite.Nil(config)
+		})
+	}
+}
+
+func (suite *ServerTLSSuite) TestReloadsModifiedCertificate() {
+	certFile, keyFile := writeSelfSignedCertificate(&suite.Suite, suite.tempDir, "server")
+	config, err := createServerTLSConfig(ServerTLSConfig{CertFile: certFile, KeyFile: keyFile, ClientCAFile: ""})
+	suite.Require().NoError(err)
+	writeSelfSignedCertificate(&suite.Suite, suite.tempDir, "server-renewed")
+	suite.Require().NoError(os.Rename(path.Join(suite.tempDir, "server-renewed.crt"), certFile))
+	suite.Require().NoError(os.Rename(path.Join(suite.tempDir, "server-renewed.key"), keyFile))
+	suite.setModificationTime(certFile, keyFile)
+	suite.Equal("server-renewed", suite.getCertificateCommonName(config))
+}
+
+func (suite *ServerTLSSuite) TestKeepsPreviousCertificateIfReloadFails() {
+	certFile, keyFile := writeSelfSignedCertificate(&suite.Suite, suite.tempDir, "server")
+	config, err := createServerTLSConfig(ServerTLSConfig{CertFile: certFile, KeyFile: keyFile, ClientCAFile: ""})
+	suite.Require().NoError(err)
+	suite.Require().NoError(os.WriteFile(certFile, []byte("invalid"), 0600))
+	suite.setModificationTime(certFile, keyFile)
+	suite.Equal("server", suite.getCertificateCommonName(config))
+}
+
+func (suite *ServerTLSSuite) TestServerWithTLS() {
+	certFile, keyFile := writeSelfSignedCertificate(&suite.Suite, suite.tempDir, "localhost")
+	api, err := CreateWithConfig(createMockExtensionController(), ServerConfig{
+		ServerAddress:                 "localhost:8083",
+		AddCauseToInternalServerError: false,
+		TLS:                           &ServerTLSConfig{CertFile: certFile, KeyFile: keyFile, ClientCAFile: ""},
+		Database:                      DatabaseConfig{ValidateServerCertificate: false, CABundleFile: "", CertificateFingerprint: "", Targets: nil, DefaultTarget: "", Pool: DatabasePoolConfig{MaxSize: 0, IdleTimeout: 0}},
+		Jobs:                          JobsConfig{Directory: "", Retention: 0},
+		RequestTimeout:                0,
+		ReadHeaderTimeout:             0,
+		ShutdownTimeout:               0,
+		ShutdownDelay:                 0,
+	})
+	suite.Require().NoError(err)
+	api.StartInBackground()
+	defer api.Stop()
+	rootCAs, err := loadCertPool(certFile)
+	suite.Require().NoError(err)
+	//nolint:exhaustruct // Default values are ok for other fields
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: rootCAs, MinVersion: tls.VersionTLS12}}}
+	request, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "https://localhost:8083/openapi.json", nil)
+	suite.Require().NoError(err)
+	response, err := client.Do(request)
+	suite.Require().NoError(err)
+	defer response.Body.Close()
+	suite.Equal(http.StatusOK, response.StatusCode)
+}
+
+func (suite *ServerTLSSuite) TestCreateWithInvalidConfigFails() {
+	var tests = []struct {
+		name          string
+		config        ServerConfig
+		expectedError string
+	}{
+		{"invalid TLS config", ServerConfig{ServerAddress: "", AddCauseToInternalServerError: false, TLS: &ServerTLSConfig{CertFile: "", KeyFile: "", ClientCAFile: ""},
+			Database: DatabaseConfig{ValidateServerCertificate: false, CABundleFile: "", CertificateFingerprint: "", Targets: nil, DefaultTarget: "", Pool: DatabasePoolConfig{MaxSize: 0, IdleTimeout: 0}}, Jobs: JobsConfig{Directory: "", Retention: 0},
+			RequestTimeout: 0, ReadHeaderTimeout: 0, ShutdownTimeout: 0, ShutdownDelay: 0},
+			"invalid server TLS configuration: TLS requires both a certificate file and a key file"},
+		{"invalid database config", ServerConfig{ServerAddress: "", AddCauseToInternalServerError: false, TLS: nil,
+			Database: DatabaseConfig{ValidateServerCertificate: false, CABundleFile: "", CertificateFingerprint: "invalid", Targets: nil, DefaultTarget: "", Pool: DatabasePoolConfig{MaxSize: 0, IdleTimeout: 0}}, Jobs: JobsConfig{Directory: "", Retention: 0},
+			RequestTimeout: 0, ReadHeaderTimeout: 0, ShutdownTimeout: 0, ShutdownDelay: 0},
+			`invalid database configuration: invalid database certificate fingerprint "invalid", expected SHA256 checksum with 64 hex characters`},
+		{"negative timeout", ServerConfig{ServerAddress: "", AddCauseToInternalServerError: false, TLS: nil,
+			Database: DatabaseConfig{ValidateServerCertificate: false, CABundleFile: "", CertificateFingerprint: "", Targets: nil, DefaultTarget: "", Pool: DatabasePoolConfig{MaxSize: 0, IdleTimeout: 0}}, Jobs: JobsConfig{Directory: "", Retention: 0},
+			RequestTimeout: 0, ReadHeaderTimeout: 0, ShutdownTimeout: 0, ShutdownDelay: -time.Second},
+			"invalid timeout configuration: request timeout 0s, read header timeout 0s, shutdown timeout 0s and shutdown delay -1s must not be negative"},
+	}
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			api, err := CreateWithConfig(createMockExtensionController(), test.config)
+			suite.EqualError(err, test.expectedError)
+			suite.Nil(api)
+		})
+	}
+}
+
+func (suite *ServerTLSSuite) getCertificateCommonName(config *tls.Config) string {
+	//nolint:exhaustruct // Not needed for test
+	certificate, err := config.GetCertificate(&tls.ClientHelloInfo{})
+	suite.Require().NoError(err)
+	parsed, err := x509.ParseCertificate(certificate.Certificate[0])
+	suite.Require().NoError(err)
+	return parsed.Subject.CommonName
+}
+
+// setModificationTime sets the modification time of the given files to the future so that the reloader detects the change
+// even if the file system has a coarse time resolution.
+func (suite *ServerTLSSuite) setModificationTime(files ...string) {
+	modTime := time.Now().Add(time.Minute)
+	for _, file := range files {
+		suite.Require().NoError(os.Chtimes(file, modTime, modTime))
+	}
+}
+
+// writeSelfSignedCertificate creates a self-signed certificate for localhost with the given common name
+// and writes certificate and key to files "<name>.crt" and "<name>.key" in the given directory.
+func writeSelfSignedCertificate(s *suite.Suite, dir, name string) (certFile string, keyFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+	//nolint:exhaustruct // Default values are ok for other fields
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		DNSNames:              []string{"localhost"},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	certificate, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	s.Require().NoError(err)
+	keyBytes, err := x509.MarshalECPrivateKey(key)
+	s.Require().NoError(err)
+	certFile = path.Join(dir, name+".crt")
+	keyFile = path.Join(dir, name+".key")
+	s.Require().NoError(os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate}), 0600))
+	s.Require().NoError(os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes}), 0600))
+	return certFile, keyFile
+}
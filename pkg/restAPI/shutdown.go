@@ -0,0 +1,48 @@
+package restAPI
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// drainer coordinates the graceful shutdown of the server.
+//
+// When the server stops, it first starts draining: EM refuses new modifying requests and reports that it is not ready,
+// while running requests and jobs can still finish. If they don't finish within the drain period, the server aborts them
+// by cancelling their context. The database then rolls back their transactions.
+type drainer struct {
+	ctx      context.Context // Base context of all requests and jobs, cancelled when aborting
+	cancel   context.CancelFunc
+	draining atomic.Bool
+}
+
+func newDrainer() *drainer {
+	ctx, cancel := context.WithCancel(context.Background())
+	//nolint:exhaustruct // Zero value of draining is ok
+	return &drainer{ctx: ctx, cancel: cancel}
+}
+
+// startDraining makes EM refuse new modifying requests.
+func (d *drainer) startDraining() {
+	d.draining.Store(true)
+}
+
+func (d *drainer) isDraining() bool {
+	return d.draining.Load()
+}
+
+// abort cancels all running requests and jobs.
+func (d *drainer) abort() {
+	d.cancel()
+}
+
+// detach returns a context with the values of the given request context that is not cancelled when the request is finished,
+// but when the drain period expires. Call the returned function when the operation is finished.
+func (d *drainer) detach(requestContext context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.WithoutCancel(requestContext))
+	stop := context.AfterFunc(d.ctx, cancel)
+	return ctx, func() {
+		stop()
+		cancel()
+	}
+}
@@ -0,0 +1,51 @@
+package restAPI
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type DrainerSuite struct {
+	suite.Suite
+}
+
+func TestDrainerSuite(t *testing.T) {
+	suite.Run(t, new(DrainerSuite))
+}
+
+type testContextKey struct{}
+
+func (suite *DrainerSuite) TestStartDraining() {
+	drainer := newDrainer()
+	suite.False(drainer.isDraining())
+	drainer.startDraining()
+	suite.True(drainer.isDraining())
+	suite.NoError(drainer.ctx.Err())
+}
+
+func (suite *DrainerSuite) TestAbortCancelsContext() {
+	drainer := newDrainer()
+	drainer.abort()
+	suite.ErrorIs(drainer.ctx.Err(), context.Canceled)
+}
+
+func (suite *DrainerSuite) TestDetachedContextSurvivesRequest() {
+	drainer := newDrainer()
+	requestContext, cancelRequest := context.WithCancel(context.WithValue(context.Background(), testContextKey{}, "value"))
+	ctx, cancel := drainer.detach(requestContext)
+	defer cancel()
+	cancelRequest()
+	suite.NoError(ctx.Err())
+	suite.Equal("value", ctx.Value(testContextKey{}))
+}
+
+func (suite *DrainerSuite) TestDetachedContextCancelledByAbort() {
+	drainer := newDrainer()
+	ctx, cancel := drainer.detach(context.Background())
+	defer cancel()
+	drainer.abort()
+	<-ctx.Done()
+	suite.ErrorIs(ctx.Err(), context.Canceled)
+}
@@ -1,8 +1,6 @@
 package restAPI
 
 import (
-	"bytes"
-	"fmt"
 	"net/http"
 	"time"
 
@@ -19,17 +17,19 @@ import (
 )
 
 /* [impl -> dsn~rest-interface~1]. */
-func setupStandaloneAPI(controller extensionController.TransactionController, addCauseToInternalServerError bool) (http.Handler, *openapi.API, error) {
+func setupStandaloneAPI(controller extensionController.TransactionController, addCauseToInternalServerError bool, database *databaseConnector, jobs *jobManager, drainer *drainer, requestTimeout time.Duration) (http.Handler, *openapi.API, error) {
 	api, err := CreateOpenApi()
 	if err != nil {
 		return nil, nil, err
 	}
 	r := chi.NewRouter()
 	r.Use(middleware.RequestID)
+	r.Use(tracingMiddleware())
 	r.Use(loggerMiddleware())
+	r.Use(metricsMiddleware())
 	r.Use(middleware.Recoverer)
 
-	err = addPublicEndpointsWithController(api, addCauseToInternalServerError, controller)
+	err = addPublicEndpointsWithController(api, addCauseToInternalServerError, controller, database, jobs, drainer)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -43,12 +43,15 @@ func setupStandaloneAPI(controller extensionController.TransactionController, ad
 		r.Method(http.MethodGet, "/openapi/*", httpswagger.Handler(
 			httpswagger.URL("/openapi.json"),
 		))
+		r.Method(http.MethodGet, metricsPath, createMetricsHandler(database))
+		r.MethodFunc(http.MethodGet, healthLivePath, handleLiveness())
+		r.MethodFunc(http.MethodGet, healthReadyPath, handleReadiness(controller, drainer))
 	})
 
 	r.Group(func(r chi.Router) {
 		for _, handleConfig := range api.GetHandleFunc() {
 			log.Tracef("Add func %s %s", handleConfig.Method, handleConfig.Path)
-			r.With(middleware.Timeout(60*time.Second)).Method(handleConfig.Method, handleConfig.Path, handleConfig.HandlerFunc)
+			r.With(middleware.Timeout(requestTimeout)).Method(handleConfig.Method, handleConfig.Path, handleConfig.HandlerFunc)
 		}
 	})
 
@@ -67,41 +70,16 @@ func CreateOpenApi() (*openapi.API, error) {
 		return nil, err
 	}
 	api.DefaultResponse(&openapi.MethodResponse{
-		Description: "Default error",
+		Description: "Default error. Field errorCode contains a stable code identifying the error, see the error code catalog in doc/error_codes.md.",
 		Value: &apiErrors.APIError{
 			Status:        500,
 			Message:       "Something went wrong.",
 			RequestID:     "Rn3x8gcEInnHt205B4c7QZ",
+			ErrorCode:     apiErrors.API_INTERNAL_SERVER_ERROR.Code,
+			Mitigations:   apiErrors.API_INTERNAL_SERVER_ERROR.Mitigations,
+			Details:       []apiErrors.ErrorDetail{{Field: "param1", ErrorCode: apiErrors.DETAIL_INVALID_VALUE, Message: "The value has an invalid format."}},
 			OriginalError: nil,
 		},
 	})
 	return api, nil
 }
-
-func loggerMiddleware() func(next http.Handler) http.Handler {
-	return func(next http.Handler) http.Handler {
-		fn := func(w http.ResponseWriter, r *http.Request) {
-			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
-			buf := &bytes.Buffer{}
-			logger := GetLogger(r.Context())
-			t1 := time.Now()
-			defer func() {
-
-				scheme := "http"
-				if r.TLS != nil {
-					scheme = "https"
-				}
-
-				fmt.Fprintf(buf, "\"%s %s://%s%s %s\" ", r.Method, scheme, r.Host, r.RequestURI, r.Proto)
-				buf.WriteString("from ")
-				buf.WriteString(r.RemoteAddr)
-				buf.WriteString(" - ")
-				fmt.Fprintf(buf, "%d %dB in %s", ww.Status(), ww.BytesWritten(), time.Since(t1))
-				logger.Info(buf.String())
-			}()
-
-			next.ServeHTTP(ww, r)
-		}
-		return http.HandlerFunc(fn)
-	}
-}
@@ -0,0 +1,36 @@
+package restAPI
+
+import (
+	"net/http"
+
+	"github.com/exasol/extension-manager/pkg/tracing"
+	"github.com/go-chi/chi/v5/middleware"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// tracingMiddleware creates a span for each request. The span continues the trace of the client if the request contains trace headers.
+// The span name is the method and route pattern, e.g. "GET /api/v1/extensionmanager/extensions".
+func tracingMiddleware() func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			ctx, span := tracing.StartServerSpan(r.Context(), r.Header, r.Method,
+				attribute.String("http.request.method", r.Method),
+				attribute.String("url.path", r.URL.Path),
+				attribute.String("em.request_id", middleware.GetReqID(r.Context())))
+			defer span.End()
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			r = r.WithContext(ctx)
+			next.ServeHTTP(ww, r)
+
+			route := getRoutePattern(r)
+			status := getResponseStatus(ww)
+			span.SetName(r.Method + " " + route)
+			span.SetAttributes(attribute.String("http.route", route), attribute.Int("http.response.status_code", status))
+			if status >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(status))
+			}
+		}
+		return http.HandlerFunc(fn)
+	}
+}
@@ -0,0 +1,100 @@
+package restAPI
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/exasol/extension-manager/pkg/tracing"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type TracingMiddlewareSuite struct {
+	suite.Suite
+	recorder         *tracetest.SpanRecorder
+	previousProvider trace.TracerProvider
+	traceIdInHandler string
+}
+
+func TestTracingMiddlewareSuite(t *testing.T) {
+	suite.Run(t, new(TracingMiddlewareSuite))
+}
+
+func (suite *TracingMiddlewareSuite) SetupTest() {
+	suite.previousProvider = otel.GetTracerProvider()
+	suite.recorder = tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(suite.recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	suite.traceIdInHandler = ""
+}
+
+func (suite *TracingMiddlewareSuite) TearDownTest() {
+	otel.SetTracerProvider(suite.previousProvider)
+}
+
+func (suite *TracingMiddlewareSuite) TestSpanContainsRoute() {
+	suite.serve(httptest.NewRequest(http.MethodGet, "/extensions/ext-id", nil))
+	span := suite.getSingleSpan()
+	suite.Equal("GET /extensions/{extensionId}", span.Name())
+	suite.Equal(trace.SpanKindServer, span.SpanKind())
+	suite.Subset(span.Attributes(), []attribute.KeyValue{
+		attribute.String("http.request.method", "GET"),
+		attribute.String("url.path", "/extensions/ext-id"),
+		attribute.String("http.route", "/extensions/{extensionId}"),
+		attribute.Int("http.response.status_code", 200),
+	})
+	suite.Equal(codes.Unset, span.Status().Code)
+	suite.Equal(span.SpanContext().TraceID().String(), suite.traceIdInHandler)
+}
+
+func (suite *TracingMiddlewareSuite) TestSpanContinuesClientTrace() {
+	request := httptest.NewRequest(http.MethodGet, "/extensions/ext-id", nil)
+	request.Header.Set("traceparent", "00-4bf92f3577b34ea8e64736ca1b6f7d36-00f067aa0ba902b7-01")
+	suite.serve(request)
+	span := suite.getSingleSpan()
+	suite.Equal("4bf92f3577b34ea8e64736ca1b6f7d36", span.SpanContext().TraceID().String())
+	suite.Equal("00f067aa0ba902b7", span.Parent().SpanID().String())
+	suite.Equal("4bf92f3577b34ea8e64736ca1b6f7d36", suite.traceIdInHandler)
+}
+
+func (suite *TracingMiddlewareSuite) TestSpanForServerError() {
+	suite.serve(httptest.NewRequest(http.MethodGet, "/failure", nil))
+	span := suite.getSingleSpan()
+	suite.Contains(span.Attributes(), attribute.Int("http.response.status_code", 500))
+	suite.Equal(codes.Error, span.Status().Code)
+}
+
+func (suite *TracingMiddlewareSuite) TestSpanForUnmatchedRoute() {
+	suite.serve(httptest.NewRequest(http.MethodGet, "/unknown", nil))
+	span := suite.getSingleSpan()
+	suite.Equal("GET unmatched", span.Name())
+	suite.Contains(span.Attributes(), attribute.Int("http.response.status_code", 404))
+	suite.Equal(codes.Unset, span.Status().Code)
+}
+
+func (suite *TracingMiddlewareSuite) serve(request *http.Request) {
+	router := chi.NewRouter()
+	router.Use(middleware.RequestID, tracingMiddleware())
+	router.Get("/extensions/{extensionId}", func(_ http.ResponseWriter, r *http.Request) {
+		suite.traceIdInHandler = tracing.TraceID(r.Context())
+	})
+	router.Get("/failure", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	router.ServeHTTP(httptest.NewRecorder(), request)
+}
+
+func (suite *TracingMiddlewareSuite) getSingleSpan() sdktrace.ReadOnlySpan {
+	spans := suite.recorder.Ended()
+	suite.Require().Len(spans, 1)
+	return spans[0]
+}
@@ -0,0 +1,130 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/exasol/extension-manager/pkg/apiErrors"
+)
+
+// MASKED_VALUE replaces secret values in log messages and error messages.
+const MASKED_VALUE = "******"
+
+// Masker replaces secret values like passwords in texts and errors.
+// A nil Masker does not mask anything.
+type Masker struct {
+	values []string
+}
+
+// NewMasker creates a new [Masker] for the given secret values. Empty values are ignored.
+// The masker also replaces the values escaped for SQL string literals, i.e. with single quotes doubled,
+// so that secrets are masked in SQL statements like CREATE CONNECTION.
+func NewMasker(values ...string) *Masker {
+	nonEmptyValues := make([]string, 0, len(values))
+	for _, value := range values {
+		if value == "" {
+			continue
+		}
+		nonEmptyValues = append(nonEmptyValues, value)
+		if escaped := escapeSqlString(value); escaped != value {
+			nonEmptyValues = append(nonEmptyValues, escaped)
+		}
+	}
+	// Replace longer values first in case one secret value contains another one.
+	sort.SliceStable(nonEmptyValues, func(i, j int) bool { return len(nonEmptyValues[i]) > len(nonEmptyValues[j]) })
+	return &Masker{values: nonEmptyValues}
+}
+
+func escapeSqlString(value string) string {
+	return strings.ReplaceAll(value, "'", "''")
+}
+
+// Mask replaces all secret values in the given text with [MASKED_VALUE].
+func (m *Masker) Mask(text string) string {
+	if m == nil {
+		return text
+	}
+	for _, value := range m.values {
+		text = strings.ReplaceAll(text, value, MASKED_VALUE)
+	}
+	return text
+}
+
+// MaskError returns an error with all secret values in the message replaced by [MASKED_VALUE].
+// The returned error wraps the original error, so [errors.Is] and [errors.As] still find errors in its chain
+// and the error code is preserved.
+// If the error is an [apiErrors.APIError] the result is also an [apiErrors.APIError] with the same status, error code and masked details.
+func (m *Masker) MaskError(err error) error {
+	if err == nil || m == nil || len(m.values) == 0 {
+		return err
+	}
+	message := err.Error()
+	maskedMessage := m.Mask(message)
+	var apiErr *apiErrors.APIError
+	if !errors.As(err, &apiErr) {
+		if maskedMessage == message {
+			return err
+		}
+		return &maskedError{message: maskedMessage, cause: err}
+	}
+	maskedDetails, detailsChanged := m.maskDetails(apiErr.Details)
+	if maskedMessage == message && !detailsChanged {
+		return err
+	}
+	return &apiErrors.APIError{
+		Status:        apiErr.Status,
+		Message:       m.Mask(apiErr.Message),
+		RequestID:     apiErr.RequestID,
+		ErrorCode:     apiErr.ErrorCode,
+		Mitigations:   apiErr.Mitigations,
+		Details:       maskedDetails,
+		OriginalError: &maskedError{message: maskedMessage, cause: err},
+	}
+}
+
+// maskedError replaces the message of an error with the masked message but keeps the original error in the chain.
+type maskedError struct {
+	message string
+	cause   error
+}
+
+func (e *maskedError) Error() string {
+	return e.message
+}
+
+func (e *maskedError) Unwrap() error {
+	return e.cause
+}
+
+func (m *Masker) maskDetails(details []apiErrors.ErrorDetail) (maskedDetails []apiErrors.ErrorDetail, changed bool) {
+	if details == nil {
+		return nil, false
+	}
+	maskedDetails = make([]apiErrors.ErrorDetail, 0, len(details))
+	for _, detail := range details {
+		maskedMessage := m.Mask(detail.Message)
+		changed = changed || maskedMessage != detail.Message
+		maskedDetails = append(maskedDetails, apiErrors.ErrorDetail{Field: detail.Field, ErrorCode: detail.ErrorCode, Message: maskedMessage})
+	}
+	return maskedDetails, changed
+}
+
+type maskerContextKey struct{}
+
+// WithMasker returns a copy of the context that contains the given [Masker].
+func WithMasker(ctx context.Context, masker *Masker) context.Context {
+	return context.WithValue(ctx, maskerContextKey{}, masker)
+}
+
+// MaskerFromContext returns the [Masker] stored in the context or nil if the context does not contain one.
+func MaskerFromContext(ctx context.Context) *Masker {
+	if ctx == nil {
+		return nil
+	}
+	if masker, ok := ctx.Value(maskerContextKey{}).(*Masker); ok {
+		return masker
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/exasol/extension-manager/pkg/apiErrors"
+	"github.com/stretchr/testify/suite"
+)
+
+type MaskerSuite struct {
+	suite.Suite
+}
+
+func TestMaskerSuite(t *testing.T) {
+	suite.Run(t, new(MaskerSuite))
+}
+
+func (suite *MaskerSuite) TestMask() {
+	var tests = []struct {
+		name     string
+		values   []string
+		text     string
+		expected string
+	}{
+		{name: "no secrets", values: nil, text: "text", expected: "text"},
+		{name: "empty secret ignored", values: []string{""}, text: "text", expected: "text"},
+		{name: "secret not contained", values: []string{"secret"}, text: "text", expected: "text"},
+		{name: "single occurrence", values: []string{"secret"}, text: "password 'secret'", expected: "password '******'"},
+		{name: "multiple occurrences", values: []string{"secret"}, text: "secret secret", expected: "****** ******"},
+		{name: "multiple secrets", values: []string{"secret1", "secret2"}, text: "secret1 secret2", expected: "****** ******"},
+		{name: "longer secret first", values: []string{"pass", "password"}, text: "password", expected: "******"},
+		{name: "secret with quote", values: []string{"it's"}, text: "password it's", expected: "password ******"},
+		{name: "secret with quote in SQL literal", values: []string{"it's"}, text: "IDENTIFIED BY 'it''s'", expected: "IDENTIFIED BY '******'"},
+		{name: "secret with multiple quotes in SQL literal", values: []string{"'a'b'"}, text: "IDENTIFIED BY '''a''b'''", expected: "IDENTIFIED BY '******'"},
+	}
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			suite.Equal(test.expected, NewMasker(test.values...).Mask(test.text))
+		})
+	}
+}
+
+func (suite *MaskerSuite) TestMaskNilMasker() {
+	var masker *Masker
+	suite.Equal("secret", masker.Mask("secret"))
+}
+
+func (suite *MaskerSuite) TestMaskErrorNil() {
+	suite.NoError(NewMasker("secret").MaskError(nil))
+}
+
+func (suite *MaskerSuite) TestMaskErrorWithoutSecretReturnsOriginalError() {
+	err := errors.New("error")
+	suite.Same(err, NewMasker("secret").MaskError(err))
+}
+
+func (suite *MaskerSuite) TestMaskError() {
+	err := fmt.Errorf("wrapped: %w", errors.New("invalid password 'secret'"))
+	suite.EqualError(NewMasker("secret").MaskError(err), "wrapped: invalid password '******'")
+}
+
+func (suite *MaskerSuite) TestMaskErrorKeepsErrorChain() {
+	cause := errors.New("invalid password 'secret'")
+	maskedErr := NewMasker("secret").MaskError(fmt.Errorf("wrapped: %w", cause))
+	suite.EqualError(maskedErr, "wrapped: invalid password '******'")
+	suite.ErrorIs(maskedErr, cause)
+}
+
+func (suite *MaskerSuite) TestMaskAPIError() {
+	err := fmt.Errorf("wrapped: %w", apiErrors.NewBadRequestErrorF("invalid password 'secret'"))
+	maskedErr := NewMasker("secret").MaskError(err)
+	suite.EqualError(maskedErr, "invalid password '******'")
+	apiErr := apiErrors.UnwrapAPIError(maskedErr)
+	suite.Equal(http.StatusBadRequest, apiErr.Status)
+	suite.EqualError(apiErr.OriginalError, "wrapped: invalid password '******'")
+	suite.ErrorIs(apiErr.OriginalError, err)
+}
+
+func (suite *MaskerSuite) TestMaskAPIErrorKeepsDetails() {
+	details := []apiErrors.ErrorDetail{{Field: "p1", ErrorCode: apiErrors.DETAIL_INVALID_VALUE, Message: "invalid value"}}
+	err := apiErrors.NewBadRequestErrorWithDetails("invalid password 'secret'", details)
+	apiErr := apiErrors.UnwrapAPIError(NewMasker("secret").MaskError(err))
+	suite.Equal("invalid password '******'", apiErr.Message)
+	suite.Equal(details, apiErr.Details)
+}
+
+func (suite *MaskerSuite) TestMaskAPIErrorDetails() {
+	err := apiErrors.NewBadRequestErrorWithDetails("invalid parameters", []apiErrors.ErrorDetail{{Field: "p1", ErrorCode: apiErrors.DETAIL_INVALID_VALUE, Message: "invalid value 'secret'"}})
+	apiErr := apiErrors.UnwrapAPIError(NewMasker("secret").MaskError(err))
+	suite.Equal("invalid parameters", apiErr.Message)
+	suite.Equal([]apiErrors.ErrorDetail{{Field: "p1", ErrorCode: apiErrors.DETAIL_INVALID_VALUE, Message: "invalid value '******'"}}, apiErr.Details)
+}
+
+func (suite *MaskerSuite) TestMaskAPIErrorKeepsErrorCode() {
+	err := apiErrors.CTRL_INVALID_PARAMETERS.NewErrorF("invalid password 'secret'")
+	apiErr := apiErrors.UnwrapAPIError(NewMasker("secret").MaskError(err))
+	suite.Equal("invalid password '******'", apiErr.Message)
+	suite.Equal("E-EM-CTRL-3", apiErr.ErrorCode)
+	suite.Equal(apiErrors.CTRL_INVALID_PARAMETERS.Mitigations, apiErr.Mitigations)
+}
+
+func (suite *MaskerSuite) TestMaskInternalErrorKeepsErrorCode() {
+	err := apiErrors.CTRL_BEGIN_TRANSACTION_FAILED.NewInternalErrorF("invalid password 'secret'")
+	maskedErr := NewMasker("secret").MaskError(err)
+	suite.EqualError(maskedErr, "invalid password '******'")
+	suite.Equal("E-EM-CTRL-5", apiErrors.UnwrapAPIError(maskedErr).ErrorCode)
+}
+
+func (suite *MaskerSuite) TestMaskerFromContext() {
+	masker := NewMasker("secret")
+	suite.Same(masker, MaskerFromContext(WithMasker(context.Background(), masker)))
+}
+
+func (suite *MaskerSuite) TestMaskerFromContextWithoutMasker() {
+	suite.Nil(MaskerFromContext(context.Background()))
+}
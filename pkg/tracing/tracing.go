@@ -0,0 +1,129 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Exporters for spans supported by [Setup].
+const (
+	ExporterNone   = "none"   // Don't export spans
+	ExporterStdout = "stdout" // Write spans to standard output, useful for local debugging
+	ExporterOTLP   = "otlp"   // Send spans to an OpenTelemetry collector using OTLP over HTTP
+)
+
+// DefaultServiceName is the service name reported with all spans if [Config.ServiceName] is empty.
+const DefaultServiceName = "extension-manager"
+
+const tracerName = "github.com/exasol/extension-manager"
+
+// Config contains the configuration for exporting spans.
+type Config struct {
+	// Exporter for spans, one of [ExporterNone], [ExporterStdout] or [ExporterOTLP]. Empty is the same as [ExporterNone].
+	Exporter string
+	// Endpoint of the OTLP collector, e.g. "localhost:4318". If this is empty, the exporter uses environment variable
+	// OTEL_EXPORTER_OTLP_ENDPOINT or the default endpoint "localhost:4318".
+	OTLPEndpoint string
+	// Use HTTP instead of HTTPS for sending spans to the OTLP collector.
+	OTLPInsecure bool
+	// Service name reported with all spans. Default: [DefaultServiceName].
+	ServiceName string
+}
+
+// Setup configures the global OpenTelemetry tracer provider for the given exporter and the propagation of trace IDs
+// using W3C Trace Context headers. The returned function flushes pending spans and must be called before the application stops.
+//
+// Trace context is propagated even if no exporter is configured, so that log messages can contain the trace ID of incoming requests.
+func Setup(ctx context.Context, config Config) (shutdown func(context.Context) error, err error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	if config.Exporter == "" || config.Exporter == ExporterNone {
+		return func(context.Context) error { return nil }, nil
+	}
+	exporter, err := createExporter(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	serviceName := config.ServiceName
+	if serviceName == "" {
+		serviceName = DefaultServiceName
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(serviceName))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+func createExporter(ctx context.Context, config Config) (sdktrace.SpanExporter, error) {
+	switch config.Exporter {
+	case ExporterStdout:
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create stdout span exporter: %w", err)
+		}
+		return exporter, nil
+	case ExporterOTLP:
+		options := make([]otlptracehttp.Option, 0, 2)
+		if config.OTLPEndpoint != "" {
+			options = append(options, otlptracehttp.WithEndpoint(config.OTLPEndpoint))
+		}
+		if config.OTLPInsecure {
+			options = append(options, otlptracehttp.WithInsecure())
+		}
+		exporter, err := otlptracehttp.New(ctx, options...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create OTLP span exporter: %w", err)
+		}
+		return exporter, nil
+	default:
+		return nil, fmt.Errorf("unsupported trace exporter %q, expected one of %q, %q or %q", config.Exporter, ExporterNone, ExporterStdout, ExporterOTLP)
+	}
+}
+
+// Start starts a new span as child of the span in the given context.
+// The caller must end the span, e.g. with [End].
+func Start(ctx context.Context, spanName string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return otel.Tracer(tracerName).Start(ctx, spanName, trace.WithAttributes(attributes...))
+}
+
+// End ends the span and marks it as failed if err is not nil.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// StartServerSpan starts a span for an incoming request. If the request headers contain a trace context,
+// e.g. a W3C traceparent header, the span continues the trace of the client.
+func StartServerSpan(ctx context.Context, header http.Header, spanName string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(header))
+	return otel.Tracer(tracerName).Start(ctx, spanName, trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(attributes...))
+}
+
+// TraceID returns the ID of the trace of the span in the given context or an empty string if the context contains no valid span.
+func TraceID(ctx context.Context) string {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.HasTraceID() {
+		return ""
+	}
+	return spanContext.TraceID().String()
+}
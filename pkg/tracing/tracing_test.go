@@ -0,0 +1,133 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	clientTraceId     = "4bf92f3577b34ea8e64736ca1b6f7d36"
+	clientTraceParent = "00-" + clientTraceId + "-00f067aa0ba902b7-01"
+)
+
+type TracingSuite struct {
+	suite.Suite
+	recorder         *tracetest.SpanRecorder
+	previousProvider trace.TracerProvider
+}
+
+func TestTracingSuite(t *testing.T) {
+	suite.Run(t, new(TracingSuite))
+}
+
+func (suite *TracingSuite) SetupTest() {
+	suite.previousProvider = otel.GetTracerProvider()
+	suite.recorder = tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(suite.recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+}
+
+func (suite *TracingSuite) TearDownTest() {
+	otel.SetTracerProvider(suite.previousProvider)
+}
+
+func (suite *TracingSuite) TestSetupWithoutExporter() {
+	for _, exporter := range []string{"", ExporterNone} {
+		suite.Run(exporter, func() {
+			shutdown, err := Setup(context.Background(), Config{Exporter: exporter, OTLPEndpoint: "", OTLPInsecure: false, ServiceName: ""})
+			suite.Require().NoError(err)
+			suite.Require().NoError(shutdown(context.Background()))
+		})
+	}
+}
+
+func (suite *TracingSuite) TestSetupConfiguresPropagator() {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+	_, err := Setup(context.Background(), Config{Exporter: ExporterNone, OTLPEndpoint: "", OTLPInsecure: false, ServiceName: ""})
+	suite.Require().NoError(err)
+	suite.Contains(otel.GetTextMapPropagator().Fields(), "traceparent")
+}
+
+func (suite *TracingSuite) TestSetupWithStdoutExporter() {
+	shutdown, err := Setup(context.Background(), Config{Exporter: ExporterStdout, OTLPEndpoint: "", OTLPInsecure: false, ServiceName: "test-service"})
+	suite.Require().NoError(err)
+	suite.Require().NoError(shutdown(context.Background()))
+}
+
+func (suite *TracingSuite) TestSetupWithOtlpExporter() {
+	shutdown, err := Setup(context.Background(), Config{Exporter: ExporterOTLP, OTLPEndpoint: "localhost:4318", OTLPInsecure: true, ServiceName: ""})
+	suite.Require().NoError(err)
+	suite.Require().NoError(shutdown(context.Background()))
+}
+
+func (suite *TracingSuite) TestSetupWithUnsupportedExporter() {
+	shutdown, err := Setup(context.Background(), Config{Exporter: "jaeger", OTLPEndpoint: "", OTLPInsecure: false, ServiceName: ""})
+	suite.Require().EqualError(err, `unsupported trace exporter "jaeger", expected one of "none", "stdout" or "otlp"`)
+	suite.Nil(shutdown)
+}
+
+func (suite *TracingSuite) TestStartCreatesChildSpan() {
+	ctx, parent := Start(context.Background(), "parent")
+	_, child := Start(ctx, "child", attribute.String("key", "value"))
+	End(child, nil)
+	End(parent, nil)
+	spans := suite.recorder.Ended()
+	suite.Require().Len(spans, 2)
+	suite.Equal("child", spans[0].Name())
+	suite.Equal(parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	suite.Contains(spans[0].Attributes(), attribute.String("key", "value"))
+	suite.Equal(codes.Unset, spans[0].Status().Code)
+}
+
+func (suite *TracingSuite) TestStartWithoutContext() {
+	//nolint:staticcheck // Nil context is allowed for callers without context
+	ctx, span := Start(nil, "span")
+	End(span, nil)
+	suite.NotNil(ctx)
+	suite.Len(suite.recorder.Ended(), 1)
+}
+
+func (suite *TracingSuite) TestEndWithError() {
+	_, span := Start(context.Background(), "span")
+	End(span, errors.New("mock error"))
+	spans := suite.recorder.Ended()
+	suite.Require().Len(spans, 1)
+	suite.Equal(codes.Error, spans[0].Status().Code)
+	suite.Equal("mock error", spans[0].Status().Description)
+	suite.Require().Len(spans[0].Events(), 1)
+	suite.Equal("exception", spans[0].Events()[0].Name)
+}
+
+func (suite *TracingSuite) TestStartServerSpanContinuesClientTrace() {
+	header := http.Header{}
+	header.Set("traceparent", clientTraceParent)
+	ctx, span := StartServerSpan(context.Background(), header, "GET /path")
+	End(span, nil)
+	suite.Equal(clientTraceId, TraceID(ctx))
+	spans := suite.recorder.Ended()
+	suite.Require().Len(spans, 1)
+	suite.Equal(trace.SpanKindServer, spans[0].SpanKind())
+	suite.Equal("00f067aa0ba902b7", spans[0].Parent().SpanID().String())
+}
+
+func (suite *TracingSuite) TestStartServerSpanStartsNewTrace() {
+	ctx, span := StartServerSpan(context.Background(), http.Header{}, "GET /path")
+	End(span, nil)
+	suite.Len(TraceID(ctx), 32)
+	suite.NotEqual(clientTraceId, TraceID(ctx))
+}
+
+func (suite *TracingSuite) TestTraceIDWithoutSpan() {
+	suite.Empty(TraceID(context.Background()))
+}